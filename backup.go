@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/dto"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"os"
+	"sort"
+)
+
+// runBackupTournament menjalankan subcommand `backup-tournament -tournament
+// id [-o file]` yang menulis bundle JSON sebuah Tournament ke file atau
+// stdout, sama dengan GET /tournaments/{id}/backup.
+func runBackupTournament(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("backup-tournament", flag.ExitOnError)
+	tournamentID := flags.Uint("tournament", 0, "tournament to back up")
+	output := flags.String("o", "", "output file (default stdout)")
+	flags.Parse(args)
+	if *tournamentID == 0 {
+		log.Fatal("Usage: backup-tournament -tournament id [-o file]")
+	}
+
+	config.ConnectDatabase(cfg.Database)
+
+	bundle, err := services.New(repositories.New(config.DB, openStorage(cfg))).Backups.Backup(context.Background(), *tournamentID)
+	if err != nil {
+		log.Fatal("Failed to back up tournament: ", err)
+	}
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(bundle); err != nil {
+		log.Fatal("Failed to write tournament backup: ", err)
+	}
+}
+
+// runRestoreTournament menjalankan subcommand `restore-tournament [-name
+// name] file` yang membuat Tournament baru dari hasil backup-tournament.
+func runRestoreTournament(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("restore-tournament", flag.ExitOnError)
+	name := flags.String("name", "", "name of the new tournament (default the name in the backup)")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		log.Fatal("Usage: restore-tournament [-name name] file")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	var bundle dto.TournamentBundleDto
+	if err := json.NewDecoder(file).Decode(&bundle); err != nil {
+		log.Fatal("Invalid tournament backup file: ", err)
+	}
+
+	config.ConnectDatabase(cfg.Database)
+
+	ctx := services.WithAuditActor(context.Background(), cliActor)
+	result, err := services.New(repositories.New(config.DB, openStorage(cfg))).Backups.Restore(ctx, bundle, *name)
+	if err != nil {
+		log.Fatal("Failed to restore tournament: ", err)
+	}
+
+	fmt.Printf("Restored tournament %d\n", result.TournamentID)
+	printCounts("created", result.Created)
+	printCounts("reused", result.Reused)
+}
+
+func printCounts(label string, counts map[string]int) {
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Printf("  %-8s %-25s %d\n", label, kind, counts[kind])
+	}
+}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"ml-master-data/storage"
+
+	"github.com/joho/godotenv"
+)
+
+// Config adalah seluruh konfigurasi aplikasi. Nilainya dibaca sekali oleh
+// Load lalu diteruskan ke router, controller, repository dan subcommand CLI.
+type Config struct {
+	// Env adalah APP_ENV: development atau production (default).
+	Env string
+	// Port adalah PORT tempat server HTTP mendengarkan, default 8080.
+	Port int
+	// BaseURL adalah BASE_URL, alamat publik server tanpa "/" di akhir.
+	// Dipakai untuk URL gambar dan Swagger. Default http://localhost:<Port>.
+	BaseURL   string
+	JWTSecret string
+	Database  DatabaseConfig
+	Storage   StorageConfig
+	// SoftDeleteRetentionDays adalah SOFT_DELETE_RETENTION_DAYS, default 30,
+	// minimal 1 agar data yang baru dihapus tidak langsung di-purge.
+	SoftDeleteRetentionDays int
+	// MediaGC mengatur job pembersih media yatim.
+	MediaGC MediaGCConfig
+	// Seeders adalah SEEDERS, seeder yang dijalankan saat server boot.
+	Seeders []string
+	// SeedUserPassword adalah SEED_USER_PASSWORD untuk seeder users.
+	SeedUserPassword string
+}
+
+type DatabaseConfig struct {
+	// Driver adalah DB_DRIVER: mysql (default), postgres atau sqlite.
+	Driver   string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	// Name adalah nama database, atau path file untuk sqlite.
+	Name    string
+	SSLMode string
+	// AutoMigrate menjalankan migration yang tertunda saat boot.
+	AutoMigrate bool
+}
+
+// StorageConfig memilih tempat file media disimpan.
+type StorageConfig struct {
+	// Driver adalah STORAGE_DRIVER: local (default) atau s3.
+	Driver string
+	// LocalDir adalah STORAGE_LOCAL_DIR, default public/images. File di
+	// dalamnya dilayani server di BaseURL/media.
+	LocalDir string
+	// S3Endpoint, S3Region, S3Bucket, S3AccessKey dan S3SecretKey diambil
+	// dari S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY dan S3_SECRET_KEY.
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	// S3PublicURL adalah S3_PUBLIC_URL, prefix URL publik objek (misalnya
+	// CDN). Default S3Endpoint/S3Bucket.
+	S3PublicURL string
+}
+
+// MediaGCConfig mengatur job yang merekonsiliasi storage media dengan
+// referensi gambar di database.
+type MediaGCConfig struct {
+	// IntervalHours adalah MEDIA_GC_INTERVAL_HOURS, default 24. Nilai 0
+	// mematikan job.
+	IntervalHours int
+	// MinAgeHours adalah MEDIA_GC_MIN_AGE_HOURS, default 24, minimal 1 agar
+	// upload yang belum sempat dipasang tidak ikut dihapus. Objek yang lebih
+	// muda tidak pernah dianggap yatim.
+	MinAgeHours int
+	// Delete adalah MEDIA_GC_DELETE. Jika false job hanya melaporkan.
+	Delete bool
+}
+
+// IsDevelopment bernilai true jika APP_ENV=development.
+func (c *Config) IsDevelopment() bool {
+	return c.Env == "development"
+}
+
+// Addr adalah alamat listen server HTTP.
+func (c *Config) Addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// Load membaca konfigurasi dari environment. Jika CONFIG_FILE diisi, file
+// tersebut (format .env) wajib ada; jika tidak, .env di direktori kerja
+// dibaca bila ada. Variabel environment selalu menang atas isi file.
+func Load() (*Config, error) {
+	if file := os.Getenv("CONFIG_FILE"); file != "" {
+		if err := godotenv.Load(file); err != nil {
+			return nil, fmt.Errorf("load config file %s: %w", file, err)
+		}
+	} else if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load .env: %w", err)
+	}
+
+	cfg, err := FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// FromEnv membaca Config dari environment dan mengisi nilai default tanpa
+// memvalidasi nilai wajib.
+func FromEnv() (*Config, error) {
+	var problems []string
+	intEnv := func(key string, fallback int) int {
+		value := os.Getenv(key)
+		if value == "" {
+			return fallback
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s must be a number, got %q", key, value))
+		}
+		return n
+	}
+
+	cfg := &Config{
+		Env:       stringEnv("APP_ENV", "production"),
+		Port:      intEnv("PORT", 8080),
+		BaseURL:   strings.TrimSuffix(os.Getenv("BASE_URL"), "/"),
+		JWTSecret: os.Getenv("JWT_SECRET"),
+		Database: DatabaseConfig{
+			Driver:      stringEnv("DB_DRIVER", "mysql"),
+			Host:        os.Getenv("DB_HOST"),
+			Port:        os.Getenv("DB_PORT"),
+			User:        os.Getenv("DB_USER"),
+			Password:    os.Getenv("DB_PASSWORD"),
+			Name:        os.Getenv("DB_NAME"),
+			SSLMode:     stringEnv("DB_SSLMODE", "disable"),
+			AutoMigrate: os.Getenv("DB_AUTO_MIGRATE") == "true",
+		},
+		Storage: StorageConfig{
+			Driver:      stringEnv("STORAGE_DRIVER", "local"),
+			LocalDir:    stringEnv("STORAGE_LOCAL_DIR", "public/images"),
+			S3Endpoint:  os.Getenv("S3_ENDPOINT"),
+			S3Region:    stringEnv("S3_REGION", "us-east-1"),
+			S3Bucket:    os.Getenv("S3_BUCKET"),
+			S3AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			S3SecretKey: os.Getenv("S3_SECRET_KEY"),
+			S3PublicURL: os.Getenv("S3_PUBLIC_URL"),
+		},
+		SoftDeleteRetentionDays: intEnv("SOFT_DELETE_RETENTION_DAYS", 30),
+		MediaGC: MediaGCConfig{
+			IntervalHours: intEnv("MEDIA_GC_INTERVAL_HOURS", 24),
+			MinAgeHours:   intEnv("MEDIA_GC_MIN_AGE_HOURS", 24),
+			Delete:        os.Getenv("MEDIA_GC_DELETE") == "true",
+		},
+		Seeders:          splitList(os.Getenv("SEEDERS")),
+		SeedUserPassword: os.Getenv("SEED_USER_PASSWORD"),
+	}
+
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = fmt.Sprintf("http://localhost:%d", cfg.Port)
+	}
+	if cfg.Database.Port == "" {
+		switch cfg.Database.Driver {
+		case "mysql":
+			cfg.Database.Port = "3306"
+		case "postgres":
+			cfg.Database.Port = "5432"
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, invalid(problems)
+	}
+	return cfg, nil
+}
+
+// Validate memastikan nilai wajib terisi dan semua nilai masuk akal. Semua
+// masalah dilaporkan sekaligus.
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.Env != "development" && c.Env != "production" {
+		problems = append(problems, fmt.Sprintf("APP_ENV must be development or production, got %q", c.Env))
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("PORT must be between 1 and 65535, got %d", c.Port))
+	}
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("BASE_URL must be an absolute http(s) URL, got %q", c.BaseURL))
+	}
+	if c.JWTSecret == "" {
+		problems = append(problems, "JWT_SECRET is required")
+	} else if len(c.JWTSecret) < 16 && !c.IsDevelopment() {
+		problems = append(problems, "JWT_SECRET must be at least 16 characters outside development")
+	}
+	if c.SoftDeleteRetentionDays < 1 {
+		problems = append(problems, "SOFT_DELETE_RETENTION_DAYS must be at least 1")
+	}
+	if c.MediaGC.IntervalHours < 0 {
+		problems = append(problems, "MEDIA_GC_INTERVAL_HOURS must not be negative")
+	}
+	if c.MediaGC.MinAgeHours < 1 {
+		problems = append(problems, "MEDIA_GC_MIN_AGE_HOURS must be at least 1")
+	}
+
+	switch c.Database.Driver {
+	case "mysql", "postgres":
+		for _, required := range [][2]string{{"DB_HOST", c.Database.Host}, {"DB_USER", c.Database.User}, {"DB_NAME", c.Database.Name}} {
+			if required[1] == "" {
+				problems = append(problems, required[0]+" is required for DB_DRIVER="+c.Database.Driver)
+			}
+		}
+	case "sqlite":
+		if c.Database.Name == "" {
+			problems = append(problems, "DB_NAME is required for DB_DRIVER=sqlite")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unsupported DB_DRIVER %q, use mysql, postgres or sqlite", c.Database.Driver))
+	}
+
+	switch c.Storage.Driver {
+	case "local":
+		if c.Storage.LocalDir == "" {
+			problems = append(problems, "STORAGE_LOCAL_DIR is required for STORAGE_DRIVER=local")
+		}
+	case "s3":
+		for _, required := range [][2]string{{"S3_ENDPOINT", c.Storage.S3Endpoint}, {"S3_BUCKET", c.Storage.S3Bucket}, {"S3_ACCESS_KEY", c.Storage.S3AccessKey}, {"S3_SECRET_KEY", c.Storage.S3SecretKey}} {
+			if required[1] == "" {
+				problems = append(problems, required[0]+" is required for STORAGE_DRIVER=s3")
+			}
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unsupported STORAGE_DRIVER %q, use local or s3", c.Storage.Driver))
+	}
+
+	if len(problems) > 0 {
+		return invalid(problems)
+	}
+	return nil
+}
+
+// OpenStorage membuat backend storage sesuai c.Storage.
+func (c *Config) OpenStorage() (storage.Storage, error) {
+	if c.Storage.Driver == "s3" {
+		return storage.NewS3(storage.S3Options{
+			Endpoint:  c.Storage.S3Endpoint,
+			Region:    c.Storage.S3Region,
+			Bucket:    c.Storage.S3Bucket,
+			AccessKey: c.Storage.S3AccessKey,
+			SecretKey: c.Storage.S3SecretKey,
+			PublicURL: c.Storage.S3PublicURL,
+		})
+	}
+	return storage.NewLocal(c.Storage.LocalDir, c.BaseURL+"/media"), nil
+}
+
+func invalid(problems []string) error {
+	return errors.New("invalid configuration:\n  " + strings.Join(problems, "\n  "))
+}
+
+func stringEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// splitList memecah daftar yang dipisah koma dan membuang item kosong.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigDefaultsAndValidation(t *testing.T) {
+	for _, key := range []string{"APP_ENV", "PORT", "BASE_URL", "JWT_SECRET", "DB_DRIVER", "DB_HOST", "DB_PORT", "DB_USER", "DB_NAME", "SOFT_DELETE_RETENTION_DAYS"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("PORT", "9090")
+	t.Setenv("DB_DRIVER", "postgres")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BaseURL != "http://localhost:9090" || cfg.Addr() != ":9090" || cfg.Database.Port != "5432" || cfg.SoftDeleteRetentionDays != 30 {
+		t.Fatalf("defaults = %+v", cfg)
+	}
+
+	// Semua masalah dilaporkan sekaligus.
+	err = cfg.Validate()
+	if err == nil {
+		t.Fatal("config without JWT_SECRET and database settings is valid")
+	}
+	for _, want := range []string{"JWT_SECRET is required", "DB_HOST is required", "DB_USER is required", "DB_NAME is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	// Retensi 0 akan langsung menghapus permanen data yang baru di-soft delete
+	cfg.SoftDeleteRetentionDays = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "SOFT_DELETE_RETENTION_DAYS must be at least 1") {
+		t.Errorf("retention 0: err = %v", err)
+	}
+	cfg.MediaGC.MinAgeHours = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "MEDIA_GC_MIN_AGE_HOURS must be at least 1") {
+		t.Errorf("media gc min age 0: err = %v", err)
+	}
+
+	t.Setenv("PORT", "eighty")
+	if _, err := FromEnv(); err == nil || !strings.Contains(err.Error(), "PORT must be a number") {
+		t.Fatalf("invalid PORT: err = %v", err)
+	}
+}
+
+func TestConfigLoadsOptionalFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.env")
+	content := "JWT_SECRET=from-file-secret-value\nDB_DRIVER=sqlite\nDB_NAME=file.db\nBASE_URL=https://api.example.com/ml/\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// godotenv tidak menimpa variabel yang sudah ada, walaupun kosong.
+	for _, key := range []string{"APP_ENV", "PORT", "BASE_URL", "JWT_SECRET", "DB_DRIVER", "DB_NAME"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	t.Setenv("CONFIG_FILE", file)
+	// Environment menang atas isi file.
+	t.Setenv("DB_NAME", "env.db")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.JWTSecret != "from-file-secret-value" || cfg.Database.Name != "env.db" || cfg.BaseURL != "https://api.example.com/ml" {
+		t.Fatalf("cfg = %+v", cfg)
+	}
+
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.env"))
+	if _, err := Load(); err == nil {
+		t.Fatal("missing CONFIG_FILE was accepted")
+	}
+}
@@ -3,63 +3,96 @@ package config
 import (
 	"fmt"
 	"log"
-	"ml-master-data/models"
-	"os"
+	"ml-master-data/migrations"
+	"ml-master-data/services"
+	"strings"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
-func ConnectDatabase() {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_NAME"))
+// OpenDatabase membuka koneksi tanpa menjalankan migration maupun memasang
+// callback audit. Dipakai langsung oleh subcommand migrate.
+func OpenDatabase(cfg DatabaseConfig) *gorm.DB {
+	dialector, err := Dialector(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	database, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	database, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
 		panic("Failed to connect to database!")
 	}
 
-	fmt.Println("Connected to database successfully")
-
-	err = database.AutoMigrate(
-		&models.User{},
-		&models.Tournament{},
-		&models.Match{},
-		&models.PlayerMatch{},
-		&models.CoachMatch{},
-		&models.Game{},
-		&models.Team{},
-		&models.Player{},
-		&models.Coach{},
-		&models.Hero{},
-		&models.MatchTeamDetail{},
-		&models.HeroPick{},
-		&models.HeroBan{},
-		&models.HeroPickGame{},
-		&models.HeroBanGame{},
-		&models.PriorityPick{},
-		&models.PriorityBan{},
-		&models.FlexPick{},
-		&models.GameResult{},
-		&models.TrioMid{},
-		&models.TrioMidHero{},
-		&models.Goldlaner{},
-		&models.Explaner{},
-		&models.TurtleResult{},
-		&models.LordResult{},
-	)
+	log.Println("Connected to database successfully")
+
+	return database
+}
+
+// Dialector memilih driver database berdasarkan cfg.Driver (mysql, postgres
+// atau sqlite). Untuk sqlite, cfg.Name berisi path file database atau
+// ":memory:".
+func Dialector(cfg DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "", "mysql":
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
+		return mysql.Open(dsn), nil
+
+	case "postgres":
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+			cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port, cfg.SSLMode)
+		return postgres.Open(dsn), nil
+
+	case "sqlite":
+		return sqlite.Open(SQLiteDSN(cfg.Name)), nil
+	}
+
+	return nil, fmt.Errorf("unsupported DB_DRIVER %q, use mysql, postgres or sqlite", cfg.Driver)
+}
+
+// SQLiteDSN menambahkan pragma foreign_keys karena SQLite tidak menegakkan
+// foreign key secara default.
+func SQLiteDSN(path string) string {
+	if strings.Contains(path, "_pragma=foreign_keys") {
+		return path
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "_pragma=foreign_keys(1)"
+}
+
+// ConnectDatabase membuka koneksi, menjalankan migration yang tertunda jika
+// cfg.AutoMigrate, memasang callback audit lalu menyimpannya di DB.
+func ConnectDatabase(cfg DatabaseConfig) {
+	database := OpenDatabase(cfg)
 
+	pending, err := migrations.Pending(database)
 	if err != nil {
-		log.Fatal("Failed to migrate database: ", err)
+		log.Fatal("Failed to read migration status: ", err)
 	}
 
-	log.Println("Database migrated successfully")
+	if pending > 0 {
+		if !cfg.AutoMigrate {
+			log.Fatalf("Database has %d pending migrations, run `go run . migrate up` first", pending)
+		}
+
+		if err := migrations.Up(database); err != nil {
+			log.Fatal("Failed to migrate database: ", err)
+		}
+
+		log.Println("Database migrated successfully")
+	}
+
+	if err := services.RegisterAuditCallbacks(database); err != nil {
+		log.Fatal("Failed to register audit callbacks: ", err)
+	}
 
 	DB = database
 }
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ml-master-data/dto"
+	"ml-master-data/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditController menampilkan riwayat perubahan data.
+type AuditController struct {
+	audits *services.AuditService
+}
+
+func NewAuditController(audits *services.AuditService) *AuditController {
+	return &AuditController{audits: audits}
+}
+
+// GetAuditLogs godoc
+// @Summary Get audit logs
+// @Description Get the history of data changes, newest first, filtered by entity or user
+// @Tags Audit
+// @Security Bearer
+// @Produce json
+// @Param entity_type query string false "Entity type (table name), e.g. matches"
+// @Param entity_id query int false "Entity ID"
+// @Param user_id query int false "User ID of the actor"
+// @Param action query string false "Action (create, update, delete)"
+// @Param limit query int false "Maximum number of rows (default 50, max 500)"
+// @Param offset query int false "Number of rows to skip"
+// @Success 200 {array} dto.AuditLogResponseDto
+// @Failure 400 {string} string "Invalid input"
+// @Failure 500 {string} string "Internal server error"
+// @Router /audit [get]
+func (h *AuditController) GetAuditLogs(c *gin.Context) {
+	input := dto.AuditLogQueryDto{}
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	auditLogs, err := h.audits.GetLogs(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]dto.AuditLogResponseDto, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		response = append(response, dto.AuditLogResponseDto{
+			AuditLogID: auditLog.AuditLogID,
+			UserID:     auditLog.UserID,
+			Username:   auditLog.Username,
+			EntityType: auditLog.EntityType,
+			EntityID:   auditLog.EntityID,
+			Action:     auditLog.Action,
+			Before:     auditSnapshot(auditLog.Before),
+			After:      auditSnapshot(auditLog.After),
+			CreatedAt:  auditLog.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func auditSnapshot(snapshot string) json.RawMessage {
+	if snapshot == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(snapshot)
+}
@@ -1,15 +1,26 @@
 package controllers
 
 import (
-	"ml-master-data/config"
+	"errors"
+
 	"ml-master-data/dto"
 	"ml-master-data/models"
+	"ml-master-data/services"
 	"ml-master-data/utils"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
 )
 
+// AuthController menangani login dan profil user yang sedang login.
+type AuthController struct {
+	users     *services.UserService
+	jwtSecret string
+}
+
+func NewAuthController(users *services.UserService, jwtSecret string) *AuthController {
+	return &AuthController{users: users, jwtSecret: jwtSecret}
+}
+
 // @Summary Login
 // @Description Login to get JWT token
 // @Tags Auth
@@ -21,7 +32,7 @@ import (
 // @Failure 401 {string} string "Invalid credentials"
 // @Failure 500 {string} string "Internal server error"
 // @Router /login [post]
-func Login(c *gin.Context) {
+func (h *AuthController) Login(c *gin.Context) {
 	var loginDto dto.LoginDto
 
 	if err := c.ShouldBindJSON(&loginDto); err != nil {
@@ -29,18 +40,17 @@ func Login(c *gin.Context) {
 		return
 	}
 
-	var user models.User
-	if err := config.DB.Where("username = ?", loginDto.Username).First(&user).Error; err != nil {
+	user, err := h.users.Authenticate(c.Request.Context(), loginDto.Username, loginDto.Password)
+	if errors.Is(err, services.ErrInvalidCredentials) {
 		c.JSON(401, gin.H{"error": "Invalid credentials"})
 		return
 	}
-
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(loginDto.Password)); err != nil {
-		c.JSON(401, gin.H{"error": "Invalid credentials"})
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
 		return
 	}
 
-	token, err := utils.GenerateJWT(user)
+	token, err := utils.GenerateJWT(h.jwtSecret, user)
 	if err != nil {
 		c.JSON(500, gin.H{"error": "Could not generate token"})
 		return
@@ -57,7 +67,7 @@ func Login(c *gin.Context) {
 // @Success 200 {object} models.User "User data"
 // @Failure 500 {string} string "Internal server error"
 // @Router /me [get]
-func Me(c *gin.Context) {
+func (h *AuthController) Me(c *gin.Context) {
 	userCtx, _ := c.Get("user")
 
 	var user models.User
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"ml-master-data/dto"
+	"ml-master-data/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBundleSize membatasi ukuran body restore, termasuk file gambar yang
+// di-encode base64.
+const maxBundleSize = 64 << 20
+
+// BackupController menangani backup dan restore Tournament sebagai JSON.
+type BackupController struct {
+	backups *services.TournamentBackupService
+}
+
+func NewBackupController(backups *services.TournamentBackupService) *BackupController {
+	return &BackupController{backups: backups}
+}
+
+// BackupTournament godoc
+// @Summary Download a tournament backup
+// @Description Download a versioned JSON bundle with the tournament, its draft rules and every match, team detail, player and coach assignment, game, draft, lane result and objective under it, together with the teams, players, coaches, heroes and patches they reference. Deleted matches and games are left out.
+// @Tags Tournament
+// @Security Bearer
+// @Produce json
+// @Param tournamentID path string true "Tournament ID"
+// @Success 200 {object} dto.TournamentBundleDto
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Tournament not found"
+// @Router /tournaments/{tournamentID}/backup [get]
+func (h *BackupController) BackupTournament(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
+		return
+	}
+
+	bundle, err := h.backups.Backup(c.Request.Context(), tournamentID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tournament-%d.json"`, tournamentID))
+	c.Data(http.StatusOK, gin.MIMEJSON+"; charset=utf-8", data)
+}
+
+// RestoreTournamentBackup godoc
+// @Summary Restore a tournament backup
+// @Description Create a new tournament from a bundle made by GET /tournaments/{tournamentID}/backup, possibly on another instance. Teams, heroes and patches are matched by name or version and players and coaches by team and name; missing ones are created and existing ones are left unchanged. All IDs are remapped. Image files in the bundle are processed like uploads and stored under new keys. Nothing is saved if the bundle is invalid.
+// @Tags Tournament
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param name query string false "Name of the new tournament (default the name in the bundle)"
+// @Param bundle body dto.TournamentBundleDto true "Tournament backup"
+// @Success 201 {object} dto.TournamentRestoreDto
+// @Failure 400 {string} string "Invalid input"
+// @Failure 413 {string} string "Backup is too large"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tournaments/backup [post]
+func (h *BackupController) RestoreTournamentBackup(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBundleSize)
+
+	var bundle dto.TournamentBundleDto
+	if err := c.ShouldBindJSON(&bundle); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Backup must not exceed %d MB", maxBundleSize>>20)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.backups.Restore(c.Request.Context(), bundle, c.Query("name"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"ml-master-data/dto"
+	"ml-master-data/services"
+	"ml-master-data/tabular"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paramID membaca path parameter berupa ID. Jika tidak valid, respon 400
+// langsung dikirim dan ok bernilai false.
+func paramID(c *gin.Context, name string) (id uint, ok bool) {
+	value, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return 0, false
+	}
+	return uint(value), true
+}
+
+// respondError memetakan error dari service ke status HTTP yang sesuai.
+func respondError(c *gin.Context, err error) {
+	var notFound *services.NotFoundError
+	var conflict *services.ConflictError
+	var invalid *services.ValidationError
+
+	switch {
+	case errors.As(err, &notFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &conflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.As(err, &invalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// statsPatch membaca filter ?patch=<versi> endpoint statistik dan
+// mengembalikan ID patch-nya, atau nil jika tidak difilter. Jika tidak
+// valid, respon error langsung dikirim dan ok bernilai false.
+func statsPatch(c *gin.Context, patches *services.PatchService) (patchID *uint, ok bool) {
+	var query dto.StatisticsQueryDto
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if query.Patch == "" {
+		return nil, true
+	}
+
+	patch, err := patches.GetByVersion(c.Request.Context(), query.Patch)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	return &patch.PatchID, true
+}
+
+// mimeCSV adalah content type export CSV.
+const mimeCSV = "text/csv"
+
+// respondList mengirim data daftar atau statistik sebagai JSON, atau sebagai
+// CSV jika diminta lewat ?format=csv atau header Accept: text/csv. name
+// dipakai sebagai nama file CSV.
+func respondList(c *gin.Context, name string, data interface{}) {
+	var format string
+	switch c.Query("format") {
+	case "csv":
+		format = mimeCSV
+	case "json":
+		format = gin.MIMEJSON
+	case "":
+		format = c.NegotiateFormat(gin.MIMEJSON, mimeCSV)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+		return
+	}
+	if format != mimeCSV {
+		c.JSON(http.StatusOK, data)
+		return
+	}
+
+	table, err := tabular.New(data)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	var buf bytes.Buffer
+	if err := table.WriteCSV(&buf); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, name))
+	c.Data(http.StatusOK, mimeCSV+"; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ml-master-data/dto"
+	"ml-master-data/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventHeartbeat adalah jeda komentar kosong di stream SSE agar proxy tidak
+// menutup koneksi yang sedang sepi.
+const eventHeartbeat = 15 * time.Second
+
+// EventController menangani stream Server-Sent Events perubahan data match
+// dan tournament.
+type EventController struct {
+	events      *services.EventBus
+	matches     *services.MatchService
+	tournaments *services.TournamentService
+}
+
+func NewEventController(events *services.EventBus, matches *services.MatchService, tournaments *services.TournamentService) *EventController {
+	return &EventController{events: events, matches: matches, tournaments: tournaments}
+}
+
+// MatchEvents godoc
+// @Summary Stream changes of a match
+// @Description Server-Sent Events stream of changes to a match as they are saved: the match itself, games (including the winner), lord and turtle results, and hero picks and bans. Each event is named after its type, for example "game.updated" or "hero_pick.created", and its data is a dto.EventDto. Reconnect with the Last-Event-ID header or last_event_id to receive events missed in between. Browsers' EventSource cannot send headers, so the token may be given as access_token instead.
+// @Tags Event
+// @Security Bearer
+// @Produce text/event-stream
+// @Param matchID path string true "Match ID"
+// @Param last_event_id query integer false "Resume after this event ID"
+// @Param access_token query string false "JWT, instead of the Authorization header"
+// @Success 200 {object} dto.EventDto
+// @Failure 404 {string} string "Match not found"
+// @Router /matches/{matchID}/events [get]
+func (h *EventController) MatchEvents(c *gin.Context) {
+	matchID, ok := paramID(c, "matchID")
+	if !ok {
+		return
+	}
+
+	if _, err := h.matches.GetByID(c.Request.Context(), matchID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.stream(c, services.EventFilter{MatchID: matchID})
+}
+
+// TournamentEvents godoc
+// @Summary Stream changes of a tournament
+// @Description Server-Sent Events stream of changes to every match of a tournament, with the same events as /matches/{matchID}/events plus matches being created and deleted.
+// @Tags Event
+// @Security Bearer
+// @Produce text/event-stream
+// @Param tournamentID path string true "Tournament ID"
+// @Param last_event_id query integer false "Resume after this event ID"
+// @Param access_token query string false "JWT, instead of the Authorization header"
+// @Success 200 {object} dto.EventDto
+// @Failure 404 {string} string "Tournament not found"
+// @Router /tournaments/{tournamentID}/events [get]
+func (h *EventController) TournamentEvents(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
+		return
+	}
+
+	if _, err := h.tournaments.GetByID(c.Request.Context(), tournamentID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.stream(c, services.EventFilter{TournamentID: tournamentID})
+}
+
+// stream mengirim event yang cocok dengan filter sampai client memutus
+// koneksi atau tertinggal terlalu jauh.
+func (h *EventController) stream(c *gin.Context, filter services.EventFilter) {
+	var query dto.EventStreamQueryDto
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lastID := query.LastEventID
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		if id, err := strconv.ParseUint(header, 10, 64); err == nil {
+			lastID = id
+		}
+	}
+
+	events, cancel := h.events.Subscribe(filter, lastID)
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// retry membuat EventSource tersambung ulang dalam satu detik
+	fmt.Fprint(c.Writer, "retry: 1000\n\n")
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			err = writeEvent(c.Writer, event)
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(c.Writer, ": ping\n\n")
+		}
+		if err != nil {
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, event dto.EventDto) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
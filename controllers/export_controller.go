@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"ml-master-data/services"
+	"ml-master-data/tabular"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mimeXLSX adalah content type workbook Excel.
+const mimeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// ExportController menangani export data ke spreadsheet.
+type ExportController struct {
+	exports *services.ExportService
+}
+
+func NewExportController(exports *services.ExportService) *ExportController {
+	return &ExportController{exports: exports}
+}
+
+// ExportTournament godoc
+// @Summary Export a tournament workbook
+// @Description Download an Excel workbook with one sheet each for matches, games, drafts, hero picks, hero bans, priority picks, priority bans, flex picks, lane early results and lord/turtle objectives. Teams and heroes are written by name.
+// @Tags Tournament
+// @Security Bearer
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param tournamentID path string true "Tournament ID"
+// @Success 200 {file} file
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Tournament not found"
+// @Router /tournaments/{tournamentID}/export.xlsx [get]
+func (h *ExportController) ExportTournament(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
+		return
+	}
+
+	sheets, err := h.exports.TournamentWorkbook(c.Request.Context(), tournamentID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tabular.WriteXLSX(&buf, sheets...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tournament-%d.xlsx"`, tournamentID))
+	c.Data(http.StatusOK, mimeXLSX, buf.Bytes())
+}
@@ -1,101 +1,110 @@
 package controllers
 
 import (
-	"fmt"
-	"ml-master-data/config"
+	"ml-master-data/dto"
 	"ml-master-data/models"
 	"ml-master-data/services"
-	"ml-master-data/utils"
 	"net/http"
-	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// HeroController menangani endpoint Hero.
+type HeroController struct {
+	heroes *services.HeroService
+	media  *services.MediaService
+}
+
+func NewHeroController(heroes *services.HeroService, media *services.MediaService) *HeroController {
+	return &HeroController{heroes: heroes, media: media}
+}
+
 // GetAllHeroes godoc
 // @Summary Get all heroes
-// @Description Get all heroes data
+// @Description Get all heroes data, optionally filtered by class, lane, name or alias and release date
 // @Tags Hero
-// @Produce json
+// @Produce json,text/csv
 // @Security Bearer
+// @Param class query string false "Primary or secondary class (tank, fighter, assassin, mage, marksman, support)"
+// @Param lane query string false "Viable lane (gold, exp, roam, mid, jungler)"
+// @Param q query string false "Part of the hero name or an alias"
+// @Param released_after query string false "Released on or after this date (YYYY-MM-DD)"
+// @Param released_before query string false "Released on or before this date (YYYY-MM-DD)"
+// @Param format query string false "Response format (json, csv); Accept: text/csv also selects CSV"
 // @Success 200 {array} models.Hero
+// @Failure 400 {string} string "Invalid input"
 // @Router /heroes [get]
-func GetAllHeroes(c *gin.Context) {
-	var heroes []models.Hero
+func (h *HeroController) GetAllHeroes(c *gin.Context) {
+	var query dto.HeroQueryDto
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	if err := config.DB.Find(&heroes).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	heroes, err := h.heroes.GetAll(c.Request.Context(), query)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, heroes)
+	respondList(c, "heroes", heroes)
 }
 
 // CreateHero godoc
 // @Summary Create a hero
 // @Description Create a hero and save its image
 // @Tags Hero
+// @Accept multipart/form-data,json
 // @Produce json
 // @Security Bearer
 // @Param name formData string true "Hero name"
-// @Param image formData file true "Hero image"
+// @Param image formData file false "Hero image"
+// @Param image_media_id formData integer false "Media ID from POST /media, instead of image"
+// @Param primary_class formData string false "Primary class (tank, fighter, assassin, mage, marksman, support)"
+// @Param secondary_class formData string false "Secondary class"
+// @Param release_date formData string false "Release date (YYYY-MM-DD)"
+// @Param lanes formData []string false "Viable lanes (gold, exp, roam, mid, jungler)" collectionFormat(multi)
+// @Param aliases formData []string false "Alternate names" collectionFormat(multi)
 // @Success 201 {object} models.Hero
 // @Router /heroes [post]
-func CreateHero(c *gin.Context) {
+func (h *HeroController) CreateHero(c *gin.Context) {
+	if err := limitUpload(c, services.HeroUploads); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	var input dto.HeroRequestDto
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	// Mengambil nama hero dari FormValue
-	name := c.PostForm("name")
-	if name == "" {
+	if input.Name == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Hero name is required"})
 		return
 	}
 
-	file, err := c.FormFile("image")
-	var heroImagePath string
+	// Membuat objek hero baru
+	hero := models.Hero{Name: input.Name}
+	if err := h.heroes.SetMetadata(&hero, input); err != nil {
+		respondError(c, err)
+		return
+	}
 
-	// Menetapkan path default jika tidak ada gambar
+	// Gambar dari file upload atau media ID; placeholder jika tidak ada
+	image, provided, err := receiveImage(c, h.media, services.HeroUploads, "image", input.ImageMediaID)
 	if err != nil {
-		heroImagePath = "https://placehold.co/400x600"
-	} else {
-		// Memeriksa ukuran file
-		if file.Size > 500*1024 { // 500 KB
-			c.JSON(http.StatusBadRequest, gin.H{"error": "File size must not exceed 500 KB"})
-			return
-		}
-
-		// Mendapatkan ekstensi file
-		ext := strings.ToLower(filepath.Ext(file.Filename))
-
-		// Validasi ekstensi file
-		if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type"})
-			return
-		}
-
-		// Membuat nama file unik
-		newFileName := utils.GenerateUniqueFileName("hero") + ext
-		heroImagePath = fmt.Sprintf("public/images/%s", newFileName)
-
-		// Menyimpan file yang diupload
-		if err := c.SaveUploadedFile(file, heroImagePath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save hero image"})
-			return
-		}
-
-		heroImagePath = os.Getenv("BASE_URL") + "/" + heroImagePath
+		respondError(c, err)
+		return
 	}
-
-	// Membuat objek hero baru
-	hero := models.Hero{
-		Name:  name,
-		Image: heroImagePath,
+	if !provided {
+		image = models.PlaceholderImage
 	}
+	hero.Image = image
 
 	// Menyimpan hero ke database
-	if err := config.DB.Create(&hero).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.heroes.Create(c.Request.Context(), &hero); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -112,12 +121,15 @@ func CreateHero(c *gin.Context) {
 // @Param heroID path string true "Hero ID"
 // @Success 200 {object} models.Hero
 // @Router /heroes/{heroID} [get]
-func GetHeroByID(c *gin.Context) {
-	heroID := c.Param("heroID")
+func (h *HeroController) GetHeroByID(c *gin.Context) {
+	heroID, ok := paramID(c, "heroID")
+	if !ok {
+		return
+	}
 
-	var hero models.Hero
-	if err := config.DB.First(&hero, heroID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Hero not found"})
+	hero, err := h.heroes.GetByID(c.Request.Context(), heroID)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -128,83 +140,70 @@ func GetHeroByID(c *gin.Context) {
 // @Summary Update a hero
 // @Description Update a hero and save its image
 // @Tags Hero
+// @Accept multipart/form-data,json
 // @Produce json
 // @Security Bearer
 // @Param heroID path string true "Hero ID"
 // @Param name formData string false "Hero name"
 // @Param image formData file false "Hero image"
+// @Param image_media_id formData integer false "Media ID from POST /media, instead of image"
+// @Param primary_class formData string false "Primary class (tank, fighter, assassin, mage, marksman, support)"
+// @Param secondary_class formData string false "Secondary class"
+// @Param release_date formData string false "Release date (YYYY-MM-DD)"
+// @Param lanes formData []string false "Viable lanes (gold, exp, roam, mid, jungler)" collectionFormat(multi)
+// @Param aliases formData []string false "Alternate names" collectionFormat(multi)
 // @Success 200 {object} models.Hero
 // @Router /heroes/{heroID} [put]
-func UpdateHero(c *gin.Context) {
-	heroID := c.Param("heroID")
-	if heroID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Hero ID is required"})
+func (h *HeroController) UpdateHero(c *gin.Context) {
+	heroID, ok := paramID(c, "heroID")
+	if !ok {
 		return
 	}
 
-	var hero models.Hero
-	if err := config.DB.First(&hero, heroID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Hero not found"})
+	hero, err := h.heroes.GetByID(c.Request.Context(), heroID)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	// Mengambil nama hero dari FormValue
-	name := c.PostForm("name")
+	if err := limitUpload(c, services.HeroUploads); err != nil {
+		respondError(c, err)
+		return
+	}
 
-	// Mengambil file gambar dari FormFile
-	file, err := c.FormFile("image")
+	var input dto.HeroRequestDto
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	// Memperbarui nama jika ada
-	if name != "" {
-		hero.Name = name
+	if input.Name != "" {
+		hero.Name = input.Name
+	}
+	if err := h.heroes.SetMetadata(&hero, input); err != nil {
+		respondError(c, err)
+		return
 	}
 
-	// Memeriksa jika ada gambar baru yang diupload
-	if err == nil {
-		// Memeriksa ukuran file
-		if file.Size > 500*1024 { // 500 KB
-			c.JSON(http.StatusBadRequest, gin.H{"error": "File size must not exceed 500 KB"})
-			return
-		}
-
-		// Validasi ekstensi file
-		ext := strings.ToLower(filepath.Ext(file.Filename))
-		if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type"})
-			return
-		}
-
-		// Cek apakah file gambar lama ada di sistem
-		if hero.Image != "" && hero.Image != "https://placehold.co/400x600" {
-			// Cek apakah file gambar lama ada di sistem
-			heroImagePath := strings.Replace(hero.Image, os.Getenv("BASE_URL")+"/", "", 1)
-			if _, err := os.Stat(heroImagePath); err == nil {
-				// Jika file ada, hapus file gambar lama dari folder images
-				if err := os.Remove(heroImagePath); err != nil {
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove old image"})
-					return
-				}
-			} else if os.IsNotExist(err) {
-				// Jika file tidak ada, berikan pesan peringatan (opsional)
-				c.JSON(http.StatusNotFound, gin.H{"warning": "Old image not found, skipping deletion"})
-			}
-		}
-
-		// Membuat nama file unik
-		newFileName := utils.GenerateUniqueFileName("hero") + ext
-		heroImagePath := fmt.Sprintf("public/images/%s", newFileName)
-
-		// Menyimpan file yang diupload
-		if err := c.SaveUploadedFile(file, heroImagePath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save new image"})
+	// Memeriksa jika ada gambar baru
+	image, provided, err := receiveImage(c, h.media, services.HeroUploads, "image", input.ImageMediaID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if provided {
+		// Hapus gambar lama dari storage
+		if err := h.media.Remove(c.Request.Context(), hero.Image); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove old image"})
 			return
 		}
-		hero.Image = os.Getenv("BASE_URL") + "/" + heroImagePath // Perbarui dengan path gambar baru
+		hero.Image = image
 	}
 
 	// Simpan perubahan ke database
-	if err := config.DB.Save(&hero).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.heroes.Update(c.Request.Context(), &hero); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -224,21 +223,14 @@ func UpdateHero(c *gin.Context) {
 // @Failure 404 {string} string "Hero not found" or "Old image not found, skipping deletion"
 // @Failure 500 {string} string "Failed to remove old image" or "Internal server error"
 // @Router /heroes/{heroID} [delete]
-func DeleteHero(c *gin.Context) {
-	heroID := c.Param("heroID")
-	if heroID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Hero ID is required"})
-		return
-	}
-
-	hero := models.Hero{}
-	if err := config.DB.Where("hero_id = ?", heroID).First(&hero).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Hero not found"})
+func (h *HeroController) DeleteHero(c *gin.Context) {
+	heroID, ok := paramID(c, "heroID")
+	if !ok {
 		return
 	}
 
-	if err := services.DeleteHero(config.DB, hero); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.heroes.Delete(c.Request.Context(), heroID); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ml-master-data/liquipedia"
+	"ml-master-data/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LiquipediaImportController menangani import match dari file Liquipedia.
+type LiquipediaImportController struct {
+	imports *services.LiquipediaImportService
+}
+
+func NewLiquipediaImportController(imports *services.LiquipediaImportService) *LiquipediaImportController {
+	return &LiquipediaImportController{imports: imports}
+}
+
+// ImportLiquipedia godoc
+// @Summary Import matches from Liquipedia files
+// @Description Import matches, games, hero picks, hero bans and drafts from saved Liquipedia pages: wikitext with Match templates (standalone, in a Matchlist or in a Bracket) or LPDB match2 JSON. Several files may be sent in the file field. Teams are matched by name or team template and heroes by name or alias, ignoring case, spacing and punctuation; the names field of the response lists how every name was matched, with suggestions for unknown names. The team on the blue side picks first, and picks and bans 1-3 count as the first phase. Matches imported before are matched by stage, date and teams, so the same file can be imported again. If any name is unknown nothing is imported and the errors are returned with status 400. With dry_run the diff is returned without saving anything.
+// @Tags Tournament
+// @Security Bearer
+// @Accept multipart/form-data
+// @Produce json
+// @Param tournamentID path string true "Tournament ID"
+// @Param file formData file true "Wikitext or JSON file"
+// @Param dry_run formData bool false "Only return the diff"
+// @Success 200 {object} dto.LiquipediaImportResultDto
+// @Failure 400 {object} dto.LiquipediaImportResultDto
+// @Failure 404 {string} string "Tournament not found"
+// @Router /tournaments/{tournamentID}/import/liquipedia [post]
+func (h *LiquipediaImportController) ImportLiquipedia(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
+		return
+	}
+
+	if !limitImport(c) {
+		return
+	}
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["file"]) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	var matches []liquipedia.Match
+	for _, header := range form.File["file"] {
+		if header.Size > maxImportSize {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		read, err := liquipedia.Read(header.Filename, file)
+		file.Close()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Liquipedia file " + header.Filename + ": " + err.Error()})
+			return
+		}
+		matches = append(matches, read...)
+	}
+
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+	result, err := h.imports.Import(c.Request.Context(), tournamentID, matches, dryRun)
+	var invalid *services.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		c.JSON(http.StatusBadRequest, result)
+	case err != nil:
+		respondError(c, err)
+	default:
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"ml-master-data/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediaController menangani upload gambar yang dipasang ke entity belakangan.
+type MediaController struct {
+	media *services.MediaService
+}
+
+func NewMediaController(media *services.MediaService) *MediaController {
+	return &MediaController{media: media}
+}
+
+// UploadMedia godoc
+// @Summary Upload an image
+// @Description Upload an image and get a media ID to attach later through image_media_id or full_draft_image_media_id. Send a multipart form with a "file" field, or the raw image as the request body. Unattached uploads expire.
+// @Tags Media
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param kind query string true "Entity kind: hero, team, player, coach or draft"
+// @Param file formData file true "Image"
+// @Success 201 {object} models.Media
+// @Failure 400 {string} string "Invalid kind, file type or size"
+// @Failure 500 {string} string "Internal server error"
+// @Router /media [post]
+func (h *MediaController) UploadMedia(c *gin.Context) {
+	// Policy harus diketahui sebelum body dibaca
+	policy, ok := services.UploadPolicies[c.Query("kind")]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be one of hero, team, player, coach or draft"})
+		return
+	}
+
+	// Sisa 1 MB untuk header dan field multipart
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, policy.MaxSize+1<<20)
+
+	body, err := mediaBody(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	media, err := h.media.Upload(c.Request.Context(), policy, body)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		err = policy.TooLarge()
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, media)
+}
+
+// mediaBody mengembalikan isi gambar tanpa menyimpan body ke memori atau
+// file sementara: part "file" dari multipart form dibaca langsung dari
+// stream, selain itu body request dianggap gambar mentah.
+func mediaBody(c *gin.Context) (io.Reader, error) {
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		return c.Request.Body, nil
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, &services.ValidationError{Message: "Invalid multipart form"}
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, &services.ValidationError{Message: "File is required"}
+		}
+		if err != nil {
+			return nil, &services.ValidationError{Message: "Invalid multipart form"}
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
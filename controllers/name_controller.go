@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"net/http"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NameController menangani pencocokan nama, pencarian, alias team dan
+// pemain, serta antrean review nama.
+type NameController struct {
+	names *services.NameResolverService
+}
+
+func NewNameController(names *services.NameResolverService) *NameController {
+	return &NameController{names: names}
+}
+
+// ResolveName godoc
+// @Summary Resolve a team, player or hero name
+// @Description Match a name the way importers do: by name, alias, or normalized name ignoring case, spacing, punctuation and, for teams, words like "Esports". A name that matches several entities, or only resembles some (for example "RRQ Hoshi" and "RRQ"), is ambiguous and lists the candidates. team_id limits player names to one team.
+// @Tags Name
+// @Security Bearer
+// @Produce json
+// @Param kind query string true "Entity kind (team, player, hero)"
+// @Param name query string true "Name to resolve"
+// @Param team_id query integer false "Team of the player"
+// @Success 200 {object} dto.NameResolutionDto
+// @Failure 400 {string} string "Invalid input"
+// @Router /names/resolve [get]
+func (h *NameController) ResolveName(c *gin.Context) {
+	var query dto.NameQueryDto
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.names.Resolve(c.Request.Context(), query.Kind, query.Name, query.TeamID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Search godoc
+// @Summary Search teams, players and heroes
+// @Description Search names and aliases of teams, players and heroes with exact, normalized, fuzzy and partial matching, best match first. At most 20 results are returned.
+// @Tags Name
+// @Security Bearer
+// @Produce json
+// @Param q query string true "Search text"
+// @Param kind query string false "Only this entity kind (team, player, hero)"
+// @Success 200 {array} dto.NameCandidateDto
+// @Failure 400 {string} string "Invalid input"
+// @Router /search [get]
+func (h *NameController) Search(c *gin.Context) {
+	var query dto.SearchQueryDto
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	results, err := h.names.Search(c.Request.Context(), query.Query, query.Kind)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// GetTeamAliases godoc
+// @Summary Get the aliases of a team
+// @Tags Name
+// @Security Bearer
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Success 200 {object} dto.AliasesDto
+// @Failure 404 {string} string "Team not found"
+// @Router /teams/{teamID}/aliases [get]
+func (h *NameController) GetTeamAliases(c *gin.Context) {
+	h.getAliases(c, models.NameKindTeam, "teamID")
+}
+
+// SetTeamAliases godoc
+// @Summary Replace the aliases of a team
+// @Description Replace the other names a team is known by, such as sponsor names or abbreviations. An alias already used by another team is rejected.
+// @Tags Name
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param dto body dto.AliasesDto true "Aliases"
+// @Success 200 {object} dto.AliasesDto
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Team not found"
+// @Failure 409 {string} string "Alias is already used"
+// @Router /teams/{teamID}/aliases [put]
+func (h *NameController) SetTeamAliases(c *gin.Context) {
+	h.setAliases(c, models.NameKindTeam, "teamID")
+}
+
+// GetPlayerAliases godoc
+// @Summary Get the aliases of a player
+// @Tags Name
+// @Security Bearer
+// @Produce json
+// @Param playerID path string true "Player ID"
+// @Success 200 {object} dto.AliasesDto
+// @Failure 404 {string} string "Player not found"
+// @Router /players/{playerID}/aliases [get]
+func (h *NameController) GetPlayerAliases(c *gin.Context) {
+	h.getAliases(c, models.NameKindPlayer, "playerID")
+}
+
+// SetPlayerAliases godoc
+// @Summary Replace the aliases of a player
+// @Description Replace the other names a player is known by, such as former IGNs. An alias already used by another player of the same team is rejected.
+// @Tags Name
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param playerID path string true "Player ID"
+// @Param dto body dto.AliasesDto true "Aliases"
+// @Success 200 {object} dto.AliasesDto
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Player not found"
+// @Failure 409 {string} string "Alias is already used"
+// @Router /players/{playerID}/aliases [put]
+func (h *NameController) SetPlayerAliases(c *gin.Context) {
+	h.setAliases(c, models.NameKindPlayer, "playerID")
+}
+
+func (h *NameController) getAliases(c *gin.Context, kind, param string) {
+	id, ok := paramID(c, param)
+	if !ok {
+		return
+	}
+
+	aliases, err := h.names.Aliases(c.Request.Context(), kind, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AliasesDto{Aliases: aliases})
+}
+
+func (h *NameController) setAliases(c *gin.Context, kind, param string) {
+	id, ok := paramID(c, param)
+	if !ok {
+		return
+	}
+
+	var input dto.AliasesDto
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	aliases, err := h.names.SetAliases(c.Request.Context(), kind, id, input.Aliases)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AliasesDto{Aliases: aliases})
+}
+
+// GetNameReviews godoc
+// @Summary Get the name review queue
+// @Description Get names from imports that could not be matched for certain, most frequent first, with their current candidates.
+// @Tags Name
+// @Security Bearer
+// @Produce json
+// @Param kind query string false "Entity kind (team, player, hero)"
+// @Param status query string false "Review status (pending, resolved, dismissed, all); default pending"
+// @Success 200 {array} dto.NameReviewDto
+// @Failure 400 {string} string "Invalid input"
+// @Router /name-reviews [get]
+func (h *NameController) GetNameReviews(c *gin.Context) {
+	var query dto.NameReviewQueryDto
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reviews, err := h.names.Reviews(c.Request.Context(), query.Kind, query.Status)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// ResolveNameReview godoc
+// @Summary Resolve a name review
+// @Description Decide which team, player or hero a reviewed name refers to. The name is saved as an alias of that entity so later imports match it.
+// @Tags Name
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param reviewID path string true "Name review ID"
+// @Param dto body dto.NameReviewResolveDto true "Entity"
+// @Success 200 {object} models.NameReview
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Name review or entity not found"
+// @Failure 409 {string} string "Name is already used by another entity"
+// @Router /name-reviews/{reviewID}/resolve [post]
+func (h *NameController) ResolveNameReview(c *gin.Context) {
+	reviewID, ok := paramID(c, "reviewID")
+	if !ok {
+		return
+	}
+
+	var input dto.NameReviewResolveDto
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.names.ResolveReview(c.Request.Context(), reviewID, input.EntityID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// DismissNameReview godoc
+// @Summary Dismiss a name review
+// @Description Mark a reviewed name as not referring to any existing team, player or hero; it is not queued again.
+// @Tags Name
+// @Security Bearer
+// @Produce json
+// @Param reviewID path string true "Name review ID"
+// @Success 200 {object} models.NameReview
+// @Failure 404 {string} string "Name review not found"
+// @Router /name-reviews/{reviewID}/dismiss [post]
+func (h *NameController) DismissNameReview(c *gin.Context) {
+	reviewID, ok := paramID(c, "reviewID")
+	if !ok {
+		return
+	}
+
+	review, err := h.names.DismissReview(c.Request.Context(), reviewID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
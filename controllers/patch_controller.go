@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"net/http"
+
+	"ml-master-data/dto"
+	"ml-master-data/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PatchController menangani endpoint Patch.
+type PatchController struct {
+	patches *services.PatchService
+}
+
+func NewPatchController(patches *services.PatchService) *PatchController {
+	return &PatchController{patches: patches}
+}
+
+// GetAllPatches godoc
+// @Summary Get all patches
+// @Description Get all game patches, newest release first
+// @Tags Patch
+// @Security Bearer
+// @Produce json,text/csv
+// @Param format query string false "Response format (json, csv); Accept: text/csv also selects CSV"
+// @Success 200 {array} models.Patch
+// @Failure 500 {string} string "Internal server error"
+// @Router /patches [get]
+func (h *PatchController) GetAllPatches(c *gin.Context) {
+	patches, err := h.patches.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondList(c, "patches", patches)
+}
+
+// GetPatchByID godoc
+// @Summary Get a patch by ID
+// @Description Get a game patch by ID
+// @Tags Patch
+// @Security Bearer
+// @Produce json
+// @Param patchID path string true "Patch ID"
+// @Success 200 {object} models.Patch
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Patch not found"
+// @Router /patches/{patchID} [get]
+func (h *PatchController) GetPatchByID(c *gin.Context) {
+	patchID, ok := paramID(c, "patchID")
+	if !ok {
+		return
+	}
+
+	patch, err := h.patches.GetByID(c.Request.Context(), patchID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, patch)
+}
+
+// CreatePatch godoc
+// @Summary Create a patch
+// @Description Create a game patch with its version and release date (YYYY-MM-DD)
+// @Tags Patch
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param dto body dto.PatchRequestDto true "Patch request"
+// @Success 201 {object} models.Patch
+// @Failure 400 {string} string "Invalid input"
+// @Failure 409 {string} string "Patch already exists"
+// @Router /patches [post]
+func (h *PatchController) CreatePatch(c *gin.Context) {
+	input := dto.PatchRequestDto{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	patch, err := h.patches.Create(c.Request.Context(), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, patch)
+}
+
+// UpdatePatch godoc
+// @Summary Update a patch
+// @Description Update the version or release date of a patch; games already recorded on it keep their patch
+// @Tags Patch
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param patchID path string true "Patch ID"
+// @Param dto body dto.PatchRequestDto true "Patch request"
+// @Success 200 {object} models.Patch
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Patch not found"
+// @Failure 409 {string} string "Patch already exists"
+// @Router /patches/{patchID} [put]
+func (h *PatchController) UpdatePatch(c *gin.Context) {
+	patchID, ok := paramID(c, "patchID")
+	if !ok {
+		return
+	}
+
+	input := dto.PatchRequestDto{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	patch, err := h.patches.Update(c.Request.Context(), patchID, input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, patch)
+}
+
+// DeletePatch godoc
+// @Summary Delete a patch
+// @Description Delete a patch that no game was played on
+// @Tags Patch
+// @Security Bearer
+// @Produce json
+// @Param patchID path string true "Patch ID"
+// @Success 200 {string} string "Patch deleted successfully"
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Patch not found"
+// @Failure 409 {string} string "Patch is used by games"
+// @Router /patches/{patchID} [delete]
+func (h *PatchController) DeletePatch(c *gin.Context) {
+	patchID, ok := paramID(c, "patchID")
+	if !ok {
+		return
+	}
+
+	if err := h.patches.Delete(c.Request.Context(), patchID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Patch deleted successfully"})
+}
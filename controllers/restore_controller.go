@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Restore a deleted tournament
+// @Description Restore a soft-deleted tournament together with the matches and games deleted with it
+// @Tags Tournament
+// @Security Bearer
+// @Produce json
+// @Param tournamentID path string true "Tournament ID"
+// @Success 200 {string} string "Tournament restored successfully"
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Tournament not found"
+// @Failure 409 {string} string "Tournament is not deleted"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tournaments/{tournamentID}/restore [post]
+func (h *TournamentController) RestoreTournament(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
+		return
+	}
+
+	if err := h.tournaments.Restore(c.Request.Context(), tournamentID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tournament restored successfully"})
+}
+
+// @Summary Restore a deleted team
+// @Description Restore a soft-deleted team together with the matches deleted with it
+// @Tags Team
+// @Security Bearer
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Success 200 {string} string "Team restored successfully"
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Team not found"
+// @Failure 409 {string} string "Team is not deleted"
+// @Failure 500 {string} string "Internal server error"
+// @Router /teams/{teamID}/restore [post]
+func (h *TeamController) RestoreTeam(c *gin.Context) {
+	teamID, ok := paramID(c, "teamID")
+	if !ok {
+		return
+	}
+
+	if err := h.teams.Restore(c.Request.Context(), teamID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Team restored successfully"})
+}
+
+// @Summary Restore a deleted match
+// @Description Restore a soft-deleted match together with the games deleted with it. The tournament and both teams must not be deleted.
+// @Tags Match
+// @Security Bearer
+// @Produce json
+// @Param matchID path string true "Match ID"
+// @Success 200 {string} string "Match restored successfully"
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Match not found"
+// @Failure 409 {string} string "Match is not deleted or its tournament/team is deleted"
+// @Failure 500 {string} string "Internal server error"
+// @Router /matches/{matchID}/restore [post]
+func (h *MatchController) RestoreMatch(c *gin.Context) {
+	matchID, ok := paramID(c, "matchID")
+	if !ok {
+		return
+	}
+
+	if err := h.matches.Restore(c.Request.Context(), matchID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Match restored successfully"})
+}
+
+// @Summary Restore a deleted game
+// @Description Restore a soft-deleted game. The match must not be deleted.
+// @Tags Game
+// @Security Bearer
+// @Produce json
+// @Param matchID path string true "Match ID"
+// @Param gameID path string true "Game ID"
+// @Success 200 {string} string "Game restored successfully"
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Game not found"
+// @Failure 409 {string} string "Game is not deleted or its match is deleted"
+// @Failure 500 {string} string "Internal server error"
+// @Router /matches/{matchID}/games/{gameID}/restore [post]
+func (h *GameController) RestoreGame(c *gin.Context) {
+	matchID, ok := paramID(c, "matchID")
+	if !ok {
+		return
+	}
+	gameID, ok := paramID(c, "gameID")
+	if !ok {
+		return
+	}
+
+	if err := h.games.Restore(c.Request.Context(), matchID, gameID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Game restored successfully"})
+}
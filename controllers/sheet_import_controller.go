@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ml-master-data/services"
+	"ml-master-data/tabular"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize membatasi ukuran file yang di-import; untuk import
+// Liquipedia batas ini berlaku untuk semua file sekaligus.
+const maxImportSize = 10 << 20
+
+// limitImport membatasi body request import lalu membaca form multipart-nya,
+// sehingga body yang terlalu besar ditolak sebelum seluruhnya diterima. Sisa
+// 1 MB untuk header dan field multipart. Mengembalikan false jika respon
+// sudah dikirim.
+func limitImport(c *gin.Context) bool {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize+1<<20)
+	var tooLarge *http.MaxBytesError
+	if err := c.Request.ParseMultipartForm(32 << 20); errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+		return false
+	}
+	return true
+}
+
+// SheetImportController menangani import match dan draft dari spreadsheet.
+type SheetImportController struct {
+	imports *services.SheetImportService
+}
+
+func NewSheetImportController(imports *services.SheetImportService) *SheetImportController {
+	return &SheetImportController{imports: imports}
+}
+
+// ImportTournament godoc
+// @Summary Import matches and drafts from a spreadsheet
+// @Description Import a CSV or XLSX file laid out like the tournament export workbook. Sheets: Matches, Match Players, Games, Hero Picks, Hero Bans, Drafts, Priority Picks, Priority Bans, Flex Picks, Lane Results and Objectives; headers may be written as "Team A Score" or "team_a_score". Teams, heroes and players are matched by name or ID. A match_id that does not exist in the tournament is a key for a new match listed in the Matches sheet. A CSV file holds one sheet named after the file (for example hero-picks.csv) unless the sheet field is given. Every row is validated first; if any row is invalid nothing is imported and the errors are returned with status 400. With dry_run the diff is returned without saving anything.
+// @Tags Tournament
+// @Security Bearer
+// @Accept multipart/form-data
+// @Produce json
+// @Param tournamentID path string true "Tournament ID"
+// @Param file formData file true "CSV or XLSX file"
+// @Param sheet formData string false "Sheet name of a CSV file"
+// @Param dry_run formData bool false "Only return the diff"
+// @Success 200 {object} dto.SheetImportResultDto
+// @Failure 400 {object} dto.SheetImportResultDto
+// @Failure 404 {string} string "Tournament not found"
+// @Router /tournaments/{tournamentID}/import [post]
+func (h *SheetImportController) ImportTournament(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
+		return
+	}
+
+	if !limitImport(c) {
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if header.Size > maxImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	sheets, err := tabular.Read(header.Filename, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid spreadsheet: " + err.Error()})
+		return
+	}
+	if name := c.PostForm("sheet"); name != "" && len(sheets) == 1 {
+		sheets[0].Name = name
+	}
+
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+	result, err := h.imports.Import(c.Request.Context(), tournamentID, sheets, dryRun)
+	var invalid *services.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		c.JSON(http.StatusBadRequest, result)
+	case err != nil:
+		respondError(c, err)
+	default:
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -3,32 +3,39 @@ package controllers
 import (
 	"net/http"
 
-	"ml-master-data/config" // Ganti dengan path yang sesuai untuk package database Anda
 	"ml-master-data/dto"
-	"ml-master-data/models"
 	"ml-master-data/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+// TournamentController menangani endpoint Tournament.
+type TournamentController struct {
+	tournaments *services.TournamentService
+}
+
+func NewTournamentController(tournaments *services.TournamentService) *TournamentController {
+	return &TournamentController{tournaments: tournaments}
+}
+
 // GetAllTournaments gets all tournaments
 // @Summary Get all tournaments
 // @Description Get all tournaments
 // @Tags Tournament
 // @Security Bearer
-// @Produce json
+// @Produce json,text/csv
+// @Param format query string false "Response format (json, csv); Accept: text/csv also selects CSV"
 // @Success 200 {array} models.Tournament
 // @Failure 500 {string} string "Internal server error"
 // @Router /tournaments [get]
-func GetAllTournaments(c *gin.Context) {
-	var tournaments []models.Tournament
-
-	if err := config.DB.Find(&tournaments).Error; err != nil {
+func (h *TournamentController) GetAllTournaments(c *gin.Context) {
+	tournaments, err := h.tournaments.GetAll(c.Request.Context())
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, tournaments)
+	respondList(c, "tournaments", tournaments)
 }
 
 // GetTournamentByID gets a tournament by ID
@@ -42,17 +49,15 @@ func GetAllTournaments(c *gin.Context) {
 // @Failure 400 {string} string "Invalid input"
 // @Failure 404 {string} string "Tournament not found"
 // @Router /tournaments/{tournamentID} [get]
-func GetTournamentByID(c *gin.Context) {
-	tournamentID := c.Param("tournamentID")
-	if tournamentID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Tournament ID is required"})
+func (h *TournamentController) GetTournamentByID(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
 		return
 	}
 
-	var tournament models.Tournament
-
-	if err := config.DB.First(&tournament, tournamentID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+	tournament, err := h.tournaments.GetByID(c.Request.Context(), tournamentID)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -70,7 +75,7 @@ func GetTournamentByID(c *gin.Context) {
 // @Failure 400 {string} string "Invalid input"
 // @Failure 500 {string} string "Internal server error"
 // @Router /tournaments [post]
-func CreateTournament(c *gin.Context) {
+func (h *TournamentController) CreateTournament(c *gin.Context) {
 
 	input := dto.TournamentRequestDto{}
 
@@ -79,11 +84,8 @@ func CreateTournament(c *gin.Context) {
 		return
 	}
 
-	var tournament models.Tournament
-
-	tournament.Name = input.Name
-
-	if err := config.DB.Create(&tournament).Error; err != nil {
+	tournament, err := h.tournaments.Create(c.Request.Context(), input)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -104,17 +106,9 @@ func CreateTournament(c *gin.Context) {
 // @Failure 404 {string} string "Tournament not found"
 // @Failure 500 {string} string "Internal server error"
 // @Router /tournaments/{tournamentID} [put]
-func UpdateTournament(c *gin.Context) {
-	tournamentID := c.Param("tournamentID")
-	if tournamentID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Tournament ID is required"})
-		return
-	}
-
-	var tournament models.Tournament
-
-	if err := config.DB.First(&tournament, tournamentID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+func (h *TournamentController) UpdateTournament(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
 		return
 	}
 
@@ -125,13 +119,9 @@ func UpdateTournament(c *gin.Context) {
 		return
 	}
 
-	if input.Name != "" {
-		tournament.Name = input.Name
-	}
-
-	// Update the tournament's name
-	if err := config.DB.Save(&tournament).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	tournament, err := h.tournaments.Update(c.Request.Context(), tournamentID, input)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -149,25 +139,76 @@ func UpdateTournament(c *gin.Context) {
 // @Failure 404 {string} string "Tournament not found"
 // @Failure 500 {string} string "Internal server error"
 // @Router /tournaments/{tournamentID} [delete]
-func DeleteTournament(c *gin.Context) {
-	tournamentID := c.Param("tournamentID")
-	var tournament models.Tournament
+func (h *TournamentController) DeleteTournament(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
+		return
+	}
+
+	if err := h.tournaments.Delete(c.Request.Context(), tournamentID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tournament deleted successfully"})
+}
 
-	if err := config.DB.First(&tournament, tournamentID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+// GetTournamentRule godoc
+// @Summary Get tournament draft rules
+// @Description Get the draft rules of a tournament: draft format, bans per phase, fearless mode and disabled heroes. Tournaments without rules return the unrestricted defaults.
+// @Tags Tournament
+// @Security Bearer
+// @Produce json
+// @Param tournamentID path string true "Tournament ID"
+// @Success 200 {object} models.TournamentRule
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Tournament not found"
+// @Router /tournaments/{tournamentID}/rules [get]
+func (h *TournamentController) GetTournamentRule(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
 		return
 	}
 
-	tournament = models.Tournament{}
-	if err := config.DB.Where("tournament_id = ?", tournamentID).First(&tournament).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+	rule, err := h.tournaments.GetRule(c.Request.Context(), tournamentID)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	if err := services.DeleteTournament(config.DB, uint(tournament.TournamentID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	c.JSON(http.StatusOK, rule)
+}
+
+// UpdateTournamentRule godoc
+// @Summary Update tournament draft rules
+// @Description Update the draft rules of a tournament. New hero picks and bans are validated against them; drafts already recorded are not re-checked.
+// @Tags Tournament
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param tournamentID path string true "Tournament ID"
+// @Param dto body dto.TournamentRuleRequestDto true "Tournament rule request"
+// @Success 200 {object} models.TournamentRule
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Tournament not found"
+// @Router /tournaments/{tournamentID}/rules [put]
+func (h *TournamentController) UpdateTournamentRule(c *gin.Context) {
+	tournamentID, ok := paramID(c, "tournamentID")
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Tournament deleted successfully"})
+	input := dto.TournamentRuleRequestDto{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.tournaments.UpdateRule(c.Request.Context(), tournamentID, input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
 }
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"ml-master-data/models"
+	"ml-master-data/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// receiveImage membaca gambar baru dari request untuk entity dengan policy:
+// file multipart pada field, atau mediaID hasil POST /media untuk klien
+// yang hanya mengirim JSON. provided bernilai false jika request tidak
+// membawa gambar.
+func receiveImage(c *gin.Context, media *services.MediaService, policy services.UploadPolicy, field string, mediaID uint) (image models.Image, provided bool, err error) {
+	if err := limitUpload(c, policy); err != nil {
+		return "", true, err
+	}
+	if header, err := c.FormFile(field); err == nil {
+		// Ukuran dari header multipart ditolak lebih awal sebelum diproses
+		if header.Size > policy.MaxSize {
+			return "", true, policy.TooLarge()
+		}
+		file, err := header.Open()
+		if err != nil {
+			return "", true, err
+		}
+		defer file.Close()
+
+		image, err = media.Store(c.Request.Context(), policy, file)
+		return image, true, err
+	}
+
+	if mediaID != 0 {
+		image, err = media.Take(c.Request.Context(), mediaID, policy)
+		return image, true, err
+	}
+	return "", false, nil
+}
+
+// limitUpload membatasi body multipart sesuai policy lalu membaca form-nya,
+// sehingga file besar ditolak sebelum seluruhnya diterima. Harus dipanggil
+// sebelum ShouldBind karena binding ikut membaca form; form yang sudah
+// dibaca tidak dibatasi lagi.
+func limitUpload(c *gin.Context, policy services.UploadPolicy) error {
+	if !strings.HasPrefix(c.ContentType(), "multipart/") || c.Request.MultipartForm != nil {
+		return nil
+	}
+
+	// Sisa 1 MB untuk header dan field multipart, sama seperti POST /media
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, policy.MaxSize+1<<20)
+	err := c.Request.ParseMultipartForm(32 << 20)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return policy.TooLarge()
+	}
+	if err != nil {
+		return &services.ValidationError{Message: "Invalid multipart form"}
+	}
+	return nil
+}
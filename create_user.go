@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"os"
+	"strings"
+)
+
+// runCreateUser menjalankan subcommand `create-user -username name
+// [-password secret]`. Tanpa -password, password dibaca dari baris pertama
+// stdin agar tidak tersimpan di history shell.
+func runCreateUser(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("create-user", flag.ExitOnError)
+	username := flags.String("username", "", "username of the new user")
+	password := flags.String("password", "", "password of the new user, read from stdin if empty")
+	flags.Parse(args)
+
+	if *username == "" {
+		log.Fatal("-username is required")
+	}
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			log.Fatal("Failed to read password: ", err)
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	config.ConnectDatabase(cfg.Database)
+
+	ctx := services.WithAuditActor(context.Background(), cliActor)
+	user, err := services.New(repositories.New(config.DB, openStorage(cfg))).Users.Create(ctx, *username, *password)
+	if err != nil {
+		log.Fatal("Failed to create user: ", err)
+	}
+	fmt.Printf("Created user %q (user_id %d)\n", user.Username, user.UserID)
+}
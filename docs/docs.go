@@ -15,6 +15,84 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the history of data changes, newest first, filtered by entity or user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Get audit logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity type (table name), e.g. matches",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the actor",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action (create, update, delete)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rows (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AuditLogResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/coaches/{coachID}": {
             "get": {
                 "security": [
@@ -76,6 +154,10 @@ const docTemplate = `{
                     }
                 ],
                 "description": "Update a coach in a team and save its image",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -102,6 +184,12 @@ const docTemplate = `{
                         "description": "Coach image",
                         "name": "image",
                         "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of image",
+                        "name": "image_media_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -112,7 +200,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Coach ID is required\" or \"File size must not exceed 500 KB\" or \"Invalid file type",
+                        "description": "Coach ID is required\" or \"File size must not exceed 2 MB\" or \"Invalid file type",
                         "schema": {
                             "type": "string"
                         }
@@ -188,7 +276,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Game"
@@ -208,6 +297,12 @@ const docTemplate = `{
                         "name": "gameID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -522,7 +617,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Game"
@@ -542,6 +638,12 @@ const docTemplate = `{
                         "name": "gameID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -550,7 +652,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/controllers.GameResultDto"
+                                "$ref": "#/definitions/dto.GameResultDto"
                             }
                         }
                     },
@@ -587,7 +689,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Game"
@@ -607,6 +710,12 @@ const docTemplate = `{
                         "name": "gameID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1015,7 +1124,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.TrioMidResultDto"
+                            "$ref": "#/definitions/dto.TrioMidResultDto"
                         }
                     }
                 ],
@@ -1059,7 +1168,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Game"
@@ -1079,6 +1189,12 @@ const docTemplate = `{
                         "name": "gameID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1390,14 +1506,53 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Get all heroes data",
+                "description": "Get all heroes data, optionally filtered by class, lane, name or alias and release date",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Hero"
                 ],
                 "summary": "Get all heroes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Primary or secondary class (tank, fighter, assassin, mage, marksman, support)",
+                        "name": "class",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Viable lane (gold, exp, roam, mid, jungler)",
+                        "name": "lane",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the hero name or an alias",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or after this date (YYYY-MM-DD)",
+                        "name": "released_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or before this date (YYYY-MM-DD)",
+                        "name": "released_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1407,6 +1562,12 @@ const docTemplate = `{
                                 "$ref": "#/definitions/models.Hero"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             },
@@ -1417,6 +1578,10 @@ const docTemplate = `{
                     }
                 ],
                 "description": "Create a hero and save its image",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -1436,8 +1601,51 @@ const docTemplate = `{
                         "type": "file",
                         "description": "Hero image",
                         "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of image",
+                        "name": "image_media_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Primary class (tank, fighter, assassin, mage, marksman, support)",
+                        "name": "primary_class",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secondary class",
+                        "name": "secondary_class",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release date (YYYY-MM-DD)",
+                        "name": "release_date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Viable lanes (gold, exp, roam, mid, jungler)",
+                        "name": "lanes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Alternate names",
+                        "name": "aliases",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -1490,6 +1698,10 @@ const docTemplate = `{
                     }
                 ],
                 "description": "Update a hero and save its image",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -1516,6 +1728,50 @@ const docTemplate = `{
                         "description": "Hero image",
                         "name": "image",
                         "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of image",
+                        "name": "image_media_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Primary class (tank, fighter, assassin, mage, marksman, support)",
+                        "name": "primary_class",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secondary class",
+                        "name": "secondary_class",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release date (YYYY-MM-DD)",
+                        "name": "release_date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Viable lanes (gold, exp, roam, mid, jungler)",
+                        "name": "lanes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Alternate names",
+                        "name": "aliases",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -1790,6 +2046,58 @@ const docTemplate = `{
                 }
             }
         },
+        "/matches/{matchID}/events": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of changes to a match as they are saved: the match itself, games (including the winner), lord and turtle results, and hero picks and bans. Each event is named after its type, for example \"game.updated\" or \"hero_pick.created\", and its data is a dto.EventDto. Reconnect with the Last-Event-ID header or last_event_id to receive events missed in between. Browsers' EventSource cannot send headers, so the token may be given as access_token instead.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Event"
+                ],
+                "summary": "Stream changes of a match",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Match ID",
+                        "name": "matchID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT, instead of the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EventDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Match not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/matches/{matchID}/games": {
             "get": {
                 "security": [
@@ -1802,7 +2110,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Game"
@@ -1815,6 +2124,12 @@ const docTemplate = `{
                         "name": "matchID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1843,7 +2158,8 @@ const docTemplate = `{
                 ],
                 "description": "Create a new game for the specified match with additional information including the first pick team ID, second pick team ID, winner team ID, game number, video link, and optionally a full draft image.",
                 "consumes": [
-                    "multipart/form-data"
+                    "multipart/form-data",
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -1899,6 +2215,18 @@ const docTemplate = `{
                         "description": "Full Draft Image",
                         "name": "full_draft_image",
                         "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of full_draft_image",
+                        "name": "full_draft_image_media_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Patch ID (defaults to the patch live on the match date)",
+                        "name": "patch_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -1998,7 +2326,8 @@ const docTemplate = `{
                 ],
                 "description": "Update a game with the given game ID and match ID with the given information",
                 "consumes": [
-                    "multipart/form-data"
+                    "multipart/form-data",
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -2057,6 +2386,18 @@ const docTemplate = `{
                         "description": "Full Draft Image",
                         "name": "full_draft_image",
                         "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of full_draft_image",
+                        "name": "full_draft_image_media_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Patch ID (defaults to the patch live on the match date)",
+                        "name": "patch_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -2159,7 +2500,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Game"
@@ -2179,6 +2521,12 @@ const docTemplate = `{
                         "name": "gameID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2481,40 +2829,105 @@ const docTemplate = `{
                 }
             }
         },
-        "/matches/{matchID}/games/{gameID}/turtle-results": {
+        "/matches/{matchID}/games/{gameID}/restore": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Add a turtle result for a game with the given game ID and match ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Restore a soft-deleted game. The match must not be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Game"
                 ],
-                "summary": "Add a turtle result",
+                "summary": "Restore a deleted game",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game ID",
-                        "name": "gameID",
+                        "description": "Match ID",
+                        "name": "matchID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Match ID",
-                        "name": "matchID",
+                        "description": "Game ID",
+                        "name": "gameID",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
-                    {
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not deleted or its match is deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{matchID}/games/{gameID}/turtle-results": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add a turtle result for a game with the given game ID and match ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Add a turtle result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Match ID",
+                        "name": "matchID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
                         "description": "Turtle result data",
                         "name": "turtleResult",
                         "in": "body",
@@ -2758,6 +3171,64 @@ const docTemplate = `{
                 }
             }
         },
+        "/matches/{matchID}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Restore a soft-deleted match together with the games deleted with it. The tournament and both teams must not be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Match"
+                ],
+                "summary": "Restore a deleted match",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Match ID",
+                        "name": "matchID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Match restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Match not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Match is not deleted or its tournament/team is deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/matches/{matchID}/teams": {
             "get": {
                 "security": [
@@ -2770,7 +3241,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -2784,6 +3256,12 @@ const docTemplate = `{
                         "name": "matchID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2829,7 +3307,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -2849,6 +3328,12 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3108,7 +3593,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -3128,6 +3614,18 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3449,7 +3947,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -3470,6 +3969,18 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3508,7 +4019,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Add hero ban to match",
+                "description": "Add hero ban to match; rejected with 400 if it breaks the tournament draft rules",
                 "consumes": [
                     "application/json"
                 ],
@@ -3585,7 +4096,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -3606,6 +4118,18 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3640,7 +4164,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Update hero ban in match",
+                "description": "Update hero ban in match; rejected with 400 if it breaks the tournament draft rules",
                 "consumes": [
                     "application/json"
                 ],
@@ -3786,7 +4310,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -3807,6 +4332,18 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3839,7 +4376,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Add hero pick to match",
+                "description": "Add hero pick to match; rejected with 400 if it breaks the tournament draft rules",
                 "consumes": [
                     "application/json"
                 ],
@@ -3916,7 +4453,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -3937,6 +4475,18 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3971,7 +4521,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Update hero pick in match",
+                "description": "Update hero pick in match; rejected with 400 if it breaks the tournament draft rules",
                 "consumes": [
                     "application/json"
                 ],
@@ -4116,7 +4666,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -4136,6 +4687,12 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4392,7 +4949,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -4413,6 +4971,18 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4743,7 +5313,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Match"
@@ -4764,6 +5335,18 @@ const docTemplate = `{
                         "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -5106,48 +5689,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/{playerID}": {
-            "get": {
+        "/media": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get a player by ID with the given player ID",
+                "description": "Upload an image and get a media ID to attach later through image_media_id or full_draft_image_media_id. Send a multipart form with a \"file\" field, or the raw image as the request body. Unattached uploads expire.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Media"
                 ],
-                "summary": "Get a player by ID",
+                "summary": "Upload an image",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Player ID",
-                        "name": "playerID",
-                        "in": "path",
+                        "description": "Entity kind: hero, team, player, coach or draft",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Player"
+                            "$ref": "#/definitions/models.Media"
                         }
                     },
                     "400": {
-                        "description": "Player ID is required",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Player not found",
+                        "description": "Invalid kind, file type or size",
                         "schema": {
                             "type": "string"
                         }
@@ -5159,51 +5743,49 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/name-reviews": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete a player in a team and all its related data",
+                "description": "Get names from imports that could not be matched for certain, most frequent first, with their current candidates.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Name"
                 ],
-                "summary": "Delete a player in a team",
+                "summary": "Get the name review queue",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Player ID",
-                        "name": "playerID",
-                        "in": "path",
-                        "required": true
+                        "description": "Entity kind (team, player, hero)",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Review status (pending, resolved, dismissed, all); default pending",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Player deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.NameReviewDto"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Player ID is required",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Player not found",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "string"
                         }
@@ -5211,36 +5793,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/{playerID}/tournaments/{tournamentID}/player-statistics": {
-            "get": {
+        "/name-reviews/{reviewID}/dismiss": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get player statistics with the given player ID and tournament ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mark a reviewed name as not referring to any existing team, player or hero; it is not queued again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Name"
                 ],
-                "summary": "Get player statistics",
+                "summary": "Dismiss a name review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Player ID",
-                        "name": "playerID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tournament ID",
-                        "name": "tournamentID",
+                        "description": "Name review ID",
+                        "name": "reviewID",
                         "in": "path",
                         "required": true
                     }
@@ -5249,17 +5821,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controllers.PlayerStats"
+                            "$ref": "#/definitions/models.NameReview"
                         }
                     },
-                    "400": {
-                        "description": "Player ID and Tournament ID are required\" or \"Invalid Player ID format\" or \"Invalid Tournament ID format",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Name review not found",
                         "schema": {
                             "type": "string"
                         }
@@ -5267,57 +5833,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/{teamID}": {
-            "put": {
+        "/name-reviews/{reviewID}/resolve": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update a player in a team and save its image",
+                "description": "Decide which team, player or hero a reviewed name refers to. The name is saved as an alias of that entity so later imports match it.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Name"
                 ],
-                "summary": "Update a player in a team",
+                "summary": "Resolve a name review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Player ID",
-                        "name": "teamID",
+                        "description": "Name review ID",
+                        "name": "reviewID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Player name",
-                        "name": "name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Player image",
-                        "name": "image",
-                        "in": "formData"
+                        "description": "Entity",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NameReviewResolveDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Player"
+                            "$ref": "#/definitions/models.NameReview"
                         }
                     },
                     "400": {
-                        "description": "Player ID is required\" or \"File size must not exceed 500 KB\" or \"Invalid file type",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Player not found",
+                        "description": "Name review or entity not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Name is already used by another entity",
                         "schema": {
                             "type": "string"
                         }
@@ -5325,28 +5897,90 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams": {
+        "/names/resolve": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all teams",
+                "description": "Match a name the way importers do: by name, alias, or normalized name ignoring case, spacing, punctuation and, for teams, words like \"Esports\". A name that matches several entities, or only resembles some (for example \"RRQ Hoshi\" and \"RRQ\"), is ambiguous and lists the candidates. team_id limits player names to one team.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Name"
+                ],
+                "summary": "Resolve a team, player or hero name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity kind (team, player, hero)",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name to resolve",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Team of the player",
+                        "name": "team_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NameResolutionDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patches": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get all game patches, newest release first",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Patch"
+                ],
+                "summary": "Get all patches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all teams",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Team"
+                                "$ref": "#/definitions/models.Patch"
                             }
                         }
                     },
@@ -5364,45 +5998,43 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Create a team and save its logo",
+                "description": "Create a game patch with its version and release date (YYYY-MM-DD)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Patch"
                 ],
-                "summary": "Create a team",
+                "summary": "Create a patch",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Team name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Team logo",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "description": "Patch request",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchRequestDto"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Team"
+                            "$ref": "#/definitions/models.Patch"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "409": {
+                        "description": "Patch already exists",
                         "schema": {
                             "type": "string"
                         }
@@ -5410,26 +6042,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamID}": {
+        "/patches/{patchID}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get a team by ID",
+                "description": "Get a game patch by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Patch"
                 ],
-                "summary": "Get a team by ID",
+                "summary": "Get a patch by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "description": "Patch ID",
+                        "name": "patchID",
                         "in": "path",
                         "required": true
                     }
@@ -5438,17 +6070,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Team"
+                            "$ref": "#/definitions/models.Patch"
                         }
                     },
                     "400": {
-                        "description": "Team ID is required",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Team not found",
+                        "description": "Patch not found",
                         "schema": {
                             "type": "string"
                         }
@@ -5461,50 +6093,56 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Update a team and save its logo",
+                "description": "Update the version or release date of a patch; games already recorded on it keep their patch",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Patch"
                 ],
-                "summary": "Update a team",
+                "summary": "Update a patch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "description": "Patch ID",
+                        "name": "patchID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Team name",
-                        "name": "name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Team logo",
-                        "name": "image",
-                        "in": "formData"
+                        "description": "Patch request",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchRequestDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Team"
+                            "$ref": "#/definitions/models.Patch"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Patch not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Patch already exists",
                         "schema": {
                             "type": "string"
                         }
@@ -5517,47 +6155,44 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Delete a team and all related data",
+                "description": "Delete a patch that no game was played on",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Team"
+                    "Patch"
                 ],
-                "summary": "Delete a team",
+                "summary": "Delete a patch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "description": "Patch ID",
+                        "name": "patchID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Team deleted successfully",
+                        "description": "Patch deleted successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Team ID is required\" or \"Bad request",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Team not found",
+                        "description": "Patch not found",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "409": {
+                        "description": "Patch is used by games",
                         "schema": {
                             "type": "string"
                         }
@@ -5565,14 +6200,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamID}/coaches": {
+        "/players/{playerID}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all coaches in a team with the given team ID",
+                "description": "Get a player by ID with the given player ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5582,12 +6217,12 @@ const docTemplate = `{
                 "tags": [
                     "Team"
                 ],
-                "summary": "Get all coaches in a team",
+                "summary": "Get a player by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "description": "Player ID",
+                        "name": "playerID",
                         "in": "path",
                         "required": true
                     }
@@ -5596,20 +6231,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Coach"
-                            }
+                            "$ref": "#/definitions/models.Player"
                         }
                     },
                     "400": {
-                        "description": "Team ID is required",
+                        "description": "Player ID is required",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Team not found",
+                        "description": "Player not found",
                         "schema": {
                             "type": "string"
                         }
@@ -5622,63 +6254,44 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a coach in a team and save its image",
+                "description": "Delete a player in a team and all its related data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Team"
                 ],
-                "summary": "Create a coach in a team",
+                "summary": "Delete a player in a team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "description": "Player ID",
+                        "name": "playerID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Coach name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Coach image",
-                        "name": "image",
-                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.Coach"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                    "200": {
+                        "description": "Player deleted successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Player ID is required",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Team not found",
+                        "description": "Player not found",
                         "schema": {
                             "type": "string"
                         }
@@ -5692,29 +6305,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{teamID}/players": {
+        "/players/{playerID}/aliases": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all players in a team with the given team ID",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Name"
                 ],
-                "summary": "Get all players in a team",
+                "summary": "Get the aliases of a player",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "description": "Player ID",
+                        "name": "playerID",
                         "in": "path",
                         "required": true
                     }
@@ -5723,83 +6332,73 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Player"
-                            }
+                            "$ref": "#/definitions/dto.AliasesDto"
                         }
                     },
-                    "400": {
-                        "description": "Team ID is required",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Team not found",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Player not found",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a player in a team by ID and save its image",
+                "description": "Replace the other names a player is known by, such as former IGNs. An alias already used by another player of the same team is rejected.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Team"
+                    "Name"
                 ],
-                "summary": "Create a player in a team",
+                "summary": "Replace the aliases of a player",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "description": "Player ID",
+                        "name": "playerID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Player name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Player image",
-                        "name": "image",
-                        "in": "formData"
+                        "description": "Aliases",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AliasesDto"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Player"
+                            "$ref": "#/definitions/dto.AliasesDto"
                         }
                     },
                     "400": {
-                        "description": "Team ID is required\" or \"Name and Role are required\" or \"File size must not exceed 500 KB\" or \"Invalid file type",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Team not found",
+                        "description": "Player not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Alias is already used",
                         "schema": {
                             "type": "string"
                         }
@@ -5807,29 +6406,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournaments": {
+        "/players/{playerID}/tournaments/{tournamentID}/player-statistics": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all tournaments",
-                "produces": [
+                "description": "Get player statistics with the given player ID and tournament ID",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
                 "tags": [
-                    "Tournament"
+                    "Team"
+                ],
+                "summary": "Get player statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all tournaments",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Tournament"
-                            }
+                            "$ref": "#/definitions/dto.PlayerStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -5839,47 +6473,75 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/players/{teamID}": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new tournament with the given name",
+                "description": "Update a player in a team and save its image",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tournament"
+                    "Team"
                 ],
-                "summary": "Create a new tournament",
+                "summary": "Update a player in a team",
                 "parameters": [
                     {
-                        "description": "Tournament request",
-                        "name": "dto",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.TournamentRequestDto"
-                        }
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Player image",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of image",
+                        "name": "image_media_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Tournament"
+                            "$ref": "#/definitions/models.Player"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Player ID is required\" or \"File size must not exceed 2 MB\" or \"Invalid file type",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Player already exists in the team",
                         "schema": {
                             "type": "string"
                         }
@@ -5887,35 +6549,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournaments/{tournamentID}": {
+        "/search": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get a tournament by ID",
+                "description": "Search names and aliases of teams, players and heroes with exact, normalized, fuzzy and partial matching, best match first. At most 20 results are returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tournament"
+                    "Name"
                 ],
-                "summary": "Get a tournament by ID",
+                "summary": "Search teams, players and heroes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tournament ID",
-                        "name": "tournamentID",
-                        "in": "path",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this entity kind (team, player, hero)",
+                        "name": "kind",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Tournament"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.NameCandidateDto"
+                            }
                         }
                     },
                     "400": {
@@ -5923,64 +6594,42 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "404": {
-                        "description": "Tournament not found",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/teams": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update a tournament with the given name",
+                "description": "Get all teams",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "Tournament"
+                    "Team"
                 ],
-                "summary": "Update a tournament",
+                "summary": "Get all teams",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tournament ID",
-                        "name": "tournamentID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Tournament request",
-                        "name": "dto",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.TournamentRequestDto"
-                        }
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Tournament"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Tournament not found",
-                        "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Team"
+                            }
                         }
                     },
                     "500": {
@@ -5991,44 +6640,60 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete a tournament with the given tournament ID",
+                "description": "Create a team and save its logo",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tournament"
+                    "Team"
                 ],
-                "summary": "Delete a tournament",
+                "summary": "Create a team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tournament ID",
-                        "name": "tournamentID",
-                        "in": "path",
+                        "description": "Team name",
+                        "name": "name",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Team logo",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of image",
+                        "name": "image_media_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Tournament deleted successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Team"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Bad request",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Tournament not found",
+                    "409": {
+                        "description": "Team already exists",
                         "schema": {
                             "type": "string"
                         }
@@ -6042,15 +6707,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournaments/{tournamentID}/coachs/{coachID}/coach-statistics": {
+        "/teams/{teamID}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get coach statistics with the given coach ID and tournament ID",
+                "description": "Get a team by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Get a team by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Team ID is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update a team and save its logo",
                 "consumes": [
+                    "multipart/form-data",
                     "application/json"
                 ],
                 "produces": [
@@ -6059,38 +6769,49 @@ const docTemplate = `{
                 "tags": [
                     "Team"
                 ],
-                "summary": "Get coach statistics",
+                "summary": "Update a team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Coach ID",
-                        "name": "coachID",
+                        "description": "Team ID",
+                        "name": "teamID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Tournament ID",
-                        "name": "tournamentID",
-                        "in": "path",
-                        "required": true
+                        "description": "Team name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Team logo",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of image",
+                        "name": "image_media_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controllers.CoachStats"
+                            "$ref": "#/definitions/models.Team"
                         }
                     },
                     "400": {
-                        "description": "Coach ID and Tournament ID are required",
+                        "description": "Bad request",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Coach not found",
+                    "409": {
+                        "description": "Team already exists",
                         "schema": {
                             "type": "string"
                         }
@@ -6102,50 +6823,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tournaments/{tournamentID}/matches": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all matches for a tournament with the given tournament ID",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Delete a team and all related data",
                 "tags": [
-                    "Match"
+                    "Team"
                 ],
-                "summary": "Get all matches for a tournament",
+                "summary": "Delete a team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tournament ID",
-                        "name": "tournamentID",
+                        "description": "Team ID",
+                        "name": "teamID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Team deleted successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.MatchResponseDto"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Team ID is required\" or \"Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Tournament not found",
+                        "description": "Team not found",
                         "schema": {
                             "type": "string"
                         }
@@ -6157,67 +6876,128 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/teams/{teamID}/aliases": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Name"
+                ],
+                "summary": "Get the aliases of a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AliasesDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a match for a tournament and save its data",
+                "description": "Replace the other names a team is known by, such as sponsor names or abbreviations. An alias already used by another team is rejected.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Match"
+                    "Name"
                 ],
-                "summary": "Create a match for a tournament",
+                "summary": "Replace the aliases of a team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tournament ID",
-                        "name": "tournamentID",
+                        "description": "Team ID",
+                        "name": "teamID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Match request",
+                        "description": "Aliases",
                         "name": "dto",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.MatchRequestDto"
+                            "$ref": "#/definitions/dto.AliasesDto"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Match"
+                            "$ref": "#/definitions/dto.AliasesDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Alias is already used",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/tournaments/{tournamentID}/teams/{teamID}/team-statistics": {
+        "/teams/{teamID}/coaches": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get team statistics with the given team ID",
+                "description": "Get all coaches in a team with the given team ID",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
                     "Team"
                 ],
-                "summary": "Get team statistics",
+                "summary": "Get all coaches in a team",
                 "parameters": [
                     {
                         "type": "string",
@@ -6228,21 +7008,23 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Tournament ID",
-                        "name": "tournamentID",
-                        "in": "path",
-                        "required": true
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controllers.TeamStatisticsDto"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Coach"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Team ID is required\" or \"Invalid Team ID format",
+                        "description": "Team ID is required",
                         "schema": {
                             "type": "string"
                         }
@@ -6260,112 +7042,2430 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "controllers.CoachStats": {
-            "type": "object",
-            "properties": {
-                "total_game": {
-                    "type": "integer"
-                },
-                "total_game_win": {
-                    "type": "integer"
-                },
-                "total_match": {
-                    "type": "integer"
-                },
-                "total_match_win": {
-                    "type": "integer"
-                }
-            }
-        },
-        "controllers.GameResultDto": {
-            "type": "object",
-            "properties": {
-                "draw": {
-                    "type": "integer"
-                },
-                "lose": {
-                    "type": "integer"
-                },
-                "result": {
-                    "type": "string"
-                },
-                "win": {
-                    "type": "integer"
-                }
-            }
-        },
-        "controllers.PlayerStats": {
-            "type": "object",
-            "properties": {
-                "total_game": {
-                    "type": "integer"
-                },
-                "total_game_win": {
-                    "type": "integer"
-                },
-                "total_match": {
-                    "type": "integer"
-                },
-                "total_match_win": {
-                    "type": "integer"
-                }
-            }
-        },
-        "controllers.TeamStatisticsDto": {
-            "type": "object",
-            "properties": {
-                "teamID": {
-                    "type": "integer"
-                },
-                "totalFirstPick": {
-                    "type": "integer"
-                },
-                "totalFirstPickAndLose": {
-                    "type": "integer"
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a coach in a team and save its image",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Create a coach in a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Coach name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Coach image",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of image",
+                        "name": "image_media_id",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Coach"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamID}/players": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get all players in a team with the given team ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Get all players in a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Player"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Team ID is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a player in a team by ID and save its image",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Create a player in a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Player image",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID from POST /media, instead of image",
+                        "name": "image_media_id",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Player"
+                        }
+                    },
+                    "400": {
+                        "description": "Team ID is required\" or \"Name and Role are required\" or \"File size must not exceed 2 MB\" or \"Invalid file type",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Player already exists in the team",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamID}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Restore a soft-deleted team together with the matches deleted with it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Restore a deleted team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Team is not deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get all tournaments",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Get all tournaments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tournament"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a new tournament with the given name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Create a new tournament",
+                "parameters": [
+                    {
+                        "description": "Tournament request",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TournamentRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tournament"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/backup": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a new tournament from a bundle made by GET /tournaments/{tournamentID}/backup, possibly on another instance. Teams, heroes and patches are matched by name or version and players and coaches by team and name; missing ones are created and existing ones are left unchanged. All IDs are remapped. Image files in the bundle are processed like uploads and stored under new keys. Nothing is saved if the bundle is invalid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Restore a tournament backup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the new tournament (default the name in the bundle)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Tournament backup",
+                        "name": "bundle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TournamentBundleDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TournamentRestoreDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Backup is too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a tournament by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Get a tournament by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tournament"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update a tournament with the given name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Update a tournament",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tournament request",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TournamentRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tournament"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a tournament with the given tournament ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Delete a tournament",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tournament deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/backup": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Download a versioned JSON bundle with the tournament, its draft rules and every match, team detail, player and coach assignment, game, draft, lane result and objective under it, together with the teams, players, coaches, heroes and patches they reference. Deleted matches and games are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Download a tournament backup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TournamentBundleDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/coachs/{coachID}/coach-statistics": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get coach statistics with the given coach ID and tournament ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Get coach statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coach ID",
+                        "name": "coachID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CoachStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Coach not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/events": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of changes to every match of a tournament, with the same events as /matches/{matchID}/events plus matches being created and deleted.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Event"
+                ],
+                "summary": "Stream changes of a tournament",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT, instead of the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EventDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/export.xlsx": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Download an Excel workbook with one sheet each for matches, games, drafts, hero picks, hero bans, priority picks, priority bans, flex picks, lane early results and lord/turtle objectives. Teams and heroes are written by name.",
+                "produces": [
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Export a tournament workbook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/import": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Import a CSV or XLSX file laid out like the tournament export workbook. Sheets: Matches, Match Players, Games, Hero Picks, Hero Bans, Drafts, Priority Picks, Priority Bans, Flex Picks, Lane Results and Objectives; headers may be written as \"Team A Score\" or \"team_a_score\". Teams, heroes and players are matched by name or ID. A match_id that does not exist in the tournament is a key for a new match listed in the Matches sheet. A CSV file holds one sheet named after the file (for example hero-picks.csv) unless the sheet field is given. Every row is validated first; if any row is invalid nothing is imported and the errors are returned with status 400. With dry_run the diff is returned without saving anything.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Import matches and drafts from a spreadsheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sheet name of a CSV file",
+                        "name": "sheet",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the diff",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SheetImportResultDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SheetImportResultDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/import/liquipedia": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Import matches, games, hero picks, hero bans and drafts from saved Liquipedia pages: wikitext with Match templates (standalone, in a Matchlist or in a Bracket) or LPDB match2 JSON. Several files may be sent in the file field. Teams are matched by name or team template and heroes by name or alias, ignoring case, spacing and punctuation; the names field of the response lists how every name was matched, with suggestions for unknown names. The team on the blue side picks first, and picks and bans 1-3 count as the first phase. Matches imported before are matched by stage, date and teams, so the same file can be imported again. If any name is unknown nothing is imported and the errors are returned with status 400. With dry_run the diff is returned without saving anything.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Import matches from Liquipedia files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Wikitext or JSON file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the diff",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LiquipediaImportResultDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LiquipediaImportResultDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/matches": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get all matches for a tournament with the given tournament ID",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Match"
+                ],
+                "summary": "Get all matches for a tournament",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MatchResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a match for a tournament and save its data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Match"
+                ],
+                "summary": "Create a match for a tournament",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Match request",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MatchRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Match"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Restore a soft-deleted tournament together with the matches and games deleted with it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Restore a deleted tournament",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tournament restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Tournament is not deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/rules": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the draft rules of a tournament: draft format, bans per phase, fearless mode and disabled heroes. Tournaments without rules return the unrestricted defaults.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Get tournament draft rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TournamentRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update the draft rules of a tournament. New hero picks and bans are validated against them; drafts already recorded are not re-checked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournament"
+                ],
+                "summary": "Update tournament draft rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tournament rule request",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TournamentRuleRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TournamentRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/teams/{teamID}/team-statistics": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get team statistics with the given team ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Team"
+                ],
+                "summary": "Get team statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tournament ID",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count games played on this patch version",
+                        "name": "patch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format (json, csv); Accept: text/csv also selects CSV",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TeamStatisticsDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AliasesDto": {
+            "type": "object",
+            "required": [
+                "aliases"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.AuditLogResponseDto": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "audit_log_id": {
+                    "type": "integer"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BundleCoachDto": {
+            "type": "object",
+            "properties": {
+                "coach_id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleCoachMatchDto": {
+            "type": "object",
+            "properties": {
+                "coach_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BundleGameDto": {
+            "type": "object",
+            "properties": {
+                "explaners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleLaneDto"
+                    }
+                },
+                "first_pick_team_id": {
+                    "type": "integer"
+                },
+                "full_draft_image": {
+                    "type": "string"
+                },
+                "game_number": {
+                    "type": "integer"
+                },
+                "goldlaners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleLaneDto"
+                    }
+                },
+                "lord_results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleObjectiveDto"
+                    }
+                },
+                "patch_id": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleGameResultDto"
+                    }
+                },
+                "second_pick_team_id": {
+                    "type": "integer"
+                },
+                "trio_mids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleTrioMidDto"
+                    }
+                },
+                "turtle_results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleObjectiveDto"
+                    }
+                },
+                "video_link": {
+                    "type": "string"
+                },
+                "winner_team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleGameResultDto": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleHeroDraftDto": {
+            "type": "object",
+            "properties": {
+                "first_phase": {
+                    "type": "integer"
+                },
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleHeroDraftGameDto"
+                    }
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "second_phase": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleHeroDraftGameDto": {
+            "type": "object",
+            "properties": {
+                "drafted": {
+                    "type": "boolean"
+                },
+                "game_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleHeroDto": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "lanes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "primary_class": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "secondary_class": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BundleLaneDto": {
+            "type": "object",
+            "properties": {
+                "early_result": {
+                    "type": "string"
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleMatchDto": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "integer"
+                },
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleGameDto"
+                    }
+                },
+                "played_at": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "team_a_id": {
+                    "type": "integer"
+                },
+                "team_a_score": {
+                    "type": "integer"
+                },
+                "team_b_id": {
+                    "type": "integer"
+                },
+                "team_b_score": {
+                    "type": "integer"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleMatchTeamDto"
+                    }
+                }
+            }
+        },
+        "dto.BundleMatchTeamDto": {
+            "type": "object",
+            "properties": {
+                "coaches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleCoachMatchDto"
+                    }
+                },
+                "flex_picks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundlePriorityDto"
+                    }
+                },
+                "hero_bans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleHeroDraftDto"
+                    }
+                },
+                "hero_picks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleHeroDraftDto"
+                    }
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundlePlayerMatchDto"
+                    }
+                },
+                "priority_bans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundlePriorityDto"
+                    }
+                },
+                "priority_picks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundlePriorityDto"
+                    }
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleObjectiveDto": {
+            "type": "object",
+            "properties": {
+                "initiate": {
+                    "type": "string"
+                },
+                "phase": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "setup": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundlePatchDto": {
+            "type": "object",
+            "properties": {
+                "patch_id": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BundlePlayerDto": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundlePlayerMatchDto": {
+            "type": "object",
+            "properties": {
+                "player_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BundlePriorityDto": {
+            "type": "object",
+            "properties": {
+                "hero_id": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleRuleDto": {
+            "type": "object",
+            "properties": {
+                "bans_first_phase": {
+                    "type": "integer"
+                },
+                "bans_second_phase": {
+                    "type": "integer"
+                },
+                "disabled_hero_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "draft_format": {
+                    "type": "string"
+                },
+                "fearless_mode": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BundleTeamDto": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleTournamentDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "rule": {
+                    "$ref": "#/definitions/dto.BundleRuleDto"
+                }
+            }
+        },
+        "dto.BundleTrioMidDto": {
+            "type": "object",
+            "properties": {
+                "early_result": {
+                    "type": "string"
+                },
+                "heroes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleTrioMidHeroDto"
+                    }
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BundleTrioMidHeroDto": {
+            "type": "object",
+            "properties": {
+                "early_result": {
+                    "type": "string"
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CoachMatchResponseDto": {
+            "type": "object",
+            "properties": {
+                "coach": {
+                    "type": "object",
+                    "properties": {
+                        "coach_id": {
+                            "type": "integer"
+                        },
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "team_id": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "coach_match_id": {
+                    "type": "integer"
+                },
+                "match_team_detail_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CoachStats": {
+            "type": "object",
+            "properties": {
+                "total_game": {
+                    "type": "integer"
+                },
+                "total_game_win": {
+                    "type": "integer"
+                },
+                "total_match": {
+                    "type": "integer"
+                },
+                "total_match_win": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.EventDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {},
+                "entity_id": {
+                    "type": "integer"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "tournament_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExplanerRequestDto": {
+            "type": "object",
+            "required": [
+                "hero_id"
+            ],
+            "properties": {
+                "early_result": {
+                    "type": "string"
+                },
+                "hero_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExplanerResponseDto": {
+            "type": "object",
+            "properties": {
+                "early_result": {
+                    "type": "string"
+                },
+                "explaner_id": {
+                    "type": "integer"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "hero": {
+                    "type": "object",
+                    "properties": {
+                        "hero_id": {
+                            "type": "integer"
+                        },
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "team": {
+                    "type": "object",
+                    "properties": {
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "team_id": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.FlexPickRequestDto": {
+            "type": "object",
+            "required": [
+                "hero_id",
+                "pick_rate",
+                "role",
+                "total"
+            ],
+            "properties": {
+                "hero_id": {
+                    "type": "integer"
+                },
+                "pick_rate": {
+                    "type": "number"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "gold",
+                        "exp",
+                        "roam",
+                        "mid",
+                        "jungler"
+                    ]
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.FlexPickResponseDto": {
+            "type": "object",
+            "properties": {
+                "flex_pick_id": {
+                    "type": "integer"
+                },
+                "hero": {
+                    "type": "object",
+                    "properties": {
+                        "hero_id": {
+                            "type": "integer"
+                        },
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "match_team_detail_id": {
+                    "type": "integer"
+                },
+                "pick_rate": {
+                    "type": "number"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GameResponseDto": {
+            "type": "object",
+            "properties": {
+                "first_pick_team_id": {
+                    "type": "integer"
+                },
+                "first_team": {
+                    "type": "object",
+                    "properties": {
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "team_id": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "full_draft_image": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "game_number": {
+                    "type": "integer"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "patch_id": {
+                    "type": "integer"
+                },
+                "patch_version": {
+                    "type": "string"
+                },
+                "second_pick_team_id": {
+                    "type": "integer"
+                },
+                "second_team": {
+                    "type": "object",
+                    "properties": {
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "team_id": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "video_link": {
+                    "type": "string"
+                },
+                "winner_team": {
+                    "type": "object",
+                    "properties": {
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "team_id": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "winner_team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GameResultDto": {
+            "type": "object",
+            "properties": {
+                "draw": {
+                    "type": "integer"
+                },
+                "lose": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "win": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GoldlanerRequestDto": {
+            "type": "object",
+            "required": [
+                "hero_id"
+            ],
+            "properties": {
+                "early_result": {
+                    "type": "string"
+                },
+                "hero_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GoldlanerResponseDto": {
+            "type": "object",
+            "properties": {
+                "early_result": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "goldlaner_id": {
+                    "type": "integer"
+                },
+                "hero": {
+                    "type": "object",
+                    "properties": {
+                        "hero_id": {
+                            "type": "integer"
+                        },
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "team": {
+                    "type": "object",
+                    "properties": {
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "team_id": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.HeroBanRequestDto": {
+            "type": "object",
+            "required": [
+                "first_phase",
+                "hero_id",
+                "second_phase",
+                "total"
+            ],
+            "properties": {
+                "first_phase": {
+                    "type": "integer"
+                },
+                "hero_ban_game": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "required": [
+                            "game_id",
+                            "game_number",
+                            "is_banned"
+                        ],
+                        "properties": {
+                            "game_id": {
+                                "type": "integer"
+                            },
+                            "game_number": {
+                                "type": "integer"
+                            },
+                            "is_banned": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "second_phase": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.HeroBanResponseDto": {
+            "type": "object",
+            "properties": {
+                "first_phase": {
+                    "type": "integer"
+                },
+                "hero": {
+                    "type": "object",
+                    "properties": {
+                        "hero_id": {
+                            "type": "integer"
+                        },
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "hero_ban_game": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "game_id": {
+                                "type": "integer"
+                            },
+                            "game_number": {
+                                "type": "integer"
+                            },
+                            "hero_ban_game_id": {
+                                "type": "integer"
+                            },
+                            "hero_ban_id": {
+                                "type": "integer"
+                            },
+                            "is_banned": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                },
+                "hero_ban_id": {
+                    "type": "integer"
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "match_team_detail_id": {
+                    "type": "integer"
+                },
+                "second_phase": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.HeroPickRequestDto": {
+            "type": "object",
+            "required": [
+                "first_phase",
+                "hero_id",
+                "second_phase",
+                "total"
+            ],
+            "properties": {
+                "first_phase": {
+                    "type": "integer"
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "hero_pick_game": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "required": [
+                            "game_id",
+                            "game_number",
+                            "is_picked"
+                        ],
+                        "properties": {
+                            "game_id": {
+                                "type": "integer"
+                            },
+                            "game_number": {
+                                "type": "integer"
+                            },
+                            "is_picked": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                },
+                "second_phase": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.HeroPickResponseDto": {
+            "type": "object",
+            "properties": {
+                "first_phase": {
+                    "type": "integer"
+                },
+                "hero": {
+                    "type": "object",
+                    "properties": {
+                        "hero_id": {
+                            "type": "integer"
+                        },
+                        "image": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "hero_id": {
+                    "type": "integer"
+                },
+                "hero_pick_game": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "game_id": {
+                                "type": "integer"
+                            },
+                            "game_number": {
+                                "type": "integer"
+                            },
+                            "hero_pick_game_id": {
+                                "type": "integer"
+                            },
+                            "hero_pick_id": {
+                                "type": "integer"
+                            },
+                            "is_picked": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
                 },
-                "totalFirstPickAndWin": {
+                "hero_pick_id": {
                     "type": "integer"
                 },
-                "totalGame": {
+                "match_team_detail_id": {
                     "type": "integer"
                 },
-                "totalGameAndLose": {
+                "second_phase": {
                     "type": "integer"
                 },
-                "totalGameAndWin": {
+                "total": {
                     "type": "integer"
+                }
+            }
+        },
+        "dto.LiquipediaImportResultDto": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
                 },
-                "totalMatch": {
-                    "type": "integer"
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SheetImportChangeDto"
+                    }
                 },
-                "totalMatchAndLose": {
+                "created": {
                     "type": "integer"
                 },
-                "totalMatchAndWin": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SheetImportErrorDto"
+                    }
+                },
+                "matches": {
                     "type": "integer"
                 },
-                "totalSecondPick": {
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LiquipediaNameDto"
+                    }
+                },
+                "unchanged": {
                     "type": "integer"
                 },
-                "totalSecondPickAndLose": {
+                "updated": {
                     "type": "integer"
+                }
+            }
+        },
+        "dto.LiquipediaNameDto": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
                 },
-                "totalSecondPickAndWin": {
+                "matched": {
+                    "type": "string"
+                },
+                "matched_id": {
                     "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.LoginDto": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "controllers.TrioMidResultDto": {
+        "dto.LordResultRequestDto": {
             "type": "object",
             "required": [
+                "initiate",
+                "phase",
+                "result",
+                "setup",
                 "team_id"
             ],
             "properties": {
-                "early_result": {
+                "initiate": {
+                    "type": "string"
+                },
+                "phase": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "setup": {
                     "type": "string"
                 },
                 "team_id": {
@@ -6373,15 +9473,30 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.CoachMatchResponseDto": {
+        "dto.LordResultResponseDto": {
             "type": "object",
             "properties": {
-                "coach": {
+                "game_id": {
+                    "type": "integer"
+                },
+                "initiate": {
+                    "type": "string"
+                },
+                "lord_result_id": {
+                    "type": "integer"
+                },
+                "phase": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "setup": {
+                    "type": "string"
+                },
+                "team": {
                     "type": "object",
                     "properties": {
-                        "coach_id": {
-                            "type": "integer"
-                        },
                         "image": {
                             "type": "string"
                         },
@@ -6392,62 +9507,87 @@ const docTemplate = `{
                             "type": "integer"
                         }
                     }
-                },
-                "coach_match_id": {
-                    "type": "integer"
-                },
-                "match_team_detail_id": {
-                    "type": "integer"
-                },
-                "role": {
-                    "type": "string"
                 }
             }
         },
-        "dto.ExplanerRequestDto": {
+        "dto.MatchRequestDto": {
             "type": "object",
             "required": [
-                "hero_id"
+                "date",
+                "day",
+                "stage",
+                "team_a_id",
+                "team_a_score",
+                "team_b_id",
+                "team_b_score"
             ],
             "properties": {
-                "early_result": {
+                "date": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "integer"
+                },
+                "played_at": {
+                    "description": "PlayedAt adalah tanggal lengkap match (YYYY-MM-DD), dipakai untuk\nmenentukan patch game; string kosong mengosongkannya.",
                     "type": "string"
                 },
-                "hero_id": {
+                "stage": {
+                    "type": "string"
+                },
+                "team_a_id": {
+                    "type": "integer"
+                },
+                "team_a_score": {
+                    "type": "integer"
+                },
+                "team_b_id": {
+                    "type": "integer"
+                },
+                "team_b_score": {
                     "type": "integer"
                 }
             }
         },
-        "dto.ExplanerResponseDto": {
+        "dto.MatchResponseDto": {
             "type": "object",
             "properties": {
-                "early_result": {
-                    "type": "string"
+                "date": {
+                    "type": "integer"
                 },
-                "explaner_id": {
+                "day": {
                     "type": "integer"
                 },
-                "game_id": {
+                "match_id": {
                     "type": "integer"
                 },
-                "hero": {
+                "played_at": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "team_a": {
                     "type": "object",
                     "properties": {
-                        "hero_id": {
-                            "type": "integer"
-                        },
                         "image": {
                             "type": "string"
                         },
                         "name": {
                             "type": "string"
+                        },
+                        "team_id": {
+                            "type": "integer"
                         }
                     }
                 },
-                "hero_id": {
+                "team_a_id": {
                     "type": "integer"
                 },
-                "team": {
+                "team_a_score": {
+                    "type": "integer"
+                },
+                "team_b": {
                     "type": "object",
                     "properties": {
                         "image": {
@@ -6461,258 +9601,238 @@ const docTemplate = `{
                         }
                     }
                 },
-                "team_id": {
+                "team_b_id": {
+                    "type": "integer"
+                },
+                "team_b_score": {
+                    "type": "integer"
+                },
+                "tournament_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.FlexPickRequestDto": {
+        "dto.NameCandidateDto": {
             "type": "object",
-            "required": [
-                "hero_id",
-                "pick_rate",
-                "role",
-                "total"
-            ],
             "properties": {
-                "hero_id": {
+                "id": {
                     "type": "integer"
                 },
-                "pick_rate": {
-                    "type": "number"
+                "kind": {
+                    "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "gold",
-                        "exp",
-                        "roam",
-                        "mid",
-                        "jungler"
-                    ]
+                "method": {
+                    "type": "string"
                 },
-                "total": {
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "team_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.FlexPickResponseDto": {
+        "dto.NameResolutionDto": {
             "type": "object",
             "properties": {
-                "flex_pick_id": {
-                    "type": "integer"
-                },
-                "hero": {
-                    "type": "object",
-                    "properties": {
-                        "hero_id": {
-                            "type": "integer"
-                        },
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        }
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NameCandidateDto"
                     }
                 },
-                "match_team_detail_id": {
-                    "type": "integer"
+                "kind": {
+                    "type": "string"
                 },
-                "pick_rate": {
-                    "type": "number"
+                "match": {
+                    "$ref": "#/definitions/dto.NameCandidateDto"
                 },
-                "role": {
+                "name": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 },
-                "total": {
+                "team_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.GameResponseDto": {
+        "dto.NameReviewDto": {
             "type": "object",
             "properties": {
-                "first_pick_team_id": {
-                    "type": "integer"
-                },
-                "first_team": {
-                    "type": "object",
-                    "properties": {
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "team_id": {
-                            "type": "integer"
-                        }
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NameCandidateDto"
                     }
                 },
-                "full_draft_image": {
+                "created_at": {
                     "type": "string"
                 },
-                "game_id": {
+                "entity_id": {
                     "type": "integer"
                 },
-                "game_number": {
-                    "type": "integer"
+                "kind": {
+                    "type": "string"
                 },
-                "match_id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "second_pick_team_id": {
+                "name_review_id": {
                     "type": "integer"
                 },
-                "second_team": {
-                    "type": "object",
-                    "properties": {
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "team_id": {
-                            "type": "integer"
-                        }
-                    }
+                "occurrences": {
+                    "description": "Occurrences adalah berapa kali nama ini gagal dicocokkan.",
+                    "type": "integer"
                 },
-                "video_link": {
+                "source": {
+                    "description": "Source adalah importer yang terakhir menemukan nama ini.",
                     "type": "string"
                 },
-                "winner_team": {
-                    "type": "object",
-                    "properties": {
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "team_id": {
-                            "type": "integer"
-                        }
-                    }
+                "status": {
+                    "type": "string"
                 },
-                "winner_team_id": {
+                "team_id": {
+                    "description": "TeamID membatasi nama pemain ke tim tersebut; 0 untuk team dan hero.",
                     "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.GoldlanerRequestDto": {
+        "dto.NameReviewResolveDto": {
             "type": "object",
             "required": [
-                "hero_id"
+                "entity_id"
             ],
             "properties": {
-                "early_result": {
-                    "type": "string"
-                },
-                "hero_id": {
+                "entity_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.GoldlanerResponseDto": {
+        "dto.PatchRequestDto": {
             "type": "object",
             "properties": {
-                "early_result": {
+                "release_date": {
                     "type": "string"
                 },
-                "game_id": {
+                "version": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "dto.PlayerMatchRequestDto": {
+            "type": "object",
+            "required": [
+                "player_id",
+                "role"
+            ],
+            "properties": {
+                "player_id": {
                     "type": "integer"
                 },
-                "goldlaner_id": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "goldlaner",
+                        "explaner",
+                        "roamer",
+                        "midlaner",
+                        "jungler"
+                    ]
+                }
+            }
+        },
+        "dto.PlayerMatchResponseDto": {
+            "type": "object",
+            "properties": {
+                "match_team_detail_id": {
                     "type": "integer"
                 },
-                "hero": {
+                "player": {
                     "type": "object",
                     "properties": {
-                        "hero_id": {
-                            "type": "integer"
-                        },
                         "image": {
                             "type": "string"
                         },
                         "name": {
                             "type": "string"
-                        }
-                    }
-                },
-                "hero_id": {
-                    "type": "integer"
-                },
-                "team": {
-                    "type": "object",
-                    "properties": {
-                        "image": {
-                            "type": "string"
                         },
-                        "name": {
-                            "type": "string"
+                        "player_id": {
+                            "type": "integer"
                         },
                         "team_id": {
                             "type": "integer"
                         }
                     }
                 },
-                "team_id": {
+                "player_match_id": {
                     "type": "integer"
+                },
+                "role": {
+                    "type": "string"
                 }
             }
         },
-        "dto.HeroBanRequestDto": {
+        "dto.PlayerStats": {
+            "type": "object",
+            "properties": {
+                "total_game": {
+                    "type": "integer"
+                },
+                "total_game_win": {
+                    "type": "integer"
+                },
+                "total_match": {
+                    "type": "integer"
+                },
+                "total_match_win": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PriorityBanRequestDto": {
             "type": "object",
             "required": [
-                "first_phase",
+                "ban_rate",
                 "hero_id",
-                "second_phase",
+                "role",
                 "total"
             ],
             "properties": {
-                "first_phase": {
-                    "type": "integer"
-                },
-                "hero_ban_game": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "required": [
-                            "game_id",
-                            "game_number",
-                            "is_banned"
-                        ],
-                        "properties": {
-                            "game_id": {
-                                "type": "integer"
-                            },
-                            "game_number": {
-                                "type": "integer"
-                            },
-                            "is_banned": {
-                                "type": "boolean"
-                            }
-                        }
-                    }
+                "ban_rate": {
+                    "type": "number"
                 },
                 "hero_id": {
                     "type": "integer"
                 },
-                "second_phase": {
-                    "type": "integer"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "gold",
+                        "exp",
+                        "roam",
+                        "mid",
+                        "jungler"
+                    ]
                 },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "dto.HeroBanResponseDto": {
+        "dto.PriorityBanResponseDto": {
             "type": "object",
             "properties": {
-                "first_phase": {
-                    "type": "integer"
+                "ban_rate": {
+                    "type": "number"
                 },
                 "hero": {
                     "type": "object",
@@ -6728,97 +9848,53 @@ const docTemplate = `{
                         }
                     }
                 },
-                "hero_ban_game": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "game_id": {
-                                "type": "integer"
-                            },
-                            "game_number": {
-                                "type": "integer"
-                            },
-                            "hero_ban_game_id": {
-                                "type": "integer"
-                            },
-                            "hero_ban_id": {
-                                "type": "integer"
-                            },
-                            "is_banned": {
-                                "type": "boolean"
-                            }
-                        }
-                    }
-                },
-                "hero_ban_id": {
-                    "type": "integer"
-                },
-                "hero_id": {
-                    "type": "integer"
-                },
                 "match_team_detail_id": {
                     "type": "integer"
                 },
-                "second_phase": {
+                "priority_ban_id": {
                     "type": "integer"
                 },
+                "role": {
+                    "type": "string"
+                },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "dto.HeroPickRequestDto": {
+        "dto.PriorityPickRequestDto": {
             "type": "object",
             "required": [
-                "first_phase",
                 "hero_id",
-                "second_phase",
+                "pick_rate",
+                "role",
                 "total"
             ],
             "properties": {
-                "first_phase": {
-                    "type": "integer"
-                },
                 "hero_id": {
                     "type": "integer"
                 },
-                "hero_pick_game": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "required": [
-                            "game_id",
-                            "game_number",
-                            "is_picked"
-                        ],
-                        "properties": {
-                            "game_id": {
-                                "type": "integer"
-                            },
-                            "game_number": {
-                                "type": "integer"
-                            },
-                            "is_picked": {
-                                "type": "boolean"
-                            }
-                        }
-                    }
+                "pick_rate": {
+                    "type": "number"
                 },
-                "second_phase": {
-                    "type": "integer"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "gold",
+                        "exp",
+                        "roam",
+                        "mid",
+                        "jungler"
+                    ]
                 },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "dto.HeroPickResponseDto": {
+        "dto.PriorityPickResponseDto": {
             "type": "object",
             "properties": {
-                "first_phase": {
-                    "type": "integer"
-                },
                 "hero": {
                     "type": "object",
                     "properties": {
@@ -6833,409 +9909,279 @@ const docTemplate = `{
                         }
                     }
                 },
-                "hero_id": {
+                "match_team_detail_id": {
                     "type": "integer"
                 },
-                "hero_pick_game": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "game_id": {
-                                "type": "integer"
-                            },
-                            "game_number": {
-                                "type": "integer"
-                            },
-                            "hero_pick_game_id": {
-                                "type": "integer"
-                            },
-                            "hero_pick_id": {
-                                "type": "integer"
-                            },
-                            "is_picked": {
-                                "type": "boolean"
-                            }
-                        }
-                    }
-                },
-                "hero_pick_id": {
-                    "type": "integer"
+                "pick_rate": {
+                    "type": "number"
                 },
-                "match_team_detail_id": {
+                "priority_pick_id": {
                     "type": "integer"
                 },
-                "second_phase": {
-                    "type": "integer"
+                "role": {
+                    "type": "string"
                 },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "dto.LoginDto": {
+        "dto.SheetImportChangeDto": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "action": {
                     "type": "string"
                 },
-                "username": {
+                "entity": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SheetImportFieldDto"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "sheet": {
                     "type": "string"
                 }
             }
         },
-        "dto.LordResultRequestDto": {
+        "dto.SheetImportErrorDto": {
             "type": "object",
-            "required": [
-                "initiate",
-                "phase",
-                "result",
-                "setup",
-                "team_id"
-            ],
             "properties": {
-                "initiate": {
+                "column": {
                     "type": "string"
                 },
-                "phase": {
+                "message": {
                     "type": "string"
                 },
-                "result": {
-                    "type": "string"
+                "row": {
+                    "type": "integer"
                 },
-                "setup": {
+                "sheet": {
                     "type": "string"
-                },
-                "team_id": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.LordResultResponseDto": {
+        "dto.SheetImportFieldDto": {
             "type": "object",
             "properties": {
-                "game_id": {
-                    "type": "integer"
-                },
-                "initiate": {
-                    "type": "string"
-                },
-                "lord_result_id": {
-                    "type": "integer"
-                },
-                "phase": {
+                "after": {
                     "type": "string"
                 },
-                "result": {
+                "before": {
                     "type": "string"
                 },
-                "setup": {
+                "field": {
                     "type": "string"
-                },
-                "team": {
-                    "type": "object",
-                    "properties": {
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "team_id": {
-                            "type": "integer"
-                        }
-                    }
                 }
             }
         },
-        "dto.MatchRequestDto": {
+        "dto.SheetImportResultDto": {
             "type": "object",
-            "required": [
-                "date",
-                "day",
-                "stage",
-                "team_a_id",
-                "team_a_score",
-                "team_b_id",
-                "team_b_score"
-            ],
             "properties": {
-                "date": {
-                    "type": "integer"
-                },
-                "day": {
-                    "type": "integer"
+                "applied": {
+                    "type": "boolean"
                 },
-                "stage": {
-                    "type": "string"
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SheetImportChangeDto"
+                    }
                 },
-                "team_a_id": {
+                "created": {
                     "type": "integer"
                 },
-                "team_a_score": {
-                    "type": "integer"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "team_b_id": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SheetImportErrorDto"
+                    }
+                },
+                "unchanged": {
                     "type": "integer"
                 },
-                "team_b_score": {
+                "updated": {
                     "type": "integer"
                 }
             }
         },
-        "dto.MatchResponseDto": {
+        "dto.TeamStatisticsDto": {
             "type": "object",
             "properties": {
-                "date": {
-                    "type": "integer"
-                },
-                "day": {
+                "teamID": {
                     "type": "integer"
                 },
-                "match_id": {
+                "totalFirstPick": {
                     "type": "integer"
                 },
-                "stage": {
-                    "type": "string"
-                },
-                "team_a": {
-                    "type": "object",
-                    "properties": {
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "team_id": {
-                            "type": "integer"
-                        }
-                    }
-                },
-                "team_a_id": {
+                "totalFirstPickAndLose": {
                     "type": "integer"
                 },
-                "team_a_score": {
+                "totalFirstPickAndWin": {
                     "type": "integer"
                 },
-                "team_b": {
-                    "type": "object",
-                    "properties": {
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "team_id": {
-                            "type": "integer"
-                        }
-                    }
-                },
-                "team_b_id": {
+                "totalGame": {
                     "type": "integer"
                 },
-                "team_b_score": {
+                "totalGameAndLose": {
                     "type": "integer"
                 },
-                "tournament_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "dto.PlayerMatchRequestDto": {
-            "type": "object",
-            "required": [
-                "player_id",
-                "role"
-            ],
-            "properties": {
-                "player_id": {
+                "totalGameAndWin": {
                     "type": "integer"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "goldlaner",
-                        "explaner",
-                        "roamer",
-                        "midlaner",
-                        "jungler"
-                    ]
-                }
-            }
-        },
-        "dto.PlayerMatchResponseDto": {
-            "type": "object",
-            "properties": {
-                "match_team_detail_id": {
+                "totalMatch": {
                     "type": "integer"
                 },
-                "player": {
-                    "type": "object",
-                    "properties": {
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "player_id": {
-                            "type": "integer"
-                        },
-                        "team_id": {
-                            "type": "integer"
-                        }
-                    }
-                },
-                "player_match_id": {
+                "totalMatchAndLose": {
                     "type": "integer"
                 },
-                "role": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.PriorityBanRequestDto": {
-            "type": "object",
-            "required": [
-                "ban_rate",
-                "hero_id",
-                "role",
-                "total"
-            ],
-            "properties": {
-                "ban_rate": {
-                    "type": "number"
-                },
-                "hero_id": {
+                "totalMatchAndWin": {
                     "type": "integer"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "gold",
-                        "exp",
-                        "roam",
-                        "mid",
-                        "jungler"
-                    ]
+                "totalSecondPick": {
+                    "type": "integer"
                 },
-                "total": {
+                "totalSecondPickAndLose": {
+                    "type": "integer"
+                },
+                "totalSecondPickAndWin": {
                     "type": "integer"
                 }
             }
         },
-        "dto.PriorityBanResponseDto": {
+        "dto.TournamentBundleDto": {
             "type": "object",
             "properties": {
-                "ban_rate": {
-                    "type": "number"
+                "coaches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleCoachDto"
+                    }
                 },
-                "hero": {
+                "exported_at": {
+                    "type": "string"
+                },
+                "files": {
                     "type": "object",
-                    "properties": {
-                        "hero_id": {
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
                             "type": "integer"
-                        },
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
                         }
                     }
                 },
-                "match_team_detail_id": {
-                    "type": "integer"
+                "heroes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleHeroDto"
+                    }
                 },
-                "priority_ban_id": {
-                    "type": "integer"
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleMatchDto"
+                    }
                 },
-                "role": {
-                    "type": "string"
+                "patches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundlePatchDto"
+                    }
                 },
-                "total": {
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundlePlayerDto"
+                    }
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleTeamDto"
+                    }
+                },
+                "tournament": {
+                    "$ref": "#/definitions/dto.BundleTournamentDto"
+                },
+                "version": {
                     "type": "integer"
                 }
             }
         },
-        "dto.PriorityPickRequestDto": {
+        "dto.TournamentRequestDto": {
             "type": "object",
             "required": [
-                "hero_id",
-                "pick_rate",
-                "role",
-                "total"
+                "name"
             ],
             "properties": {
-                "hero_id": {
-                    "type": "integer"
-                },
-                "pick_rate": {
-                    "type": "number"
-                },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "gold",
-                        "exp",
-                        "roam",
-                        "mid",
-                        "jungler"
-                    ]
-                },
-                "total": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "dto.PriorityPickResponseDto": {
+        "dto.TournamentRestoreDto": {
             "type": "object",
             "properties": {
-                "hero": {
+                "created": {
                     "type": "object",
-                    "properties": {
-                        "hero_id": {
-                            "type": "integer"
-                        },
-                        "image": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        }
+                    "additionalProperties": {
+                        "type": "integer"
                     }
                 },
-                "match_team_detail_id": {
-                    "type": "integer"
-                },
-                "pick_rate": {
-                    "type": "number"
-                },
-                "priority_pick_id": {
-                    "type": "integer"
-                },
-                "role": {
-                    "type": "string"
+                "reused": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 },
-                "total": {
+                "tournament_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.TournamentRequestDto": {
+        "dto.TournamentRuleRequestDto": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "type": "string"
+                "bans_first_phase": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "bans_second_phase": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "disabled_hero_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "draft_format": {
+                    "type": "string",
+                    "enum": [
+                        "standard",
+                        "global_ban"
+                    ]
+                },
+                "fearless_mode": {
+                    "type": "string",
+                    "enum": [
+                        "off",
+                        "team",
+                        "full"
+                    ]
                 }
             }
         },
@@ -7307,6 +10253,20 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TrioMidResultDto": {
+            "type": "object",
+            "required": [
+                "team_id"
+            ],
+            "properties": {
+                "early_result": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.TurtleResultRequestDto": {
             "type": "object",
             "required": [
@@ -7409,6 +10369,9 @@ const docTemplate = `{
                 "image": {
                     "type": "string"
                 },
+                "image_variants": {
+                    "$ref": "#/definitions/models.ImageVariants"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -7440,12 +10403,18 @@ const docTemplate = `{
         "models.Game": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
                 "first_pick_team_id": {
                     "type": "integer"
                 },
                 "full_draft_image": {
                     "type": "string"
                 },
+                "full_draft_image_variants": {
+                    "$ref": "#/definitions/models.ImageVariants"
+                },
                 "game_id": {
                     "type": "integer"
                 },
@@ -7455,6 +10424,9 @@ const docTemplate = `{
                 "match_id": {
                     "type": "integer"
                 },
+                "patch_id": {
+                    "type": "integer"
+                },
                 "second_pick_team_id": {
                     "type": "integer"
                 },
@@ -7489,14 +10461,54 @@ const docTemplate = `{
         "models.Hero": {
             "type": "object",
             "properties": {
+                "aliases": {
+                    "description": "Aliases adalah nama lain hero, misalnya nama lama atau singkatan.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "hero_id": {
                     "type": "integer"
                 },
                 "image": {
                     "type": "string"
                 },
+                "image_variants": {
+                    "$ref": "#/definitions/models.ImageVariants"
+                },
+                "lanes": {
+                    "description": "Lanes adalah lane yang bisa diisi hero, dipakai analisis draft dan\ndeteksi flex pick.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
+                },
+                "primary_class": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "secondary_class": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImageVariants": {
+            "type": "object",
+            "properties": {
+                "card": {
+                    "type": "string"
+                },
+                "full": {
+                    "type": "string"
+                },
+                "thumbnail": {
+                    "type": "string"
                 }
             }
         },
@@ -7535,9 +10547,15 @@ const docTemplate = `{
                 "day": {
                     "type": "integer"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "match_id": {
                     "type": "integer"
                 },
+                "played_at": {
+                    "type": "string"
+                },
                 "stage": {
                     "type": "string"
                 },
@@ -7558,12 +10576,87 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Media": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "image_variants": {
+                    "$ref": "#/definitions/models.ImageVariants"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "media_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.NameReview": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "name_review_id": {
+                    "type": "integer"
+                },
+                "occurrences": {
+                    "description": "Occurrences adalah berapa kali nama ini gagal dicocokkan.",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Source adalah importer yang terakhir menemukan nama ini.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "description": "TeamID membatasi nama pemain ke tim tersebut; 0 untuk team dan hero.",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Patch": {
+            "type": "object",
+            "properties": {
+                "patch_id": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
         "models.Player": {
             "type": "object",
             "properties": {
                 "image": {
                     "type": "string"
                 },
+                "image_variants": {
+                    "$ref": "#/definitions/models.ImageVariants"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -7578,9 +10671,15 @@ const docTemplate = `{
         "models.Team": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
                 "image": {
                     "type": "string"
                 },
+                "image_variants": {
+                    "$ref": "#/definitions/models.ImageVariants"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -7592,6 +10691,9 @@ const docTemplate = `{
         "models.Tournament": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -7600,6 +10702,33 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TournamentRule": {
+            "type": "object",
+            "properties": {
+                "bans_first_phase": {
+                    "description": "BansFirstPhase dan BansSecondPhase adalah jumlah ban setiap tim per\ngame di tiap fase; 0 berarti tidak dibatasi.",
+                    "type": "integer"
+                },
+                "bans_second_phase": {
+                    "type": "integer"
+                },
+                "disabled_hero_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "draft_format": {
+                    "type": "string"
+                },
+                "fearless_mode": {
+                    "type": "string"
+                },
+                "tournament_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.TrioMid": {
             "type": "object",
             "properties": {
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogQueryDto struct {
+	EntityType string `form:"entity_type"`
+	EntityID   uint   `form:"entity_id"`
+	UserID     uint   `form:"user_id"`
+	Action     string `form:"action" binding:"omitempty,oneof=create update delete"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=500"`
+	Offset     int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type AuditLogResponseDto struct {
+	AuditLogID uint            `json:"audit_log_id"`
+	UserID     uint            `json:"user_id"`
+	Username   string          `json:"username"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint            `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
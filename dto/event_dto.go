@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// EventDto adalah perubahan data yang dikirim lewat stream
+// /matches/{id}/events dan /tournaments/{id}/events. Type berbentuk
+// "<entity>.<aksi>", misalnya "game.updated" atau "hero_pick.created";
+// Data berisi data terbaru entity, kosong untuk aksi deleted.
+type EventDto struct {
+	ID           uint64      `json:"id"`
+	Type         string      `json:"type"`
+	TournamentID uint        `json:"tournament_id"`
+	MatchID      uint        `json:"match_id"`
+	GameID       uint        `json:"game_id,omitempty"`
+	TeamID       uint        `json:"team_id,omitempty"`
+	EntityID     uint        `json:"entity_id"`
+	Data         interface{} `json:"data,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// EventStreamQueryDto adalah query stream event. LastEventID dipakai
+// client yang tidak bisa mengirim header Last-Event-ID; AccessToken
+// menggantikan header Authorization untuk EventSource di browser.
+type EventStreamQueryDto struct {
+	LastEventID uint64 `form:"last_event_id"`
+	AccessToken string `form:"access_token"`
+}
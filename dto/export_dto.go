@@ -0,0 +1,89 @@
+package dto
+
+import "time"
+
+// Baris-baris di bawah ini adalah isi sheet export workbook tournament. Tim
+// dan hero ditulis dengan namanya agar sheet bisa dibaca tanpa API.
+
+type ExportMatchRow struct {
+	MatchID    uint       `json:"match_id"`
+	Stage      string     `json:"stage"`
+	Day        int        `json:"day"`
+	Date       int        `json:"date"`
+	PlayedAt   *time.Time `json:"played_at"`
+	TeamA      string     `json:"team_a"`
+	TeamB      string     `json:"team_b"`
+	TeamAScore int        `json:"team_a_score"`
+	TeamBScore int        `json:"team_b_score"`
+}
+
+type ExportGameRow struct {
+	MatchID        uint    `json:"match_id"`
+	Stage          string  `json:"stage"`
+	GameNumber     int     `json:"game_number"`
+	Patch          *string `json:"patch"`
+	FirstPickTeam  string  `json:"first_pick_team"`
+	SecondPickTeam string  `json:"second_pick_team"`
+	WinnerTeam     string  `json:"winner_team"`
+	VideoLink      string  `json:"video_link"`
+}
+
+// ExportDraftRow adalah satu hero yang di-pick atau di-ban sebuah tim di
+// satu Game.
+type ExportDraftRow struct {
+	MatchID    uint   `json:"match_id"`
+	Stage      string `json:"stage"`
+	GameNumber int    `json:"game_number"`
+	Team       string `json:"team"`
+	Action     string `json:"action"` // pick atau ban
+	Hero       string `json:"hero"`
+}
+
+// ExportHeroDraftRow adalah satu HeroPick atau HeroBan tim dalam Match.
+type ExportHeroDraftRow struct {
+	MatchID     uint   `json:"match_id"`
+	Stage       string `json:"stage"`
+	Team        string `json:"team"`
+	Hero        string `json:"hero"`
+	FirstPhase  int    `json:"first_phase"`
+	SecondPhase int    `json:"second_phase"`
+	Total       int    `json:"total"`
+}
+
+// ExportPriorityRow adalah satu PriorityPick, PriorityBan atau FlexPick.
+// Rate adalah pick rate atau ban rate-nya.
+type ExportPriorityRow struct {
+	MatchID uint    `json:"match_id"`
+	Stage   string  `json:"stage"`
+	Team    string  `json:"team"`
+	Hero    string  `json:"hero"`
+	Role    string  `json:"role"`
+	Total   int     `json:"total"`
+	Rate    float64 `json:"rate"`
+}
+
+// ExportLaneRow adalah hasil early game satu hero di lane explaner,
+// goldlaner atau trio mid.
+type ExportLaneRow struct {
+	MatchID     uint   `json:"match_id"`
+	Stage       string `json:"stage"`
+	GameNumber  int    `json:"game_number"`
+	Team        string `json:"team"`
+	Lane        string `json:"lane"`
+	Role        string `json:"role"`
+	Hero        string `json:"hero"`
+	EarlyResult string `json:"early_result"`
+}
+
+// ExportObjectiveRow adalah satu LordResult atau TurtleResult.
+type ExportObjectiveRow struct {
+	MatchID    uint   `json:"match_id"`
+	Stage      string `json:"stage"`
+	GameNumber int    `json:"game_number"`
+	Team       string `json:"team"`
+	Objective  string `json:"objective"` // lord atau turtle
+	Phase      string `json:"phase"`
+	Setup      string `json:"setup"`
+	Initiate   string `json:"initiate"`
+	Result     string `json:"result"`
+}
@@ -1,12 +1,30 @@
 package dto
 
+import "ml-master-data/models"
+
+// GameRequestDto adalah body create game, baik multipart form dengan file
+// full_draft_image maupun JSON dengan full_draft_image_media_id.
 type GameRequestDto struct {
-	FirstPickTeamID  uint   `json:"first_pick_team_id" binding:"required"`
-	SecondPickTeamID uint   `json:"second_pick_team_id" binding:"required"`
-	WinnerTeamID     uint   `json:"winner_team_id" binding:"required"`
-	GameNumber       int    `json:"game_number" binding:"required"`
-	VideoLink        string `json:"video_link"`
-	FullDraftImage   string `json:"full_draft_image"`
+	FirstPickTeamID       uint   `form:"first_pick_team_id" json:"first_pick_team_id" binding:"required"`
+	SecondPickTeamID      uint   `form:"second_pick_team_id" json:"second_pick_team_id" binding:"required"`
+	WinnerTeamID          uint   `form:"winner_team_id" json:"winner_team_id" binding:"required"`
+	GameNumber            int    `form:"game_number" json:"game_number" binding:"required"`
+	VideoLink             string `form:"video_link" json:"video_link"`
+	FullDraftImageMediaID uint   `form:"full_draft_image_media_id" json:"full_draft_image_media_id"`
+	// PatchID kosong berarti patch yang berlaku pada tanggal match.
+	PatchID *uint `form:"patch_id" json:"patch_id"`
+}
+
+// GameUpdateRequestDto adalah body update game; field yang tidak dikirim
+// tidak diubah.
+type GameUpdateRequestDto struct {
+	FirstPickTeamID       *uint   `form:"first_pick_team_id" json:"first_pick_team_id"`
+	SecondPickTeamID      *uint   `form:"second_pick_team_id" json:"second_pick_team_id"`
+	WinnerTeamID          *uint   `form:"winner_team_id" json:"winner_team_id"`
+	GameNumber            *int    `form:"game_number" json:"game_number"`
+	VideoLink             *string `form:"video_link" json:"video_link"`
+	FullDraftImageMediaID uint    `form:"full_draft_image_media_id" json:"full_draft_image_media_id"`
+	PatchID               *uint   `form:"patch_id" json:"patch_id"`
 }
 
 type GameResponseDto struct {
@@ -14,25 +32,27 @@ type GameResponseDto struct {
 	MatchID         uint `json:"match_id"`
 	FirstPickTeamID uint `json:"first_pick_team_id"`
 	FirstTeam       struct {
-		TeamID uint   `json:"team_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		TeamID uint         `json:"team_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:first_team_" json:"first_team"`
 	SecondPickTeamID uint `json:"second_pick_team_id"`
 	SecondTeam       struct {
-		TeamID uint   `json:"team_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		TeamID uint         `json:"team_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:second_team_" json:"second_team"`
 	WinnerTeamID uint `json:"winner_team_id"`
 	WinnerTeam   struct {
-		TeamID uint   `json:"team_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		TeamID uint         `json:"team_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:winner_team_" json:"winner_team"`
-	GameNumber     int    `json:"game_number"`
-	VideoLink      string `json:"video_link"`
-	FullDraftImage string `json:"full_draft_image"`
+	GameNumber     int          `json:"game_number"`
+	VideoLink      string       `json:"video_link"`
+	FullDraftImage models.Image `json:"full_draft_image" swaggertype:"string"`
+	PatchID        *uint        `json:"patch_id"`
+	PatchVersion   *string      `json:"patch_version"`
 }
 
 type LordResultRequestDto struct {
@@ -47,9 +67,9 @@ type LordResultResponseDto struct {
 	LordResultID uint `json:"lord_result_id"`
 	GameID       uint `json:"game_id"`
 	Team         struct {
-		TeamID uint   `json:"team_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		TeamID uint         `json:"team_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:team_" json:"team"`
 	Phase    string `json:"phase"`
 	Setup    string `json:"setup"`
@@ -69,9 +89,9 @@ type TurtleResultResponseDto struct {
 	TurtleResultID uint `json:"turtle_result_id"`
 	GameID         uint `json:"game_id"`
 	Team           struct {
-		TeamID uint   `json:"team_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		TeamID uint         `json:"team_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:team_" json:"team"`
 	Phase    string `json:"phase"`
 	Setup    string `json:"setup"`
@@ -89,15 +109,15 @@ type ExplanerResponseDto struct {
 	GameID     uint `json:"game_id"`
 	TeamID     uint `json:"team_id"`
 	Team       struct {
-		TeamID uint   `json:"team_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		TeamID uint         `json:"team_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:team_" json:"team"`
 	HeroID uint `json:"hero_id"`
 	Hero   struct {
-		HeroID uint   `json:"hero_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		HeroID uint         `json:"hero_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:hero_" json:"hero"`
 	EarlyResult string `json:"early_result"`
 }
@@ -112,15 +132,15 @@ type GoldlanerResponseDto struct {
 	GameID      uint `json:"game_id"`
 	TeamID      uint `json:"team_id"`
 	Team        struct {
-		TeamID uint   `json:"team_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		TeamID uint         `json:"team_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:team_" json:"team"`
 	HeroID uint `json:"hero_id"`
 	Hero   struct {
-		HeroID uint   `json:"hero_id"`
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		HeroID uint         `json:"hero_id"`
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:hero_" json:"hero"`
 	EarlyResult string `json:"early_result"`
 }
@@ -139,13 +159,26 @@ type TrioMidResponseDto struct {
 	Role          string `json:"role"`
 	EarlyResult   string `json:"early_result"`
 	Team          struct {
-		TeamID uint   `json:"team_id"` // Tetap menggunakan team_id
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		TeamID uint         `json:"team_id"` // Tetap menggunakan team_id
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:team_" json:"team"`
 	Hero struct {
-		HeroID uint   `json:"hero_id"` // Tetap menggunakan hero_id
-		Name   string `json:"name"`
-		Image  string `json:"image"`
+		HeroID uint         `json:"hero_id"` // Tetap menggunakan hero_id
+		Name   string       `json:"name"`
+		Image  models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:hero_" json:"hero"`
 }
+
+type TrioMidResultDto struct {
+	TeamID      uint   `json:"team_id" binding:"required"`
+	EarlyResult string `gorm:"type:enum('win', 'draw', 'lose')" json:"early_result"`
+}
+
+// GameResultDto merangkum hasil early game ketiga lane sebuah tim di Game.
+type GameResultDto struct {
+	Win    int    `json:"win"`
+	Draw   int    `json:"draw"`
+	Lose   int    `json:"lose"`
+	Result string `json:"result"`
+}
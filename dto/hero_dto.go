@@ -0,0 +1,23 @@
+package dto
+
+// HeroRequestDto adalah body create dan update hero. Field metadata yang
+// tidak dikirim tidak diubah; string kosong mengosongkan kelas dan tanggal
+// rilis, dan daftar yang hanya berisi string kosong mengosongkan lanes atau
+// aliases.
+type HeroRequestDto struct {
+	ImageEntityRequestDto
+	PrimaryClass   *string  `form:"primary_class" json:"primary_class"`
+	SecondaryClass *string  `form:"secondary_class" json:"secondary_class"`
+	ReleaseDate    *string  `form:"release_date" json:"release_date"`
+	Lanes          []string `form:"lanes" json:"lanes"`
+	Aliases        []string `form:"aliases" json:"aliases"`
+}
+
+// HeroQueryDto adalah filter GET /heroes.
+type HeroQueryDto struct {
+	Class          string `form:"class" binding:"omitempty,oneof=tank fighter assassin mage marksman support"`
+	Lane           string `form:"lane" binding:"omitempty,oneof=gold exp roam mid jungler"`
+	Query          string `form:"q"`
+	ReleasedAfter  string `form:"released_after" binding:"omitempty,datetime=2006-01-02"`
+	ReleasedBefore string `form:"released_before" binding:"omitempty,datetime=2006-01-02"`
+}
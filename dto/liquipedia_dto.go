@@ -0,0 +1,23 @@
+package dto
+
+// LiquipediaImportResultDto adalah hasil import file Liquipedia: hasil
+// import spreadsheet dari match yang dibaca, ditambah hasil pencocokan nama
+// team dan hero. Error dari file Liquipedia ditulis dengan sheet
+// "Liquipedia" dan Row berisi nomor match di file.
+type LiquipediaImportResultDto struct {
+	SheetImportResultDto
+	Matches int                 `json:"matches"`
+	Names   []LiquipediaNameDto `json:"names"`
+}
+
+// LiquipediaNameDto adalah satu nama team atau hero di file Liquipedia.
+// Method bernilai exact, alias atau normalized jika nama cocok; jika tidak,
+// Suggestions berisi nama yang mirip.
+type LiquipediaNameDto struct {
+	Kind        string   `json:"kind"`
+	Name        string   `json:"name"`
+	MatchedID   uint     `json:"matched_id,omitempty"`
+	Matched     string   `json:"matched,omitempty"`
+	Method      string   `json:"method,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
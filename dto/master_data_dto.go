@@ -0,0 +1,30 @@
+package dto
+
+// MasterDataVersion adalah versi format MasterDataDto yang ditulis oleh
+// export dan diterima oleh import.
+const MasterDataVersion = 1
+
+// MasterDataDto berisi hero serta team beserta pemain dan pelatihnya tanpa
+// ID, sehingga bisa dipindahkan antar instance dan dicocokkan lewat nama.
+type MasterDataDto struct {
+	Version int                 `json:"version"`
+	Heroes  []MasterDataHeroDto `json:"heroes"`
+	Teams   []MasterDataTeamDto `json:"teams"`
+}
+
+type MasterDataHeroDto struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+type MasterDataTeamDto struct {
+	Name    string                `json:"name"`
+	Image   string                `json:"image"`
+	Players []MasterDataMemberDto `json:"players"`
+	Coaches []MasterDataMemberDto `json:"coaches"`
+}
+
+type MasterDataMemberDto struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
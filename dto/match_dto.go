@@ -1,9 +1,18 @@
 package dto
 
+import (
+	"time"
+
+	"ml-master-data/models"
+)
+
 type MatchRequestDto struct {
-	Stage      *string `json:"stage" binding:"required"`
-	Day        *int    `json:"day" binding:"required"`
-	Date       *int    `json:"date" binding:"required"`
+	Stage *string `json:"stage" binding:"required"`
+	Day   *int    `json:"day" binding:"required"`
+	Date  *int    `json:"date" binding:"required"`
+	// PlayedAt adalah tanggal lengkap match (YYYY-MM-DD), dipakai untuk
+	// menentukan patch game; string kosong mengosongkannya.
+	PlayedAt   *string `json:"played_at"`
 	TeamAID    *uint   `json:"team_a_id" binding:"required"`
 	TeamBID    *uint   `json:"team_b_id" binding:"required"`
 	TeamAScore *int    `json:"team_a_score" binding:"required"`
@@ -11,22 +20,23 @@ type MatchRequestDto struct {
 }
 
 type MatchResponseDto struct {
-	MatchID      *uint   `json:"match_id"`
-	TournamentID *uint   `json:"tournament_id"`
-	Stage        *string `json:"stage"`
-	Day          *int    `json:"day"`
-	Date         *int    `json:"date"`
-	TeamAID      *uint   `json:"team_a_id"`
+	MatchID      *uint      `json:"match_id"`
+	TournamentID *uint      `json:"tournament_id"`
+	Stage        *string    `json:"stage"`
+	Day          *int       `json:"day"`
+	Date         *int       `json:"date"`
+	PlayedAt     *time.Time `json:"played_at"`
+	TeamAID      *uint      `json:"team_a_id"`
 	TeamA        *struct {
-		TeamID *uint   `json:"team_id"`
-		Name   *string `json:"name"`
-		Image  *string `json:"image"`
+		TeamID *uint         `json:"team_id"`
+		Name   *string       `json:"name"`
+		Image  *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:team_a_" json:"team_a"`
 	TeamBID *uint `json:"team_b_id"`
 	TeamB   *struct {
-		TeamID *uint   `json:"team_id"`
-		Name   *string `json:"name"`
-		Image  *string `json:"image"`
+		TeamID *uint         `json:"team_id"`
+		Name   *string       `json:"name"`
+		Image  *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:team_b_" json:"team_b"`
 	TeamAScore *int `json:"team_a_score"`
 	TeamBScore *int `json:"team_b_score"`
@@ -46,10 +56,10 @@ type PlayerMatchResponseDto struct {
 	MatchTeamDetailID *uint   `json:"match_team_detail_id"`
 	Role              *string `json:"role"`
 	Player            *struct {
-		PlayerID *uint   `json:"player_id"`
-		TeamID   *uint   `json:"team_id"`
-		Name     *string `json:"name"`
-		Image    *string `json:"image"`
+		PlayerID *uint         `json:"player_id"`
+		TeamID   *uint         `json:"team_id"`
+		Name     *string       `json:"name"`
+		Image    *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:player_" json:"player"`
 }
 
@@ -67,10 +77,10 @@ type CoachMatchResponseDto struct {
 	MatchTeamDetailID *uint   `json:"match_team_detail_id"`
 	Role              *string `json:"role"`
 	Coach             *struct {
-		CoachID *uint   `json:"coach_id"`
-		TeamID  *uint   `json:"team_id"`
-		Name    *string `json:"name"`
-		Image   *string `json:"image"`
+		CoachID *uint         `json:"coach_id"`
+		TeamID  *uint         `json:"team_id"`
+		Name    *string       `json:"name"`
+		Image   *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:coach_" json:"coach"`
 }
 
@@ -91,9 +101,9 @@ type HeroPickResponseDto struct {
 	MatchTeamDetailID *uint `json:"match_team_detail_id"`
 	HeroID            *uint `json:"hero_id"`
 	Hero              *struct {
-		HeroID *uint   `json:"hero_id"`
-		Name   *string `json:"name"`
-		Image  *string `json:"image"`
+		HeroID *uint         `json:"hero_id"`
+		Name   *string       `json:"name"`
+		Image  *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:hero_" json:"hero"`
 	FirstPhase   *int `json:"first_phase"`
 	SecondPhase  *int `json:"second_phase"`
@@ -104,7 +114,7 @@ type HeroPickResponseDto struct {
 		GameID         uint `json:"game_id"`
 		GameNumber     int  `json:"game_number"`
 		IsPicked       bool `json:"is_picked"`
-	} `json:"hero_pick_game"`
+	} `gorm:"-" json:"hero_pick_game"`
 }
 
 type HeroBanRequestDto struct {
@@ -124,9 +134,9 @@ type HeroBanResponseDto struct {
 	MatchTeamDetailID *uint `json:"match_team_detail_id"`
 	HeroID            *uint `json:"hero_id"`
 	Hero              *struct {
-		HeroID *uint   `json:"hero_id"`
-		Name   *string `json:"name"`
-		Image  *string `json:"image"`
+		HeroID *uint         `json:"hero_id"`
+		Name   *string       `json:"name"`
+		Image  *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:hero_" json:"hero"`
 	FirstPhase  *int `json:"first_phase"`
 	SecondPhase *int `json:"second_phase"`
@@ -137,7 +147,7 @@ type HeroBanResponseDto struct {
 		GameID        uint `json:"game_id"`
 		GameNumber    int  `json:"game_number"`
 		IsBanned      bool `json:"is_banned"`
-	} `json:"hero_ban_game"`
+	} `gorm:"-" json:"hero_ban_game"`
 }
 
 type PriorityPickRequestDto struct {
@@ -151,9 +161,9 @@ type PriorityPickResponseDto struct {
 	PriorityPickID    *uint `json:"priority_pick_id"`
 	MatchTeamDetailID *uint `json:"match_team_detail_id"`
 	Hero              *struct {
-		HeroID *uint   `json:"hero_id"`
-		Name   *string `json:"name"`
-		Image  *string `json:"image"`
+		HeroID *uint         `json:"hero_id"`
+		Name   *string       `json:"name"`
+		Image  *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:hero_" json:"hero"`
 	Total    *int     `json:"total"`
 	Role     *string  `json:"role"`
@@ -171,9 +181,9 @@ type FlexPickResponseDto struct {
 	FlexPickID        *uint `json:"flex_pick_id"`
 	MatchTeamDetailID *uint `json:"match_team_detail_id"`
 	Hero              *struct {
-		HeroID *uint   `json:"hero_id"`
-		Name   *string `json:"name"`
-		Image  *string `json:"image"`
+		HeroID *uint         `json:"hero_id"`
+		Name   *string       `json:"name"`
+		Image  *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:hero_" json:"hero"`
 	Total    *int     `json:"total"`
 	Role     *string  `json:"role"`
@@ -191,9 +201,9 @@ type PriorityBanResponseDto struct {
 	PriorityBanID     *uint `json:"priority_ban_id"`
 	MatchTeamDetailID *uint `json:"match_team_detail_id"`
 	Hero              *struct {
-		HeroID *uint   `json:"hero_id"`
-		Name   *string `json:"name"`
-		Image  *string `json:"image"`
+		HeroID *uint         `json:"hero_id"`
+		Name   *string       `json:"name"`
+		Image  *models.Image `json:"image" swaggertype:"string"`
 	} `gorm:"embedded;embeddedPrefix:hero_" json:"hero"`
 	Total   *int     `json:"total"`
 	Role    *string  `json:"role"`
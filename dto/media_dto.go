@@ -0,0 +1,9 @@
+package dto
+
+// ImageEntityRequestDto adalah body create dan update hero, team, pemain dan
+// pelatih. Dikirim sebagai multipart form dengan file image, atau sebagai
+// JSON dengan image_media_id hasil POST /media.
+type ImageEntityRequestDto struct {
+	Name         string `form:"name" json:"name"`
+	ImageMediaID uint   `form:"image_media_id" json:"image_media_id"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import "ml-master-data/models"
+
+// NameQueryDto adalah query GET /names/resolve. TeamID membatasi
+// pencocokan nama pemain ke satu tim.
+type NameQueryDto struct {
+	Kind   string `form:"kind" binding:"required,oneof=team player hero"`
+	Name   string `form:"name" binding:"required"`
+	TeamID uint   `form:"team_id"`
+}
+
+// SearchQueryDto adalah query GET /search; Kind kosong mencari di semua
+// jenis.
+type SearchQueryDto struct {
+	Query string `form:"q" binding:"required"`
+	Kind  string `form:"kind" binding:"omitempty,oneof=team player hero"`
+}
+
+// NameCandidateDto adalah team, pemain atau hero yang mungkin dimaksud
+// sebuah nama. Method bernilai exact, alias, normalized atau fuzzy, atau
+// partial di hasil pencarian; Score 1 berarti cocok pasti.
+type NameCandidateDto struct {
+	Kind   string  `json:"kind"`
+	ID     uint    `json:"id"`
+	Name   string  `json:"name"`
+	TeamID uint    `json:"team_id,omitempty"`
+	Method string  `json:"method"`
+	Score  float64 `json:"score"`
+}
+
+// NameResolutionDto adalah hasil pencocokan satu nama. Status matched
+// berarti Match terisi; ambiguous berarti ada beberapa Candidates dan nama
+// perlu direview; unknown berarti tidak ada yang mirip.
+type NameResolutionDto struct {
+	Kind       string             `json:"kind"`
+	Name       string             `json:"name"`
+	TeamID     uint               `json:"team_id,omitempty"`
+	Status     string             `json:"status"`
+	Match      *NameCandidateDto  `json:"match"`
+	Candidates []NameCandidateDto `json:"candidates"`
+}
+
+// AliasesDto adalah daftar alias team atau pemain. PUT mengganti semua
+// alias; daftar kosong menghapus semuanya.
+type AliasesDto struct {
+	Aliases []string `json:"aliases" binding:"required"`
+}
+
+// NameReviewQueryDto adalah filter GET /name-reviews. Status kosong berarti
+// pending.
+type NameReviewQueryDto struct {
+	Kind   string `form:"kind" binding:"omitempty,oneof=team player hero"`
+	Status string `form:"status" binding:"omitempty,oneof=pending resolved dismissed all"`
+}
+
+// NameReviewDto adalah nama di antrean review beserta kandidat saat ini.
+type NameReviewDto struct {
+	models.NameReview
+	Candidates []NameCandidateDto `json:"candidates"`
+}
+
+// NameReviewResolveDto adalah body POST /name-reviews/{id}/resolve:
+// entity yang dimaksud nama tersebut.
+type NameReviewResolveDto struct {
+	EntityID uint `json:"entity_id" binding:"required"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// PatchRequestDto adalah body create dan update patch. Saat update, field
+// kosong tidak diubah.
+type PatchRequestDto struct {
+	Version     string `json:"version" binding:"max=20"`
+	ReleaseDate string `json:"release_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// StatisticsQueryDto adalah filter yang diterima endpoint statistik team,
+// player dan coach serta daftar draft per match (hero pick/ban, priority dan
+// flex pick). Hero pick/ban difilter lewat Game tempat hero di-pick atau
+// di-ban; priority dan flex pick dicatat per match sehingga hanya muncul
+// jika Match punya Game di patch tersebut.
+type StatisticsQueryDto struct {
+	Patch string `form:"patch"`
+}
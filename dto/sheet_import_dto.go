@@ -0,0 +1,42 @@
+package dto
+
+// SheetImportResultDto adalah hasil import spreadsheet. Jika Errors tidak
+// kosong, tidak ada data yang disimpan.
+type SheetImportResultDto struct {
+	DryRun    bool                   `json:"dry_run"`
+	Applied   bool                   `json:"applied"`
+	Created   int                    `json:"created"`
+	Updated   int                    `json:"updated"`
+	Unchanged int                    `json:"unchanged"`
+	Changes   []SheetImportChangeDto `json:"changes"`
+	Errors    []SheetImportErrorDto  `json:"errors"`
+}
+
+// SheetImportChangeDto adalah perubahan yang dihasilkan satu baris
+// spreadsheet. Action bernilai create atau update; Key menjelaskan data
+// yang diubah dengan nama tim dan hero.
+type SheetImportChangeDto struct {
+	Sheet  string                `json:"sheet"`
+	Row    int                   `json:"row"`
+	Action string                `json:"action"`
+	Entity string                `json:"entity"`
+	Key    string                `json:"key"`
+	Fields []SheetImportFieldDto `json:"fields"`
+}
+
+// SheetImportFieldDto adalah nilai sebuah field sebelum dan sesudah import.
+// Before kosong untuk data baru.
+type SheetImportFieldDto struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// SheetImportErrorDto menjelaskan baris spreadsheet yang tidak valid. Row 0
+// berarti masalahnya ada pada sheet, bukan pada baris tertentu.
+type SheetImportErrorDto struct {
+	Sheet   string `json:"sheet"`
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+// PlayerStats adalah jumlah match dan game seorang pemain di satu tournament.
+type PlayerStats struct {
+	TotalMatch    int `json:"total_match"`
+	TotalMatchWin int `json:"total_match_win"`
+	TotalGame     int `json:"total_game"`
+	TotalGameWin  int `json:"total_game_win"`
+}
+
+// CoachStats adalah jumlah match dan game seorang coach di satu tournament.
+type CoachStats struct {
+	TotalMatch    int `json:"total_match"`
+	TotalMatchWin int `json:"total_match_win"`
+	TotalGame     int `json:"total_game"`
+	TotalGameWin  int `json:"total_game_win"`
+}
+
+// TeamStatisticsDto adalah rekap match, game dan hasil first/second pick
+// sebuah tim di satu tournament.
+type TeamStatisticsDto struct {
+	TeamID                 uint `json:"teamID"`
+	TotalMatch             int  `json:"totalMatch"`
+	TotalMatchAndWin       int  `json:"totalMatchAndWin"`
+	TotalMatchAndLose      int  `json:"totalMatchAndLose"`
+	TotalGame              int  `json:"totalGame"`
+	TotalGameAndWin        int  `json:"totalGameAndWin"`
+	TotalGameAndLose       int  `json:"totalGameAndLose"`
+	TotalFirstPick         int  `json:"totalFirstPick"`
+	TotalFirstPickAndWin   int  `json:"totalFirstPickAndWin"`
+	TotalFirstPickAndLose  int  `json:"totalFirstPickAndLose"`
+	TotalSecondPick        int  `json:"totalSecondPick"`
+	TotalSecondPickAndWin  int  `json:"totalSecondPickAndWin"`
+	TotalSecondPickAndLose int  `json:"totalSecondPickAndLose"`
+}
@@ -0,0 +1,194 @@
+package dto
+
+import "time"
+
+// TournamentBundleVersion adalah versi format TournamentBundleDto yang
+// ditulis oleh backup. Restore juga menerima versi 1, yang belum membawa
+// Files.
+const TournamentBundleVersion = 2
+
+// TournamentBundleDto adalah backup lengkap satu Tournament beserta semua
+// data di bawahnya. Match, game dan draft disusun bertingkat tanpa ID;
+// team, pemain, pelatih, hero dan patch yang dirujuk ikut disertakan dengan
+// ID dari instance asal, dan dipetakan ulang saat restore. Image ditulis
+// sebagai referensi (key storage atau URL); file sumber gambar yang
+// dirujuk ikut di Files, per key, sebagai base64. Saat restore file
+// diproses ulang seperti upload dan disimpan dengan key baru.
+type TournamentBundleDto struct {
+	Version    int                 `json:"version"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Tournament BundleTournamentDto `json:"tournament"`
+	Teams      []BundleTeamDto     `json:"teams"`
+	Players    []BundlePlayerDto   `json:"players"`
+	Coaches    []BundleCoachDto    `json:"coaches"`
+	Heroes     []BundleHeroDto     `json:"heroes"`
+	Patches    []BundlePatchDto    `json:"patches"`
+	Matches    []BundleMatchDto    `json:"matches"`
+	Files      map[string][]byte   `json:"files"`
+}
+
+type BundleTournamentDto struct {
+	Name string         `json:"name"`
+	Rule *BundleRuleDto `json:"rule"`
+}
+
+type BundleRuleDto struct {
+	DraftFormat     string `json:"draft_format"`
+	BansFirstPhase  int    `json:"bans_first_phase"`
+	BansSecondPhase int    `json:"bans_second_phase"`
+	FearlessMode    string `json:"fearless_mode"`
+	DisabledHeroIDs []uint `json:"disabled_hero_ids"`
+}
+
+type BundleTeamDto struct {
+	TeamID uint   `json:"team_id"`
+	Name   string `json:"name"`
+	Image  string `json:"image"`
+}
+
+type BundlePlayerDto struct {
+	PlayerID uint   `json:"player_id"`
+	TeamID   uint   `json:"team_id"`
+	Name     string `json:"name"`
+	Image    string `json:"image"`
+}
+
+type BundleCoachDto struct {
+	CoachID uint   `json:"coach_id"`
+	TeamID  uint   `json:"team_id"`
+	Name    string `json:"name"`
+	Image   string `json:"image"`
+}
+
+type BundleHeroDto struct {
+	HeroID         uint       `json:"hero_id"`
+	Name           string     `json:"name"`
+	Image          string     `json:"image"`
+	PrimaryClass   string     `json:"primary_class"`
+	SecondaryClass string     `json:"secondary_class"`
+	ReleaseDate    *time.Time `json:"release_date"`
+	Lanes          []string   `json:"lanes"`
+	Aliases        []string   `json:"aliases"`
+}
+
+type BundlePatchDto struct {
+	PatchID     uint      `json:"patch_id"`
+	Version     string    `json:"version"`
+	ReleaseDate time.Time `json:"release_date"`
+}
+
+type BundleMatchDto struct {
+	Stage      string               `json:"stage"`
+	Day        int                  `json:"day"`
+	Date       int                  `json:"date"`
+	PlayedAt   *time.Time           `json:"played_at"`
+	TeamAID    uint                 `json:"team_a_id"`
+	TeamBID    uint                 `json:"team_b_id"`
+	TeamAScore int                  `json:"team_a_score"`
+	TeamBScore int                  `json:"team_b_score"`
+	Teams      []BundleMatchTeamDto `json:"teams"`
+	Games      []BundleGameDto      `json:"games"`
+}
+
+// BundleMatchTeamDto adalah MatchTeamDetail salah satu tim beserta pemain,
+// pelatih dan draft-nya di match tersebut.
+type BundleMatchTeamDto struct {
+	TeamID        uint                   `json:"team_id"`
+	Players       []BundlePlayerMatchDto `json:"players"`
+	Coaches       []BundleCoachMatchDto  `json:"coaches"`
+	HeroPicks     []BundleHeroDraftDto   `json:"hero_picks"`
+	HeroBans      []BundleHeroDraftDto   `json:"hero_bans"`
+	PriorityPicks []BundlePriorityDto    `json:"priority_picks"`
+	PriorityBans  []BundlePriorityDto    `json:"priority_bans"`
+	FlexPicks     []BundlePriorityDto    `json:"flex_picks"`
+}
+
+type BundlePlayerMatchDto struct {
+	PlayerID uint   `json:"player_id"`
+	Role     string `json:"role"`
+}
+
+type BundleCoachMatchDto struct {
+	CoachID uint   `json:"coach_id"`
+	Role    string `json:"role"`
+}
+
+// BundleHeroDraftDto adalah hero pick atau hero ban. Games berisi status
+// pick/ban di setiap game, dirujuk lewat nomor game.
+type BundleHeroDraftDto struct {
+	HeroID      uint                     `json:"hero_id"`
+	FirstPhase  int                      `json:"first_phase"`
+	SecondPhase int                      `json:"second_phase"`
+	Total       int                      `json:"total"`
+	Games       []BundleHeroDraftGameDto `json:"games"`
+}
+
+type BundleHeroDraftGameDto struct {
+	GameNumber int  `json:"game_number"`
+	Drafted    bool `json:"drafted"`
+}
+
+// BundlePriorityDto adalah priority pick, priority ban atau flex pick; Rate
+// adalah pick rate atau ban rate.
+type BundlePriorityDto struct {
+	HeroID uint    `json:"hero_id"`
+	Role   string  `json:"role"`
+	Total  int     `json:"total"`
+	Rate   float64 `json:"rate"`
+}
+
+type BundleGameDto struct {
+	GameNumber       int                   `json:"game_number"`
+	FirstPickTeamID  uint                  `json:"first_pick_team_id"`
+	SecondPickTeamID uint                  `json:"second_pick_team_id"`
+	WinnerTeamID     uint                  `json:"winner_team_id"`
+	VideoLink        string                `json:"video_link"`
+	FullDraftImage   string                `json:"full_draft_image"`
+	PatchID          *uint                 `json:"patch_id"`
+	Results          []BundleGameResultDto `json:"results"`
+	Explaners        []BundleLaneDto       `json:"explaners"`
+	Goldlaners       []BundleLaneDto       `json:"goldlaners"`
+	TrioMids         []BundleTrioMidDto    `json:"trio_mids"`
+	LordResults      []BundleObjectiveDto  `json:"lord_results"`
+	TurtleResults    []BundleObjectiveDto  `json:"turtle_results"`
+}
+
+type BundleGameResultDto struct {
+	TeamID uint   `json:"team_id"`
+	Result string `json:"result"`
+}
+
+type BundleLaneDto struct {
+	TeamID      uint   `json:"team_id"`
+	HeroID      uint   `json:"hero_id"`
+	EarlyResult string `json:"early_result"`
+}
+
+type BundleTrioMidDto struct {
+	TeamID      uint                   `json:"team_id"`
+	EarlyResult *string                `json:"early_result"`
+	Heroes      []BundleTrioMidHeroDto `json:"heroes"`
+}
+
+type BundleTrioMidHeroDto struct {
+	HeroID      uint   `json:"hero_id"`
+	Role        string `json:"role"`
+	EarlyResult string `json:"early_result"`
+}
+
+type BundleObjectiveDto struct {
+	TeamID   uint   `json:"team_id"`
+	Phase    string `json:"phase"`
+	Setup    string `json:"setup"`
+	Initiate string `json:"initiate"`
+	Result   string `json:"result"`
+}
+
+// TournamentRestoreDto adalah hasil restore bundle: Tournament baru dan
+// jumlah baris yang dibuat per jenis data. Reused adalah team, pemain,
+// pelatih, hero dan patch yang sudah ada dan dicocokkan lewat nama.
+type TournamentRestoreDto struct {
+	TournamentID uint           `json:"tournament_id"`
+	Created      map[string]int `json:"created"`
+	Reused       map[string]int `json:"reused"`
+}
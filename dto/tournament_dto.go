@@ -3,3 +3,13 @@ package dto
 type TournamentRequestDto struct {
 	Name string `json:"name" binding:"required"`
 }
+
+// TournamentRuleRequestDto adalah body update aturan draft tournament;
+// field yang tidak dikirim tidak diubah.
+type TournamentRuleRequestDto struct {
+	DraftFormat     *string `json:"draft_format" binding:"omitempty,oneof=standard global_ban"`
+	BansFirstPhase  *int    `json:"bans_first_phase" binding:"omitempty,min=0,max=10"`
+	BansSecondPhase *int    `json:"bans_second_phase" binding:"omitempty,min=0,max=10"`
+	FearlessMode    *string `json:"fearless_mode" binding:"omitempty,oneof=off team full"`
+	DisabledHeroIDs []uint  `json:"disabled_hero_ids"`
+}
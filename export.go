@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/dto"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"os"
+)
+
+// runExport menjalankan subcommand `export [-o file]` yang menulis master
+// data sebagai JSON ke file atau stdout.
+func runExport(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	output := flags.String("o", "", "output file (default stdout)")
+	flags.Parse(args)
+
+	config.ConnectDatabase(cfg.Database)
+
+	data, err := services.New(repositories.New(config.DB, openStorage(cfg))).MasterData.Export(context.Background())
+	if err != nil {
+		log.Fatal("Failed to export master data: ", err)
+	}
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		log.Fatal("Failed to write master data: ", err)
+	}
+}
+
+// runImport menjalankan subcommand `import file` yang membaca hasil export.
+// Import bisa diulang tanpa membuat data ganda.
+func runImport(cfg *config.Config, args []string) {
+	if len(args) != 1 {
+		log.Fatal("Usage: import file")
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	var data dto.MasterDataDto
+	if err := json.NewDecoder(file).Decode(&data); err != nil {
+		log.Fatal("Invalid master data file: ", err)
+	}
+
+	config.ConnectDatabase(cfg.Database)
+
+	ctx := services.WithAuditActor(context.Background(), cliActor)
+	result, err := services.New(repositories.New(config.DB, openStorage(cfg))).MasterData.Import(ctx, data)
+	if err != nil {
+		log.Fatal("Failed to import master data: ", err)
+	}
+	fmt.Printf("Created %d and updated %d records\n", result.Created, result.Updated)
+}
@@ -0,0 +1,143 @@
+// Package imaging memproses gambar hasil upload: jenis file dideteksi dari
+// isinya (bukan ekstensi), gambar di-decode lalu di-encode ulang sehingga
+// metadata seperti EXIF ikut terbuang, dan diperkecil ke beberapa ukuran
+// standar dalam format WebP.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// ErrUnsupported dikembalikan jika isi file bukan gambar yang didukung atau
+// tidak bisa di-decode.
+var ErrUnsupported = errors.New("unsupported image type")
+
+// ErrTooLarge dikembalikan jika dimensi gambar melebihi MaxPixels.
+var ErrTooLarge = errors.New("image dimensions too large")
+
+// MaxPixels membatasi jumlah piksel gambar sumber agar file kecil dengan
+// dimensi raksasa tidak menghabiskan memori saat di-decode.
+const MaxPixels = 40_000_000
+
+// ContentType dan Ext dipakai untuk semua varian hasil proses.
+const (
+	ContentType = "image/webp"
+	Ext         = ".webp"
+)
+
+// Nama varian gambar.
+const (
+	Thumbnail = "thumbnail"
+	Card      = "card"
+	Full      = "full"
+)
+
+// Size adalah ukuran standar varian: sisi terpanjang gambar diperkecil ke
+// Max piksel. Gambar yang lebih kecil tidak diperbesar.
+type Size struct {
+	Name string
+	Max  int
+}
+
+// Sizes berisi semua varian yang dibuat untuk setiap upload, dari yang
+// terkecil.
+var Sizes = []Size{
+	{Name: Thumbnail, Max: 160},
+	{Name: Card, Max: 480},
+	{Name: Full, Max: 1600},
+}
+
+// allowedTypes adalah jenis gambar sumber yang diterima, hasil deteksi
+// http.DetectContentType.
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// Variant adalah satu ukuran gambar hasil proses dalam format WebP.
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// Process membaca gambar dari r, memastikan isinya gambar yang didukung,
+// lalu menghasilkan satu varian WebP untuk setiap Sizes.
+func Process(r io.Reader) ([]Variant, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !allowedTypes[http.DetectContentType(data)] {
+		return nil, ErrUnsupported
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrUnsupported
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+
+	variants := make([]Variant, 0, len(Sizes))
+	for _, size := range Sizes {
+		img := resize(src, size.Max)
+		var buf bytes.Buffer
+		if err := nativewebp.Encode(&buf, img, nil); err != nil {
+			return nil, fmt.Errorf("encode %s: %w", size.Name, err)
+		}
+		bounds := img.Bounds()
+		variants = append(variants, Variant{
+			Name:   size.Name,
+			Width:  bounds.Dx(),
+			Height: bounds.Dy(),
+			Data:   buf.Bytes(),
+		})
+	}
+	return variants, nil
+}
+
+// resize menggambar ulang src ke kanvas baru dengan sisi terpanjang paling
+// besar limit piksel. Kanvas baru selalu dibuat agar tidak ada data sumber
+// yang ikut ter-encode.
+func resize(src image.Image, limit int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > limit || height > limit {
+		if width >= height {
+			height = max(1, height*limit/width)
+			width = limit
+		} else {
+			width = max(1, width*limit/height)
+			height = limit
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,103 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// testPNG membuat PNG berukuran width x height.
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// pngHeader membuat awal file PNG yang hanya berisi header dengan dimensi
+// width x height, cukup untuk image.DecodeConfig.
+func pngHeader(width, height uint32) []byte {
+	chunk := []byte("IHDR")
+	chunk = binary.BigEndian.AppendUint32(chunk, width)
+	chunk = binary.BigEndian.AppendUint32(chunk, height)
+	chunk = append(chunk, 8, 6, 0, 0, 0)
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(chunk)-4))
+	data = append(data, chunk...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(chunk))
+}
+
+func TestProcess(t *testing.T) {
+	variants, err := Process(bytes.NewReader(testPNG(t, 2000, 1000)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Setiap varian berupa WebP dan tidak melebihi ukuran standarnya.
+	want := map[string]image.Point{
+		Thumbnail: {160, 80},
+		Card:      {480, 240},
+		Full:      {1600, 800},
+	}
+	if len(variants) != len(want) {
+		t.Fatalf("variants = %d, want %d", len(variants), len(want))
+	}
+	for _, variant := range variants {
+		config, format, err := image.DecodeConfig(bytes.NewReader(variant.Data))
+		if err != nil || format != "webp" {
+			t.Fatalf("%s: format = %q, err = %v", variant.Name, format, err)
+		}
+		size := want[variant.Name]
+		if config.Width != size.X || config.Height != size.Y || variant.Width != size.X || variant.Height != size.Y {
+			t.Fatalf("%s: size = %dx%d, want %v", variant.Name, config.Width, config.Height, size)
+		}
+	}
+
+	// Gambar kecil tidak diperbesar, gambar tegak diperkecil menurut tingginya.
+	variants, err = Process(bytes.NewReader(testPNG(t, 100, 50)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full := variants[len(variants)-1]; full.Width != 100 || full.Height != 50 {
+		t.Fatalf("full = %dx%d, want 100x50", full.Width, full.Height)
+	}
+	variants, err = Process(bytes.NewReader(testPNG(t, 100, 400)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thumbnail := variants[0]; thumbnail.Width != 40 || thumbnail.Height != 160 {
+		t.Fatalf("thumbnail = %dx%d, want 40x160", thumbnail.Width, thumbnail.Height)
+	}
+}
+
+func TestProcessRejectsInvalidImages(t *testing.T) {
+	// Jenis file ditentukan dari isinya, bukan ekstensi.
+	for name, data := range map[string][]byte{
+		"html":      []byte("<html>not an image</html>"),
+		"empty":     nil,
+		"truncated": testPNG(t, 40, 40)[:60],
+	} {
+		if _, err := Process(bytes.NewReader(data)); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("%s: err = %v, want ErrUnsupported", name, err)
+		}
+	}
+
+	// Dimensi raksasa ditolak sebelum gambar di-decode.
+	if _, err := Process(bytes.NewReader(pngHeader(10000, 10000))); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("huge image: err = %v, want ErrTooLarge", err)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/dto"
+	"ml-master-data/liquipedia"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"os"
+	"strings"
+)
+
+// runImportLiquipedia menjalankan subcommand `import-liquipedia -tournament
+// id [-dry-run] file...` yang meng-import match dari wikitext atau JSON
+// Liquipedia, sama dengan POST /tournaments/{id}/import/liquipedia.
+func runImportLiquipedia(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("import-liquipedia", flag.ExitOnError)
+	tournamentID := flags.Uint("tournament", 0, "tournament to import into")
+	dryRun := flags.Bool("dry-run", false, "only print the diff")
+	flags.Parse(args)
+	if *tournamentID == 0 || flags.NArg() == 0 {
+		log.Fatal("Usage: import-liquipedia -tournament id [-dry-run] file...")
+	}
+
+	var matches []liquipedia.Match
+	for _, name := range flags.Args() {
+		file, err := os.Open(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		read, err := liquipedia.Read(name, file)
+		file.Close()
+		if err != nil {
+			log.Fatalf("Invalid Liquipedia file %s: %v", name, err)
+		}
+		matches = append(matches, read...)
+	}
+
+	config.ConnectDatabase(cfg.Database)
+
+	ctx := services.WithAuditActor(context.Background(), cliActor)
+	result, err := services.New(repositories.New(config.DB, openStorage(cfg))).Liquipedia.Import(ctx, *tournamentID, matches, *dryRun)
+	printLiquipediaNames(result.Names)
+	printSheetImport(result.SheetImportResultDto)
+
+	var invalid *services.ValidationError
+	if errors.As(err, &invalid) {
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatal("Failed to import Liquipedia matches: ", err)
+	}
+}
+
+// printLiquipediaNames menulis nama yang tidak cocok persis, karena hanya
+// nama itu yang perlu diperiksa.
+func printLiquipediaNames(names []dto.LiquipediaNameDto) {
+	for _, name := range names {
+		switch {
+		case name.Method == "":
+			line := fmt.Sprintf("unknown %s %q", name.Kind, name.Name)
+			if len(name.Suggestions) > 0 {
+				line += " (did you mean " + strings.Join(name.Suggestions, ", ") + "?)"
+			}
+			fmt.Println(line)
+		case name.Name != name.Matched:
+			fmt.Printf("matched %s %q to %q (%s)\n", name.Kind, name.Name, name.Matched, name.Method)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/dto"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"ml-master-data/tabular"
+	"os"
+	"strings"
+)
+
+// runImportSheet menjalankan subcommand `import-sheet -tournament id
+// [-dry-run] [-sheet name] file` yang meng-import match dan draft dari
+// spreadsheet CSV atau XLSX, sama dengan POST
+// /tournaments/{id}/import.
+func runImportSheet(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("import-sheet", flag.ExitOnError)
+	tournamentID := flags.Uint("tournament", 0, "tournament to import into")
+	dryRun := flags.Bool("dry-run", false, "only print the diff")
+	sheet := flags.String("sheet", "", "sheet name of a CSV file (default the file name)")
+	flags.Parse(args)
+	if *tournamentID == 0 || flags.NArg() != 1 {
+		log.Fatal("Usage: import-sheet -tournament id [-dry-run] [-sheet name] file")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	sheets, err := tabular.Read(file.Name(), file)
+	if err != nil {
+		log.Fatal("Invalid spreadsheet: ", err)
+	}
+	if *sheet != "" && len(sheets) == 1 {
+		sheets[0].Name = *sheet
+	}
+
+	config.ConnectDatabase(cfg.Database)
+
+	ctx := services.WithAuditActor(context.Background(), cliActor)
+	result, err := services.New(repositories.New(config.DB, openStorage(cfg))).SheetImport.Import(ctx, *tournamentID, sheets, *dryRun)
+	printSheetImport(result)
+
+	var invalid *services.ValidationError
+	if errors.As(err, &invalid) {
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatal("Failed to import spreadsheet: ", err)
+	}
+}
+
+func printSheetImport(result dto.SheetImportResultDto) {
+	for _, change := range result.Changes {
+		fields := make([]string, 0, len(change.Fields))
+		for _, field := range change.Fields {
+			if field.Before == "" {
+				fields = append(fields, fmt.Sprintf("%s=%s", field.Field, field.After))
+			} else {
+				fields = append(fields, fmt.Sprintf("%s: %s -> %s", field.Field, field.Before, field.After))
+			}
+		}
+		fmt.Printf("%-7s %s row %d: %s %s %s\n", change.Action, change.Sheet, change.Row, change.Entity, change.Key, strings.Join(fields, ", "))
+	}
+	for _, problem := range result.Errors {
+		location := problem.Sheet
+		if problem.Row > 0 {
+			location += fmt.Sprintf(" row %d", problem.Row)
+		}
+		if problem.Column != "" {
+			location += " " + problem.Column
+		}
+		fmt.Printf("error   %s: %s\n", location, problem.Message)
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		fmt.Printf("%d problems found, nothing was imported\n", len(result.Errors))
+	case result.DryRun:
+		fmt.Printf("Dry run: would create %d, update %d and leave %d records unchanged\n", result.Created, result.Updated, result.Unchanged)
+	default:
+		fmt.Printf("Created %d, updated %d and left %d records unchanged\n", result.Created, result.Updated, result.Unchanged)
+	}
+}
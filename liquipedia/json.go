@@ -0,0 +1,114 @@
+package liquipedia
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lpdbMatch adalah satu record match2 LPDB. Beberapa field kadang ditulis
+// sebagai string dan kadang sebagai angka, sehingga dibaca mentah.
+type lpdbMatch struct {
+	Section     string          `json:"section"`
+	Date        string          `json:"date"`
+	BestOf      json.RawMessage `json:"bestof"`
+	BracketData json.RawMessage `json:"match2bracketdata"`
+	Opponents   []struct {
+		Name     string          `json:"name"`
+		Template string          `json:"template"`
+		Score    json.RawMessage `json:"score"`
+	} `json:"match2opponents"`
+	Games []struct {
+		Winner    json.RawMessage            `json:"winner"`
+		VOD       string                     `json:"vod"`
+		ExtraData map[string]json.RawMessage `json:"extradata"`
+	} `json:"match2games"`
+}
+
+// ParseJSON membaca record match2 LPDB, baik respon API ({"result": [...]}),
+// array record, maupun satu record.
+func ParseJSON(data []byte) ([]Match, error) {
+	var records []lpdbMatch
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, err
+		}
+	} else {
+		var response struct {
+			Result []lpdbMatch `json:"result"`
+		}
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, err
+		}
+		records = response.Result
+		if records == nil {
+			var record lpdbMatch
+			if err := json.Unmarshal(data, &record); err != nil {
+				return nil, err
+			}
+			if len(record.Opponents) > 0 {
+				records = []lpdbMatch{record}
+			}
+		}
+	}
+
+	matches := make([]Match, 0, len(records))
+	for i, record := range records {
+		if len(record.Opponents) != 2 {
+			return nil, fmt.Errorf("match %d has %d opponents, want 2", i+1, len(record.Opponents))
+		}
+		matches = append(matches, record.match())
+	}
+	return matches, nil
+}
+
+func (r lpdbMatch) match() Match {
+	m := Match{Section: r.Section, Date: parseDate(r.Date), BestOf: rawInt(r.BestOf)}
+	if m.Section == "" {
+		// match2bracketdata berupa [] jika kosong
+		var bracket struct {
+			SectionHeader string `json:"sectionheader"`
+			Title         string `json:"title"`
+		}
+		if json.Unmarshal(r.BracketData, &bracket) == nil {
+			m.Section = bracket.SectionHeader
+			if m.Section == "" {
+				m.Section = bracket.Title
+			}
+		}
+	}
+	for i, opponent := range r.Opponents {
+		m.Opponents[i] = Opponent{Name: opponent.Name, Template: opponent.Template, Score: rawInt(opponent.Score)}
+	}
+
+	for i, g := range r.Games {
+		params := map[string]string{"winner": strconv.Itoa(rawInt(g.Winner)), "vod": g.VOD}
+		for key, value := range g.ExtraData {
+			var text string
+			if json.Unmarshal(value, &text) == nil {
+				params[strings.ToLower(key)] = strings.TrimSpace(text)
+			}
+		}
+		game := parseGame(params)
+		game.Number = i + 1
+		if game.Winner != 0 || len(game.Picks[0])+len(game.Picks[1]) > 0 {
+			m.Games = append(m.Games, game)
+		}
+	}
+	return m
+}
+
+// rawInt membaca angka yang ditulis sebagai angka atau string, atau -1.
+func rawInt(raw json.RawMessage) int {
+	var text string
+	if json.Unmarshal(raw, &text) != nil {
+		text = string(raw)
+	}
+	number, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return -1
+	}
+	return number
+}
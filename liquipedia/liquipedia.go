@@ -0,0 +1,130 @@
+// Package liquipedia membaca data match Liquipedia yang disimpan offline,
+// baik wikitext template Match (Matchlist dan Bracket) maupun JSON match2
+// dari LPDB.
+package liquipedia
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNoMatches dikembalikan jika file tidak berisi satu pun Match.
+var ErrNoMatches = errors.New("no matches found")
+
+// Match adalah satu match Liquipedia. Opponents[0] adalah opponent1.
+type Match struct {
+	// Section adalah judul Matchlist, atau judul bagian halaman tempat
+	// match berada.
+	Section   string
+	Date      *time.Time
+	BestOf    int
+	Opponents [2]Opponent
+	Games     []Game
+}
+
+// Opponent adalah tim di sebuah match. Name adalah nama tampilan dan
+// Template adalah kunci team template Liquipedia, misalnya "onic ph";
+// salah satunya bisa kosong.
+type Opponent struct {
+	Name     string
+	Template string
+	// Score bernilai -1 jika tidak ditulis.
+	Score int
+}
+
+// Label mengembalikan Name, atau Template jika Name kosong.
+func (o Opponent) Label() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	return o.Template
+}
+
+// Game adalah satu map di match. Indeks 0 array adalah opponent1.
+type Game struct {
+	Number int
+	// Winner adalah 1 atau 2, atau 0 jika belum diketahui.
+	Winner int
+	// Sides berisi "blue" atau "red"; tim di sisi blue mendapat first
+	// pick.
+	Sides [2]string
+	Picks [2][]string
+	Bans  [2][]string
+	VOD   string
+}
+
+// Score mengembalikan skor opponent (0 atau 1), atau jumlah game yang
+// dimenangkannya jika skor tidak ditulis.
+func (m Match) Score(opponent int) int {
+	if score := m.Opponents[opponent].Score; score >= 0 {
+		return score
+	}
+	wins := 0
+	for _, game := range m.Games {
+		if game.Winner == opponent+1 {
+			wins++
+		}
+	}
+	return wins
+}
+
+// Read membaca match dari file bernama filename. File .json dibaca sebagai
+// JSON LPDB; file lain dibaca sebagai wikitext kecuali isinya diawali JSON.
+func Read(filename string, r io.Reader) ([]Match, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+	trimmed := bytes.TrimSpace(data)
+	if strings.EqualFold(filepath.Ext(filename), ".json") || bytes.HasPrefix(trimmed, []byte("[")) || bytes.HasPrefix(trimmed, []byte(`{"`)) {
+		matches, err = ParseJSON(data)
+	} else {
+		matches = ParseWikitext(string(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, ErrNoMatches
+	}
+	return matches, nil
+}
+
+// dateLayouts adalah format tanggal yang dipakai Liquipedia, dicoba
+// berurutan.
+var dateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"January 2, 2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006 15:04",
+	"Jan 2, 2006",
+}
+
+// parseDate membaca tanggal seperti "March 15, 2024 - 17:00" atau
+// "2024-03-15 17:00". Zona waktu diabaikan karena yang dipakai hanya
+// tanggal yang tertulis.
+func parseDate(value string) *time.Time {
+	value = strings.Join(strings.Fields(strings.ReplaceAll(value, " - ", " ")), " ")
+	for value != "" {
+		for _, layout := range dateLayouts {
+			if date, err := time.Parse(layout, value); err == nil {
+				return &date
+			}
+		}
+		// Buang kata terakhir, misalnya singkatan zona waktu
+		i := strings.LastIndex(value, " ")
+		if i < 0 {
+			break
+		}
+		value = value[:i]
+	}
+	return nil
+}
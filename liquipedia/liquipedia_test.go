@@ -0,0 +1,111 @@
+package liquipedia
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// page adalah potongan halaman Liquipedia dengan satu match yang sudah
+// dimainkan di Matchlist dan satu yang belum di bawah judul bagian.
+const page = `==Playoffs==
+{{Matchlist|id=abc123|title=Upper Bracket
+|M1={{Match
+    |opponent1={{TeamOpponent|team a|score=2}}
+    |opponent2={{TeamOpponent|template=team b|name=Team B Esports}}
+    |date=March 15, 2024 - 17:00 {{Abbr/WIB}}
+    |bestof=3
+    |map1={{Map|winner=1|team1side=red|team2side=blue
+        |t1h1=[[Layla]]|t1h2=Tigreal|t2h1=eudora
+        |t1b1=Eudora|t2b1=Layla}}
+    |map2={{Map|winner=1|team1side=blue|team2side=red|t1h1=Layla|t2h1=Eudora}}
+    |map3={{Map|winner=|t1h1=}}
+    |vodgame1=https://youtu.be/game1
+}}
+}}
+==Group Stage==
+{{Match|opponent1={{TeamOpponent|team c}}|opponent2={{TeamOpponent|team d}}|date=2024-03-16}}`
+
+func TestParseWikitext(t *testing.T) {
+	matches := ParseWikitext(page)
+	if len(matches) != 2 {
+		t.Fatalf("matches = %+v", matches)
+	}
+
+	played := matches[0]
+	if played.Section != "Upper Bracket" || played.BestOf != 3 || played.Date == nil || played.Date.Format("2006-01-02 15:04") != "2024-03-15 17:00" {
+		t.Fatalf("match = %+v", played)
+	}
+	want := [2]Opponent{{Template: "team a", Score: 2}, {Name: "Team B Esports", Template: "team b", Score: -1}}
+	if played.Opponents != want || played.Opponents[1].Label() != "Team B Esports" || played.Opponents[0].Label() != "team a" {
+		t.Fatalf("opponents = %+v", played.Opponents)
+	}
+
+	// Map tanpa pemenang dan tanpa hero dilewati
+	if len(played.Games) != 2 {
+		t.Fatalf("games = %+v", played.Games)
+	}
+	first := played.Games[0]
+	if first.Number != 1 || first.Winner != 1 || first.Sides != [2]string{"red", "blue"} || first.VOD != "https://youtu.be/game1" {
+		t.Fatalf("game 1 = %+v", first)
+	}
+	if !reflect.DeepEqual(first.Picks, [2][]string{{"Layla", "Tigreal"}, {"eudora"}}) || !reflect.DeepEqual(first.Bans, [2][]string{{"Eudora"}, {"Layla"}}) {
+		t.Fatalf("game 1 draft = %+v, %+v", first.Picks, first.Bans)
+	}
+
+	// Skor yang tidak ditulis dihitung dari pemenang game
+	if played.Score(0) != 2 || played.Score(1) != 0 {
+		t.Fatalf("score = %d-%d, want 2-0", played.Score(0), played.Score(1))
+	}
+
+	upcoming := matches[1]
+	if upcoming.Section != "Group Stage" || upcoming.Date == nil || upcoming.Date.Format("2006-01-02") != "2024-03-16" || len(upcoming.Games) != 0 {
+		t.Fatalf("upcoming = %+v", upcoming)
+	}
+}
+
+func TestRead(t *testing.T) {
+	record := `{"result": [{
+		"section": "Week 1",
+		"date": "2024-02-16 18:00:00",
+		"bestof": "3",
+		"match2opponents": [{"name": "Team A", "score": "1"}, {"name": "Team B", "score": 2}],
+		"match2games": [
+			{"winner": "2", "extradata": {"team1hero1": "Layla", "team2hero1": "Tigreal"}},
+			{"winner": 1},
+			{"winner": "2", "vod": "https://youtu.be/game3"}
+		]
+	}]}`
+
+	// JSON dikenali dari ekstensi atau dari isinya
+	for _, filename := range []string{"week1.json", "week1.txt"} {
+		matches, err := Read(filename, strings.NewReader(record))
+		if err != nil {
+			t.Fatalf("%s: %v", filename, err)
+		}
+		if len(matches) != 1 {
+			t.Fatalf("%s: matches = %+v", filename, matches)
+		}
+		match := matches[0]
+		if match.Section != "Week 1" || match.BestOf != 3 || match.Score(0) != 1 || match.Score(1) != 2 || match.Opponents[0].Label() != "Team A" {
+			t.Fatalf("%s: match = %+v", filename, match)
+		}
+		if len(match.Games) != 3 || match.Games[0].Winner != 2 || match.Games[2].VOD != "https://youtu.be/game3" {
+			t.Fatalf("%s: games = %+v", filename, match.Games)
+		}
+		if !reflect.DeepEqual(match.Games[0].Picks, [2][]string{{"Layla"}, {"Tigreal"}}) {
+			t.Fatalf("%s: picks = %+v", filename, match.Games[0].Picks)
+		}
+	}
+
+	if matches, err := Read("mpl.wiki", strings.NewReader(page)); err != nil || len(matches) != 2 {
+		t.Fatalf("wikitext: matches = %d, err = %v", len(matches), err)
+	}
+	if _, err := Read("notes.wiki", strings.NewReader("no matches here")); !errors.Is(err, ErrNoMatches) {
+		t.Fatalf("file without matches: err = %v, want ErrNoMatches", err)
+	}
+	if _, err := Read("broken.json", strings.NewReader("not json")); err == nil {
+		t.Fatal("invalid JSON was accepted")
+	}
+}
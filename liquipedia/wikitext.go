@@ -0,0 +1,283 @@
+package liquipedia
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// template adalah satu pemanggilan {{name|arg|key=value}}. Nilai parameter
+// disimpan mentah, termasuk template di dalamnya.
+type template struct {
+	name   string
+	args   []string
+	params map[string]string
+	// keys adalah nama parameter sesuai urutan di wikitext.
+	keys []string
+}
+
+var (
+	commentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	headingPattern = regexp.MustCompile(`(?m)^(=+)\s*(.*?)\s*=+\s*$`)
+	linkPattern    = regexp.MustCompile(`\[\[(?:[^\]|]*\|)?([^\]]*)\]\]`)
+	mapPattern     = regexp.MustCompile(`^map(\d+)$`)
+	// draftPattern mencocokkan kunci hero di Map dan extradata LPDB,
+	// misalnya t1h3, t2b1, team1hero3 atau team2ban1.
+	draftPattern = regexp.MustCompile(`^(?:t|team)([12])(h|hero|champion|pick|b|ban)(\d+)$`)
+)
+
+// maxTemplateDepth membatasi kedalaman template yang ditelusuri untuk
+// mencari Match. Setiap tingkat memindai ulang isi template induknya,
+// sehingga tanpa batas waktu parse tumbuh kuadratik terhadap kedalaman.
+// Match di halaman Liquipedia paling dalam ada di tingkat ketiga, misalnya
+// Tabs, Bracket lalu Match.
+const maxTemplateDepth = 8
+
+// ParseWikitext membaca semua template Match di text, baik yang berdiri
+// sendiri maupun di dalam Matchlist atau Bracket. Section setiap Match
+// diambil dari parameter title Matchlist atau judul bagian terakhir
+// sebelum template. Template yang lebih dalam dari maxTemplateDepth
+// diabaikan.
+func ParseWikitext(text string) []Match {
+	text = commentPattern.ReplaceAllString(text, "")
+
+	var matches []Match
+	section, last := "", 0
+	for _, span := range templateSpans(text) {
+		if headings := headingPattern.FindAllStringSubmatch(text[last:span[0]], -1); len(headings) > 0 {
+			section = plain(headings[len(headings)-1][2])
+		}
+		last = span[1]
+		matches = collectMatches(matches, parseTemplate(text[span[0]+2:span[1]-2]), section, 1)
+	}
+	return matches
+}
+
+// collectMatches menambahkan t jika t adalah Match, atau semua Match di
+// parameternya jika bukan. depth adalah tingkat t dari teks teratas.
+func collectMatches(matches []Match, t template, section string, depth int) []Match {
+	if t.name == "match" {
+		return append(matches, parseMatch(t, section))
+	}
+	if depth >= maxTemplateDepth {
+		return matches
+	}
+	if title := plain(t.params["title"]); title != "" && t.name == "matchlist" {
+		section = title
+	}
+
+	values := append([]string{}, t.args...)
+	for _, key := range t.keys {
+		values = append(values, t.params[key])
+	}
+	for _, value := range values {
+		for _, span := range templateSpans(value) {
+			matches = collectMatches(matches, parseTemplate(value[span[0]+2:span[1]-2]), section, depth+1)
+		}
+	}
+	return matches
+}
+
+func parseMatch(t template, section string) Match {
+	m := Match{Section: section, Date: parseDate(plain(t.params["date"]))}
+	m.BestOf, _ = strconv.Atoi(plain(t.params["bestof"]))
+	for i := range m.Opponents {
+		m.Opponents[i] = parseOpponent(t.params[fmt.Sprintf("opponent%d", i+1)])
+	}
+
+	var numbers []int
+	for _, key := range t.keys {
+		if match := mapPattern.FindStringSubmatch(key); match != nil {
+			number, _ := strconv.Atoi(match[1])
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+	for _, number := range numbers {
+		value := t.params[fmt.Sprintf("map%d", number)]
+		spans := templateSpans(value)
+		if len(spans) == 0 {
+			continue
+		}
+		game := parseGame(parseTemplate(value[spans[0][0]+2 : spans[0][1]-2]).params)
+		game.Number = number
+		if game.VOD == "" {
+			game.VOD = plain(t.params[fmt.Sprintf("vodgame%d", number)])
+		}
+		if game.Winner != 0 || len(game.Picks[0])+len(game.Picks[1]) > 0 {
+			m.Games = append(m.Games, game)
+		}
+	}
+	return m
+}
+
+func parseOpponent(value string) Opponent {
+	opponent := Opponent{Score: -1}
+	spans := templateSpans(value)
+	if len(spans) == 0 {
+		opponent.Name = plain(value)
+		return opponent
+	}
+
+	t := parseTemplate(value[spans[0][0]+2 : spans[0][1]-2])
+	if len(t.args) > 0 {
+		opponent.Template = plain(t.args[0])
+	}
+	if template := plain(t.params["template"]); template != "" {
+		opponent.Template = template
+	}
+	opponent.Name = plain(t.params["name"])
+	if score, err := strconv.Atoi(plain(t.params["score"])); err == nil {
+		opponent.Score = score
+	}
+	return opponent
+}
+
+// parseGame membaca parameter Map, atau extradata game LPDB yang memakai
+// kunci yang sama.
+func parseGame(params map[string]string) Game {
+	var game Game
+	game.Winner, _ = strconv.Atoi(params["winner"])
+	if game.Winner != 1 && game.Winner != 2 {
+		game.Winner = 0
+	}
+	game.VOD = params["vod"]
+	for i := range game.Sides {
+		game.Sides[i] = strings.ToLower(params[fmt.Sprintf("team%dside", i+1)])
+	}
+
+	type slot struct {
+		index int
+		hero  string
+	}
+	var picks, bans [2][]slot
+	for key, value := range params {
+		match := draftPattern.FindStringSubmatch(key)
+		if match == nil || value == "" {
+			continue
+		}
+		team, _ := strconv.Atoi(match[1])
+		index, _ := strconv.Atoi(match[3])
+		s := slot{index, value}
+		if strings.HasPrefix(match[2], "b") {
+			bans[team-1] = append(bans[team-1], s)
+		} else {
+			picks[team-1] = append(picks[team-1], s)
+		}
+	}
+	for i := range picks {
+		for _, slots := range []*[]slot{&picks[i], &bans[i]} {
+			sort.Slice(*slots, func(a, b int) bool { return (*slots)[a].index < (*slots)[b].index })
+		}
+		for _, s := range picks[i] {
+			game.Picks[i] = append(game.Picks[i], s.hero)
+		}
+		for _, s := range bans[i] {
+			game.Bans[i] = append(game.Bans[i], s.hero)
+		}
+	}
+	return game
+}
+
+// templateSpans mengembalikan posisi awal dan akhir setiap template tingkat
+// teratas di text, termasuk kurung kurawalnya.
+func templateSpans(text string) [][2]int {
+	var spans [][2]int
+	depth, start := 0, 0
+	for i := 0; i < len(text)-1; i++ {
+		switch text[i : i+2] {
+		case "{{":
+			if depth == 0 {
+				start = i
+			}
+			depth++
+			i++
+		case "}}":
+			if depth == 0 {
+				continue
+			}
+			depth--
+			i++
+			if depth == 0 {
+				spans = append(spans, [2]int{start, i + 1})
+			}
+		}
+	}
+	return spans
+}
+
+// parseTemplate membaca isi template tanpa kurung kurawal. Nama template
+// dan nama parameter ditulis huruf kecil; nilai parameter bernama yang
+// tidak berisi template sudah dibersihkan lewat plain.
+func parseTemplate(body string) template {
+	t := template{params: map[string]string{}}
+	for i, part := range splitTopLevel(body, '|') {
+		if i == 0 {
+			t.name = strings.ToLower(strings.TrimSpace(part))
+			continue
+		}
+		if eq := indexTopLevel(part, '='); eq >= 0 {
+			key := strings.ToLower(strings.TrimSpace(part[:eq]))
+			value := strings.TrimSpace(part[eq+1:])
+			if len(templateSpans(value)) == 0 {
+				value = plain(value)
+			}
+			if _, ok := t.params[key]; !ok {
+				t.keys = append(t.keys, key)
+			}
+			t.params[key] = value
+			continue
+		}
+		t.args = append(t.args, strings.TrimSpace(part))
+	}
+	return t
+}
+
+// splitTopLevel memecah text pada sep yang tidak berada di dalam template
+// atau link.
+func splitTopLevel(text string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		if i+1 < len(text) {
+			switch text[i : i+2] {
+			case "{{", "[[":
+				depth++
+				i++
+				continue
+			case "}}", "]]":
+				if depth > 0 {
+					depth--
+				}
+				i++
+				continue
+			}
+		}
+		if text[i] == sep && depth == 0 {
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+func indexTopLevel(text string, sep byte) int {
+	parts := splitTopLevel(text, sep)
+	if len(parts) == 1 {
+		return -1
+	}
+	return len(parts[0])
+}
+
+// plain membuang template dan markup link dari nilai wikitext.
+func plain(value string) string {
+	spans := templateSpans(value)
+	for i := len(spans) - 1; i >= 0; i-- {
+		value = value[:spans[i][0]] + value[spans[i][1]:]
+	}
+	value = linkPattern.ReplaceAllString(value, "$1")
+	value = strings.NewReplacer("'''", "", "''", "", "&nbsp;", " ").Replace(value)
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,31 @@
+package liquipedia
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWikitextDeepNesting(t *testing.T) {
+	// Template bersarang sangat dalam tidak boleh membuat parse kuadratik
+	text := strings.Repeat("{{x|", 32000) + strings.Repeat("}}", 32000)
+	start := time.Now()
+	if matches := ParseWikitext(text); len(matches) != 0 {
+		t.Fatalf("matches = %v", matches)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("parse took %s", elapsed)
+	}
+
+	// Match di dalam Tabs dan Bracket tetap terbaca, Match di bawah batas
+	// kedalaman diabaikan
+	match := "{{Match|opponent1={{TeamOpponent|team a}}|opponent2={{TeamOpponent|team b}}}}"
+	nested := "{{Tabs|content1={{Bracket|R1M1=" + match + "}}}}"
+	if matches := ParseWikitext(nested); len(matches) != 1 || matches[0].Opponents[0].Template != "team a" {
+		t.Fatalf("matches = %+v", matches)
+	}
+	deep := strings.Repeat("{{x|", maxTemplateDepth) + match + strings.Repeat("}}", maxTemplateDepth)
+	if matches := ParseWikitext(deep); len(matches) != 0 {
+		t.Fatalf("matches = %+v", matches)
+	}
+}
@@ -1,14 +1,42 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"ml-master-data/config"
-	"ml-master-data/routes"
-	"ml-master-data/seeders"
-
-	"github.com/joho/godotenv"
+	"ml-master-data/models"
+	"ml-master-data/storage"
+	"os"
 )
 
+// command adalah subcommand CLI. Semua subcommand memakai Config yang sama
+// dengan server.
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(cfg *config.Config, args []string)
+}
+
+// cliActor dicatat di audit log untuk perubahan dari subcommand CLI.
+var cliActor = models.User{Username: "cli"}
+
+var commands = []command{
+	{"serve", "serve", "start the HTTP server (default)", runServe},
+	{"migrate", "migrate [up|down [steps]|status]", "apply, roll back or list migrations", runMigrate},
+	{"seed", "seed [-only users,heroes,teams]", "run seeders", runSeed},
+	{"demo", "demo [-seed n] [-teams n]", "generate a synthetic demo tournament", runDemo},
+	{"create-user", "create-user -username name [-password secret]", "create a user, reading the password from stdin if omitted", runCreateUser},
+	{"recompute-stats", "recompute-stats [-tournament id]", "recompute match scores, game results, draft totals and missing game patches", runRecomputeStats},
+	{"export", "export [-o file]", "export heroes, teams, players and coaches as JSON", runExport},
+	{"import", "import file", "import heroes, teams, players and coaches from JSON", runImport},
+	{"import-sheet", "import-sheet -tournament id [-dry-run] file", "import matches and drafts of a tournament from CSV or XLSX", runImportSheet},
+	{"import-liquipedia", "import-liquipedia -tournament id [-dry-run] file...", "import matches and drafts of a tournament from Liquipedia wikitext or JSON", runImportLiquipedia},
+	{"backup-tournament", "backup-tournament -tournament id [-o file]", "write a tournament and everything under it as a JSON bundle", runBackupTournament},
+	{"restore-tournament", "restore-tournament [-name name] file", "create a new tournament from a JSON bundle", runRestoreTournament},
+	{"media-gc", "media-gc [-delete] [-min-age 24h]", "report orphaned media and dangling image links, optionally cleaning them up", runMediaGC},
+}
+
 // @title ML Master Data API
 // @version 1.0
 // @description API for ML Master Data
@@ -19,16 +47,39 @@ import (
 // @in header
 // @name Authorization
 func main() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
+	name, args := "serve", []string{}
+	if len(os.Args) > 1 {
+		name, args = os.Args[1], os.Args[2:]
 	}
 
-	config.ConnectDatabase()
+	for _, command := range commands {
+		if command.name == name {
+			cfg, err := config.Load()
+			if err != nil {
+				log.Fatal(err)
+			}
+			command.run(cfg, args)
+			return
+		}
+	}
 
-	seeders.Seed()
+	usage()
+	os.Exit(2)
+}
 
-	r := routes.SetupRouter()
-	r.Static("/public", "./public")
-	r.Run(":8080")
+// openStorage membuka storage media sesuai konfigurasi atau menghentikan
+// program jika gagal.
+func openStorage(cfg *config.Config) storage.Storage {
+	files, err := cfg.OpenStorage()
+	if err != nil {
+		log.Fatal("Failed to open storage: ", err)
+	}
+	return files
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [arguments]\n\nCommands:\n", os.Args[0])
+	for _, command := range commands {
+		fmt.Fprintf(os.Stderr, "  %-50s %s\n", command.usage, command.description)
+	}
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"time"
+)
+
+// runMediaGC menjalankan subcommand `media-gc [-delete] [-min-age d]` yang
+// melaporkan objek media yatim dan link gambar yang putus, lalu
+// membersihkannya jika -delete.
+func runMediaGC(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("media-gc", flag.ExitOnError)
+	remove := flags.Bool("delete", false, "delete orphaned objects and reset dangling image links")
+	minAge := flags.Duration("min-age", time.Duration(cfg.MediaGC.MinAgeHours)*time.Hour, "ignore objects younger than this")
+	flags.Parse(args)
+	if *minAge < time.Hour {
+		log.Fatal("-min-age must be at least 1h")
+	}
+
+	config.ConnectDatabase(cfg.Database)
+
+	ctx := services.WithAuditActor(context.Background(), cliActor)
+	media := services.New(repositories.New(config.DB, openStorage(cfg))).Media
+	report, err := media.CollectGarbage(ctx, services.MediaGCOptions{Delete: *remove, MinAge: *minAge})
+
+	for _, object := range report.Orphans {
+		fmt.Printf("orphan    %s (%d bytes, %s)\n", object.Key, object.Size, object.ModTime.Format(time.RFC3339))
+	}
+	for _, ref := range report.Dangling {
+		fmt.Printf("dangling  %s.%s id=%d -> %s\n", ref.Table, ref.Column, ref.ID, ref.Image)
+	}
+	fmt.Printf("%d objects, %d image references, %d orphans, %d dangling links\n",
+		report.Objects, report.References, len(report.Orphans), len(report.Dangling))
+	if *remove {
+		fmt.Printf("Deleted %d orphans, reset %d dangling links and expired %d unattached uploads\n", report.Deleted, report.Reset, report.Expired)
+	}
+	if err != nil {
+		log.Fatal("Media garbage collection failed: ", err)
+	}
+}
@@ -2,13 +2,14 @@ package middlewares
 
 import (
 	"ml-master-data/models"
+	"ml-master-data/services"
 	"ml-master-data/utils"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -30,7 +31,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		token := tokenParts[1]
 
 		// Validate the token
-		claims, err := utils.ValidateJWT(token)
+		claims, err := utils.ValidateJWT(jwtSecret, token)
 		if err != nil {
 			c.JSON(401, gin.H{"error": "Invalid token"})
 			c.Abort()
@@ -45,6 +46,20 @@ func AuthMiddleware() gin.HandlerFunc {
 		// Set the userID in the context for later use
 		c.Set("userID", claims.UserID)
 		c.Set("user", user)
+		// Context request dipakai query database dan mencatat user di audit log
+		c.Request = c.Request.WithContext(services.WithAuditActor(c.Request.Context(), user))
+		c.Next()
+	}
+}
+
+// QueryTokenMiddleware memakai query access_token sebagai header
+// Authorization jika header tersebut tidak dikirim. Hanya dipasang di stream
+// SSE karena EventSource di browser tidak bisa mengirim header.
+func QueryTokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.Query("access_token"); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/migrations"
+	"strconv"
+)
+
+// runMigrate menjalankan subcommand `migrate up`, `migrate down [steps]`
+// dan `migrate status`.
+func runMigrate(cfg *config.Config, args []string) {
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	db := config.OpenDatabase(cfg.Database)
+
+	switch command {
+	case "up":
+		if err := migrations.Up(db); err != nil {
+			log.Fatal(err)
+		}
+		log.Println("All migrations applied")
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Fatal("Invalid number of steps")
+			}
+		}
+
+		if err := migrations.Down(db, steps); err != nil {
+			log.Fatal(err)
+		}
+
+	case "status":
+		statuses, err := migrations.Statuses(db)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-30s %-20s %s\n", status.ID, appliedAt, status.Description)
+		}
+
+	default:
+		log.Fatalf("Unknown migrate command %q, use up, down or status", command)
+	}
+}
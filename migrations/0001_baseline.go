@@ -0,0 +1,294 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		ID:          "0001_baseline",
+		Description: "create initial schema",
+		Up: func(tx *gorm.DB) error {
+			tables := baselineTables()
+
+			// Database lama dibuat tanpa foreign key, bersihkan data yatim dulu
+			if err := cleanOrphanRows(tx, tables...); err != nil {
+				return err
+			}
+			if tx.Dialector.Name() == "mysql" {
+				return tx.AutoMigrate(tables...)
+			}
+
+			// Database lain tidak mengenal enum; tabel yang memakainya
+			// dibuat dengan DDL eksplisit di createEnumTables
+			var portable []interface{}
+			for _, table := range tables {
+				stmt := &gorm.Statement{DB: tx}
+				if err := stmt.Parse(table); err != nil {
+					return err
+				}
+				if !isEnumTable(stmt.Schema.Table) {
+					portable = append(portable, table)
+				}
+			}
+			if err := tx.AutoMigrate(portable...); err != nil {
+				return err
+			}
+			return createEnumTables(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(baselineTables()...)
+		},
+	})
+}
+
+// baselineTables adalah salinan skema pada saat versioned migration
+// diperkenalkan. Jangan diubah; perubahan skema berikutnya ditulis sebagai
+// migration baru.
+func baselineTables() []interface{} {
+	type User struct {
+		UserID   uint   `gorm:"primaryKey;autoIncrement"`
+		Username string `gorm:"unique"`
+		Password string
+	}
+	type Tournament struct {
+		TournamentID uint           `gorm:"primaryKey;autoIncrement"`
+		Name         string         `gorm:"size:100;"`
+		DeletedAt    gorm.DeletedAt `gorm:"index"`
+	}
+	type Team struct {
+		TeamID    uint `gorm:"primaryKey;autoIncrement"`
+		Name      string
+		Image     string
+		DeletedAt gorm.DeletedAt `gorm:"index"`
+	}
+	type Hero struct {
+		HeroID uint `gorm:"primaryKey;autoIncrement"`
+		Name   string
+		Image  string
+	}
+	type Player struct {
+		PlayerID uint `gorm:"primaryKey;autoIncrement"`
+		TeamID   uint
+		Name     string
+		Image    string
+		Team     Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type Coach struct {
+		CoachID uint `gorm:"primaryKey;autoIncrement"`
+		TeamID  uint
+		Name    string
+		Image   string
+		Team    Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type Match struct {
+		MatchID      uint `gorm:"primaryKey;autoIncrement"`
+		TournamentID uint
+		Stage        string
+		Day          int
+		Date         int
+		TeamAID      uint
+		TeamBID      uint
+		TeamAScore   int
+		TeamBScore   int
+		DeletedAt    gorm.DeletedAt `gorm:"index"`
+		Tournament   Tournament     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		TeamA        Team           `gorm:"foreignKey:TeamAID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		TeamB        Team           `gorm:"foreignKey:TeamBID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type MatchTeamDetail struct {
+		MatchTeamDetailID uint `gorm:"primaryKey;autoIncrement"`
+		MatchID           uint
+		TeamID            uint
+		Match             Match `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Team              Team  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type Game struct {
+		GameID           uint `gorm:"primaryKey;autoIncrement"`
+		MatchID          uint
+		FirstPickTeamID  uint
+		SecondPickTeamID uint
+		WinnerTeamID     uint
+		GameNumber       int
+		VideoLink        string
+		FullDraftImage   string
+		DeletedAt        gorm.DeletedAt `gorm:"index"`
+		Match            Match          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		FirstPickTeam    Team           `gorm:"foreignKey:FirstPickTeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		SecondPickTeam   Team           `gorm:"foreignKey:SecondPickTeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		WinnerTeam       Team           `gorm:"foreignKey:WinnerTeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type PlayerMatch struct {
+		PlayerMatchID     uint `gorm:"primaryKey;autoIncrement"`
+		MatchTeamDetailID uint
+		PlayerID          uint
+		Role              string          `gorm:"type:enum('goldlaner', 'explaner', 'roamer', 'midlaner', 'jungler');"`
+		MatchTeamDetail   MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Player            Player          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type CoachMatch struct {
+		CoachMatchID      uint `gorm:"primaryKey;autoIncrement"`
+		MatchTeamDetailID uint
+		Role              string
+		CoachID           uint
+		MatchTeamDetail   MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Coach             Coach           `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type HeroPick struct {
+		HeroPickID        uint `gorm:"primaryKey;autoIncrement"`
+		MatchTeamDetailID uint
+		HeroID            uint
+		FirstPhase        int
+		SecondPhase       int
+		Total             int
+		MatchTeamDetail   MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Hero              Hero            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type HeroBan struct {
+		HeroBanID         uint `gorm:"primaryKey;autoIncrement"`
+		MatchTeamDetailID uint
+		HeroID            uint
+		FirstPhase        int
+		SecondPhase       int
+		Total             int
+		MatchTeamDetail   MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Hero              Hero            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type HeroPickGame struct {
+		HeroPickGameID uint `gorm:"primaryKey;autoIncrement"`
+		HeroPickID     uint
+		GameID         uint
+		GameNumber     int
+		IsPicked       bool
+		HeroPick       HeroPick `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Game           Game     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type HeroBanGame struct {
+		HeroBanGameID uint `gorm:"primaryKey;autoIncrement"`
+		HeroBanID     uint
+		GameID        uint
+		GameNumber    int
+		IsBanned      bool
+		HeroBan       HeroBan `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Game          Game    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type PriorityPick struct {
+		PriorityPickID    uint `gorm:"primaryKey;autoIncrement"`
+		MatchTeamDetailID uint
+		HeroID            uint
+		Total             int
+		Role              string `gorm:"type:enum('gold', 'exp', 'roam', 'mid', 'jungler');"`
+		PickRate          float64
+		MatchTeamDetail   MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Hero              Hero            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type PriorityBan struct {
+		PriorityBanID     uint `gorm:"primaryKey;autoIncrement"`
+		MatchTeamDetailID uint
+		HeroID            uint
+		Total             int
+		Role              string `gorm:"type:enum('gold', 'exp', 'roam', 'mid', 'jungler');"`
+		BanRate           float64
+		MatchTeamDetail   MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Hero              Hero            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type FlexPick struct {
+		FlexPickID        uint `gorm:"primaryKey;autoIncrement"`
+		MatchTeamDetailID uint
+		HeroID            uint
+		Total             int
+		Role              string `gorm:"type:enum('gold', 'exp', 'roam', 'mid', 'jungler');"`
+		PickRate          float64
+		MatchTeamDetail   MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Hero              Hero            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type GameResult struct {
+		GameResultID uint `gorm:"primaryKey;autoIncrement"`
+		GameID       uint
+		TeamID       uint
+		Result       string `gorm:"type:enum('win', 'lose');"`
+		Game         Game   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Team         Team   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type TrioMid struct {
+		TrioMidID   uint `gorm:"primaryKey;autoIncrement"`
+		GameID      uint
+		TeamID      uint
+		EarlyResult *string `gorm:"type:enum('win', 'draw', 'lose')"`
+		Game        Game    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Team        Team    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type TrioMidHero struct {
+		TrioMidHeroID uint `gorm:"primaryKey;autoIncrement"`
+		TrioMidID     uint
+		HeroID        uint
+		Role          string  `gorm:"type:enum('jungler', 'midlaner', 'roamer')"`
+		EarlyResult   string  `gorm:"type:enum('win', 'draw', 'lose')"`
+		TrioMid       TrioMid `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Hero          Hero    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type Goldlaner struct {
+		GoldlanerID uint `gorm:"primaryKey;autoIncrement"`
+		GameID      uint
+		TeamID      uint
+		HeroID      uint
+		EarlyResult string `gorm:"type:enum('win', 'draw', 'lose')"`
+		Game        Game   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Team        Team   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Hero        Hero   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type Explaner struct {
+		ExplanerID  uint `gorm:"primaryKey;autoIncrement"`
+		GameID      uint
+		TeamID      uint
+		HeroID      uint
+		EarlyResult string `gorm:"type:enum('win', 'draw', 'lose')"`
+		Game        Game   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Team        Team   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Hero        Hero   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type TurtleResult struct {
+		TurtleResultID uint `gorm:"primaryKey;autoIncrement"`
+		GameID         uint
+		TeamID         uint
+		Phase          string
+		Setup          string `gorm:"type:enum('early', 'late', 'no')"`
+		Initiate       string `gorm:"type:enum('yes', 'no')"`
+		Result         string `gorm:"type:enum('yes', 'no')"`
+		Game           Game   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Team           Team   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type LordResult struct {
+		LordResultID uint `gorm:"primaryKey;autoIncrement"`
+		GameID       uint
+		TeamID       uint
+		Phase        string
+		Setup        string `gorm:"type:enum('early', 'late', 'no')"`
+		Initiate     string `gorm:"type:enum('yes', 'no')"`
+		Result       string `gorm:"type:enum('yes', 'no')"`
+		Game         Game   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Team         Team   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	}
+	type AuditLog struct {
+		AuditLogID uint      `gorm:"primaryKey;autoIncrement"`
+		UserID     uint      `gorm:"index"`
+		Username   string    `gorm:"size:100"`
+		EntityType string    `gorm:"size:100;index:idx_audit_logs_entity"`
+		EntityID   uint      `gorm:"index:idx_audit_logs_entity"`
+		Action     string    `gorm:"type:enum('create', 'update', 'delete')"`
+		Before     string    `gorm:"type:text"`
+		After      string    `gorm:"type:text"`
+		CreatedAt  time.Time `gorm:"index"`
+	}
+
+	return []interface{}{
+		&User{}, &Tournament{}, &Team{}, &Hero{}, &Player{}, &Coach{},
+		&Match{}, &MatchTeamDetail{}, &Game{}, &PlayerMatch{}, &CoachMatch{},
+		&HeroPick{}, &HeroBan{}, &HeroPickGame{}, &HeroBanGame{},
+		&PriorityPick{}, &PriorityBan{}, &FlexPick{}, &GameResult{},
+		&TrioMid{}, &TrioMidHero{}, &Goldlaner{}, &Explaner{},
+		&TurtleResult{}, &LordResult{}, &AuditLog{},
+	}
+}
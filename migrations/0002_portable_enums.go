@@ -0,0 +1,266 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// enumColumns adalah kolom yang di 0001_baseline memakai tipe enum MySQL.
+// Sekarang disimpan sebagai varchar dengan check constraint agar bisa
+// dipakai di PostgreSQL dan SQLite.
+var enumColumns = []struct {
+	table, column, values string
+}{
+	{"player_matches", "role", "'goldlaner', 'explaner', 'roamer', 'midlaner', 'jungler'"},
+	{"priority_picks", "role", "'gold', 'exp', 'roam', 'mid', 'jungler'"},
+	{"priority_bans", "role", "'gold', 'exp', 'roam', 'mid', 'jungler'"},
+	{"flex_picks", "role", "'gold', 'exp', 'roam', 'mid', 'jungler'"},
+	{"game_results", "result", "'win', 'lose'"},
+	{"trio_mids", "early_result", "'win', 'draw', 'lose'"},
+	{"trio_mid_heros", "role", "'jungler', 'midlaner', 'roamer'"},
+	{"trio_mid_heros", "early_result", "'win', 'draw', 'lose'"},
+	{"goldlaners", "early_result", "'win', 'draw', 'lose'"},
+	{"explaners", "early_result", "'win', 'draw', 'lose'"},
+	{"turtle_results", "setup", "'early', 'late', 'no'"},
+	{"turtle_results", "initiate", "'yes', 'no'"},
+	{"turtle_results", "result", "'yes', 'no'"},
+	{"lord_results", "setup", "'early', 'late', 'no'"},
+	{"lord_results", "initiate", "'yes', 'no'"},
+	{"lord_results", "result", "'yes', 'no'"},
+	{"audit_logs", "action", "'create', 'update', 'delete'"},
+}
+
+func init() {
+	register(Migration{
+		ID:          "0002_portable_enums",
+		Description: "convert MySQL enum columns to varchar with check constraints",
+		Up: func(tx *gorm.DB) error {
+			// ALTER ... MODIFY hanya ada di MySQL. Database lain tidak pernah
+			// punya kolom enum karena 0001_baseline memakai createEnumTables.
+			if tx.Dialector.Name() != "mysql" {
+				return nil
+			}
+
+			for _, c := range enumColumns {
+				if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s MODIFY %s VARCHAR(20)", c.table, c.column)).Error; err != nil {
+					return err
+				}
+
+				name := fmt.Sprintf("chk_%s_%s", c.table, c.column)
+				if tx.Migrator().HasConstraint(c.table, name) {
+					continue
+				}
+				if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s IN (%s))", c.table, name, c.column, c.values)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			if tx.Dialector.Name() != "mysql" {
+				return nil
+			}
+
+			for _, c := range enumColumns {
+				name := fmt.Sprintf("chk_%s_%s", c.table, c.column)
+				if tx.Migrator().HasConstraint(c.table, name) {
+					if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s DROP CHECK %s", c.table, name)).Error; err != nil {
+						return err
+					}
+				}
+
+				if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s MODIFY %s ENUM(%s)", c.table, c.column, c.values)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// enumTypes adalah tipe kolom yang berbeda antar database untuk DDL di
+// enumTables. Kolom enum sendiri selalu varchar(20) dengan check constraint.
+var enumTypes = map[string]*strings.Replacer{
+	"sqlite": strings.NewReplacer(
+		"{id}", "integer PRIMARY KEY AUTOINCREMENT",
+		"{int}", "integer",
+		"{float}", "real",
+		"{time}", "datetime",
+	),
+	"postgres": strings.NewReplacer(
+		"{id}", "bigserial PRIMARY KEY",
+		"{int}", "bigint",
+		"{float}", "decimal",
+		"{time}", "timestamptz",
+	),
+}
+
+// enumTables adalah DDL tabel 0001_baseline yang punya kolom enum, ditulis
+// dengan skema hasil 0002 di MySQL. Urutannya mengikuti foreign key.
+var enumTables = []string{
+	`CREATE TABLE player_matches (
+		player_match_id {id},
+		match_team_detail_id {int},
+		player_id {int},
+		role varchar(20),
+		CONSTRAINT fk_player_matches_match_team_detail FOREIGN KEY (match_team_detail_id) REFERENCES match_team_details (match_team_detail_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_player_matches_player FOREIGN KEY (player_id) REFERENCES players (player_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_player_matches_role CHECK (role IN ('goldlaner', 'explaner', 'roamer', 'midlaner', 'jungler'))
+	)`,
+	`CREATE TABLE priority_picks (
+		priority_pick_id {id},
+		match_team_detail_id {int},
+		hero_id {int},
+		total {int},
+		role varchar(20),
+		pick_rate {float},
+		CONSTRAINT fk_priority_picks_match_team_detail FOREIGN KEY (match_team_detail_id) REFERENCES match_team_details (match_team_detail_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_priority_picks_hero FOREIGN KEY (hero_id) REFERENCES heros (hero_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_priority_picks_role CHECK (role IN ('gold', 'exp', 'roam', 'mid', 'jungler'))
+	)`,
+	`CREATE TABLE priority_bans (
+		priority_ban_id {id},
+		match_team_detail_id {int},
+		hero_id {int},
+		total {int},
+		role varchar(20),
+		ban_rate {float},
+		CONSTRAINT fk_priority_bans_match_team_detail FOREIGN KEY (match_team_detail_id) REFERENCES match_team_details (match_team_detail_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_priority_bans_hero FOREIGN KEY (hero_id) REFERENCES heros (hero_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_priority_bans_role CHECK (role IN ('gold', 'exp', 'roam', 'mid', 'jungler'))
+	)`,
+	`CREATE TABLE flex_picks (
+		flex_pick_id {id},
+		match_team_detail_id {int},
+		hero_id {int},
+		total {int},
+		role varchar(20),
+		pick_rate {float},
+		CONSTRAINT fk_flex_picks_match_team_detail FOREIGN KEY (match_team_detail_id) REFERENCES match_team_details (match_team_detail_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_flex_picks_hero FOREIGN KEY (hero_id) REFERENCES heros (hero_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_flex_picks_role CHECK (role IN ('gold', 'exp', 'roam', 'mid', 'jungler'))
+	)`,
+	`CREATE TABLE game_results (
+		game_result_id {id},
+		game_id {int},
+		team_id {int},
+		result varchar(20),
+		CONSTRAINT fk_game_results_game FOREIGN KEY (game_id) REFERENCES games (game_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_game_results_team FOREIGN KEY (team_id) REFERENCES teams (team_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_game_results_result CHECK (result IN ('win', 'lose'))
+	)`,
+	`CREATE TABLE trio_mids (
+		trio_mid_id {id},
+		game_id {int},
+		team_id {int},
+		early_result varchar(20),
+		CONSTRAINT fk_trio_mids_game FOREIGN KEY (game_id) REFERENCES games (game_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_trio_mids_team FOREIGN KEY (team_id) REFERENCES teams (team_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_trio_mids_early_result CHECK (early_result IN ('win', 'draw', 'lose'))
+	)`,
+	`CREATE TABLE trio_mid_heros (
+		trio_mid_hero_id {id},
+		trio_mid_id {int},
+		hero_id {int},
+		role varchar(20),
+		early_result varchar(20),
+		CONSTRAINT fk_trio_mid_heros_trio_mid FOREIGN KEY (trio_mid_id) REFERENCES trio_mids (trio_mid_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_trio_mid_heros_hero FOREIGN KEY (hero_id) REFERENCES heros (hero_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_trio_mid_heros_role CHECK (role IN ('jungler', 'midlaner', 'roamer')),
+		CONSTRAINT chk_trio_mid_heros_early_result CHECK (early_result IN ('win', 'draw', 'lose'))
+	)`,
+	`CREATE TABLE goldlaners (
+		goldlaner_id {id},
+		game_id {int},
+		team_id {int},
+		hero_id {int},
+		early_result varchar(20),
+		CONSTRAINT fk_goldlaners_game FOREIGN KEY (game_id) REFERENCES games (game_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_goldlaners_team FOREIGN KEY (team_id) REFERENCES teams (team_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_goldlaners_hero FOREIGN KEY (hero_id) REFERENCES heros (hero_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_goldlaners_early_result CHECK (early_result IN ('win', 'draw', 'lose'))
+	)`,
+	`CREATE TABLE explaners (
+		explaner_id {id},
+		game_id {int},
+		team_id {int},
+		hero_id {int},
+		early_result varchar(20),
+		CONSTRAINT fk_explaners_game FOREIGN KEY (game_id) REFERENCES games (game_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_explaners_team FOREIGN KEY (team_id) REFERENCES teams (team_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_explaners_hero FOREIGN KEY (hero_id) REFERENCES heros (hero_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_explaners_early_result CHECK (early_result IN ('win', 'draw', 'lose'))
+	)`,
+	`CREATE TABLE turtle_results (
+		turtle_result_id {id},
+		game_id {int},
+		team_id {int},
+		phase text,
+		setup varchar(20),
+		initiate varchar(20),
+		result varchar(20),
+		CONSTRAINT fk_turtle_results_game FOREIGN KEY (game_id) REFERENCES games (game_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_turtle_results_team FOREIGN KEY (team_id) REFERENCES teams (team_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_turtle_results_setup CHECK (setup IN ('early', 'late', 'no')),
+		CONSTRAINT chk_turtle_results_initiate CHECK (initiate IN ('yes', 'no')),
+		CONSTRAINT chk_turtle_results_result CHECK (result IN ('yes', 'no'))
+	)`,
+	`CREATE TABLE lord_results (
+		lord_result_id {id},
+		game_id {int},
+		team_id {int},
+		phase text,
+		setup varchar(20),
+		initiate varchar(20),
+		result varchar(20),
+		CONSTRAINT fk_lord_results_game FOREIGN KEY (game_id) REFERENCES games (game_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT fk_lord_results_team FOREIGN KEY (team_id) REFERENCES teams (team_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		CONSTRAINT chk_lord_results_setup CHECK (setup IN ('early', 'late', 'no')),
+		CONSTRAINT chk_lord_results_initiate CHECK (initiate IN ('yes', 'no')),
+		CONSTRAINT chk_lord_results_result CHECK (result IN ('yes', 'no'))
+	)`,
+	`CREATE TABLE audit_logs (
+		audit_log_id {id},
+		user_id {int},
+		username varchar(100),
+		entity_type varchar(100),
+		entity_id {int},
+		action varchar(20),
+		"before" text,
+		"after" text,
+		created_at {time},
+		CONSTRAINT chk_audit_logs_action CHECK (action IN ('create', 'update', 'delete'))
+	)`,
+	`CREATE INDEX idx_audit_logs_user_id ON audit_logs (user_id)`,
+	`CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id)`,
+	`CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at)`,
+}
+
+// createEnumTables membuat tabel 0001_baseline yang punya kolom enum untuk
+// database selain MySQL, yang tidak mengenal tipe enum. Skemanya langsung
+// sama dengan hasil 0002 di MySQL: varchar dengan check constraint.
+func createEnumTables(tx *gorm.DB) error {
+	types, ok := enumTypes[tx.Dialector.Name()]
+	if !ok {
+		return fmt.Errorf("database %s tidak didukung", tx.Dialector.Name())
+	}
+
+	for _, ddl := range enumTables {
+		if err := tx.Exec(types.Replace(ddl)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isEnumTable melaporkan apakah table dibuat oleh createEnumTables.
+func isEnumTable(table string) bool {
+	for _, c := range enumColumns {
+		if c.table == table {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"os"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// imageColumns adalah kolom yang menyimpan referensi gambar.
+var imageColumns = []struct {
+	table, key, column string
+}{
+	{"heros", "hero_id", "image"},
+	{"teams", "team_id", "image"},
+	{"players", "player_id", "image"},
+	{"coaches", "coach_id", "image"},
+	{"games", "game_id", "full_draft_image"},
+}
+
+// uploadPath adalah path lama file upload lokal di dalam URL gambar.
+const uploadPath = "/public/images/"
+
+func init() {
+	register(Migration{
+		ID:          "0003_image_keys",
+		Description: "store uploaded image keys instead of absolute URLs",
+		Up: func(tx *gorm.DB) error {
+			// URL lama berbentuk BASE_URL/public/images/<file>; <file> menjadi
+			// key di storage lokal yang memakai folder yang sama.
+			return rewriteImages(tx, "%"+uploadPath+"%", func(image string) string {
+				return image[strings.LastIndex(image, uploadPath)+len(uploadPath):]
+			})
+		},
+		Down: func(tx *gorm.DB) error {
+			// Key dikembalikan menjadi URL absolut di bawah BASE_URL saat ini.
+			baseURL := strings.TrimSuffix(os.Getenv("BASE_URL"), "/")
+			return rewriteImages(tx, "%", func(image string) string {
+				if image == "" || strings.HasPrefix(image, "http://") || strings.HasPrefix(image, "https://") {
+					return image
+				}
+				return baseURL + uploadPath + image
+			})
+		},
+	})
+}
+
+// rewriteImages mengganti setiap nilai kolom gambar yang cocok dengan
+// pattern LIKE memakai rewrite. Dikerjakan per baris karena fungsi string
+// SQL berbeda antara MySQL, PostgreSQL dan SQLite.
+func rewriteImages(tx *gorm.DB, pattern string, rewrite func(string) string) error {
+	for _, c := range imageColumns {
+		if !tx.Migrator().HasTable(c.table) {
+			continue
+		}
+
+		var rows []struct {
+			ID    uint
+			Image string
+		}
+		if err := tx.Table(c.table).Select(c.key+" AS id, "+c.column+" AS image").
+			Where(c.column+" LIKE ?", pattern).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			image := rewrite(row.Image)
+			if image == row.Image {
+				continue
+			}
+			if err := tx.Table(c.table).Where(c.key+" = ?", row.ID).Update(c.column, image).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
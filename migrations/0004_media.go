@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// mediaTable adalah skema tabel media saat migration ini dibuat.
+type mediaTable struct {
+	MediaID   uint   `gorm:"primaryKey;autoIncrement"`
+	Kind      string `gorm:"size:20;index"`
+	Image     string
+	CreatedAt time.Time
+}
+
+func (mediaTable) TableName() string {
+	return "media"
+}
+
+func init() {
+	register(Migration{
+		ID:          "0004_media",
+		Description: "create media table for uploads waiting to be attached",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&mediaTable{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&mediaTable{})
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// heroMetadataColumns adalah kolom baru di tabel heros.
+type heroMetadataColumns struct {
+	HeroID         uint   `gorm:"primaryKey;autoIncrement"`
+	PrimaryClass   string `gorm:"size:20"`
+	SecondaryClass string `gorm:"size:20"`
+	ReleaseDate    *time.Time
+}
+
+func (heroMetadataColumns) TableName() string {
+	return "heros"
+}
+
+// heroTable hanya dipakai sebagai target foreign key.
+type heroTable struct {
+	HeroID uint `gorm:"primaryKey;autoIncrement"`
+}
+
+func (heroTable) TableName() string {
+	return "heros"
+}
+
+type heroLaneTable struct {
+	HeroID uint      `gorm:"primaryKey"`
+	Lane   string    `gorm:"primaryKey;size:20;check:chk_hero_lanes_lane,lane IN ('gold', 'exp', 'roam', 'mid', 'jungler')"`
+	Hero   heroTable `gorm:"foreignKey:HeroID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (heroLaneTable) TableName() string {
+	return "hero_lanes"
+}
+
+type heroAliasTable struct {
+	HeroAliasID uint      `gorm:"primaryKey;autoIncrement"`
+	HeroID      uint      `gorm:"index"`
+	Alias       string    `gorm:"size:100;index"`
+	Hero        heroTable `gorm:"foreignKey:HeroID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (heroAliasTable) TableName() string {
+	return "hero_aliases"
+}
+
+func init() {
+	register(Migration{
+		ID:          "0005_hero_metadata",
+		Description: "add hero classes, release date, lanes and aliases",
+		Up: func(tx *gorm.DB) error {
+			for _, column := range []string{"PrimaryClass", "SecondaryClass", "ReleaseDate"} {
+				if tx.Migrator().HasColumn(&heroMetadataColumns{}, column) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(&heroMetadataColumns{}, column); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().CreateTable(&heroLaneTable{}, &heroAliasTable{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&heroAliasTable{}, &heroLaneTable{}); err != nil {
+				return err
+			}
+			for _, column := range []string{"ReleaseDate", "SecondaryClass", "PrimaryClass"} {
+				if err := tx.Migrator().DropColumn(&heroMetadataColumns{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
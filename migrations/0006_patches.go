@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type patchTable struct {
+	PatchID     uint      `gorm:"primaryKey;autoIncrement"`
+	Version     string    `gorm:"size:20;uniqueIndex"`
+	ReleaseDate time.Time `gorm:"index"`
+}
+
+func (patchTable) TableName() string {
+	return "patches"
+}
+
+// gamePatchColumn adalah kolom patch_id baru di tabel games.
+type gamePatchColumn struct {
+	GameID  uint       `gorm:"primaryKey;autoIncrement"`
+	PatchID *uint      `gorm:"index"`
+	Patch   patchTable `gorm:"foreignKey:PatchID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+}
+
+func (gamePatchColumn) TableName() string {
+	return "games"
+}
+
+// matchPlayedAtColumn adalah kolom played_at baru di tabel matches.
+type matchPlayedAtColumn struct {
+	MatchID  uint `gorm:"primaryKey;autoIncrement"`
+	PlayedAt *time.Time
+}
+
+func (matchPlayedAtColumn) TableName() string {
+	return "matches"
+}
+
+func init() {
+	register(Migration{
+		ID:          "0006_patches",
+		Description: "create patches and record the patch of every game and the date of every match",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&patchTable{}); err != nil {
+				return err
+			}
+			if !tx.Migrator().HasColumn(&matchPlayedAtColumn{}, "PlayedAt") {
+				if err := tx.Migrator().AddColumn(&matchPlayedAtColumn{}, "PlayedAt"); err != nil {
+					return err
+				}
+			}
+			if !tx.Migrator().HasColumn(&gamePatchColumn{}, "PatchID") {
+				if err := tx.Migrator().AddColumn(&gamePatchColumn{}, "PatchID"); err != nil {
+					return err
+				}
+			}
+			if err := tx.Migrator().CreateIndex(&gamePatchColumn{}, "PatchID"); err != nil {
+				return err
+			}
+			// SQLite tidak bisa menambah foreign key ke tabel yang sudah ada;
+			// di sana PatchService yang menjaga patch_id tetap valid.
+			if tx.Dialector.Name() == "sqlite" {
+				return nil
+			}
+			return tx.Migrator().CreateConstraint(&gamePatchColumn{}, "Patch")
+		},
+		Down: func(tx *gorm.DB) error {
+			if tx.Dialector.Name() != "sqlite" && tx.Migrator().HasConstraint(&gamePatchColumn{}, "Patch") {
+				if err := tx.Migrator().DropConstraint(&gamePatchColumn{}, "Patch"); err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasIndex(&gamePatchColumn{}, "PatchID") {
+				if err := tx.Migrator().DropIndex(&gamePatchColumn{}, "PatchID"); err != nil {
+					return err
+				}
+			}
+			if err := tx.Migrator().DropColumn(&gamePatchColumn{}, "PatchID"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&matchPlayedAtColumn{}, "PlayedAt"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&patchTable{})
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// tournamentTable hanya dipakai sebagai target foreign key.
+type tournamentTable struct {
+	TournamentID uint `gorm:"primaryKey;autoIncrement"`
+}
+
+func (tournamentTable) TableName() string {
+	return "tournaments"
+}
+
+type tournamentRuleTable struct {
+	TournamentID    uint   `gorm:"primaryKey"`
+	DraftFormat     string `gorm:"size:20;check:chk_tournament_rules_draft_format,draft_format IN ('standard', 'global_ban')"`
+	BansFirstPhase  int
+	BansSecondPhase int
+	FearlessMode    string          `gorm:"size:20;check:chk_tournament_rules_fearless_mode,fearless_mode IN ('off', 'team', 'full')"`
+	Tournament      tournamentTable `gorm:"foreignKey:TournamentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (tournamentRuleTable) TableName() string {
+	return "tournament_rules"
+}
+
+type tournamentDisabledHeroTable struct {
+	TournamentID uint                `gorm:"primaryKey"`
+	HeroID       uint                `gorm:"primaryKey"`
+	Rule         tournamentRuleTable `gorm:"foreignKey:TournamentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Hero         heroTable           `gorm:"foreignKey:HeroID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (tournamentDisabledHeroTable) TableName() string {
+	return "tournament_disabled_heroes"
+}
+
+func init() {
+	register(Migration{
+		ID:          "0007_tournament_rules",
+		Description: "create per-tournament draft rules and disabled heroes",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&tournamentRuleTable{}, &tournamentDisabledHeroTable{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&tournamentDisabledHeroTable{}, &tournamentRuleTable{})
+		},
+	})
+}
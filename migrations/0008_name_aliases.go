@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// teamTable dan playerTable hanya dipakai sebagai target foreign key.
+type teamTable struct {
+	TeamID uint `gorm:"primaryKey;autoIncrement"`
+}
+
+func (teamTable) TableName() string {
+	return "teams"
+}
+
+type playerTable struct {
+	PlayerID uint `gorm:"primaryKey;autoIncrement"`
+}
+
+func (playerTable) TableName() string {
+	return "players"
+}
+
+type teamAliasTable struct {
+	TeamAliasID uint      `gorm:"primaryKey;autoIncrement"`
+	TeamID      uint      `gorm:"index"`
+	Alias       string    `gorm:"size:100;index"`
+	Team        teamTable `gorm:"foreignKey:TeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (teamAliasTable) TableName() string {
+	return "team_aliases"
+}
+
+type playerAliasTable struct {
+	PlayerAliasID uint        `gorm:"primaryKey;autoIncrement"`
+	PlayerID      uint        `gorm:"index"`
+	Alias         string      `gorm:"size:100;index"`
+	Player        playerTable `gorm:"foreignKey:PlayerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (playerAliasTable) TableName() string {
+	return "player_aliases"
+}
+
+type nameReviewTable struct {
+	NameReviewID uint   `gorm:"primaryKey;autoIncrement"`
+	Kind         string `gorm:"size:20;uniqueIndex:idx_name_reviews_name;check:chk_name_reviews_kind,kind IN ('team', 'player', 'hero')"`
+	Name         string `gorm:"size:100;uniqueIndex:idx_name_reviews_name"`
+	TeamID       uint   `gorm:"uniqueIndex:idx_name_reviews_name"`
+	Source       string `gorm:"size:50"`
+	Occurrences  int
+	Status       string `gorm:"size:20;index;check:chk_name_reviews_status,status IN ('pending', 'resolved', 'dismissed')"`
+	EntityID     *uint
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (nameReviewTable) TableName() string {
+	return "name_reviews"
+}
+
+func init() {
+	register(Migration{
+		ID:          "0008_name_aliases",
+		Description: "create team and player aliases and the name review queue",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&teamAliasTable{}, &playerAliasTable{}, &nameReviewTable{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&nameReviewTable{}, &playerAliasTable{}, &teamAliasTable{})
+		},
+	})
+}
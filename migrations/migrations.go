@@ -0,0 +1,160 @@
+package migrations
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration adalah satu perubahan skema yang bisa diterapkan (Up) dan
+// dibatalkan (Down). ID dipakai untuk urutan dan disimpan di tabel
+// schema_migrations, jadi tidak boleh diubah setelah dirilis.
+//
+// Migration tidak boleh bergantung pada struct di package models karena
+// struct tersebut ikut berubah seiring waktu; tulis DDL-nya secara eksplisit.
+type Migration struct {
+	ID          string
+	Description string
+	Up          func(tx *gorm.DB) error
+	Down        func(tx *gorm.DB) error
+}
+
+// SchemaMigration mencatat migration yang sudah diterapkan.
+type SchemaMigration struct {
+	ID        string    `gorm:"primaryKey;size:100"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// Status adalah keadaan satu migration terhadap database.
+type Status struct {
+	ID          string
+	Description string
+	AppliedAt   *time.Time
+}
+
+var registry []Migration
+
+func register(migration Migration) {
+	registry = append(registry, migration)
+}
+
+// All mengembalikan semua migration yang terdaftar, terurut berdasarkan ID.
+func All() []Migration {
+	migrations := append([]Migration(nil), registry...)
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].ID < migrations[j].ID })
+	return migrations
+}
+
+// Up menerapkan semua migration yang belum diterapkan secara berurutan.
+// Setiap migration dijalankan di transaksinya sendiri; perlu diingat MySQL
+// melakukan implicit commit untuk DDL sehingga Down tetap harus ditulis.
+func Up(db *gorm.DB) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range All() {
+		if _, ok := applied[migration.ID]; ok {
+			continue
+		}
+
+		log.Printf("Applying migration %s: %s", migration.ID, migration.Description)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{ID: migration.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s gagal: %w", migration.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Down membatalkan steps migration terakhir yang sudah diterapkan.
+func Down(db *gorm.DB, steps int) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	migrations := All()
+	for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+		migration := migrations[i]
+		if _, ok := applied[migration.ID]; !ok {
+			continue
+		}
+
+		log.Printf("Rolling back migration %s: %s", migration.ID, migration.Description)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, "id = ?", migration.ID).Error
+		})
+		if err != nil {
+			return fmt.Errorf("rollback migration %s gagal: %w", migration.ID, err)
+		}
+		steps--
+	}
+
+	return nil
+}
+
+// Statuses mengembalikan status semua migration yang terdaftar.
+func Statuses(db *gorm.DB) ([]Status, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	for _, migration := range All() {
+		status := Status{ID: migration.ID, Description: migration.Description}
+		if appliedAt, ok := applied[migration.ID]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Pending mengembalikan jumlah migration yang belum diterapkan.
+func Pending(db *gorm.DB) (int, error) {
+	statuses, err := Statuses(db)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[string]time.Time, error) {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	var rows []SchemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.ID] = row.AppliedAt
+	}
+	return applied, nil
+}
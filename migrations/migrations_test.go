@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDatabases membuka SQLite dan, jika TEST_POSTGRES_DSN diisi, PostgreSQL.
+// Database PostgreSQL harus kosong; semua migration dibatalkan di akhir test.
+func testDatabases(t *testing.T) map[string]*gorm.DB {
+	t.Helper()
+
+	dialectors := map[string]gorm.Dialector{
+		"sqlite": sqlite.Open(filepath.Join(t.TempDir(), "test.db") + "?_pragma=foreign_keys(1)"),
+	}
+	if dsn := os.Getenv("TEST_POSTGRES_DSN"); dsn != "" {
+		dialectors["postgres"] = postgres.Open(dsn)
+	}
+
+	databases := map[string]*gorm.DB{}
+	for name, dialector := range dialectors {
+		db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Discard})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			Down(db, len(All()))
+			db.Migrator().DropTable(&SchemaMigration{})
+			if sqlDB, err := db.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+		databases[name] = db
+	}
+	return databases
+}
+
+func TestEnumColumnsAreChecked(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := Up(db); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, c := range enumColumns {
+				constraint := "chk_" + c.table + "_" + c.column
+				if !db.Migrator().HasConstraint(c.table, constraint) {
+					t.Fatalf("constraint %s tidak ada", constraint)
+				}
+			}
+			if err := db.Exec("INSERT INTO audit_logs (action) VALUES ('create')").Error; err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Exec("INSERT INTO audit_logs (action) VALUES ('merge')").Error; err == nil {
+				t.Fatal("nilai di luar enum diterima")
+			}
+
+			// Semua migration bisa dibatalkan lalu diterapkan ulang
+			if err := Down(db, len(All())); err != nil {
+				t.Fatal(err)
+			}
+			if db.Migrator().HasTable("audit_logs") {
+				t.Fatal("audit_logs masih ada setelah down")
+			}
+			if err := Up(db); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
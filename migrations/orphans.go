@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// cleanOrphanRows melaporkan lalu menghapus baris yang menunjuk ke parent yang
+// sudah tidak ada, supaya foreign key bisa dibuat oleh AutoMigrate. Diulang
+// sampai tidak ada lagi yang dihapus karena menghapus satu baris bisa membuat
+// baris turunannya ikut menjadi yatim.
+func cleanOrphanRows(db *gorm.DB, models ...interface{}) error {
+	for {
+		var deleted int64
+
+		for _, model := range models {
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(model); err != nil {
+				return err
+			}
+
+			child := stmt.Schema.Table
+			for _, rel := range stmt.Schema.Relationships.BelongsTo {
+				parent := rel.FieldSchema.Table
+				if !db.Migrator().HasTable(child) || !db.Migrator().HasTable(parent) {
+					continue
+				}
+
+				ref := rel.References[0]
+				condition := fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s p WHERE p.%s = %s.%s)",
+					parent, ref.PrimaryKey.DBName, child, ref.ForeignKey.DBName)
+
+				var count int64
+				if err := db.Table(child).Where(condition).Count(&count).Error; err != nil {
+					return err
+				}
+				if count == 0 {
+					continue
+				}
+
+				log.Printf("Found %d orphan rows in %s (%s not in %s), deleting", count, child, ref.ForeignKey.DBName, parent)
+				if err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", child, condition)).Error; err != nil {
+					return err
+				}
+				deleted += count
+			}
+		}
+
+		if deleted == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestCleanOrphanRows(t *testing.T) {
+	// Database lama tanpa foreign key
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "legacy.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, statement := range []string{
+		"CREATE TABLE tournaments (tournament_id integer PRIMARY KEY, name text)",
+		"CREATE TABLE teams (team_id integer PRIMARY KEY, name text)",
+		"CREATE TABLE players (player_id integer PRIMARY KEY, team_id integer, name text)",
+		"CREATE TABLE matches (match_id integer PRIMARY KEY, tournament_id integer, team_a_id integer, team_b_id integer)",
+		"CREATE TABLE match_team_details (match_team_detail_id integer PRIMARY KEY, match_id integer, team_id integer)",
+		"CREATE TABLE player_matches (player_match_id integer PRIMARY KEY, match_team_detail_id integer, player_id integer, role text)",
+
+		"INSERT INTO tournaments VALUES (1, 'MPL')",
+		"INSERT INTO teams VALUES (1, 'Team A')",
+		"INSERT INTO players VALUES (1, 1, 'Player A'), (2, 99, 'Without team')",
+		"INSERT INTO matches VALUES (1, 1, 1, 1), (2, 99, 1, 1)",
+		"INSERT INTO match_team_details VALUES (1, 1, 1), (2, 2, 1)",
+		// 2 yatim karena player 2, 3 baru yatim setelah match 2 dan
+		// match_team_detail 2 dihapus
+		"INSERT INTO player_matches VALUES (1, 1, 1, 'roamer'), (2, 1, 2, 'roamer'), (3, 2, 1, 'roamer')",
+	} {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := cleanOrphanRows(db, baselineTables()...); err != nil {
+		t.Fatal(err)
+	}
+
+	for table, key := range map[string]string{
+		"tournaments":        "tournament_id",
+		"teams":              "team_id",
+		"players":            "player_id",
+		"matches":            "match_id",
+		"match_team_details": "match_team_detail_id",
+		"player_matches":     "player_match_id",
+	} {
+		var ids []uint
+		if err := db.Table(table).Order(key).Pluck(key, &ids).Error; err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 1 || ids[0] != 1 {
+			t.Errorf("%s = %v, want [1]", table, ids)
+		}
+	}
+}
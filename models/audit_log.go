@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type AuditLog struct {
+	AuditLogID uint      `gorm:"primaryKey;autoIncrement" json:"audit_log_id"`
+	UserID     uint      `gorm:"index" json:"user_id"`
+	Username   string    `gorm:"size:100" json:"username"`
+	EntityType string    `gorm:"size:100;index:idx_audit_logs_entity" json:"entity_type"`
+	EntityID   uint      `gorm:"index:idx_audit_logs_entity" json:"entity_id"`
+	Action     string    `gorm:"size:20;check:chk_audit_logs_action,action IN ('create', 'update', 'delete')" json:"action"`
+	Before     string    `gorm:"type:text" json:"before"`
+	After      string    `gorm:"type:text" json:"after"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
@@ -1,8 +1,20 @@
 package models
 
-type Coach struct {	
-	CoachID uint   `gorm:"primaryKey;autoIncrement" json:"coach_id"`
-	TeamID  uint   `json:"team_id"`
-	Name    string `json:"name"`
-	Image   string `json:"image"`
+import "encoding/json"
+
+type Coach struct {
+	CoachID       uint           `gorm:"primaryKey;autoIncrement" json:"coach_id"`
+	TeamID        uint           `json:"team_id"`
+	Name          string         `json:"name"`
+	Image         Image          `json:"image" swaggertype:"string"`
+	ImageVariants *ImageVariants `gorm:"-" json:"image_variants"`
+
+	Team Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// MarshalJSON melengkapi ImageVariants dengan URL semua varian gambar.
+func (c Coach) MarshalJSON() ([]byte, error) {
+	type coach Coach
+	c.ImageVariants = c.Image.Variants()
+	return json.Marshal(coach(c))
 }
@@ -5,4 +5,7 @@ type CoachMatch struct {
 	MatchTeamDetailID uint   `json:"match_team_detail_id"`
 	Role              string `json:"role"`
 	CoachID           uint   `json:"coach_id"`
+
+	MatchTeamDetail MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Coach           Coach           `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -1,12 +1,34 @@
 package models
 
+import (
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
 type Game struct {
-	GameID           uint   `gorm:"primaryKey;autoIncrement" json:"game_id"`
-	MatchID          uint   `json:"match_id"`
-	FirstPickTeamID  uint   `json:"first_pick_team_id"`
-	SecondPickTeamID uint   `json:"second_pick_team_id"`
-	WinnerTeamID     uint   `json:"winner_team_id"`
-	GameNumber       int    `json:"game_number"`
-	VideoLink        string `json:"video_link"`
-	FullDraftImage   string `json:"full_draft_image"`
+	GameID                 uint           `gorm:"primaryKey;autoIncrement" json:"game_id"`
+	MatchID                uint           `json:"match_id"`
+	FirstPickTeamID        uint           `json:"first_pick_team_id"`
+	SecondPickTeamID       uint           `json:"second_pick_team_id"`
+	WinnerTeamID           uint           `json:"winner_team_id"`
+	GameNumber             int            `json:"game_number"`
+	VideoLink              string         `json:"video_link"`
+	FullDraftImage         Image          `json:"full_draft_image" swaggertype:"string"`
+	FullDraftImageVariants *ImageVariants `gorm:"-" json:"full_draft_image_variants"`
+	PatchID                *uint          `gorm:"index" json:"patch_id"`
+	DeletedAt              gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string"`
+
+	Match          Match  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	FirstPickTeam  Team   `gorm:"foreignKey:FirstPickTeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	SecondPickTeam Team   `gorm:"foreignKey:SecondPickTeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	WinnerTeam     Team   `gorm:"foreignKey:WinnerTeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Patch          *Patch `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+}
+
+// MarshalJSON melengkapi FullDraftImageVariants dengan URL semua varian gambar.
+func (g Game) MarshalJSON() ([]byte, error) {
+	type game Game
+	g.FullDraftImageVariants = g.FullDraftImage.Variants()
+	return json.Marshal(game(g))
 }
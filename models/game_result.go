@@ -4,5 +4,8 @@ type GameResult struct {
 	GameResultID uint   `gorm:"primaryKey;autoIncrement" json:"game_result_id"`
 	GameID       uint   `json:"game_id"`
 	TeamID       uint   `json:"team_id"`
-	Result       string `gorm:"type:enum('win', 'lose');" json:"result"`
+	Result       string `gorm:"size:20;check:chk_game_results_result,result IN ('win', 'lose')" json:"result"`
+
+	Game Game `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Team Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
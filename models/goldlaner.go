@@ -5,5 +5,9 @@ type Goldlaner struct {
 	GameID      uint   `json:"game_id"`
 	TeamID      uint   `json:"team_id"`
 	HeroID      uint   `json:"hero_id"`
-	EarlyResult string `gorm:"type:enum('win', 'draw', 'lose')" json:"early_result"`
+	EarlyResult string `gorm:"size:20;check:chk_goldlaners_early_result,early_result IN ('win', 'draw', 'lose')" json:"early_result"`
+
+	Game Game `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Team Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Hero Hero `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -1,7 +1,94 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+)
+
+// HeroClasses adalah kelas hero yang valid untuk PrimaryClass dan
+// SecondaryClass.
+var HeroClasses = []string{"tank", "fighter", "assassin", "mage", "marksman", "support"}
+
+// Lanes adalah lane yang valid, sama dengan role di priority pick, priority
+// ban dan flex pick, terurut seperti di draft.
+var Lanes = []string{"gold", "exp", "roam", "mid", "jungler"}
+
 type Hero struct {
-	HeroID uint   `gorm:"primaryKey;autoIncrement" json:"hero_id"`
-	Name   string `json:"name"`
-	Image  string `json:"image"`
+	HeroID         uint           `gorm:"primaryKey;autoIncrement" json:"hero_id"`
+	Name           string         `json:"name"`
+	Image          Image          `json:"image" swaggertype:"string"`
+	ImageVariants  *ImageVariants `gorm:"-" json:"image_variants"`
+	PrimaryClass   string         `gorm:"size:20" json:"primary_class"`
+	SecondaryClass string         `gorm:"size:20" json:"secondary_class"`
+	ReleaseDate    *time.Time     `json:"release_date"`
+
+	// Lanes adalah lane yang bisa diisi hero, dipakai analisis draft dan
+	// deteksi flex pick.
+	Lanes HeroLanes `json:"lanes" swaggertype:"array,string"`
+	// Aliases adalah nama lain hero, misalnya nama lama atau singkatan.
+	Aliases HeroAliases `json:"aliases" swaggertype:"array,string"`
+}
+
+// MarshalJSON melengkapi ImageVariants dengan URL semua varian gambar.
+func (h Hero) MarshalJSON() ([]byte, error) {
+	type hero Hero
+	h.ImageVariants = h.Image.Variants()
+	return json.Marshal(hero(h))
+}
+
+type HeroLane struct {
+	HeroID uint   `gorm:"primaryKey" json:"hero_id"`
+	Lane   string `gorm:"primaryKey;size:20;check:chk_hero_lanes_lane,lane IN ('gold', 'exp', 'roam', 'mid', 'jungler')" json:"lane"`
+}
+
+type HeroAlias struct {
+	HeroAliasID uint   `gorm:"primaryKey;autoIncrement" json:"hero_alias_id"`
+	HeroID      uint   `gorm:"index" json:"hero_id"`
+	Alias       string `gorm:"size:100;index" json:"alias"`
+}
+
+// HeroLanes ditulis di JSON sebagai daftar nama lane.
+type HeroLanes []HeroLane
+
+func (l HeroLanes) MarshalJSON() ([]byte, error) {
+	names := make([]string, 0, len(l))
+	for _, lane := range l {
+		names = append(names, lane.Lane)
+	}
+	return json.Marshal(names)
+}
+
+func (l *HeroLanes) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*l = nil
+	for _, name := range names {
+		*l = append(*l, HeroLane{Lane: name})
+	}
+	return nil
+}
+
+// HeroAliases ditulis di JSON sebagai daftar nama lain.
+type HeroAliases []HeroAlias
+
+func (a HeroAliases) MarshalJSON() ([]byte, error) {
+	names := make([]string, 0, len(a))
+	for _, alias := range a {
+		names = append(names, alias.Alias)
+	}
+	return json.Marshal(names)
+}
+
+func (a *HeroAliases) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*a = nil
+	for _, name := range names {
+		*a = append(*a, HeroAlias{Alias: name})
+	}
+	return nil
 }
@@ -6,4 +6,7 @@ type HeroBanGame struct {
 	GameID        uint `json:"game_id"`
 	GameNumber    int  `json:"game_number"`
 	IsBanned      bool `json:"is_banned"`
+
+	HeroBan HeroBan `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Game    Game    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
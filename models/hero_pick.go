@@ -7,4 +7,7 @@ type HeroPick struct {
 	FirstPhase        int  `json:"first_phase"`
 	SecondPhase       int  `json:"second_phase"`
 	Total             int  `json:"total"`
+
+	MatchTeamDetail MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Hero            Hero            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -6,4 +6,7 @@ type HeroPickGame struct {
 	GameID         uint `json:"game_id"`
 	GameNumber     int  `json:"game_number"`
 	IsPicked       bool `json:"is_picked"`
+
+	HeroPick HeroPick `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Game     Game     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+
+	"ml-master-data/imaging"
+)
+
+// Image adalah referensi gambar: key objek di storage untuk gambar hasil
+// upload, atau URL absolut untuk gambar eksternal seperti placeholder dan
+// Cloudinary. Di JSON, Image selalu ditulis sebagai URL.
+type Image string
+
+// PlaceholderImage dipakai hero, team, pemain dan pelatih yang belum punya
+// gambar.
+const PlaceholderImage Image = "https://placehold.co/400x600"
+
+// ImageURL mengubah key objek menjadi URL publik. Diisi dari storage yang
+// aktif saat aplikasi start.
+var ImageURL = func(key string) string { return key }
+
+// Key mengembalikan key objek storage, atau false jika Image kosong atau
+// berupa URL eksternal.
+func (i Image) Key() (string, bool) {
+	if i == "" || i.IsExternal() {
+		return "", false
+	}
+	return string(i), true
+}
+
+// IsExternal bernilai true jika Image adalah URL absolut di luar storage.
+func (i Image) IsExternal() bool {
+	return strings.HasPrefix(string(i), "http://") || strings.HasPrefix(string(i), "https://")
+}
+
+// URL mengembalikan URL publik gambar.
+func (i Image) URL() string {
+	if key, ok := i.Key(); ok {
+		return ImageURL(key)
+	}
+	return string(i)
+}
+
+// ImageVariants berisi URL setiap ukuran gambar. Gambar eksternal dan gambar
+// lama yang diupload sebelum ada varian memakai URL yang sama di semua
+// ukuran.
+type ImageVariants struct {
+	Thumbnail string `json:"thumbnail"`
+	Card      string `json:"card"`
+	Full      string `json:"full"`
+}
+
+// hasVariants bernilai true jika key menunjuk varian full hasil pipeline
+// imaging, yaitu "<base>/full.webp".
+func (i Image) hasVariants() bool {
+	key, ok := i.Key()
+	return ok && path.Base(key) == imaging.Full+imaging.Ext && path.Dir(key) != "."
+}
+
+// Variant mengembalikan Image untuk varian dengan nama tersebut.
+func (i Image) Variant(name string) Image {
+	if !i.hasVariants() {
+		return i
+	}
+	return Image(path.Dir(string(i)) + "/" + name + imaging.Ext)
+}
+
+// VariantKeys mengembalikan key semua objek storage milik Image.
+func (i Image) VariantKeys() []string {
+	key, ok := i.Key()
+	if !ok {
+		return nil
+	}
+	if !i.hasVariants() {
+		return []string{key}
+	}
+	keys := make([]string, 0, len(imaging.Sizes))
+	for _, size := range imaging.Sizes {
+		keys = append(keys, string(i.Variant(size.Name)))
+	}
+	return keys
+}
+
+// Variants mengembalikan URL publik setiap ukuran gambar, atau nil jika
+// Image kosong.
+func (i Image) Variants() *ImageVariants {
+	if i == "" {
+		return nil
+	}
+	return &ImageVariants{
+		Thumbnail: i.Variant(imaging.Thumbnail).URL(),
+		Card:      i.Variant(imaging.Card).URL(),
+		Full:      i.Variant(imaging.Full).URL(),
+	}
+}
+
+func (i Image) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.URL())
+}
@@ -5,7 +5,10 @@ type LordResult struct {
 	GameID       uint   `json:"game_id"`
 	TeamID       uint   `json:"team_id"`
 	Phase        string `json:"phase"`
-	Setup        string `gorm:"type:enum('early', 'late', 'no')" json:"setup"`
-	Initiate     string `gorm:"type:enum('yes', 'no')" json:"initiate"`
-	Result       string `gorm:"type:enum('yes', 'no')" json:"result"`
+	Setup        string `gorm:"size:20;check:chk_lord_results_setup,setup IN ('early', 'late', 'no')" json:"setup"`
+	Initiate     string `gorm:"size:20;check:chk_lord_results_initiate,initiate IN ('yes', 'no')" json:"initiate"`
+	Result       string `gorm:"size:20;check:chk_lord_results_result,result IN ('yes', 'no')" json:"result"`
+
+	Game Game `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Team Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -1,13 +1,25 @@
 package models
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type Match struct {
-	MatchID      uint   `gorm:"primaryKey;autoIncrement" json:"match_id"`
-	TournamentID uint   `json:"tournament_id"`
-	Stage        string `json:"stage"`
-	Day          int    `json:"day"`
-	Date         int    `json:"date"`
-	TeamAID      uint   `json:"team_a_id"`
-	TeamBID      uint   `json:"team_b_id"`
-	TeamAScore   int    `json:"team_a_score"`
-	TeamBScore   int    `json:"team_b_score"`
+	MatchID      uint           `gorm:"primaryKey;autoIncrement" json:"match_id"`
+	TournamentID uint           `json:"tournament_id"`
+	Stage        string         `json:"stage"`
+	Day          int            `json:"day"`
+	Date         int            `json:"date"`
+	PlayedAt     *time.Time     `json:"played_at"`
+	TeamAID      uint           `json:"team_a_id"`
+	TeamBID      uint           `json:"team_b_id"`
+	TeamAScore   int            `json:"team_a_score"`
+	TeamBScore   int            `json:"team_b_score"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string"`
+
+	Tournament Tournament `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	TeamA      Team       `gorm:"foreignKey:TeamAID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	TeamB      Team       `gorm:"foreignKey:TeamBID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
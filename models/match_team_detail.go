@@ -4,4 +4,7 @@ type MatchTeamDetail struct {
 	MatchTeamDetailID uint `gorm:"primaryKey;autoIncrement" json:"match_team_detail_id"`
 	MatchID           uint `json:"match_id"`
 	TeamID            uint `json:"team_id"`
+
+	Match Match `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Team  Team  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
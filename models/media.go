@@ -0,0 +1,28 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Media adalah gambar yang diupload lewat POST /media dan menunggu dipasang
+// ke hero, team, pemain, pelatih atau game. Baris dihapus saat media
+// dipasang; objeknya menjadi milik entity tersebut.
+type Media struct {
+	MediaID       uint           `gorm:"primaryKey;autoIncrement" json:"media_id"`
+	Kind          string         `gorm:"size:20;index" json:"kind"`
+	Image         Image          `json:"image" swaggertype:"string"`
+	ImageVariants *ImageVariants `gorm:"-" json:"image_variants"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+func (Media) TableName() string {
+	return "media"
+}
+
+// MarshalJSON melengkapi ImageVariants dengan URL semua varian gambar.
+func (m Media) MarshalJSON() ([]byte, error) {
+	type media Media
+	m.ImageVariants = m.Image.Variants()
+	return json.Marshal(media(m))
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Jenis entity yang namanya bisa dicocokkan.
+const (
+	NameKindTeam   = "team"
+	NameKindPlayer = "player"
+	NameKindHero   = "hero"
+)
+
+var NameKinds = []string{NameKindTeam, NameKindPlayer, NameKindHero}
+
+// Status NameReview.
+const (
+	NameReviewPending   = "pending"
+	NameReviewResolved  = "resolved"
+	NameReviewDismissed = "dismissed"
+)
+
+var NameReviewStatuses = []string{NameReviewPending, NameReviewResolved, NameReviewDismissed}
+
+// NameReview adalah nama dari import yang tidak bisa dicocokkan dengan
+// pasti. Setelah diputuskan, nama tersebut menjadi alias EntityID sehingga
+// import berikutnya langsung cocok.
+type NameReview struct {
+	NameReviewID uint   `gorm:"primaryKey;autoIncrement" json:"name_review_id"`
+	Kind         string `gorm:"size:20;uniqueIndex:idx_name_reviews_name" json:"kind"`
+	Name         string `gorm:"size:100;uniqueIndex:idx_name_reviews_name" json:"name"`
+	// TeamID membatasi nama pemain ke tim tersebut; 0 untuk team dan hero.
+	TeamID uint `gorm:"uniqueIndex:idx_name_reviews_name" json:"team_id"`
+	// Source adalah importer yang terakhir menemukan nama ini.
+	Source string `gorm:"size:50" json:"source"`
+	// Occurrences adalah berapa kali nama ini gagal dicocokkan.
+	Occurrences int       `json:"occurrences"`
+	Status      string    `gorm:"size:20;index" json:"status"`
+	EntityID    *uint     `json:"entity_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Patch adalah versi game dan tanggal rilisnya. Setiap Game dimainkan di
+// satu Patch.
+type Patch struct {
+	PatchID     uint      `gorm:"primaryKey;autoIncrement" json:"patch_id"`
+	Version     string    `gorm:"size:20;uniqueIndex" json:"version"`
+	ReleaseDate time.Time `gorm:"index" json:"release_date"`
+}
@@ -1,8 +1,27 @@
 package models
 
+import "encoding/json"
+
 type Player struct {
-	PlayerID uint   `gorm:"primaryKey;autoIncrement" json:"player_id"`
-	TeamID   uint   `json:"team_id"`
-	Name     string `json:"name"`
-	Image    string `json:"image"`
+	PlayerID      uint           `gorm:"primaryKey;autoIncrement" json:"player_id"`
+	TeamID        uint           `json:"team_id"`
+	Name          string         `json:"name"`
+	Image         Image          `json:"image" swaggertype:"string"`
+	ImageVariants *ImageVariants `gorm:"-" json:"image_variants"`
+
+	Team Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// MarshalJSON melengkapi ImageVariants dengan URL semua varian gambar.
+func (p Player) MarshalJSON() ([]byte, error) {
+	type player Player
+	p.ImageVariants = p.Image.Variants()
+	return json.Marshal(player(p))
+}
+
+// PlayerAlias adalah nama lain pemain, misalnya IGN lama.
+type PlayerAlias struct {
+	PlayerAliasID uint   `gorm:"primaryKey;autoIncrement" json:"player_alias_id"`
+	PlayerID      uint   `gorm:"index" json:"player_id"`
+	Alias         string `gorm:"size:100;index" json:"alias"`
 }
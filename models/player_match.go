@@ -4,5 +4,8 @@ type PlayerMatch struct {
 	PlayerMatchID     uint   `gorm:"primaryKey;autoIncrement" json:"player_match_id"`
 	MatchTeamDetailID uint   `json:"match_team_detail_id"`
 	PlayerID          uint   `json:"player_id"`
-	Role              string `gorm:"type:enum('goldlaner', 'explaner', 'roamer', 'midlaner', 'jungler');" json:"role"`
+	Role              string `gorm:"size:20;check:chk_player_matches_role,role IN ('goldlaner', 'explaner', 'roamer', 'midlaner', 'jungler')" json:"role"`
+
+	MatchTeamDetail MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Player          Player          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -5,6 +5,9 @@ type PriorityPick struct {
 	MatchTeamDetailID uint    `json:"match_team_detail_id"`
 	HeroID            uint    `json:"hero_id"`
 	Total             int     `json:"total"`
-	Role              string  `gorm:"type:enum('gold', 'exp', 'roam', 'mid', 'jungler');" json:"role"`
+	Role              string  `gorm:"size:20;check:chk_priority_picks_role,role IN ('gold', 'exp', 'roam', 'mid', 'jungler')" json:"role"`
 	PickRate          float64 `json:"pick_rate"`
+
+	MatchTeamDetail MatchTeamDetail `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Hero            Hero            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -1,7 +1,30 @@
 package models
 
+import (
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
 type Team struct {
-	TeamID uint   `gorm:"primaryKey;autoIncrement" json:"team_id"`
-	Name   string `json:"name"`
-	Image  string `json:"image"`
+	TeamID        uint           `gorm:"primaryKey;autoIncrement" json:"team_id"`
+	Name          string         `json:"name"`
+	Image         Image          `json:"image" swaggertype:"string"`
+	ImageVariants *ImageVariants `gorm:"-" json:"image_variants"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string"`
+}
+
+// MarshalJSON melengkapi ImageVariants dengan URL semua varian gambar.
+func (t Team) MarshalJSON() ([]byte, error) {
+	type team Team
+	t.ImageVariants = t.Image.Variants()
+	return json.Marshal(team(t))
+}
+
+// TeamAlias adalah nama lain tim, misalnya nama sponsor atau singkatan,
+// dipakai saat mencocokkan nama dari import.
+type TeamAlias struct {
+	TeamAliasID uint   `gorm:"primaryKey;autoIncrement" json:"team_alias_id"`
+	TeamID      uint   `gorm:"index" json:"team_id"`
+	Alias       string `gorm:"size:100;index" json:"alias"`
 }
@@ -1,6 +1,9 @@
 package models
 
+import "gorm.io/gorm"
+
 type Tournament struct {
-	TournamentID uint   `gorm:"primaryKey;autoIncrement" json:"tournament_id"`
-	Name         string `gorm:"size:100;" json:"name"`
+	TournamentID uint           `gorm:"primaryKey;autoIncrement" json:"tournament_id"`
+	Name         string         `gorm:"size:100;" json:"name"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string"`
 }
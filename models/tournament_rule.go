@@ -0,0 +1,97 @@
+package models
+
+import "encoding/json"
+
+// Format draft tournament.
+const (
+	// DraftStandard: setiap game di-draft terpisah.
+	DraftStandard = "standard"
+	// DraftGlobalBan: hero yang di-ban tetap ter-ban sampai akhir series.
+	DraftGlobalBan = "global_ban"
+)
+
+// Mode fearless draft di dalam satu series.
+const (
+	FearlessOff = "off"
+	// FearlessTeam: tim tidak boleh memakai lagi hero yang sudah dia pick di
+	// game sebelumnya.
+	FearlessTeam = "team"
+	// FearlessFull: hero yang sudah di-pick tim mana pun tidak bisa di-pick
+	// lagi di series yang sama.
+	FearlessFull = "full"
+)
+
+var DraftFormats = []string{DraftStandard, DraftGlobalBan}
+
+var FearlessModes = []string{FearlessOff, FearlessTeam, FearlessFull}
+
+// TournamentRule adalah aturan draft sebuah Tournament. Hero pick dan hero
+// ban divalidasi terhadap aturan ini; tournament tanpa TournamentRule tidak
+// dibatasi.
+type TournamentRule struct {
+	TournamentID uint   `gorm:"primaryKey" json:"tournament_id"`
+	DraftFormat  string `gorm:"size:20" json:"draft_format"`
+	// BansFirstPhase dan BansSecondPhase adalah jumlah ban setiap tim per
+	// game di tiap fase; 0 berarti tidak dibatasi.
+	BansFirstPhase  int    `json:"bans_first_phase"`
+	BansSecondPhase int    `json:"bans_second_phase"`
+	FearlessMode    string `gorm:"size:20" json:"fearless_mode"`
+
+	DisabledHeroes TournamentDisabledHeroes `gorm:"foreignKey:TournamentID" json:"disabled_hero_ids" swaggertype:"array,integer"`
+}
+
+// DefaultTournamentRule adalah aturan tournament yang belum diatur.
+func DefaultTournamentRule(tournamentID uint) TournamentRule {
+	return TournamentRule{
+		TournamentID:   tournamentID,
+		DraftFormat:    DraftStandard,
+		FearlessMode:   FearlessOff,
+		DisabledHeroes: TournamentDisabledHeroes{},
+	}
+}
+
+// HeroDisabled bernilai true jika heroID tidak boleh dipakai di tournament.
+func (r TournamentRule) HeroDisabled(heroID uint) bool {
+	for _, hero := range r.DisabledHeroes {
+		if hero.HeroID == heroID {
+			return true
+		}
+	}
+	return false
+}
+
+// TournamentDisabledHero adalah hero yang tidak bisa di-pick atau di-ban di
+// sebuah tournament, misalnya hero baru.
+type TournamentDisabledHero struct {
+	TournamentID uint `gorm:"primaryKey" json:"tournament_id"`
+	HeroID       uint `gorm:"primaryKey" json:"hero_id"`
+
+	Hero Hero `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (TournamentDisabledHero) TableName() string {
+	return "tournament_disabled_heroes"
+}
+
+// TournamentDisabledHeroes ditulis di JSON sebagai daftar ID hero.
+type TournamentDisabledHeroes []TournamentDisabledHero
+
+func (d TournamentDisabledHeroes) MarshalJSON() ([]byte, error) {
+	ids := make([]uint, 0, len(d))
+	for _, hero := range d {
+		ids = append(ids, hero.HeroID)
+	}
+	return json.Marshal(ids)
+}
+
+func (d *TournamentDisabledHeroes) UnmarshalJSON(data []byte) error {
+	var ids []uint
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	*d = nil
+	for _, id := range ids {
+		*d = append(*d, TournamentDisabledHero{HeroID: id})
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package models
 
 type TrioMid struct {
-	TrioMidID   uint   `gorm:"primaryKey;autoIncrement" json:"trio_mid_id"`
-	GameID      uint   `json:"game_id"`
-	TeamID      uint   `json:"team_id"`
-	EarlyResult *string `gorm:"type:enum('win', 'draw', 'lose')" json:"early_result"`
+	TrioMidID   uint    `gorm:"primaryKey;autoIncrement" json:"trio_mid_id"`
+	GameID      uint    `json:"game_id"`
+	TeamID      uint    `json:"team_id"`
+	EarlyResult *string `gorm:"size:20;check:chk_trio_mids_early_result,early_result IN ('win', 'draw', 'lose')" json:"early_result"`
+
+	Game Game `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Team Team `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
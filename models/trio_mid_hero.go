@@ -4,6 +4,9 @@ type TrioMidHero struct {
 	TrioMidHeroID uint   `gorm:"primaryKey;autoIncrement" json:"trio_mid_hero_id"`
 	TrioMidID     uint   `json:"trio_mid_id"`
 	HeroID        uint   `json:"hero_id"`
-	Role          string `gorm:"type:enum('jungler', 'midlaner', 'roamer')" json:"role"`
-	EarlyResult   string `gorm:"type:enum('win', 'draw', 'lose')" json:"early_result"`
+	Role          string `gorm:"size:20;check:chk_trio_mid_heros_role,role IN ('jungler', 'midlaner', 'roamer')" json:"role"`
+	EarlyResult   string `gorm:"size:20;check:chk_trio_mid_heros_early_result,early_result IN ('win', 'draw', 'lose')" json:"early_result"`
+
+	TrioMid TrioMid `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Hero    Hero    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
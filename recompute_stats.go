@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+)
+
+// runRecomputeStats menjalankan subcommand `recompute-stats [-tournament
+// id]` yang memperbaiki data turunan setelah data diubah langsung di
+// database, termasuk patch game yang belum tercatat.
+func runRecomputeStats(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("recompute-stats", flag.ExitOnError)
+	tournamentID := flags.Uint("tournament", 0, "only recompute this tournament (0 for all)")
+	flags.Parse(args)
+
+	config.ConnectDatabase(cfg.Database)
+
+	ctx := services.WithAuditActor(context.Background(), cliActor)
+	result, err := services.New(repositories.New(config.DB, openStorage(cfg))).Stats.Recompute(ctx, *tournamentID)
+	if err != nil {
+		log.Fatal("Failed to recompute statistics: ", err)
+	}
+	fmt.Printf("Fixed %d match scores and %d game results; assigned patches to %d games\n",
+		result.MatchScores, result.GameResults, result.GamePatches)
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// AuditRepository membaca riwayat perubahan yang ditulis callback audit.
+type AuditRepository interface {
+	// FindAll mengembalikan AuditLog terbaru lebih dulu sesuai filter query.
+	FindAll(ctx context.Context, query dto.AuditLogQueryDto) ([]models.AuditLog, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) FindAll(ctx context.Context, input dto.AuditLogQueryDto) ([]models.AuditLog, error) {
+	query := r.db.WithContext(ctx).Model(&models.AuditLog{})
+	if input.EntityType != "" {
+		query = query.Where("entity_type = ?", input.EntityType)
+	}
+	if input.EntityID != 0 {
+		query = query.Where("entity_id = ?", input.EntityID)
+	}
+	if input.UserID != 0 {
+		query = query.Where("user_id = ?", input.UserID)
+	}
+	if input.Action != "" {
+		query = query.Where("action = ?", input.Action)
+	}
+
+	var auditLogs []models.AuditLog
+	err := query.Order("audit_log_id DESC").Limit(input.Limit).Offset(input.Offset).Find(&auditLogs).Error
+	return auditLogs, err
+}
@@ -1,18 +1,17 @@
-package services
+package repositories
 
 import (
 	"fmt"
 	"log"
-	"os"
-	"strings"
 
 	"ml-master-data/models"
+	"ml-master-data/storage"
 
 	"gorm.io/gorm"
 )
 
 // Fungsi untuk menghapus Coach dan semua relasi terkait
-func DeleteCoach(db *gorm.DB, coach models.Coach) error {
+func deleteCoach(db *gorm.DB, files storage.Storage, coach models.Coach) error {
 	// Mulai transaksi
 	tx := db.Begin()
 	if tx.Error != nil {
@@ -36,18 +35,8 @@ func DeleteCoach(db *gorm.DB, coach models.Coach) error {
 		return fmt.Errorf("gagal commit transaksi: %w", err)
 	}
 
-	if coach.Image != "" && coach.Image != "https://placehold.co/400x600" && strings.HasPrefix(coach.Image, os.Getenv("BASE_URL")) {
-		coach.Image = strings.Replace(coach.Image, os.Getenv("BASE_URL")+"/", "", 1)
-		// Cek apakah file Image lama ada di sistem
-		if _, err := os.Stat(coach.Image); err == nil {
-			// Jika file ada, hapus file Image lama dari folder images
-			if err := os.Remove(coach.Image); err != nil {
-				return fmt.Errorf("gagal menghapus gambar lama: %w", err)
-			}
-		} else if os.IsNotExist(err) {
-			// Jika file tidak ada, lanjutkan ke tahap selanjutnya
-			log.Printf("File gambar lama tidak ditemukan: %s", coach.Image)
-		}
+	if err := removeImage(db, files, coach.Image); err != nil {
+		return err
 	}
 
 	log.Printf("Coach dengan ID %d dan semua data terkait telah dihapus.", coach.CoachID)
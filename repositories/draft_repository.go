@@ -0,0 +1,355 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DraftEntry adalah satu hero yang di-pick atau di-ban sebuah tim di satu
+// Game dalam Match. FirstPhase dan SecondPhase milik HeroPick atau HeroBan
+// induknya.
+type DraftEntry struct {
+	Ban         bool
+	EntryID     uint // hero_pick_id atau hero_ban_id
+	TeamID      uint
+	HeroID      uint
+	GameNumber  int
+	FirstPhase  int
+	SecondPhase int
+}
+
+// DraftCheck memeriksa semua pick dan ban kedua tim di series sebelum
+// perubahan disimpan. Dipanggil di dalam transaksi penyimpanan setelah baris
+// Match dikunci, jadi dua perubahan di Match yang sama tidak bisa lolos
+// pemeriksaan bersamaan. Error dari check membatalkan penyimpanan.
+type DraftCheck func(entries []DraftEntry) error
+
+// DraftRepository menyimpan HeroPick dan HeroBan sebuah tim dalam Match,
+// beserta status pick/ban di setiap Game.
+type DraftRepository interface {
+	FindHeroPick(ctx context.Context, matchTeamDetailID, heroPickID uint) (models.HeroPick, error)
+	// HeroPickExists mengecek apakah hero sudah di-pick oleh tim, tanpa
+	// menghitung HeroPick excludeID.
+	HeroPickExists(ctx context.Context, matchTeamDetailID, heroID, excludeID uint) (bool, error)
+	// FindHeroPicks mengembalikan HeroPick tim di Match. Jika patchID diisi,
+	// hanya hero yang di-pick di Game patch tersebut, dan status per Game
+	// dibatasi ke Game patch itu. Berlaku juga untuk daftar hero ban.
+	FindHeroPicks(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroPickResponseDto, error)
+	FindHeroPicksInFirstPhase(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroPickResponseDto, error)
+	CreateHeroPick(ctx context.Context, heroPick *models.HeroPick, games []models.HeroPickGame, check DraftCheck) error
+	// UpdateHeroPick menyimpan HeroPick dan memperbarui HeroPickGame dengan
+	// game_number yang sama, atau membuatnya jika belum ada.
+	UpdateHeroPick(ctx context.Context, heroPick *models.HeroPick, games []models.HeroPickGame, check DraftCheck) error
+	DeleteHeroPick(ctx context.Context, heroPick models.HeroPick) error
+
+	FindHeroBan(ctx context.Context, matchTeamDetailID, heroBanID uint) (models.HeroBan, error)
+	HeroBanExists(ctx context.Context, matchTeamDetailID, heroID, excludeID uint) (bool, error)
+	FindHeroBans(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroBanResponseDto, error)
+	FindHeroBansInFirstPhase(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroBanResponseDto, error)
+	CreateHeroBan(ctx context.Context, heroBan *models.HeroBan, games []models.HeroBanGame, check DraftCheck) error
+	UpdateHeroBan(ctx context.Context, heroBan *models.HeroBan, games []models.HeroBanGame, check DraftCheck) error
+	DeleteHeroBan(ctx context.Context, heroBan models.HeroBan) error
+}
+
+type draftRepository struct {
+	db *gorm.DB
+}
+
+func NewDraftRepository(db *gorm.DB) DraftRepository {
+	return &draftRepository{db: db}
+}
+
+const heroPickQuery = `
+	SELECT 
+		hp.hero_pick_id, hp.match_team_detail_id, hp.hero_id, 
+		hp.first_phase, hp.second_phase, hp.total, 
+		h.hero_id AS hero_hero_id, h.name AS hero_name, h.image AS hero_image
+	FROM hero_picks hp
+	JOIN heros h ON hp.hero_id = h.hero_id
+	JOIN match_team_details mtd ON hp.match_team_detail_id = mtd.match_team_detail_id
+	WHERE mtd.match_id = ? AND mtd.team_id = ?
+`
+
+const heroBanQuery = `
+	SELECT 
+		hb.hero_ban_id, hb.match_team_detail_id, hb.hero_id, 
+		hb.first_phase, hb.second_phase, hb.total, 
+		h.hero_id AS hero_hero_id, h.name AS hero_name, h.image AS hero_image
+	FROM hero_bans hb
+	JOIN heros h ON hb.hero_id = h.hero_id
+	JOIN match_team_details mtd ON hb.match_team_detail_id = mtd.match_team_detail_id
+	WHERE mtd.match_id = ? AND mtd.team_id = ?
+`
+
+func (r *draftRepository) FindHeroPick(ctx context.Context, matchTeamDetailID, heroPickID uint) (models.HeroPick, error) {
+	var heroPick models.HeroPick
+	err := r.db.WithContext(ctx).Where("match_team_detail_id = ? AND hero_pick_id = ?", matchTeamDetailID, heroPickID).First(&heroPick).Error
+	return heroPick, translate(err)
+}
+
+func (r *draftRepository) HeroPickExists(ctx context.Context, matchTeamDetailID, heroID, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.HeroPick{}).
+		Where("match_team_detail_id = ? AND hero_id = ? AND hero_pick_id != ?", matchTeamDetailID, heroID, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *draftRepository) FindHeroPicks(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroPickResponseDto, error) {
+	return r.findHeroPicks(ctx, heroPickQuery, matchID, teamID, patchID)
+}
+
+func (r *draftRepository) FindHeroPicksInFirstPhase(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroPickResponseDto, error) {
+	return r.findHeroPicks(ctx, heroPickQuery+" AND hp.first_phase > 0", matchID, teamID, patchID)
+}
+
+func (r *draftRepository) findHeroPicks(ctx context.Context, query string, matchID, teamID uint, patchID *uint) ([]dto.HeroPickResponseDto, error) {
+	db := r.db.WithContext(ctx)
+	args := []interface{}{matchID, teamID}
+	games := db.Model(&models.HeroPickGame{})
+	if patchID != nil {
+		patchGames := r.db.Model(&models.Game{}).Select("game_id").Where("patch_id = ?", *patchID)
+		query += " AND hp.hero_pick_id IN (SELECT hero_pick_id FROM hero_pick_games WHERE is_picked = ? AND game_id IN (?))"
+		args = append(args, true, patchGames)
+		games = games.Where("game_id IN (?)", patchGames)
+	}
+
+	picks := []dto.HeroPickResponseDto{}
+	if err := db.Raw(query, args...).Scan(&picks).Error; err != nil {
+		return nil, err
+	}
+
+	// Ambil status pick di setiap game untuk masing-masing hero pick
+	for i := range picks {
+		if err := games.Session(&gorm.Session{}).
+			Select("hero_pick_game_id, hero_pick_id, game_number, is_picked, game_id").
+			Where("hero_pick_id = ?", picks[i].HeroPickID).
+			Scan(&picks[i].HeroPickGame).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return picks, nil
+}
+
+func (r *draftRepository) CreateHeroPick(ctx context.Context, heroPick *models.HeroPick, games []models.HeroPickGame, check DraftCheck) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkSeries(tx, heroPick.MatchTeamDetailID, check); err != nil {
+			return err
+		}
+		if err := tx.Create(heroPick).Error; err != nil {
+			return err
+		}
+
+		for _, game := range games {
+			game.HeroPickID = heroPick.HeroPickID
+			if err := tx.Create(&game).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *draftRepository) UpdateHeroPick(ctx context.Context, heroPick *models.HeroPick, games []models.HeroPickGame, check DraftCheck) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkSeries(tx, heroPick.MatchTeamDetailID, check); err != nil {
+			return err
+		}
+		if err := tx.Save(heroPick).Error; err != nil {
+			return err
+		}
+
+		for _, game := range games {
+			var heroPickGame models.HeroPickGame
+			err := tx.Where("hero_pick_id = ? AND game_number = ?", heroPick.HeroPickID, game.GameNumber).First(&heroPickGame).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				// Buat entri baru jika tidak ada
+				game.HeroPickID = heroPick.HeroPickID
+				if err := tx.Create(&game).Error; err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			default:
+				heroPickGame.IsPicked = game.IsPicked
+				if err := tx.Save(&heroPickGame).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *draftRepository) DeleteHeroPick(ctx context.Context, heroPick models.HeroPick) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("hero_pick_id = ?", heroPick.HeroPickID).Delete(&models.HeroPickGame{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&heroPick).Error
+	})
+}
+
+func (r *draftRepository) FindHeroBan(ctx context.Context, matchTeamDetailID, heroBanID uint) (models.HeroBan, error) {
+	var heroBan models.HeroBan
+	err := r.db.WithContext(ctx).Where("match_team_detail_id = ? AND hero_ban_id = ?", matchTeamDetailID, heroBanID).First(&heroBan).Error
+	return heroBan, translate(err)
+}
+
+func (r *draftRepository) HeroBanExists(ctx context.Context, matchTeamDetailID, heroID, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.HeroBan{}).
+		Where("match_team_detail_id = ? AND hero_id = ? AND hero_ban_id != ?", matchTeamDetailID, heroID, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *draftRepository) FindHeroBans(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroBanResponseDto, error) {
+	return r.findHeroBans(ctx, heroBanQuery, matchID, teamID, patchID)
+}
+
+func (r *draftRepository) FindHeroBansInFirstPhase(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroBanResponseDto, error) {
+	return r.findHeroBans(ctx, heroBanQuery+" AND hb.first_phase > 0", matchID, teamID, patchID)
+}
+
+func (r *draftRepository) findHeroBans(ctx context.Context, query string, matchID, teamID uint, patchID *uint) ([]dto.HeroBanResponseDto, error) {
+	db := r.db.WithContext(ctx)
+	args := []interface{}{matchID, teamID}
+	games := db.Model(&models.HeroBanGame{})
+	if patchID != nil {
+		patchGames := r.db.Model(&models.Game{}).Select("game_id").Where("patch_id = ?", *patchID)
+		query += " AND hb.hero_ban_id IN (SELECT hero_ban_id FROM hero_ban_games WHERE is_banned = ? AND game_id IN (?))"
+		args = append(args, true, patchGames)
+		games = games.Where("game_id IN (?)", patchGames)
+	}
+
+	bans := []dto.HeroBanResponseDto{}
+	if err := db.Raw(query, args...).Scan(&bans).Error; err != nil {
+		return nil, err
+	}
+
+	// Ambil status ban di setiap game untuk masing-masing hero ban
+	for i := range bans {
+		if err := games.Session(&gorm.Session{}).
+			Select("hero_ban_game_id, hero_ban_id, game_number, is_banned, game_id").
+			Where("hero_ban_id = ?", bans[i].HeroBanID).
+			Scan(&bans[i].HeroBanGame).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return bans, nil
+}
+
+func (r *draftRepository) CreateHeroBan(ctx context.Context, heroBan *models.HeroBan, games []models.HeroBanGame, check DraftCheck) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkSeries(tx, heroBan.MatchTeamDetailID, check); err != nil {
+			return err
+		}
+		if err := tx.Create(heroBan).Error; err != nil {
+			return err
+		}
+
+		for _, game := range games {
+			game.HeroBanID = heroBan.HeroBanID
+			if err := tx.Create(&game).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *draftRepository) UpdateHeroBan(ctx context.Context, heroBan *models.HeroBan, games []models.HeroBanGame, check DraftCheck) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkSeries(tx, heroBan.MatchTeamDetailID, check); err != nil {
+			return err
+		}
+		if err := tx.Save(heroBan).Error; err != nil {
+			return err
+		}
+
+		for _, game := range games {
+			var heroBanGame models.HeroBanGame
+			err := tx.Where("hero_ban_id = ? AND game_number = ?", heroBan.HeroBanID, game.GameNumber).First(&heroBanGame).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				// Buat entri baru jika tidak ada
+				game.HeroBanID = heroBan.HeroBanID
+				if err := tx.Create(&game).Error; err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			default:
+				heroBanGame.IsBanned = game.IsBanned
+				if err := tx.Save(&heroBanGame).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *draftRepository) DeleteHeroBan(ctx context.Context, heroBan models.HeroBan) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("hero_ban_id = ?", heroBan.HeroBanID).Delete(&models.HeroBanGame{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&heroBan).Error
+	})
+}
+
+const seriesDraftQuery = `
+	SELECT
+		false AS ban, hp.hero_pick_id AS entry_id, mtd.team_id, hp.hero_id,
+		hpg.game_number, hp.first_phase, hp.second_phase
+	FROM hero_picks hp
+	JOIN match_team_details mtd ON hp.match_team_detail_id = mtd.match_team_detail_id
+	JOIN hero_pick_games hpg ON hpg.hero_pick_id = hp.hero_pick_id AND hpg.is_picked = true
+	WHERE mtd.match_id = ?
+	UNION ALL
+	SELECT
+		true AS ban, hb.hero_ban_id AS entry_id, mtd.team_id, hb.hero_id,
+		hbg.game_number, hb.first_phase, hb.second_phase
+	FROM hero_bans hb
+	JOIN match_team_details mtd ON hb.match_team_detail_id = mtd.match_team_detail_id
+	JOIN hero_ban_games hbg ON hbg.hero_ban_id = hb.hero_ban_id AND hbg.is_banned = true
+	WHERE mtd.match_id = ?
+`
+
+// checkSeries mengunci Match pemilik matchTeamDetailID lalu menjalankan
+// check terhadap semua pick dan ban di setiap Game Match tersebut. check nil
+// berarti tidak ada yang diperiksa.
+func checkSeries(tx *gorm.DB, matchTeamDetailID uint, check DraftCheck) error {
+	if check == nil {
+		return nil
+	}
+
+	var match models.Match
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("match_id = (SELECT match_id FROM match_team_details WHERE match_team_detail_id = ?)", matchTeamDetailID).
+		First(&match).Error
+	if err != nil {
+		return translate(err)
+	}
+
+	entries := []DraftEntry{}
+	if err := tx.Raw(seriesDraftQuery, match.MatchID, match.MatchID).Scan(&entries).Error; err != nil {
+		return err
+	}
+	return check(entries)
+}
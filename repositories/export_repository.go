@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"ml-master-data/dto"
+
+	"gorm.io/gorm"
+)
+
+// ExportRepository membaca isi workbook export sebuah Tournament. Match dan
+// Game yang sudah di-soft delete tidak ikut.
+type ExportRepository interface {
+	FindMatches(ctx context.Context, tournamentID uint) ([]dto.ExportMatchRow, error)
+	FindGames(ctx context.Context, tournamentID uint) ([]dto.ExportGameRow, error)
+	FindDrafts(ctx context.Context, tournamentID uint) ([]dto.ExportDraftRow, error)
+	FindHeroPicks(ctx context.Context, tournamentID uint) ([]dto.ExportHeroDraftRow, error)
+	FindHeroBans(ctx context.Context, tournamentID uint) ([]dto.ExportHeroDraftRow, error)
+	FindPriorityPicks(ctx context.Context, tournamentID uint) ([]dto.ExportPriorityRow, error)
+	FindPriorityBans(ctx context.Context, tournamentID uint) ([]dto.ExportPriorityRow, error)
+	FindFlexPicks(ctx context.Context, tournamentID uint) ([]dto.ExportPriorityRow, error)
+	FindLaneResults(ctx context.Context, tournamentID uint) ([]dto.ExportLaneRow, error)
+	FindObjectives(ctx context.Context, tournamentID uint) ([]dto.ExportObjectiveRow, error)
+}
+
+type exportRepository struct {
+	db *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) ExportRepository {
+	return &exportRepository{db: db}
+}
+
+const exportMatchQuery = `
+	SELECT
+		m.match_id, m.stage, m.day, m.date, m.played_at,
+		ta.name AS team_a, tb.name AS team_b, m.team_a_score, m.team_b_score
+	FROM matches m
+	JOIN teams ta ON ta.team_id = m.team_a_id
+	JOIN teams tb ON tb.team_id = m.team_b_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL
+	ORDER BY m.match_id
+`
+
+const exportGameQuery = `
+	SELECT
+		m.match_id, m.stage, g.game_number, p.version AS patch,
+		fp.name AS first_pick_team, sp.name AS second_pick_team, w.name AS winner_team,
+		g.video_link
+	FROM games g
+	JOIN matches m ON m.match_id = g.match_id
+	JOIN teams fp ON fp.team_id = g.first_pick_team_id
+	JOIN teams sp ON sp.team_id = g.second_pick_team_id
+	JOIN teams w ON w.team_id = g.winner_team_id
+	LEFT JOIN patches p ON p.patch_id = g.patch_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL AND g.deleted_at IS NULL
+	ORDER BY m.match_id, g.game_number
+`
+
+// Kolom SELECT pertama pada query UNION diberi alias agar ORDER BY bisa
+// memakainya di semua database.
+const exportDraftQuery = `
+	SELECT m.match_id AS match_id, m.stage AS stage, hpg.game_number AS game_number, t.name AS team, 'pick' AS action, h.name AS hero
+	FROM hero_picks hp
+	JOIN hero_pick_games hpg ON hpg.hero_pick_id = hp.hero_pick_id AND hpg.is_picked = true
+	JOIN match_team_details mtd ON mtd.match_team_detail_id = hp.match_team_detail_id
+	JOIN matches m ON m.match_id = mtd.match_id
+	JOIN teams t ON t.team_id = mtd.team_id
+	JOIN heros h ON h.hero_id = hp.hero_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL
+	UNION ALL
+	SELECT m.match_id, m.stage, hbg.game_number, t.name AS team, 'ban' AS action, h.name AS hero
+	FROM hero_bans hb
+	JOIN hero_ban_games hbg ON hbg.hero_ban_id = hb.hero_ban_id AND hbg.is_banned = true
+	JOIN match_team_details mtd ON mtd.match_team_detail_id = hb.match_team_detail_id
+	JOIN matches m ON m.match_id = mtd.match_id
+	JOIN teams t ON t.team_id = mtd.team_id
+	JOIN heros h ON h.hero_id = hb.hero_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL
+	ORDER BY match_id, game_number, team, action DESC, hero
+`
+
+// exportHeroDraftQuery dipakai untuk hero_picks dan hero_bans, yang punya
+// kolom sama.
+const exportHeroDraftQuery = `
+	SELECT m.match_id, m.stage, t.name AS team, h.name AS hero, x.first_phase, x.second_phase, x.total
+	FROM %s x
+	JOIN match_team_details mtd ON mtd.match_team_detail_id = x.match_team_detail_id
+	JOIN matches m ON m.match_id = mtd.match_id
+	JOIN teams t ON t.team_id = mtd.team_id
+	JOIN heros h ON h.hero_id = x.hero_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL
+	ORDER BY m.match_id, t.name, h.name
+`
+
+// exportPriorityQuery dipakai untuk priority_picks, priority_bans dan
+// flex_picks; kolom rate-nya berbeda nama.
+const exportPriorityQuery = `
+	SELECT m.match_id, m.stage, t.name AS team, h.name AS hero, x.role, x.total, x.%s AS rate
+	FROM %s x
+	JOIN match_team_details mtd ON mtd.match_team_detail_id = x.match_team_detail_id
+	JOIN matches m ON m.match_id = mtd.match_id
+	JOIN teams t ON t.team_id = mtd.team_id
+	JOIN heros h ON h.hero_id = x.hero_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL
+	ORDER BY m.match_id, t.name, x.total DESC, h.name
+`
+
+const exportLaneQuery = `
+	SELECT m.match_id AS match_id, m.stage AS stage, g.game_number AS game_number, t.name AS team, 'explaner' AS lane, '' AS role, h.name AS hero, x.early_result AS early_result
+	FROM explaners x
+	JOIN games g ON g.game_id = x.game_id
+	JOIN matches m ON m.match_id = g.match_id
+	JOIN teams t ON t.team_id = x.team_id
+	JOIN heros h ON h.hero_id = x.hero_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL AND g.deleted_at IS NULL
+	UNION ALL
+	SELECT m.match_id, m.stage, g.game_number, t.name AS team, 'goldlaner' AS lane, '' AS role, h.name AS hero, x.early_result
+	FROM goldlaners x
+	JOIN games g ON g.game_id = x.game_id
+	JOIN matches m ON m.match_id = g.match_id
+	JOIN teams t ON t.team_id = x.team_id
+	JOIN heros h ON h.hero_id = x.hero_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL AND g.deleted_at IS NULL
+	UNION ALL
+	SELECT m.match_id, m.stage, g.game_number, t.name AS team, 'trio_mid' AS lane, tmh.role, h.name AS hero, tmh.early_result
+	FROM trio_mid_heros tmh
+	JOIN trio_mids x ON x.trio_mid_id = tmh.trio_mid_id
+	JOIN games g ON g.game_id = x.game_id
+	JOIN matches m ON m.match_id = g.match_id
+	JOIN teams t ON t.team_id = x.team_id
+	JOIN heros h ON h.hero_id = tmh.hero_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL AND g.deleted_at IS NULL
+	ORDER BY match_id, game_number, team, lane, role
+`
+
+const exportObjectiveQuery = `
+	SELECT m.match_id AS match_id, m.stage AS stage, g.game_number AS game_number, t.name AS team, 'lord' AS objective, x.phase AS phase, x.setup AS setup, x.initiate AS initiate, x.result AS result
+	FROM lord_results x
+	JOIN games g ON g.game_id = x.game_id
+	JOIN matches m ON m.match_id = g.match_id
+	JOIN teams t ON t.team_id = x.team_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL AND g.deleted_at IS NULL
+	UNION ALL
+	SELECT m.match_id, m.stage, g.game_number, t.name AS team, 'turtle' AS objective, x.phase, x.setup, x.initiate, x.result
+	FROM turtle_results x
+	JOIN games g ON g.game_id = x.game_id
+	JOIN matches m ON m.match_id = g.match_id
+	JOIN teams t ON t.team_id = x.team_id
+	WHERE m.tournament_id = ? AND m.deleted_at IS NULL AND g.deleted_at IS NULL
+	ORDER BY match_id, game_number, objective, team, phase
+`
+
+func (r *exportRepository) FindMatches(ctx context.Context, tournamentID uint) ([]dto.ExportMatchRow, error) {
+	rows := []dto.ExportMatchRow{}
+	err := r.db.WithContext(ctx).Raw(exportMatchQuery, tournamentID).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *exportRepository) FindGames(ctx context.Context, tournamentID uint) ([]dto.ExportGameRow, error) {
+	rows := []dto.ExportGameRow{}
+	err := r.db.WithContext(ctx).Raw(exportGameQuery, tournamentID).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *exportRepository) FindDrafts(ctx context.Context, tournamentID uint) ([]dto.ExportDraftRow, error) {
+	rows := []dto.ExportDraftRow{}
+	err := r.db.WithContext(ctx).Raw(exportDraftQuery, tournamentID, tournamentID).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *exportRepository) FindHeroPicks(ctx context.Context, tournamentID uint) ([]dto.ExportHeroDraftRow, error) {
+	return r.findHeroDrafts(ctx, "hero_picks", tournamentID)
+}
+
+func (r *exportRepository) FindHeroBans(ctx context.Context, tournamentID uint) ([]dto.ExportHeroDraftRow, error) {
+	return r.findHeroDrafts(ctx, "hero_bans", tournamentID)
+}
+
+func (r *exportRepository) findHeroDrafts(ctx context.Context, table string, tournamentID uint) ([]dto.ExportHeroDraftRow, error) {
+	rows := []dto.ExportHeroDraftRow{}
+	err := r.db.WithContext(ctx).Raw(fmt.Sprintf(exportHeroDraftQuery, table), tournamentID).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *exportRepository) FindPriorityPicks(ctx context.Context, tournamentID uint) ([]dto.ExportPriorityRow, error) {
+	return r.findPriorities(ctx, "priority_picks", "pick_rate", tournamentID)
+}
+
+func (r *exportRepository) FindPriorityBans(ctx context.Context, tournamentID uint) ([]dto.ExportPriorityRow, error) {
+	return r.findPriorities(ctx, "priority_bans", "ban_rate", tournamentID)
+}
+
+func (r *exportRepository) FindFlexPicks(ctx context.Context, tournamentID uint) ([]dto.ExportPriorityRow, error) {
+	return r.findPriorities(ctx, "flex_picks", "pick_rate", tournamentID)
+}
+
+func (r *exportRepository) findPriorities(ctx context.Context, table, rate string, tournamentID uint) ([]dto.ExportPriorityRow, error) {
+	rows := []dto.ExportPriorityRow{}
+	err := r.db.WithContext(ctx).Raw(fmt.Sprintf(exportPriorityQuery, rate, table), tournamentID).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *exportRepository) FindLaneResults(ctx context.Context, tournamentID uint) ([]dto.ExportLaneRow, error) {
+	rows := []dto.ExportLaneRow{}
+	err := r.db.WithContext(ctx).Raw(exportLaneQuery, tournamentID, tournamentID, tournamentID).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *exportRepository) FindObjectives(ctx context.Context, tournamentID uint) ([]dto.ExportObjectiveRow, error) {
+	rows := []dto.ExportObjectiveRow{}
+	err := r.db.WithContext(ctx).Raw(exportObjectiveQuery, tournamentID, tournamentID).Scan(&rows).Error
+	return rows, err
+}
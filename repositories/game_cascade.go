@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"log"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// deleteGame melakukan soft delete pada Game. Total HeroPick dan HeroBan
+// dikurangi supaya statistik draft tidak lagi menghitung game ini, dan
+// dikembalikan lagi oleh restoreGame.
+func deleteGame(db *gorm.DB, game models.Game) error {
+	// Mulai transaksi
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := softDeleteGames(tx, []models.Game{game}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	log.Printf("Game with ID %d has been soft deleted.", game.GameID)
+	return nil
+}
+
+func softDeleteGames(tx *gorm.DB, games []models.Game) error {
+	for _, game := range games {
+		if err := adjustDraftTotals(tx, game, -1); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.Game{}, game.GameID).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// adjustDraftTotals menambah/mengurangi Total HeroPick dan HeroBan untuk
+// hero yang di-pick/ban pada game ini.
+func adjustDraftTotals(tx *gorm.DB, game models.Game, delta int) error {
+	// update heropick
+	var heroPickGames []models.HeroPickGame
+	if err := tx.Where("game_number = ? AND game_id = ?", game.GameNumber, game.GameID).Find(&heroPickGames).Error; err != nil {
+		return err
+	}
+
+	for _, heroPickGame := range heroPickGames {
+		if heroPickGame.IsPicked {
+			heroPick := models.HeroPick{}
+
+			if err := tx.Where("hero_pick_id = ?", heroPickGame.HeroPickID).First(&heroPick).Error; err != nil {
+				return err
+			}
+
+			heroPick.Total = heroPick.Total + delta
+			if err := tx.Save(&heroPick).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	// update heroban
+	var heroBanGames []models.HeroBanGame
+	if err := tx.Where("game_number = ? AND game_id = ?", game.GameNumber, game.GameID).Find(&heroBanGames).Error; err != nil {
+		return err
+	}
+
+	for _, heroBanGame := range heroBanGames {
+		if heroBanGame.IsBanned {
+			heroBan := models.HeroBan{}
+
+			if err := tx.Where("hero_ban_id = ?", heroBanGame.HeroBanID).First(&heroBan).Error; err != nil {
+				return err
+			}
+
+			heroBan.Total = heroBan.Total + delta
+			if err := tx.Save(&heroBan).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// purgeGame menghapus permanen Game yang sudah di-soft delete beserta semua
+// data turunannya dan gambar draft-nya. Total HeroPick/HeroBan sudah
+// disesuaikan saat soft delete sehingga tidak diubah lagi di sini.
+func purgeGame(db *gorm.DB, files storage.Storage, game models.Game) error {
+	// Mulai transaksi
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Hapus TrioMidHero terkait
+	if err := tx.Where("trio_mid_id IN (SELECT trio_mid_id FROM trio_mids WHERE game_id = ?)", game.GameID).Delete(&models.TrioMidHero{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus TrioMid terkait
+	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.TrioMid{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus Goldlaner terkait
+	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.Goldlaner{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus Explaner terkait
+	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.Explaner{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus LordResult terkait
+	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.LordResult{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus TurtleResult terkait
+	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.TurtleResult{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus GameResult terkait
+	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.GameResult{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus HeroPickGame terkait
+	if err := tx.Where("game_number = ? AND game_id = ?", game.GameNumber, game.GameID).Delete(&models.HeroPickGame{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus HeroBanGame terkait
+	if err := tx.Where("game_number = ? AND game_id = ?", game.GameNumber, game.GameID).Delete(&models.HeroBanGame{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus Game itu sendiri
+	if err := tx.Unscoped().Delete(&models.Game{}, game.GameID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	if err := removeImage(db, files, game.FullDraftImage); err != nil {
+		return err
+	}
+
+	log.Printf("Game with ID %d and all related records have been purged.", game.GameID)
+	return nil
+}
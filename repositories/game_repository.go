@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// GameRepository menyimpan dan membaca Game dalam sebuah Match.
+type GameRepository interface {
+	// FindByID mengembalikan Game hanya jika berada di Match matchID.
+	FindByID(ctx context.Context, matchID, gameID uint) (models.Game, error)
+	// FindByGameID mengembalikan Game tanpa memeriksa Match-nya, untuk
+	// endpoint yang hanya menerima game ID.
+	FindByGameID(ctx context.Context, gameID uint) (models.Game, error)
+	// FindDetail mengembalikan Game lengkap dengan data ketiga tim.
+	FindDetail(ctx context.Context, matchID, gameID uint) (dto.GameResponseDto, error)
+	FindByMatch(ctx context.Context, matchID uint) ([]dto.GameResponseDto, error)
+	// ExistsUnscoped mengecek keberadaan Game di Match, termasuk yang sudah
+	// di-soft delete.
+	ExistsUnscoped(ctx context.Context, matchID, gameID uint) (bool, error)
+	Create(ctx context.Context, game *models.Game) error
+	Update(ctx context.Context, game *models.Game) error
+	// Delete melakukan soft delete pada Game dan mengurangi total draft.
+	Delete(ctx context.Context, game models.Game) error
+	Restore(ctx context.Context, gameID uint) error
+	// PurgeDeletedBefore menghapus permanen Game yang di-soft delete sebelum t
+	// dan mengembalikan jumlahnya.
+	PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+type gameRepository struct {
+	db    *gorm.DB
+	files storage.Storage
+}
+
+// NewGameRepository membuat GameRepository; gambar di files ikut
+// dihapus saat datanya dihapus permanen.
+func NewGameRepository(db *gorm.DB, files storage.Storage) GameRepository {
+	return &gameRepository{db: db, files: files}
+}
+
+const gameDetailQuery = `
+	SELECT 
+		g.game_id, g.match_id, g.first_pick_team_id, 
+		t1.team_id AS first_team_team_id, t1.name AS first_team_name, t1.image AS first_team_image,
+		g.second_pick_team_id, 
+		t2.team_id AS second_team_team_id, t2.name AS second_team_name, t2.image AS second_team_image,
+		g.winner_team_id, 
+		t3.team_id AS winner_team_team_id, t3.name AS winner_team_name, t3.image AS winner_team_image,
+		g.game_number, g.video_link, g.full_draft_image,
+		g.patch_id, p.version AS patch_version
+	FROM games g
+	JOIN teams t1 ON g.first_pick_team_id = t1.team_id
+	JOIN teams t2 ON g.second_pick_team_id = t2.team_id
+	JOIN teams t3 ON g.winner_team_id = t3.team_id
+	LEFT JOIN patches p ON g.patch_id = p.patch_id
+`
+
+func (r *gameRepository) FindByID(ctx context.Context, matchID, gameID uint) (models.Game, error) {
+	var game models.Game
+	err := r.db.WithContext(ctx).Where("match_id = ?", matchID).First(&game, gameID).Error
+	return game, translate(err)
+}
+
+func (r *gameRepository) FindByGameID(ctx context.Context, gameID uint) (models.Game, error) {
+	var game models.Game
+	err := r.db.WithContext(ctx).First(&game, gameID).Error
+	return game, translate(err)
+}
+
+func (r *gameRepository) FindDetail(ctx context.Context, matchID, gameID uint) (dto.GameResponseDto, error) {
+	var game dto.GameResponseDto
+
+	result := r.db.WithContext(ctx).Raw(gameDetailQuery+"WHERE g.match_id = ? AND g.game_id = ? AND g.deleted_at IS NULL", matchID, gameID).Scan(&game)
+	if result.Error != nil {
+		return game, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return game, ErrNotFound
+	}
+
+	return game, nil
+}
+
+func (r *gameRepository) FindByMatch(ctx context.Context, matchID uint) ([]dto.GameResponseDto, error) {
+	games := []dto.GameResponseDto{}
+	err := r.db.WithContext(ctx).Raw(gameDetailQuery+"WHERE g.match_id = ? AND g.deleted_at IS NULL", matchID).Scan(&games).Error
+	return games, err
+}
+
+func (r *gameRepository) ExistsUnscoped(ctx context.Context, matchID, gameID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.Game{}).Where("game_id = ? AND match_id = ?", gameID, matchID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gameRepository) Create(ctx context.Context, game *models.Game) error {
+	return r.db.WithContext(ctx).Create(game).Error
+}
+
+func (r *gameRepository) Update(ctx context.Context, game *models.Game) error {
+	return r.db.WithContext(ctx).Save(game).Error
+}
+
+func (r *gameRepository) Delete(ctx context.Context, game models.Game) error {
+	return deleteGame(r.db.WithContext(ctx), game)
+}
+
+func (r *gameRepository) Restore(ctx context.Context, gameID uint) error {
+	return translate(restoreGame(r.db.WithContext(ctx), gameID))
+}
+
+func (r *gameRepository) PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error) {
+	db := r.db.WithContext(ctx)
+
+	var games []models.Game
+	if err := deletedBefore(db, t).Find(&games).Error; err != nil {
+		return 0, err
+	}
+	for i, game := range games {
+		if err := purgeGame(db, r.files, game); err != nil {
+			return i, err
+		}
+	}
+
+	return len(games), nil
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"fmt"
+	"log"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// Fungsi untuk menghapus Hero dan semua relasi terkait
+func deleteHero(db *gorm.DB, files storage.Storage, hero models.Hero) error {
+	// Mulai transaksi
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	//hapus priority pick
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.PriorityPick{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus PriorityPick: %w", err)
+	}
+
+	// hapus priorityban
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.PriorityBan{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus PriorityBan: %w", err)
+	}
+
+	// hapus flexpick
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.FlexPick{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus FlexPick: %w", err)
+	}
+
+	// 1. Hapus HeroPickGame terkait
+	if err := tx.Where("hero_pick_id IN (SELECT hero_pick_id FROM hero_picks WHERE hero_id = ?)", hero.HeroID).Delete(&models.HeroPickGame{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// hapus heropikc
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.HeroPick{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus HeroPick: %w", err)
+	}
+
+	//  hapus herobangame
+	if err := tx.Where("hero_ban_id IN (SELECT hero_ban_id FROM hero_bans WHERE hero_id = ?)", hero.HeroID).Delete(&models.HeroBanGame{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// hapus heroban
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.HeroBan{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus HeroBan: %w", err)
+	}
+
+	// hapus explaner
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.Explaner{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus Explaner: %w", err)
+	}
+
+	// hapus goldlaner
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.Goldlaner{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus Goldlaner: %w", err)
+	}
+
+	// hapus triomidhero
+	type trioMidID struct {
+		TrioMidID uint
+	}
+
+	trioMidIDs := []trioMidID{}
+	if err := tx.Raw("SELECT trio_mid_id FROM trio_mid_heros WHERE hero_id = ?", hero.HeroID).Scan(&trioMidIDs).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal mengambil trioMidID: %w", err)
+	}
+
+	// hapus trioMidHero
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.TrioMidHero{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus TrioMidHero: %w", err)
+	}
+
+	for _, trioMidID := range trioMidIDs {
+		var trioMidHeroCount int64
+		if err := tx.Model(&models.TrioMidHero{}).Where("trio_mid_id = ?", trioMidID.TrioMidID).Count(&trioMidHeroCount).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("gagal menghitung trioMidHeroCount: %w", err)
+		}
+		if trioMidHeroCount == 0 {
+			// hapus trioMid
+			if err := tx.Where("trio_mid_id = ?", trioMidID.TrioMidID).Delete(&models.TrioMid{}).Error; err != nil {
+				tx.Rollback()
+				return fmt.Errorf("gagal menghapus TrioMid: %w", err)
+			}
+		}
+
+	}
+
+	// Hapus hero dari daftar hero yang dinonaktifkan tournament
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.TournamentDisabledHero{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus TournamentDisabledHero: %w", err)
+	}
+
+	// Hapus lane dan alias hero
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.HeroLane{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus HeroLane: %w", err)
+	}
+	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.HeroAlias{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus HeroAlias: %w", err)
+	}
+
+	// 6. Hapus Hero itu sendiri
+	if err := tx.Delete(&models.Hero{}, hero.HeroID).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus Hero: %w", err)
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+
+	if err := removeImage(db, files, hero.Image); err != nil {
+		return err
+	}
+
+	log.Printf("Hero dengan ID %d dan semua data terkait telah dihapus.", hero.HeroID)
+	return nil
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HeroFilter menyaring hasil FindAll; field kosong tidak menyaring.
+type HeroFilter struct {
+	// Class cocok dengan kelas utama atau kelas kedua hero.
+	Class string
+	Lane  string
+	// Search mencari potongan nama atau alias tanpa membedakan huruf besar.
+	Search         string
+	ReleasedAfter  *time.Time
+	ReleasedBefore *time.Time
+}
+
+// HeroRepository menyimpan dan membaca Hero.
+type HeroRepository interface {
+	FindAll(ctx context.Context, filter HeroFilter) ([]models.Hero, error)
+	FindByID(ctx context.Context, heroID uint) (models.Hero, error)
+	// FindByName mencari hero dengan nama atau alias name, tanpa membedakan
+	// huruf besar.
+	FindByName(ctx context.Context, name string) ([]models.Hero, error)
+	// Create dan Update juga menyimpan Lanes dan Aliases; isi lama diganti.
+	Create(ctx context.Context, hero *models.Hero) error
+	Update(ctx context.Context, hero *models.Hero) error
+	// Delete menghapus Hero beserta semua draft dan statistik yang memakainya.
+	Delete(ctx context.Context, hero models.Hero) error
+}
+
+type heroRepository struct {
+	db    *gorm.DB
+	files storage.Storage
+}
+
+// NewHeroRepository membuat HeroRepository; gambar di files ikut
+// dihapus saat datanya dihapus permanen.
+func NewHeroRepository(db *gorm.DB, files storage.Storage) HeroRepository {
+	return &heroRepository{db: db, files: files}
+}
+
+// withMetadata memuat Lanes dan Aliases hero.
+func withMetadata(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Lanes").
+		Preload("Aliases", func(db *gorm.DB) *gorm.DB { return db.Order("hero_alias_id") })
+}
+
+func (r *heroRepository) FindAll(ctx context.Context, filter HeroFilter) ([]models.Hero, error) {
+	query := withMetadata(r.db.WithContext(ctx))
+	if filter.Class != "" {
+		query = query.Where("primary_class = ? OR secondary_class = ?", filter.Class, filter.Class)
+	}
+	if filter.Lane != "" {
+		query = query.Where("hero_id IN (?)", r.db.Model(&models.HeroLane{}).Select("hero_id").Where("lane = ?", filter.Lane))
+	}
+	if filter.Search != "" {
+		pattern := "%" + strings.ToLower(filter.Search) + "%"
+		aliases := r.db.Model(&models.HeroAlias{}).Select("hero_id").Where("LOWER(alias) LIKE ?", pattern)
+		query = query.Where("LOWER(name) LIKE ? OR hero_id IN (?)", pattern, aliases)
+	}
+	if filter.ReleasedAfter != nil {
+		query = query.Where("release_date >= ?", *filter.ReleasedAfter)
+	}
+	if filter.ReleasedBefore != nil {
+		query = query.Where("release_date <= ?", *filter.ReleasedBefore)
+	}
+
+	heroes := []models.Hero{}
+	err := query.Order("hero_id").Find(&heroes).Error
+	return heroes, err
+}
+
+func (r *heroRepository) FindByID(ctx context.Context, heroID uint) (models.Hero, error) {
+	var hero models.Hero
+	err := withMetadata(r.db.WithContext(ctx)).First(&hero, heroID).Error
+	return hero, translate(err)
+}
+
+func (r *heroRepository) FindByName(ctx context.Context, name string) ([]models.Hero, error) {
+	name = strings.ToLower(name)
+	aliases := r.db.Model(&models.HeroAlias{}).Select("hero_id").Where("LOWER(alias) = ?", name)
+	heroes := []models.Hero{}
+	err := withMetadata(r.db.WithContext(ctx)).
+		Where("LOWER(name) = ? OR hero_id IN (?)", name, aliases).
+		Order("hero_id").
+		Find(&heroes).Error
+	return heroes, err
+}
+
+func (r *heroRepository) Create(ctx context.Context, hero *models.Hero) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(hero).Error; err != nil {
+			return err
+		}
+		return saveHeroMetadata(tx, hero)
+	})
+}
+
+func (r *heroRepository) Update(ctx context.Context, hero *models.Hero) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(hero).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.HeroLane{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.HeroAlias{}).Error; err != nil {
+			return err
+		}
+		return saveHeroMetadata(tx, hero)
+	})
+}
+
+// saveHeroMetadata menulis Lanes dan Aliases hero yang sudah punya HeroID.
+func saveHeroMetadata(tx *gorm.DB, hero *models.Hero) error {
+	for i := range hero.Lanes {
+		hero.Lanes[i].HeroID = hero.HeroID
+	}
+	for i := range hero.Aliases {
+		hero.Aliases[i].HeroAliasID = 0
+		hero.Aliases[i].HeroID = hero.HeroID
+	}
+	if len(hero.Lanes) > 0 {
+		if err := tx.Create(&hero.Lanes).Error; err != nil {
+			return err
+		}
+	}
+	if len(hero.Aliases) > 0 {
+		if err := tx.Create(&hero.Aliases).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *heroRepository) Delete(ctx context.Context, hero models.Hero) error {
+	return deleteHero(r.db.WithContext(ctx), r.files, hero)
+}
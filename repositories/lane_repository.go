@@ -0,0 +1,283 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// LaneRepository menyimpan hero dan hasil early game setiap lane sebuah tim
+// di Game: explaner, goldlaner dan trio mid.
+type LaneRepository interface {
+	// FindExplaner mengembalikan Explaner hanya jika berada di Game gameID.
+	FindExplaner(ctx context.Context, gameID, explanerID uint) (models.Explaner, error)
+	FindExplanerDetail(ctx context.Context, gameID, teamID, explanerID uint) (dto.ExplanerResponseDto, error)
+	FindExplaners(ctx context.Context, gameID, teamID uint) ([]dto.ExplanerResponseDto, error)
+	// ExplanerExists mengecek apakah hero sudah menjadi explaner tim di
+	// Game, tanpa menghitung Explaner excludeID.
+	ExplanerExists(ctx context.Context, gameID, teamID, heroID, excludeID uint) (bool, error)
+	CreateExplaner(ctx context.Context, explaner *models.Explaner) error
+	UpdateExplaner(ctx context.Context, explaner *models.Explaner) error
+	DeleteExplaner(ctx context.Context, explaner models.Explaner) error
+
+	FindGoldlaner(ctx context.Context, gameID, goldlanerID uint) (models.Goldlaner, error)
+	FindGoldlanerDetail(ctx context.Context, gameID, teamID, goldlanerID uint) (dto.GoldlanerResponseDto, error)
+	FindGoldlaners(ctx context.Context, gameID, teamID uint) ([]dto.GoldlanerResponseDto, error)
+	GoldlanerExists(ctx context.Context, gameID, teamID, heroID, excludeID uint) (bool, error)
+	CreateGoldlaner(ctx context.Context, goldlaner *models.Goldlaner) error
+	UpdateGoldlaner(ctx context.Context, goldlaner *models.Goldlaner) error
+	DeleteGoldlaner(ctx context.Context, goldlaner models.Goldlaner) error
+
+	// FindTrioMid mengembalikan TrioMid milik teamID di Game gameID.
+	FindTrioMid(ctx context.Context, gameID, teamID, trioMidID uint) (models.TrioMid, error)
+	// FindTrioMidInGame mengembalikan TrioMid trioMidID tanpa melihat timnya.
+	FindTrioMidInGame(ctx context.Context, gameID, trioMidID uint) (models.TrioMid, error)
+	FindTrioMidDetail(ctx context.Context, gameID, trioMidID uint) (dto.TrioMidResponseDto, error)
+	// FindTrioMids mengembalikan semua hero trio mid di Game gameID.
+	FindTrioMids(ctx context.Context, gameID uint) ([]dto.TrioMidResponseDto, error)
+	UpdateTrioMid(ctx context.Context, trioMid *models.TrioMid) error
+
+	// FindTrioMidHero mengembalikan TrioMidHero hanya jika TrioMid-nya berada
+	// di Game gameID.
+	FindTrioMidHero(ctx context.Context, gameID, trioMidHeroID uint) (models.TrioMidHero, error)
+	// TrioMidHeroExists mengecek apakah hero sudah ada di trio mid tim di
+	// Game, tanpa menghitung TrioMidHero excludeID.
+	TrioMidHeroExists(ctx context.Context, gameID, teamID, heroID, excludeID uint) (bool, error)
+	// CreateTrioMidHero menyimpan hero ke TrioMid tim di Game, dan membuat
+	// TrioMid-nya jika belum ada.
+	CreateTrioMidHero(ctx context.Context, gameID, teamID uint, hero *models.TrioMidHero) error
+	UpdateTrioMidHero(ctx context.Context, hero *models.TrioMidHero) error
+	// DeleteTrioMidHero menghapus hero dari TrioMid, beserta TrioMid-nya jika
+	// tidak ada hero lain. trioMidDeleted bernilai true pada kasus kedua.
+	DeleteTrioMidHero(ctx context.Context, hero models.TrioMidHero) (trioMidDeleted bool, err error)
+
+	// FindEarlyResults mengembalikan hasil early game explaner, goldlaner
+	// dan trio mid tim di Game; trio mid yang belum diisi dilewati.
+	FindEarlyResults(ctx context.Context, gameID, teamID uint) ([]string, error)
+}
+
+type laneRepository struct {
+	db *gorm.DB
+}
+
+func NewLaneRepository(db *gorm.DB) LaneRepository {
+	return &laneRepository{db: db}
+}
+
+const explanerQuery = `
+	SELECT
+		e.explaner_id, e.game_id, e.team_id, e.hero_id, e.early_result,
+		t.team_id AS team_team_id, t.name AS team_name, t.image AS team_image,
+		h.hero_id AS hero_hero_id, h.name AS hero_name, h.image AS hero_image
+	FROM explaners e
+	JOIN teams t ON e.team_id = t.team_id
+	JOIN heros h ON e.hero_id = h.hero_id
+	WHERE e.game_id = ? AND e.team_id = ?
+`
+
+const goldlanerQuery = `
+	SELECT
+		g.goldlaner_id, g.game_id, g.team_id, g.hero_id, g.early_result,
+		t.team_id AS team_team_id, t.name AS team_name, t.image AS team_image,
+		h.hero_id AS hero_hero_id, h.name AS hero_name, h.image AS hero_image
+	FROM goldlaners g
+	JOIN teams t ON g.team_id = t.team_id
+	JOIN heros h ON g.hero_id = h.hero_id
+	WHERE g.game_id = ? AND g.team_id = ?
+`
+
+const trioMidQuery = `
+	SELECT
+		tm.trio_mid_id, tm.game_id,
+		tmh.trio_mid_hero_id, tmh.role, tmh.early_result,
+		t.team_id AS team_team_id, t.name AS team_name, t.image AS team_image,
+		th.hero_id AS hero_hero_id, th.name AS hero_name, th.image AS hero_image
+	FROM trio_mids tm
+	JOIN teams t ON tm.team_id = t.team_id
+	JOIN trio_mid_heros tmh ON tm.trio_mid_id = tmh.trio_mid_id
+	JOIN heros th ON tmh.hero_id = th.hero_id
+	WHERE tm.game_id = ?
+`
+
+func (r *laneRepository) FindExplaner(ctx context.Context, gameID, explanerID uint) (models.Explaner, error) {
+	var explaner models.Explaner
+	err := r.db.WithContext(ctx).Where("game_id = ?", gameID).First(&explaner, explanerID).Error
+	return explaner, translate(err)
+}
+
+func (r *laneRepository) FindExplanerDetail(ctx context.Context, gameID, teamID, explanerID uint) (dto.ExplanerResponseDto, error) {
+	var explaner dto.ExplanerResponseDto
+	return explaner, scanOne(r.db.WithContext(ctx).Raw(explanerQuery+"AND e.explaner_id = ?", gameID, teamID, explanerID), &explaner)
+}
+
+func (r *laneRepository) FindExplaners(ctx context.Context, gameID, teamID uint) ([]dto.ExplanerResponseDto, error) {
+	explaners := []dto.ExplanerResponseDto{}
+	err := r.db.WithContext(ctx).Raw(explanerQuery, gameID, teamID).Scan(&explaners).Error
+	return explaners, err
+}
+
+func (r *laneRepository) ExplanerExists(ctx context.Context, gameID, teamID, heroID, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Explaner{}).
+		Where("game_id = ? AND team_id = ? AND hero_id = ? AND explaner_id <> ?", gameID, teamID, heroID, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *laneRepository) CreateExplaner(ctx context.Context, explaner *models.Explaner) error {
+	return r.db.WithContext(ctx).Create(explaner).Error
+}
+
+func (r *laneRepository) UpdateExplaner(ctx context.Context, explaner *models.Explaner) error {
+	return r.db.WithContext(ctx).Save(explaner).Error
+}
+
+func (r *laneRepository) DeleteExplaner(ctx context.Context, explaner models.Explaner) error {
+	return r.db.WithContext(ctx).Delete(&explaner).Error
+}
+
+func (r *laneRepository) FindGoldlaner(ctx context.Context, gameID, goldlanerID uint) (models.Goldlaner, error) {
+	var goldlaner models.Goldlaner
+	err := r.db.WithContext(ctx).Where("game_id = ?", gameID).First(&goldlaner, goldlanerID).Error
+	return goldlaner, translate(err)
+}
+
+func (r *laneRepository) FindGoldlanerDetail(ctx context.Context, gameID, teamID, goldlanerID uint) (dto.GoldlanerResponseDto, error) {
+	var goldlaner dto.GoldlanerResponseDto
+	return goldlaner, scanOne(r.db.WithContext(ctx).Raw(goldlanerQuery+"AND g.goldlaner_id = ?", gameID, teamID, goldlanerID), &goldlaner)
+}
+
+func (r *laneRepository) FindGoldlaners(ctx context.Context, gameID, teamID uint) ([]dto.GoldlanerResponseDto, error) {
+	goldlaners := []dto.GoldlanerResponseDto{}
+	err := r.db.WithContext(ctx).Raw(goldlanerQuery, gameID, teamID).Scan(&goldlaners).Error
+	return goldlaners, err
+}
+
+func (r *laneRepository) GoldlanerExists(ctx context.Context, gameID, teamID, heroID, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Goldlaner{}).
+		Where("game_id = ? AND team_id = ? AND hero_id = ? AND goldlaner_id <> ?", gameID, teamID, heroID, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *laneRepository) CreateGoldlaner(ctx context.Context, goldlaner *models.Goldlaner) error {
+	return r.db.WithContext(ctx).Create(goldlaner).Error
+}
+
+func (r *laneRepository) UpdateGoldlaner(ctx context.Context, goldlaner *models.Goldlaner) error {
+	return r.db.WithContext(ctx).Save(goldlaner).Error
+}
+
+func (r *laneRepository) DeleteGoldlaner(ctx context.Context, goldlaner models.Goldlaner) error {
+	return r.db.WithContext(ctx).Delete(&goldlaner).Error
+}
+
+func (r *laneRepository) FindTrioMid(ctx context.Context, gameID, teamID, trioMidID uint) (models.TrioMid, error) {
+	var trioMid models.TrioMid
+	err := r.db.WithContext(ctx).Where("game_id = ? AND team_id = ?", gameID, teamID).First(&trioMid, trioMidID).Error
+	return trioMid, translate(err)
+}
+
+func (r *laneRepository) FindTrioMidInGame(ctx context.Context, gameID, trioMidID uint) (models.TrioMid, error) {
+	var trioMid models.TrioMid
+	err := r.db.WithContext(ctx).Where("game_id = ?", gameID).First(&trioMid, trioMidID).Error
+	return trioMid, translate(err)
+}
+
+func (r *laneRepository) FindTrioMidDetail(ctx context.Context, gameID, trioMidID uint) (dto.TrioMidResponseDto, error) {
+	var trioMid dto.TrioMidResponseDto
+	return trioMid, scanOne(r.db.WithContext(ctx).Raw(trioMidQuery+"AND tm.trio_mid_id = ?", gameID, trioMidID), &trioMid)
+}
+
+func (r *laneRepository) FindTrioMids(ctx context.Context, gameID uint) ([]dto.TrioMidResponseDto, error) {
+	trioMids := []dto.TrioMidResponseDto{}
+	err := r.db.WithContext(ctx).Raw(trioMidQuery, gameID).Scan(&trioMids).Error
+	return trioMids, err
+}
+
+func (r *laneRepository) UpdateTrioMid(ctx context.Context, trioMid *models.TrioMid) error {
+	return r.db.WithContext(ctx).Save(trioMid).Error
+}
+
+func (r *laneRepository) FindTrioMidHero(ctx context.Context, gameID, trioMidHeroID uint) (models.TrioMidHero, error) {
+	db := r.db.WithContext(ctx)
+
+	var hero models.TrioMidHero
+	err := db.Where("trio_mid_id IN (?)", db.Model(&models.TrioMid{}).Select("trio_mid_id").Where("game_id = ?", gameID)).
+		First(&hero, trioMidHeroID).Error
+	return hero, translate(err)
+}
+
+func (r *laneRepository) TrioMidHeroExists(ctx context.Context, gameID, teamID, heroID, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.TrioMidHero{}).
+		Joins("JOIN trio_mids ON trio_mids.trio_mid_id = trio_mid_heros.trio_mid_id").
+		Where("trio_mids.game_id = ? AND trio_mids.team_id = ? AND trio_mid_heros.hero_id = ? AND trio_mid_heros.trio_mid_hero_id <> ?", gameID, teamID, heroID, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *laneRepository) CreateTrioMidHero(ctx context.Context, gameID, teamID uint, hero *models.TrioMidHero) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var trioMid models.TrioMid
+		err := tx.Where("game_id = ? AND team_id = ?", gameID, teamID).First(&trioMid).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// TrioMid dibuat dengan EarlyResult kosong
+			trioMid = models.TrioMid{GameID: gameID, TeamID: teamID}
+			err = tx.Create(&trioMid).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		hero.TrioMidID = trioMid.TrioMidID
+		return tx.Create(hero).Error
+	})
+}
+
+func (r *laneRepository) UpdateTrioMidHero(ctx context.Context, hero *models.TrioMidHero) error {
+	return r.db.WithContext(ctx).Save(hero).Error
+}
+
+func (r *laneRepository) DeleteTrioMidHero(ctx context.Context, hero models.TrioMidHero) (bool, error) {
+	trioMidDeleted := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&hero).Error; err != nil {
+			return err
+		}
+
+		var remaining int64
+		if err := tx.Model(&models.TrioMidHero{}).Where("trio_mid_id = ?", hero.TrioMidID).Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return nil
+		}
+
+		trioMidDeleted = true
+		return tx.Delete(&models.TrioMid{}, hero.TrioMidID).Error
+	})
+	return trioMidDeleted, err
+}
+
+func (r *laneRepository) FindEarlyResults(ctx context.Context, gameID, teamID uint) ([]string, error) {
+	db := r.db.WithContext(ctx)
+
+	var results []string
+	for _, model := range []interface{}{&models.Explaner{}, &models.Goldlaner{}, &models.TrioMid{}} {
+		var lane []string
+		err := db.Model(model).Where("game_id = ? AND team_id = ? AND early_result IS NOT NULL", gameID, teamID).
+			Pluck("early_result", &lane).Error
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, lane...)
+	}
+
+	return results, nil
+}
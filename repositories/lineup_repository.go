@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// LineupRepository menyimpan pemain dan coach yang diturunkan sebuah tim di
+// Match, lewat MatchTeamDetail tim tersebut.
+type LineupRepository interface {
+	// FindPlayerMatch mengembalikan PlayerMatch pemain playerID di
+	// MatchTeamDetail.
+	FindPlayerMatch(ctx context.Context, matchTeamDetailID, playerID uint) (models.PlayerMatch, error)
+	FindPlayerMatches(ctx context.Context, matchID, teamID uint) ([]dto.PlayerMatchResponseDto, error)
+	CreatePlayerMatch(ctx context.Context, playerMatch *models.PlayerMatch) error
+	UpdatePlayerMatch(ctx context.Context, playerMatch *models.PlayerMatch) error
+	DeletePlayerMatch(ctx context.Context, playerMatch models.PlayerMatch) error
+
+	FindCoachMatch(ctx context.Context, matchTeamDetailID, coachID uint) (models.CoachMatch, error)
+	FindCoachMatches(ctx context.Context, matchID, teamID uint) ([]dto.CoachMatchResponseDto, error)
+	CreateCoachMatch(ctx context.Context, coachMatch *models.CoachMatch) error
+	UpdateCoachMatch(ctx context.Context, coachMatch *models.CoachMatch) error
+	DeleteCoachMatch(ctx context.Context, coachMatch models.CoachMatch) error
+}
+
+type lineupRepository struct {
+	db *gorm.DB
+}
+
+func NewLineupRepository(db *gorm.DB) LineupRepository {
+	return &lineupRepository{db: db}
+}
+
+func (r *lineupRepository) FindPlayerMatch(ctx context.Context, matchTeamDetailID, playerID uint) (models.PlayerMatch, error) {
+	var playerMatch models.PlayerMatch
+	err := r.db.WithContext(ctx).Where("match_team_detail_id = ? AND player_id = ?", matchTeamDetailID, playerID).First(&playerMatch).Error
+	return playerMatch, translate(err)
+}
+
+func (r *lineupRepository) FindPlayerMatches(ctx context.Context, matchID, teamID uint) ([]dto.PlayerMatchResponseDto, error) {
+	players := []dto.PlayerMatchResponseDto{}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			pm.player_match_id, pm.match_team_detail_id, pm.role,
+			p.player_id AS player_player_id, p.team_id AS player_team_id,
+			p.name AS player_name, p.image AS player_image
+		FROM player_matches pm
+		JOIN players p ON pm.player_id = p.player_id
+		JOIN match_team_details mtd ON pm.match_team_detail_id = mtd.match_team_detail_id
+		WHERE mtd.match_id = ? AND mtd.team_id = ?
+	`, matchID, teamID).Scan(&players).Error
+	return players, err
+}
+
+func (r *lineupRepository) CreatePlayerMatch(ctx context.Context, playerMatch *models.PlayerMatch) error {
+	return r.db.WithContext(ctx).Create(playerMatch).Error
+}
+
+func (r *lineupRepository) UpdatePlayerMatch(ctx context.Context, playerMatch *models.PlayerMatch) error {
+	return r.db.WithContext(ctx).Save(playerMatch).Error
+}
+
+func (r *lineupRepository) DeletePlayerMatch(ctx context.Context, playerMatch models.PlayerMatch) error {
+	return r.db.WithContext(ctx).Delete(&playerMatch).Error
+}
+
+func (r *lineupRepository) FindCoachMatch(ctx context.Context, matchTeamDetailID, coachID uint) (models.CoachMatch, error) {
+	var coachMatch models.CoachMatch
+	err := r.db.WithContext(ctx).Where("match_team_detail_id = ? AND coach_id = ?", matchTeamDetailID, coachID).First(&coachMatch).Error
+	return coachMatch, translate(err)
+}
+
+func (r *lineupRepository) FindCoachMatches(ctx context.Context, matchID, teamID uint) ([]dto.CoachMatchResponseDto, error) {
+	coaches := []dto.CoachMatchResponseDto{}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			cm.coach_match_id, cm.match_team_detail_id, cm.role,
+			c.coach_id AS coach_coach_id, c.team_id AS coach_team_id,
+			c.name AS coach_name, c.image AS coach_image
+		FROM coach_matches cm
+		JOIN coaches c ON cm.coach_id = c.coach_id
+		JOIN match_team_details mtd ON cm.match_team_detail_id = mtd.match_team_detail_id
+		WHERE mtd.match_id = ? AND mtd.team_id = ?
+	`, matchID, teamID).Scan(&coaches).Error
+	return coaches, err
+}
+
+func (r *lineupRepository) CreateCoachMatch(ctx context.Context, coachMatch *models.CoachMatch) error {
+	return r.db.WithContext(ctx).Create(coachMatch).Error
+}
+
+func (r *lineupRepository) UpdateCoachMatch(ctx context.Context, coachMatch *models.CoachMatch) error {
+	return r.db.WithContext(ctx).Save(coachMatch).Error
+}
+
+func (r *lineupRepository) DeleteCoachMatch(ctx context.Context, coachMatch models.CoachMatch) error {
+	return r.db.WithContext(ctx).Delete(&coachMatch).Error
+}
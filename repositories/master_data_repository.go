@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// MasterDataImport berisi jumlah data yang dibuat dan diperbarui oleh Import.
+type MasterDataImport struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+// MasterDataRepository memindahkan hero, team, pemain dan pelatih antar
+// database tanpa bergantung pada ID.
+type MasterDataRepository interface {
+	Export(ctx context.Context) (dto.MasterDataDto, error)
+	// Import mencocokkan hero dan team lewat nama atau alias, pemain lewat
+	// team dan nama atau alias, serta pelatih lewat team dan nama, lalu membuat yang belum ada dan memperbarui image
+	// yang berbeda dalam satu transaksi. Team yang di-soft delete ikut
+	// dicocokkan tetapi tidak dipulihkan.
+	Import(ctx context.Context, data dto.MasterDataDto) (MasterDataImport, error)
+}
+
+type masterDataRepository struct {
+	db *gorm.DB
+}
+
+func NewMasterDataRepository(db *gorm.DB) MasterDataRepository {
+	return &masterDataRepository{db: db}
+}
+
+func (r *masterDataRepository) Export(ctx context.Context) (dto.MasterDataDto, error) {
+	db := r.db.WithContext(ctx)
+	data := dto.MasterDataDto{Version: dto.MasterDataVersion, Heroes: []dto.MasterDataHeroDto{}, Teams: []dto.MasterDataTeamDto{}}
+
+	var heroes []models.Hero
+	if err := db.Order("name").Find(&heroes).Error; err != nil {
+		return data, err
+	}
+	for _, hero := range heroes {
+		data.Heroes = append(data.Heroes, dto.MasterDataHeroDto{Name: hero.Name, Image: string(hero.Image)})
+	}
+
+	var teams []models.Team
+	if err := db.Order("name").Find(&teams).Error; err != nil {
+		return data, err
+	}
+	for _, team := range teams {
+		var players []models.Player
+		if err := db.Where("team_id = ?", team.TeamID).Order("name").Find(&players).Error; err != nil {
+			return data, err
+		}
+		var coaches []models.Coach
+		if err := db.Where("team_id = ?", team.TeamID).Order("name").Find(&coaches).Error; err != nil {
+			return data, err
+		}
+
+		teamDto := dto.MasterDataTeamDto{Name: team.Name, Image: string(team.Image), Players: []dto.MasterDataMemberDto{}, Coaches: []dto.MasterDataMemberDto{}}
+		for _, player := range players {
+			teamDto.Players = append(teamDto.Players, dto.MasterDataMemberDto{Name: player.Name, Image: string(player.Image)})
+		}
+		for _, coach := range coaches {
+			teamDto.Coaches = append(teamDto.Coaches, dto.MasterDataMemberDto{Name: coach.Name, Image: string(coach.Image)})
+		}
+		data.Teams = append(data.Teams, teamDto)
+	}
+
+	return data, nil
+}
+
+func (r *masterDataRepository) Import(ctx context.Context, data dto.MasterDataDto) (MasterDataImport, error) {
+	var result MasterDataImport
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, heroDto := range data.Heroes {
+			var hero models.Hero
+			if err := upsertByName(tx, nameOrAlias(tx, models.NameKindHero, heroDto.Name), &hero, &hero.Image, heroDto.Image, &result, func() {
+				hero = models.Hero{Name: heroDto.Name, Image: models.Image(heroDto.Image)}
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, teamDto := range data.Teams {
+			var team models.Team
+			if err := upsertByName(tx, nameOrAlias(tx.Unscoped(), models.NameKindTeam, teamDto.Name), &team, &team.Image, teamDto.Image, &result, func() {
+				team = models.Team{Name: teamDto.Name, Image: models.Image(teamDto.Image)}
+			}); err != nil {
+				return err
+			}
+
+			for _, playerDto := range teamDto.Players {
+				var player models.Player
+				if err := upsertByName(tx, nameOrAlias(tx.Where("team_id = ?", team.TeamID), models.NameKindPlayer, playerDto.Name), &player, &player.Image, playerDto.Image, &result, func() {
+					player = models.Player{TeamID: team.TeamID, Name: playerDto.Name, Image: models.Image(playerDto.Image)}
+				}); err != nil {
+					return err
+				}
+			}
+
+			for _, coachDto := range teamDto.Coaches {
+				var coach models.Coach
+				if err := upsertByName(tx, tx.Where("team_id = ? AND name = ?", team.TeamID, coachDto.Name), &coach, &coach.Image, coachDto.Image, &result, func() {
+					coach = models.Coach{TeamID: team.TeamID, Name: coachDto.Name, Image: models.Image(coachDto.Image)}
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// upsertByName membaca baris pertama dari query ke model. Jika tidak ada,
+// reset mengisi model dan baris dibuat lewat tx; jika ada dan image berbeda,
+// image diperbarui.
+func upsertByName(tx, query *gorm.DB, model interface{}, image *models.Image, want string, result *MasterDataImport, reset func()) error {
+	err := query.First(model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		reset()
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		result.Created++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if string(*image) != want {
+		if err := tx.Unscoped().Model(model).Update("image", want).Error; err != nil {
+			return err
+		}
+		result.Updated++
+	}
+	return nil
+}
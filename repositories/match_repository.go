@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// MatchRepository menyimpan dan membaca Match beserta MatchTeamDetail kedua
+// timnya.
+type MatchRepository interface {
+	FindByID(ctx context.Context, matchID uint) (models.Match, error)
+	// FindDetail mengembalikan Match lengkap dengan data kedua tim.
+	FindDetail(ctx context.Context, matchID uint) (dto.MatchResponseDto, error)
+	FindByTournament(ctx context.Context, tournamentID uint) ([]dto.MatchResponseDto, error)
+	FindTeamDetail(ctx context.Context, matchID, teamID uint) (models.MatchTeamDetail, error)
+	// Create menyimpan Match dan MatchTeamDetail untuk Team A dan Team B
+	// dalam satu transaksi.
+	Create(ctx context.Context, match *models.Match) error
+	Update(ctx context.Context, match *models.Match) error
+	// Delete melakukan soft delete pada Match beserta Game-nya.
+	Delete(ctx context.Context, matchID uint) error
+	Restore(ctx context.Context, matchID uint) error
+	// PurgeDeletedBefore menghapus permanen Match yang di-soft delete sebelum
+	// t dan mengembalikan jumlahnya.
+	PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+type matchRepository struct {
+	db    *gorm.DB
+	files storage.Storage
+}
+
+// NewMatchRepository membuat MatchRepository; gambar di files ikut
+// dihapus saat datanya dihapus permanen.
+func NewMatchRepository(db *gorm.DB, files storage.Storage) MatchRepository {
+	return &matchRepository{db: db, files: files}
+}
+
+const matchDetailQuery = `
+	SELECT 
+		m.match_id, m.stage, m.day, m.date, m.played_at, m.team_a_id, m.team_b_id, m.tournament_id,
+		tA.team_id AS team_a_team_id, tA.name AS team_a_name, tA.image AS team_a_image,
+		tB.team_id AS team_b_team_id, tB.name AS team_b_name, tB.image AS team_b_image,
+		m.team_a_score, m.team_b_score
+	FROM matches m
+	JOIN teams tA ON m.team_a_id = tA.team_id
+	JOIN teams tB ON m.team_b_id = tB.team_id
+`
+
+func (r *matchRepository) FindByID(ctx context.Context, matchID uint) (models.Match, error) {
+	var match models.Match
+	err := r.db.WithContext(ctx).First(&match, matchID).Error
+	return match, translate(err)
+}
+
+func (r *matchRepository) FindDetail(ctx context.Context, matchID uint) (dto.MatchResponseDto, error) {
+	match := dto.MatchResponseDto{}
+
+	result := r.db.WithContext(ctx).Raw(matchDetailQuery+"WHERE m.match_id = ? AND m.deleted_at IS NULL", matchID).Scan(&match)
+	if result.Error != nil {
+		return match, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return match, ErrNotFound
+	}
+
+	return match, nil
+}
+
+func (r *matchRepository) FindByTournament(ctx context.Context, tournamentID uint) ([]dto.MatchResponseDto, error) {
+	matches := []dto.MatchResponseDto{}
+	err := r.db.WithContext(ctx).Raw(matchDetailQuery+"WHERE m.tournament_id = ? AND m.deleted_at IS NULL", tournamentID).Scan(&matches).Error
+	return matches, err
+}
+
+func (r *matchRepository) FindTeamDetail(ctx context.Context, matchID, teamID uint) (models.MatchTeamDetail, error) {
+	var matchTeamDetail models.MatchTeamDetail
+	err := r.db.WithContext(ctx).Where("match_id = ? AND team_id = ?", matchID, teamID).First(&matchTeamDetail).Error
+	return matchTeamDetail, translate(err)
+}
+
+func (r *matchRepository) Create(ctx context.Context, match *models.Match) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(match).Error; err != nil {
+			return err
+		}
+
+		for _, teamID := range []uint{match.TeamAID, match.TeamBID} {
+			matchTeamDetail := models.MatchTeamDetail{
+				MatchID: match.MatchID,
+				TeamID:  teamID,
+			}
+			if err := tx.Create(&matchTeamDetail).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *matchRepository) Update(ctx context.Context, match *models.Match) error {
+	return r.db.WithContext(ctx).Save(match).Error
+}
+
+func (r *matchRepository) Delete(ctx context.Context, matchID uint) error {
+	return deleteMatch(r.db.WithContext(ctx), matchID)
+}
+
+func (r *matchRepository) Restore(ctx context.Context, matchID uint) error {
+	return translate(restoreMatch(r.db.WithContext(ctx), matchID))
+}
+
+func (r *matchRepository) PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error) {
+	db := r.db.WithContext(ctx)
+
+	var matches []models.Match
+	if err := deletedBefore(db, t).Find(&matches).Error; err != nil {
+		return 0, err
+	}
+	for i, match := range matches {
+		if err := purgeMatch(db, r.files, match.MatchID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(matches), nil
+}
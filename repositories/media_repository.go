@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// imageColumn adalah kolom yang menyimpan referensi gambar beserta nilai
+// pengganti jika gambarnya hilang dari storage.
+type imageColumn struct {
+	table, key, column string
+	fallback           models.Image
+}
+
+var imageColumns = []imageColumn{
+	{"heros", "hero_id", "image", models.PlaceholderImage},
+	{"teams", "team_id", "image", models.PlaceholderImage},
+	{"players", "player_id", "image", models.PlaceholderImage},
+	{"coaches", "coach_id", "image", models.PlaceholderImage},
+	{"games", "game_id", "full_draft_image", ""},
+	{"media", "media_id", "image", ""},
+}
+
+// ImageRef adalah satu referensi gambar di database.
+type ImageRef struct {
+	Table  string       `json:"table"`
+	ID     uint         `json:"id"`
+	Column string       `json:"column"`
+	Image  models.Image `json:"image"`
+}
+
+// MediaRepository menyimpan objek media dan upload yang menunggu dipasang,
+// serta mempertemukan referensi gambar di database dengan objek di storage.
+type MediaRepository interface {
+	// PutObject menyimpan satu objek ke storage.
+	PutObject(ctx context.Context, key string, body io.Reader, contentType string) error
+	// Create menyimpan upload yang menunggu dipasang.
+	Create(ctx context.Context, media *models.Media) error
+	// Take mengambil lalu menghapus upload dengan id dan kind tersebut
+	// sehingga satu upload hanya bisa dipasang sekali.
+	Take(ctx context.Context, id uint, kind string) (models.Media, error)
+	// ExpireBefore menghapus upload yang dibuat sebelum t dan belum
+	// dipasang. Objeknya menjadi yatim.
+	ExpireBefore(ctx context.Context, t time.Time) (int, error)
+
+	// ImageRefs mengembalikan semua referensi gambar yang tidak kosong,
+	// termasuk milik data yang di-soft delete.
+	ImageRefs(ctx context.Context) ([]ImageRef, error)
+	// ResetImage mengganti gambar ref dengan nilai pengganti kolomnya jika
+	// nilainya belum berubah sejak dibaca.
+	ResetImage(ctx context.Context, ref ImageRef) (bool, error)
+	// Objects mengembalikan semua objek di storage.
+	Objects(ctx context.Context) ([]storage.Object, error)
+	// DeleteObject menghapus satu objek dari storage.
+	DeleteObject(ctx context.Context, key string) error
+}
+
+type mediaRepository struct {
+	db    *gorm.DB
+	files storage.Storage
+}
+
+func NewMediaRepository(db *gorm.DB, files storage.Storage) MediaRepository {
+	return &mediaRepository{db: db, files: files}
+}
+
+func (r *mediaRepository) PutObject(ctx context.Context, key string, body io.Reader, contentType string) error {
+	return r.files.Put(ctx, key, body, contentType)
+}
+
+func (r *mediaRepository) Create(ctx context.Context, media *models.Media) error {
+	return r.db.WithContext(ctx).Create(media).Error
+}
+
+func (r *mediaRepository) Take(ctx context.Context, id uint, kind string) (models.Media, error) {
+	var media models.Media
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("kind = ? AND image <> ''", kind).First(&media, id).Error; err != nil {
+			return translate(err)
+		}
+		result := tx.Delete(&media)
+		if result.Error != nil {
+			return result.Error
+		}
+		// Sudah diambil oleh request lain
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	return media, err
+}
+
+func (r *mediaRepository) ExpireBefore(ctx context.Context, t time.Time) (int, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", t).Delete(&models.Media{})
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *mediaRepository) ImageRefs(ctx context.Context) ([]ImageRef, error) {
+	var refs []ImageRef
+	for _, c := range imageColumns {
+		var rows []struct {
+			ID    uint
+			Image models.Image
+		}
+		// Table tanpa model tidak memakai scope soft delete, sehingga gambar
+		// milik data yang masih bisa di-restore tetap dianggap dipakai.
+		err := r.db.WithContext(ctx).Table(c.table).
+			Select(c.key + " AS id, " + c.column + " AS image").
+			Where(c.column + " <> ''").
+			Order(c.key).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			refs = append(refs, ImageRef{Table: c.table, ID: row.ID, Column: c.column, Image: row.Image})
+		}
+	}
+	return refs, nil
+}
+
+func (r *mediaRepository) ResetImage(ctx context.Context, ref ImageRef) (bool, error) {
+	for _, c := range imageColumns {
+		if c.table != ref.Table || c.column != ref.Column {
+			continue
+		}
+		result := r.db.WithContext(ctx).Table(c.table).
+			Where(c.key+" = ? AND "+c.column+" = ?", ref.ID, ref.Image).
+			Update(c.column, c.fallback)
+		return result.RowsAffected > 0, result.Error
+	}
+	return false, nil
+}
+
+func (r *mediaRepository) Objects(ctx context.Context) ([]storage.Object, error) {
+	return r.files.List(ctx, "")
+}
+
+func (r *mediaRepository) DeleteObject(ctx context.Context, key string) error {
+	return r.files.Delete(ctx, key)
+}
@@ -0,0 +1,253 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// NamedEntity adalah team, pemain atau hero beserta alias-nya.
+type NamedEntity struct {
+	ID uint
+	// TeamID adalah tim pemain; 0 untuk team dan hero.
+	TeamID  uint
+	Name    string
+	Aliases []string
+}
+
+// NameReviewFilter menyaring FindReviews; field kosong tidak menyaring.
+type NameReviewFilter struct {
+	Kind   string
+	Status string
+}
+
+// NameRepository membaca nama dan alias team, pemain dan hero, serta
+// menyimpan antrean review nama. kind adalah salah satu models.NameKinds.
+type NameRepository interface {
+	// FindEntities mengembalikan semua entity kind. Team yang di-soft
+	// delete dan pemainnya tidak ikut.
+	FindEntities(ctx context.Context, kind string) ([]NamedEntity, error)
+	FindEntity(ctx context.Context, kind string, id uint) (NamedEntity, error)
+	// SetAliases mengganti semua alias entity.
+	SetAliases(ctx context.Context, kind string, id uint, aliases []string) error
+
+	FindReviews(ctx context.Context, filter NameReviewFilter) ([]models.NameReview, error)
+	FindReviewByID(ctx context.Context, reviewID uint) (models.NameReview, error)
+	// QueueReview menambahkan nama ke antrean, atau menambah Occurrences
+	// jika nama itu sudah ada. Review yang sudah resolved dibuka lagi
+	// karena aliasnya ternyata tidak lagi cocok; yang dismissed dibiarkan.
+	QueueReview(ctx context.Context, review models.NameReview) error
+	// SaveReview menyimpan review. Jika alias, nama review sekaligus
+	// ditambahkan sebagai alias EntityID.
+	SaveReview(ctx context.Context, review *models.NameReview, alias bool) error
+}
+
+type nameRepository struct {
+	db *gorm.DB
+}
+
+func NewNameRepository(db *gorm.DB) NameRepository {
+	return &nameRepository{db: db}
+}
+
+// nameTable adalah tabel entity dan tabel alias sebuah kind.
+type nameTable struct {
+	table    string
+	aliases  string
+	key      string
+	aliasKey string
+}
+
+var nameTables = map[string]nameTable{
+	models.NameKindTeam:   {table: "teams", aliases: "team_aliases", key: "team_id", aliasKey: "team_alias_id"},
+	models.NameKindPlayer: {table: "players", aliases: "player_aliases", key: "player_id", aliasKey: "player_alias_id"},
+	models.NameKindHero:   {table: "heros", aliases: "hero_aliases", key: "hero_id", aliasKey: "hero_alias_id"},
+}
+
+// newAlias membuat model alias kind agar penyimpanannya tercatat di audit
+// log seperti alias hero.
+func newAlias(kind string, id uint, alias string) interface{} {
+	switch kind {
+	case models.NameKindTeam:
+		return &models.TeamAlias{TeamID: id, Alias: alias}
+	case models.NameKindPlayer:
+		return &models.PlayerAlias{PlayerID: id, Alias: alias}
+	default:
+		return &models.HeroAlias{HeroID: id, Alias: alias}
+	}
+}
+
+// nameOrAlias menyaring query tabel kind dengan nama atau alias name tanpa
+// membedakan huruf besar.
+func nameOrAlias(query *gorm.DB, kind, name string) *gorm.DB {
+	t := nameTables[kind]
+	name = strings.ToLower(name)
+	aliases := query.Session(&gorm.Session{NewDB: true}).Table(t.aliases).Select(t.key).Where("LOWER(alias) = ?", name)
+	return query.Where("(LOWER(name) = ? OR "+t.key+" IN (?))", name, aliases)
+}
+
+// entities membaca entity kind dengan kondisi tambahan where.
+func (r *nameRepository) entities(ctx context.Context, kind string, where func(*gorm.DB) *gorm.DB) ([]NamedEntity, error) {
+	t := nameTables[kind]
+	db := r.db.WithContext(ctx)
+
+	columns := t.key + " AS id, name"
+	query := db.Table(t.table)
+	switch kind {
+	case models.NameKindTeam:
+		query = query.Where("deleted_at IS NULL")
+	case models.NameKindPlayer:
+		columns += ", team_id"
+		query = query.Where("team_id IN (?)", db.Table("teams").Select("team_id").Where("deleted_at IS NULL"))
+	}
+	query = where(query)
+
+	var rows []struct {
+		ID     uint
+		TeamID uint
+		Name   string
+	}
+	if err := query.Select(columns).Order(t.key).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]NamedEntity, 0, len(rows))
+	for _, row := range rows {
+		entities = append(entities, NamedEntity{ID: row.ID, TeamID: row.TeamID, Name: row.Name})
+	}
+	if len(entities) == 0 {
+		return entities, nil
+	}
+
+	index := make(map[uint]int, len(entities))
+	ids := make([]uint, 0, len(entities))
+	for i, entity := range entities {
+		index[entity.ID] = i
+		ids = append(ids, entity.ID)
+	}
+	var aliases []struct {
+		ID    uint
+		Alias string
+	}
+	if err := db.Table(t.aliases).Select(t.key+" AS id, alias").Where(t.key+" IN ?", ids).Order(t.key + ", alias").Scan(&aliases).Error; err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		entity := &entities[index[alias.ID]]
+		entity.Aliases = append(entity.Aliases, alias.Alias)
+	}
+	return entities, nil
+}
+
+func (r *nameRepository) FindEntities(ctx context.Context, kind string) ([]NamedEntity, error) {
+	return r.entities(ctx, kind, func(db *gorm.DB) *gorm.DB { return db })
+}
+
+func (r *nameRepository) FindEntity(ctx context.Context, kind string, id uint) (NamedEntity, error) {
+	entities, err := r.entities(ctx, kind, func(db *gorm.DB) *gorm.DB {
+		return db.Where(nameTables[kind].key+" = ?", id)
+	})
+	if err != nil {
+		return NamedEntity{}, err
+	}
+	if len(entities) == 0 {
+		return NamedEntity{}, ErrNotFound
+	}
+	return entities[0], nil
+}
+
+func (r *nameRepository) SetAliases(ctx context.Context, kind string, id uint, aliases []string) error {
+	t := nameTables[kind]
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []struct {
+			ID    uint
+			Alias string
+		}
+		if err := tx.Table(t.aliases).Select(t.aliasKey+" AS id, alias").Where(t.key+" = ?", id).Scan(&existing).Error; err != nil {
+			return err
+		}
+
+		// Alias yang tetap ada tidak dihapus agar audit log hanya berisi
+		// perubahan
+		keep := map[string]bool{}
+		for _, alias := range aliases {
+			keep[alias] = true
+		}
+		for _, alias := range existing {
+			if keep[alias.Alias] {
+				delete(keep, alias.Alias)
+				continue
+			}
+			if err := tx.Delete(newAlias(kind, id, ""), alias.ID).Error; err != nil {
+				return err
+			}
+		}
+		for _, alias := range aliases {
+			if !keep[alias] {
+				continue
+			}
+			delete(keep, alias)
+			if err := tx.Create(newAlias(kind, id, alias)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *nameRepository) FindReviews(ctx context.Context, filter NameReviewFilter) ([]models.NameReview, error) {
+	query := r.db.WithContext(ctx)
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	reviews := []models.NameReview{}
+	err := query.Order("occurrences DESC, name_review_id").Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *nameRepository) FindReviewByID(ctx context.Context, reviewID uint) (models.NameReview, error) {
+	var review models.NameReview
+	err := r.db.WithContext(ctx).First(&review, reviewID).Error
+	return review, translate(err)
+}
+
+func (r *nameRepository) QueueReview(ctx context.Context, review models.NameReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.NameReview
+		err := tx.Where("kind = ? AND name = ? AND team_id = ?", review.Kind, review.Name, review.TeamID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			review.Occurrences = 1
+			review.Status = models.NameReviewPending
+			return tx.Create(&review).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		existing.Occurrences++
+		existing.Source = review.Source
+		if existing.Status == models.NameReviewResolved {
+			existing.Status = models.NameReviewPending
+			existing.EntityID = nil
+		}
+		return tx.Save(&existing).Error
+	})
+}
+
+func (r *nameRepository) SaveReview(ctx context.Context, review *models.NameReview, alias bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		if !alias || review.EntityID == nil {
+			return nil
+		}
+		return tx.Create(newAlias(review.Kind, *review.EntityID, review.Name)).Error
+	})
+}
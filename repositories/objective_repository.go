@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// ObjectiveRepository menyimpan hasil perebutan lord dan turtle di sebuah
+// Game.
+type ObjectiveRepository interface {
+	// FindLordResult mengembalikan LordResult hanya jika berada di Game gameID.
+	FindLordResult(ctx context.Context, gameID, lordResultID uint) (models.LordResult, error)
+	FindLordResultDetail(ctx context.Context, gameID, lordResultID uint) (dto.LordResultResponseDto, error)
+	FindLordResults(ctx context.Context, gameID uint) ([]dto.LordResultResponseDto, error)
+	CreateLordResult(ctx context.Context, lordResult *models.LordResult) error
+	UpdateLordResult(ctx context.Context, lordResult *models.LordResult) error
+	DeleteLordResult(ctx context.Context, lordResult models.LordResult) error
+
+	FindTurtleResult(ctx context.Context, gameID, turtleResultID uint) (models.TurtleResult, error)
+	FindTurtleResultDetail(ctx context.Context, gameID, turtleResultID uint) (dto.TurtleResultResponseDto, error)
+	FindTurtleResults(ctx context.Context, gameID uint) ([]dto.TurtleResultResponseDto, error)
+	CreateTurtleResult(ctx context.Context, turtleResult *models.TurtleResult) error
+	UpdateTurtleResult(ctx context.Context, turtleResult *models.TurtleResult) error
+	DeleteTurtleResult(ctx context.Context, turtleResult models.TurtleResult) error
+}
+
+type objectiveRepository struct {
+	db *gorm.DB
+}
+
+func NewObjectiveRepository(db *gorm.DB) ObjectiveRepository {
+	return &objectiveRepository{db: db}
+}
+
+const lordResultQuery = `
+	SELECT
+		l.lord_result_id, l.game_id,
+		t.team_id AS team_team_id, t.name AS team_name, t.image AS team_image,
+		l.phase, l.setup, l.initiate, l.result
+	FROM lord_results l
+	JOIN teams t ON l.team_id = t.team_id
+	WHERE l.game_id = ?
+`
+
+const turtleResultQuery = `
+	SELECT
+		tr.turtle_result_id, tr.game_id,
+		t.team_id AS team_team_id, t.name AS team_name, t.image AS team_image,
+		tr.phase, tr.setup, tr.initiate, tr.result
+	FROM turtle_results tr
+	JOIN teams t ON tr.team_id = t.team_id
+	WHERE tr.game_id = ?
+`
+
+func (r *objectiveRepository) FindLordResult(ctx context.Context, gameID, lordResultID uint) (models.LordResult, error) {
+	var lordResult models.LordResult
+	err := r.db.WithContext(ctx).Where("game_id = ?", gameID).First(&lordResult, lordResultID).Error
+	return lordResult, translate(err)
+}
+
+func (r *objectiveRepository) FindLordResultDetail(ctx context.Context, gameID, lordResultID uint) (dto.LordResultResponseDto, error) {
+	var result dto.LordResultResponseDto
+	return result, scanOne(r.db.WithContext(ctx).Raw(lordResultQuery+"AND l.lord_result_id = ?", gameID, lordResultID), &result)
+}
+
+func (r *objectiveRepository) FindLordResults(ctx context.Context, gameID uint) ([]dto.LordResultResponseDto, error) {
+	results := []dto.LordResultResponseDto{}
+	err := r.db.WithContext(ctx).Raw(lordResultQuery, gameID).Scan(&results).Error
+	return results, err
+}
+
+func (r *objectiveRepository) CreateLordResult(ctx context.Context, lordResult *models.LordResult) error {
+	return r.db.WithContext(ctx).Create(lordResult).Error
+}
+
+func (r *objectiveRepository) UpdateLordResult(ctx context.Context, lordResult *models.LordResult) error {
+	return r.db.WithContext(ctx).Save(lordResult).Error
+}
+
+func (r *objectiveRepository) DeleteLordResult(ctx context.Context, lordResult models.LordResult) error {
+	return r.db.WithContext(ctx).Delete(&lordResult).Error
+}
+
+func (r *objectiveRepository) FindTurtleResult(ctx context.Context, gameID, turtleResultID uint) (models.TurtleResult, error) {
+	var turtleResult models.TurtleResult
+	err := r.db.WithContext(ctx).Where("game_id = ?", gameID).First(&turtleResult, turtleResultID).Error
+	return turtleResult, translate(err)
+}
+
+func (r *objectiveRepository) FindTurtleResultDetail(ctx context.Context, gameID, turtleResultID uint) (dto.TurtleResultResponseDto, error) {
+	var result dto.TurtleResultResponseDto
+	return result, scanOne(r.db.WithContext(ctx).Raw(turtleResultQuery+"AND tr.turtle_result_id = ?", gameID, turtleResultID), &result)
+}
+
+func (r *objectiveRepository) FindTurtleResults(ctx context.Context, gameID uint) ([]dto.TurtleResultResponseDto, error) {
+	results := []dto.TurtleResultResponseDto{}
+	err := r.db.WithContext(ctx).Raw(turtleResultQuery, gameID).Scan(&results).Error
+	return results, err
+}
+
+func (r *objectiveRepository) CreateTurtleResult(ctx context.Context, turtleResult *models.TurtleResult) error {
+	return r.db.WithContext(ctx).Create(turtleResult).Error
+}
+
+func (r *objectiveRepository) UpdateTurtleResult(ctx context.Context, turtleResult *models.TurtleResult) error {
+	return r.db.WithContext(ctx).Save(turtleResult).Error
+}
+
+func (r *objectiveRepository) DeleteTurtleResult(ctx context.Context, turtleResult models.TurtleResult) error {
+	return r.db.WithContext(ctx).Delete(&turtleResult).Error
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// PatchRepository menyimpan dan membaca Patch.
+type PatchRepository interface {
+	// FindAll mengembalikan semua Patch, yang terbaru lebih dulu.
+	FindAll(ctx context.Context) ([]models.Patch, error)
+	FindByID(ctx context.Context, patchID uint) (models.Patch, error)
+	FindByVersion(ctx context.Context, version string) (models.Patch, error)
+	// FindReleasedBy mengembalikan Patch terakhir yang rilis paling lambat
+	// pada t, yaitu patch yang berlaku pada t.
+	FindReleasedBy(ctx context.Context, t time.Time) (models.Patch, error)
+	// CountGames menghitung Game yang dimainkan di Patch, termasuk yang sudah
+	// di-soft delete.
+	CountGames(ctx context.Context, patchID uint) (int64, error)
+	Create(ctx context.Context, patch *models.Patch) error
+	Update(ctx context.Context, patch *models.Patch) error
+	Delete(ctx context.Context, patchID uint) error
+}
+
+type patchRepository struct {
+	db *gorm.DB
+}
+
+func NewPatchRepository(db *gorm.DB) PatchRepository {
+	return &patchRepository{db: db}
+}
+
+func (r *patchRepository) FindAll(ctx context.Context) ([]models.Patch, error) {
+	patches := []models.Patch{}
+	err := r.db.WithContext(ctx).Order("release_date DESC, patch_id DESC").Find(&patches).Error
+	return patches, err
+}
+
+func (r *patchRepository) FindByID(ctx context.Context, patchID uint) (models.Patch, error) {
+	var patch models.Patch
+	err := r.db.WithContext(ctx).First(&patch, patchID).Error
+	return patch, translate(err)
+}
+
+func (r *patchRepository) FindByVersion(ctx context.Context, version string) (models.Patch, error) {
+	var patch models.Patch
+	err := r.db.WithContext(ctx).Where("version = ?", version).First(&patch).Error
+	return patch, translate(err)
+}
+
+func (r *patchRepository) FindReleasedBy(ctx context.Context, t time.Time) (models.Patch, error) {
+	var patch models.Patch
+	err := r.db.WithContext(ctx).
+		Where("release_date <= ?", t).
+		Order("release_date DESC, patch_id DESC").
+		First(&patch).Error
+	return patch, translate(err)
+}
+
+func (r *patchRepository) CountGames(ctx context.Context, patchID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.Game{}).Where("patch_id = ?", patchID).Count(&count).Error
+	return count, err
+}
+
+func (r *patchRepository) Create(ctx context.Context, patch *models.Patch) error {
+	return r.db.WithContext(ctx).Create(patch).Error
+}
+
+func (r *patchRepository) Update(ctx context.Context, patch *models.Patch) error {
+	return r.db.WithContext(ctx).Save(patch).Error
+}
+
+func (r *patchRepository) Delete(ctx context.Context, patchID uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Patch{}, patchID).Error
+}
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// PriorityRepository menyimpan priority pick, priority ban dan flex pick
+// sebuah tim di Match, lewat MatchTeamDetail tim tersebut.
+type PriorityRepository interface {
+	// FindPriorityPick mengembalikan PriorityPick hanya jika milik
+	// MatchTeamDetail matchTeamDetailID.
+	FindPriorityPick(ctx context.Context, matchTeamDetailID, priorityPickID uint) (models.PriorityPick, error)
+	FindPriorityPickDetail(ctx context.Context, matchTeamDetailID, priorityPickID uint) (dto.PriorityPickResponseDto, error)
+	// FindPriorityPicks, FindPriorityBans dan FindFlexPicks mengembalikan
+	// daftar kosong jika patchID diisi dan Match tidak punya Game di patch
+	// tersebut, karena datanya dicatat per Match.
+	FindPriorityPicks(ctx context.Context, matchTeamDetailID uint, patchID *uint) ([]dto.PriorityPickResponseDto, error)
+	CreatePriorityPick(ctx context.Context, priorityPick *models.PriorityPick) error
+	UpdatePriorityPick(ctx context.Context, priorityPick *models.PriorityPick) error
+	DeletePriorityPick(ctx context.Context, priorityPick models.PriorityPick) error
+
+	FindPriorityBan(ctx context.Context, matchTeamDetailID, priorityBanID uint) (models.PriorityBan, error)
+	FindPriorityBanDetail(ctx context.Context, matchTeamDetailID, priorityBanID uint) (dto.PriorityBanResponseDto, error)
+	FindPriorityBans(ctx context.Context, matchTeamDetailID uint, patchID *uint) ([]dto.PriorityBanResponseDto, error)
+	CreatePriorityBan(ctx context.Context, priorityBan *models.PriorityBan) error
+	UpdatePriorityBan(ctx context.Context, priorityBan *models.PriorityBan) error
+	DeletePriorityBan(ctx context.Context, priorityBan models.PriorityBan) error
+
+	FindFlexPick(ctx context.Context, matchTeamDetailID, flexPickID uint) (models.FlexPick, error)
+	FindFlexPickDetail(ctx context.Context, matchTeamDetailID, flexPickID uint) (dto.FlexPickResponseDto, error)
+	FindFlexPicks(ctx context.Context, matchTeamDetailID uint, patchID *uint) ([]dto.FlexPickResponseDto, error)
+	CreateFlexPick(ctx context.Context, flexPick *models.FlexPick) error
+	UpdateFlexPick(ctx context.Context, flexPick *models.FlexPick) error
+	DeleteFlexPick(ctx context.Context, flexPick models.FlexPick) error
+}
+
+type priorityRepository struct {
+	db *gorm.DB
+}
+
+func NewPriorityRepository(db *gorm.DB) PriorityRepository {
+	return &priorityRepository{db: db}
+}
+
+const priorityPickQuery = `
+	SELECT
+		pp.priority_pick_id, pp.match_team_detail_id,
+		h.hero_id AS hero_hero_id, h.name AS hero_name, h.image AS hero_image,
+		pp.total, pp.role, pp.pick_rate
+	FROM priority_picks pp
+	JOIN heros h ON pp.hero_id = h.hero_id
+	WHERE pp.match_team_detail_id = ?
+`
+
+const priorityBanQuery = `
+	SELECT
+		pb.priority_ban_id, pb.match_team_detail_id,
+		h.hero_id AS hero_hero_id, h.name AS hero_name, h.image AS hero_image,
+		pb.total, pb.role, pb.ban_rate
+	FROM priority_bans pb
+	JOIN heros h ON pb.hero_id = h.hero_id
+	WHERE pb.match_team_detail_id = ?
+`
+
+const flexPickQuery = `
+	SELECT
+		fp.flex_pick_id, fp.match_team_detail_id,
+		h.hero_id AS hero_hero_id, h.name AS hero_name, h.image AS hero_image,
+		fp.total, fp.role, fp.pick_rate
+	FROM flex_picks fp
+	JOIN heros h ON fp.hero_id = h.hero_id
+	WHERE fp.match_team_detail_id = ?
+`
+
+// inPatch menambahkan filter patch ke query daftar per MatchTeamDetail:
+// hanya Match yang punya Game di patch tersebut yang dihitung.
+func inPatch(query string, matchTeamDetailID uint, patchID *uint) (string, []interface{}) {
+	args := []interface{}{matchTeamDetailID}
+	if patchID == nil {
+		return query, args
+	}
+	query += `
+		AND EXISTS (
+			SELECT 1 FROM match_team_details mtd
+			JOIN games g ON mtd.match_id = g.match_id
+			WHERE mtd.match_team_detail_id = ? AND g.patch_id = ? AND g.deleted_at IS NULL
+		)`
+	return query, append(args, matchTeamDetailID, *patchID)
+}
+
+func (r *priorityRepository) FindPriorityPick(ctx context.Context, matchTeamDetailID, priorityPickID uint) (models.PriorityPick, error) {
+	var priorityPick models.PriorityPick
+	err := r.db.WithContext(ctx).Where("match_team_detail_id = ?", matchTeamDetailID).First(&priorityPick, priorityPickID).Error
+	return priorityPick, translate(err)
+}
+
+func (r *priorityRepository) FindPriorityPickDetail(ctx context.Context, matchTeamDetailID, priorityPickID uint) (dto.PriorityPickResponseDto, error) {
+	var priorityPick dto.PriorityPickResponseDto
+	return priorityPick, scanOne(r.db.WithContext(ctx).Raw(priorityPickQuery+"AND pp.priority_pick_id = ?", matchTeamDetailID, priorityPickID), &priorityPick)
+}
+
+func (r *priorityRepository) FindPriorityPicks(ctx context.Context, matchTeamDetailID uint, patchID *uint) ([]dto.PriorityPickResponseDto, error) {
+	priorityPicks := []dto.PriorityPickResponseDto{}
+	query, args := inPatch(priorityPickQuery, matchTeamDetailID, patchID)
+	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&priorityPicks).Error
+	return priorityPicks, err
+}
+
+func (r *priorityRepository) CreatePriorityPick(ctx context.Context, priorityPick *models.PriorityPick) error {
+	return r.db.WithContext(ctx).Create(priorityPick).Error
+}
+
+func (r *priorityRepository) UpdatePriorityPick(ctx context.Context, priorityPick *models.PriorityPick) error {
+	return r.db.WithContext(ctx).Save(priorityPick).Error
+}
+
+func (r *priorityRepository) DeletePriorityPick(ctx context.Context, priorityPick models.PriorityPick) error {
+	return r.db.WithContext(ctx).Delete(&priorityPick).Error
+}
+
+func (r *priorityRepository) FindPriorityBan(ctx context.Context, matchTeamDetailID, priorityBanID uint) (models.PriorityBan, error) {
+	var priorityBan models.PriorityBan
+	err := r.db.WithContext(ctx).Where("match_team_detail_id = ?", matchTeamDetailID).First(&priorityBan, priorityBanID).Error
+	return priorityBan, translate(err)
+}
+
+func (r *priorityRepository) FindPriorityBanDetail(ctx context.Context, matchTeamDetailID, priorityBanID uint) (dto.PriorityBanResponseDto, error) {
+	var priorityBan dto.PriorityBanResponseDto
+	return priorityBan, scanOne(r.db.WithContext(ctx).Raw(priorityBanQuery+"AND pb.priority_ban_id = ?", matchTeamDetailID, priorityBanID), &priorityBan)
+}
+
+func (r *priorityRepository) FindPriorityBans(ctx context.Context, matchTeamDetailID uint, patchID *uint) ([]dto.PriorityBanResponseDto, error) {
+	priorityBans := []dto.PriorityBanResponseDto{}
+	query, args := inPatch(priorityBanQuery, matchTeamDetailID, patchID)
+	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&priorityBans).Error
+	return priorityBans, err
+}
+
+func (r *priorityRepository) CreatePriorityBan(ctx context.Context, priorityBan *models.PriorityBan) error {
+	return r.db.WithContext(ctx).Create(priorityBan).Error
+}
+
+func (r *priorityRepository) UpdatePriorityBan(ctx context.Context, priorityBan *models.PriorityBan) error {
+	return r.db.WithContext(ctx).Save(priorityBan).Error
+}
+
+func (r *priorityRepository) DeletePriorityBan(ctx context.Context, priorityBan models.PriorityBan) error {
+	return r.db.WithContext(ctx).Delete(&priorityBan).Error
+}
+
+func (r *priorityRepository) FindFlexPick(ctx context.Context, matchTeamDetailID, flexPickID uint) (models.FlexPick, error) {
+	var flexPick models.FlexPick
+	err := r.db.WithContext(ctx).Where("match_team_detail_id = ?", matchTeamDetailID).First(&flexPick, flexPickID).Error
+	return flexPick, translate(err)
+}
+
+func (r *priorityRepository) FindFlexPickDetail(ctx context.Context, matchTeamDetailID, flexPickID uint) (dto.FlexPickResponseDto, error) {
+	var flexPick dto.FlexPickResponseDto
+	return flexPick, scanOne(r.db.WithContext(ctx).Raw(flexPickQuery+"AND fp.flex_pick_id = ?", matchTeamDetailID, flexPickID), &flexPick)
+}
+
+func (r *priorityRepository) FindFlexPicks(ctx context.Context, matchTeamDetailID uint, patchID *uint) ([]dto.FlexPickResponseDto, error) {
+	flexPicks := []dto.FlexPickResponseDto{}
+	query, args := inPatch(flexPickQuery, matchTeamDetailID, patchID)
+	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&flexPicks).Error
+	return flexPicks, err
+}
+
+func (r *priorityRepository) CreateFlexPick(ctx context.Context, flexPick *models.FlexPick) error {
+	return r.db.WithContext(ctx).Create(flexPick).Error
+}
+
+func (r *priorityRepository) UpdateFlexPick(ctx context.Context, flexPick *models.FlexPick) error {
+	return r.db.WithContext(ctx).Save(flexPick).Error
+}
+
+func (r *priorityRepository) DeleteFlexPick(ctx context.Context, flexPick models.FlexPick) error {
+	return r.db.WithContext(ctx).Delete(&flexPick).Error
+}
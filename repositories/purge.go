@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// deletedBefore memilih baris yang sudah di-soft delete sebelum t, dipakai
+// oleh PurgeDeletedBefore di setiap repository.
+func deletedBefore(db *gorm.DB, t time.Time) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", t)
+}
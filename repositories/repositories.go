@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"errors"
+
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound dikembalikan repository jika data yang dicari tidak ada, agar
+// pemanggil tidak perlu bergantung pada error milik GORM.
+var ErrNotFound = errors.New("record not found")
+
+// Repositories mengelompokkan semua repository yang memakai koneksi database
+// yang sama.
+type Repositories struct {
+	Tournaments TournamentRepository
+	Teams       TeamRepository
+	Heroes      HeroRepository
+	Matches     MatchRepository
+	Games       GameRepository
+	Drafts      DraftRepository
+	Users       UserRepository
+	Audits      AuditRepository
+	Stats       StatsRepository
+	MasterData  MasterDataRepository
+	Media       MediaRepository
+	Patches     PatchRepository
+	Exports     ExportRepository
+	SheetImport SheetImportRepository
+	Backups     TournamentBackupRepository
+	Names       NameRepository
+	Objectives  ObjectiveRepository
+	Lanes       LaneRepository
+	Lineups     LineupRepository
+	Priorities  PriorityRepository
+}
+
+// New membuat semua repository berbasis GORM untuk db. files adalah storage
+// tempat gambar disimpan.
+func New(db *gorm.DB, files storage.Storage) Repositories {
+	return Repositories{
+		Tournaments: NewTournamentRepository(db, files),
+		Teams:       NewTeamRepository(db, files),
+		Heroes:      NewHeroRepository(db, files),
+		Matches:     NewMatchRepository(db, files),
+		Games:       NewGameRepository(db, files),
+		Drafts:      NewDraftRepository(db),
+		Users:       NewUserRepository(db),
+		Audits:      NewAuditRepository(db),
+		Stats:       NewStatsRepository(db),
+		MasterData:  NewMasterDataRepository(db),
+		Media:       NewMediaRepository(db, files),
+		Patches:     NewPatchRepository(db),
+		Exports:     NewExportRepository(db),
+		SheetImport: NewSheetImportRepository(db),
+		Backups:     NewTournamentBackupRepository(db, files),
+		Names:       NewNameRepository(db),
+		Objectives:  NewObjectiveRepository(db),
+		Lanes:       NewLaneRepository(db),
+		Lineups:     NewLineupRepository(db),
+		Priorities:  NewPriorityRepository(db),
+	}
+}
+
+// translate mengganti gorm.ErrRecordNotFound dengan ErrNotFound.
+func translate(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// scanOne membaca satu baris hasil query mentah ke dest dan mengembalikan
+// ErrNotFound jika tidak ada baris.
+func scanOne(query *gorm.DB, dest interface{}) error {
+	result := query.Scan(dest)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
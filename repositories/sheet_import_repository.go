@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SheetImportState adalah data Tournament yang sudah ada, untuk dicocokkan
+// dengan baris spreadsheet import. Match dan Game yang sudah di-soft delete
+// tidak ikut.
+type SheetImportState struct {
+	Matches          []models.Match
+	MatchTeamDetails []models.MatchTeamDetail
+	PlayerMatches    []models.PlayerMatch
+	Games            []models.Game
+	HeroPicks        []models.HeroPick
+	HeroPickGames    []models.HeroPickGame
+	HeroBans         []models.HeroBan
+	HeroBanGames     []models.HeroBanGame
+	PriorityPicks    []models.PriorityPick
+	PriorityBans     []models.PriorityBan
+	FlexPicks        []models.FlexPick
+	Explaners        []models.Explaner
+	Goldlaners       []models.Goldlaner
+	TrioMids         []models.TrioMid
+	TrioMidHeroes    []models.TrioMidHero
+	LordResults      []models.LordResult
+	TurtleResults    []models.TurtleResult
+}
+
+// SheetImportOp adalah satu baris yang dibuat (primary key nol) atau
+// diperbarui oleh import. Link dipanggil tepat sebelum Value disimpan untuk
+// mengisi foreign key dari baris baru yang disimpan lebih dulu.
+type SheetImportOp struct {
+	Value interface{}
+	Link  func()
+}
+
+// SheetImportRepository membaca dan menyimpan data import spreadsheet
+// sebuah Tournament.
+type SheetImportRepository interface {
+	Load(ctx context.Context, tournamentID uint) (SheetImportState, error)
+	// Apply menyimpan semua ops secara berurutan dalam satu transaksi.
+	Apply(ctx context.Context, ops []SheetImportOp) error
+}
+
+type sheetImportRepository struct {
+	db *gorm.DB
+}
+
+func NewSheetImportRepository(db *gorm.DB) SheetImportRepository {
+	return &sheetImportRepository{db: db}
+}
+
+func (r *sheetImportRepository) Load(ctx context.Context, tournamentID uint) (SheetImportState, error) {
+	db := r.db.WithContext(ctx)
+	var state SheetImportState
+
+	matches := db.Model(&models.Match{}).Select("match_id").Where("tournament_id = ?", tournamentID)
+	details := db.Model(&models.MatchTeamDetail{}).Select("match_team_detail_id").Where("match_id IN (?)", matches)
+	games := db.Model(&models.Game{}).Select("game_id").Where("match_id IN (?)", matches)
+	trioMids := db.Model(&models.TrioMid{}).Select("trio_mid_id").Where("game_id IN (?)", games)
+	heroPicks := db.Model(&models.HeroPick{}).Select("hero_pick_id").Where("match_team_detail_id IN (?)", details)
+	heroBans := db.Model(&models.HeroBan{}).Select("hero_ban_id").Where("match_team_detail_id IN (?)", details)
+
+	queries := []struct {
+		dest  interface{}
+		query *gorm.DB
+	}{
+		{&state.Matches, db.Where("tournament_id = ?", tournamentID).Order("match_id")},
+		{&state.MatchTeamDetails, db.Where("match_id IN (?)", matches).Order("match_team_detail_id")},
+		{&state.PlayerMatches, db.Where("match_team_detail_id IN (?)", details).Order("player_match_id")},
+		{&state.Games, db.Where("match_id IN (?)", matches).Order("game_id")},
+		{&state.HeroPicks, db.Where("match_team_detail_id IN (?)", details).Order("hero_pick_id")},
+		{&state.HeroPickGames, db.Where("hero_pick_id IN (?)", heroPicks).Order("hero_pick_game_id")},
+		{&state.HeroBans, db.Where("match_team_detail_id IN (?)", details).Order("hero_ban_id")},
+		{&state.HeroBanGames, db.Where("hero_ban_id IN (?)", heroBans).Order("hero_ban_game_id")},
+		{&state.PriorityPicks, db.Where("match_team_detail_id IN (?)", details).Order("priority_pick_id")},
+		{&state.PriorityBans, db.Where("match_team_detail_id IN (?)", details).Order("priority_ban_id")},
+		{&state.FlexPicks, db.Where("match_team_detail_id IN (?)", details).Order("flex_pick_id")},
+		{&state.Explaners, db.Where("game_id IN (?)", games).Order("explaner_id")},
+		{&state.Goldlaners, db.Where("game_id IN (?)", games).Order("goldlaner_id")},
+		{&state.TrioMids, db.Where("game_id IN (?)", games).Order("trio_mid_id")},
+		{&state.TrioMidHeroes, db.Where("trio_mid_id IN (?)", trioMids).Order("trio_mid_hero_id")},
+		{&state.LordResults, db.Where("game_id IN (?)", games).Order("lord_result_id")},
+		{&state.TurtleResults, db.Where("game_id IN (?)", games).Order("turtle_result_id")},
+	}
+	for _, q := range queries {
+		if err := q.query.Find(q.dest).Error; err != nil {
+			return state, err
+		}
+	}
+	return state, nil
+}
+
+func (r *sheetImportRepository) Apply(ctx context.Context, ops []SheetImportOp) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, op := range ops {
+			if op.Link != nil {
+				op.Link()
+			}
+			if err := tx.Omit(clause.Associations).Save(op.Value).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotDeleted    = errors.New("record is not deleted")
+	ErrParentDeleted = errors.New("parent record is deleted, restore it first")
+)
+
+// beginSoftDelete memulai transaksi dengan waktu yang sama untuk semua
+// deleted_at, sehingga data yang terhapus bersamaan bisa dikenali saat restore.
+func beginSoftDelete(db *gorm.DB) *gorm.DB {
+	now := db.NowFunc()
+	return db.Session(&gorm.Session{NowFunc: func() time.Time { return now }}).Begin()
+}
+
+func softDeleteMatches(tx *gorm.DB, matchIDs []uint) error {
+	for _, matchID := range matchIDs {
+		var games []models.Game
+		if err := tx.Where("match_id = ?", matchID).Find(&games).Error; err != nil {
+			return err
+		}
+
+		if err := softDeleteGames(tx, games); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.Match{}, matchID).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreTournament mengembalikan Tournament yang di-soft delete beserta Match
+// dan Game yang ikut terhapus bersamanya.
+func restoreTournament(db *gorm.DB, tournamentID uint) error {
+	var tournament models.Tournament
+	if err := db.Unscoped().First(&tournament, tournamentID).Error; err != nil {
+		return err
+	}
+	if !tournament.DeletedAt.Valid {
+		return ErrNotDeleted
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Match dengan tim yang masih terhapus tidak ikut dikembalikan
+	liveTeams := tx.Model(&models.Team{}).Select("team_id")
+
+	var matchIDs []uint
+	if err := tx.Unscoped().Model(&models.Match{}).
+		Where("tournament_id = ? AND deleted_at >= ?", tournamentID, tournament.DeletedAt.Time).
+		Where("team_a_id IN (?) AND team_b_id IN (?)", liveTeams, liveTeams).
+		Pluck("match_id", &matchIDs).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := restoreMatches(tx, matchIDs, tournament.DeletedAt.Time); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Unscoped().Model(&models.Tournament{}).Where("tournament_id = ?", tournamentID).Update("deleted_at", nil).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	log.Printf("Tournament with ID %d has been restored with %d matches.", tournamentID, len(matchIDs))
+	return nil
+}
+
+// restoreTeam mengembalikan Team yang di-soft delete beserta Match yang ikut
+// terhapus bersamanya, selama tournament dan tim lawannya tidak terhapus.
+func restoreTeam(db *gorm.DB, teamID uint) error {
+	var team models.Team
+	if err := db.Unscoped().First(&team, teamID).Error; err != nil {
+		return err
+	}
+	if !team.DeletedAt.Valid {
+		return ErrNotDeleted
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Unscoped().Model(&models.Team{}).Where("team_id = ?", teamID).Update("deleted_at", nil).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	liveTeams := tx.Model(&models.Team{}).Select("team_id")
+	liveTournaments := tx.Model(&models.Tournament{}).Select("tournament_id")
+
+	var matchIDs []uint
+	if err := tx.Unscoped().Model(&models.Match{}).
+		Where("(team_a_id = ? OR team_b_id = ?) AND deleted_at >= ?", teamID, teamID, team.DeletedAt.Time).
+		Where("team_a_id IN (?) AND team_b_id IN (?) AND tournament_id IN (?)", liveTeams, liveTeams, liveTournaments).
+		Pluck("match_id", &matchIDs).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := restoreMatches(tx, matchIDs, team.DeletedAt.Time); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	log.Printf("Team with ID %d has been restored with %d matches.", teamID, len(matchIDs))
+	return nil
+}
+
+// restoreMatch mengembalikan Match yang di-soft delete beserta Game yang ikut
+// terhapus bersamanya. Tournament dan kedua tim harus sudah aktif.
+func restoreMatch(db *gorm.DB, matchID uint) error {
+	var match models.Match
+	if err := db.Unscoped().First(&match, matchID).Error; err != nil {
+		return err
+	}
+	if !match.DeletedAt.Valid {
+		return ErrNotDeleted
+	}
+
+	if err := requireLive(db, &models.Tournament{}, match.TournamentID); err != nil {
+		return err
+	}
+	if err := requireLive(db, &models.Team{}, match.TeamAID); err != nil {
+		return err
+	}
+	if err := requireLive(db, &models.Team{}, match.TeamBID); err != nil {
+		return err
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := restoreMatches(tx, []uint{matchID}, match.DeletedAt.Time); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	log.Printf("Match with ID %d has been restored.", matchID)
+	return nil
+}
+
+// restoreGame mengembalikan Game yang di-soft delete. Match-nya harus aktif.
+func restoreGame(db *gorm.DB, gameID uint) error {
+	var game models.Game
+	if err := db.Unscoped().First(&game, gameID).Error; err != nil {
+		return err
+	}
+	if !game.DeletedAt.Valid {
+		return ErrNotDeleted
+	}
+
+	if err := requireLive(db, &models.Match{}, game.MatchID); err != nil {
+		return err
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := restoreGames(tx, []models.Game{game}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	log.Printf("Game with ID %d has been restored.", gameID)
+	return nil
+}
+
+func requireLive(db *gorm.DB, model interface{}, id uint) error {
+	if err := db.First(model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrParentDeleted
+		}
+		return err
+	}
+	return nil
+}
+
+// restoreMatches mengembalikan Match beserta Game yang terhapus pada atau
+// setelah deletedAt (yaitu yang ikut terhapus bersama induknya).
+func restoreMatches(tx *gorm.DB, matchIDs []uint, deletedAt time.Time) error {
+	for _, matchID := range matchIDs {
+		var games []models.Game
+		if err := tx.Unscoped().Where("match_id = ? AND deleted_at >= ?", matchID, deletedAt).Find(&games).Error; err != nil {
+			return err
+		}
+
+		if err := restoreGames(tx, games); err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&models.Match{}).Where("match_id = ?", matchID).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func restoreGames(tx *gorm.DB, games []models.Game) error {
+	for _, game := range games {
+		if err := tx.Unscoped().Model(&models.Game{}).Where("game_id = ?", game.GameID).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		if err := adjustDraftTotals(tx, game, 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// StatsRecompute berisi jumlah baris yang diperbaiki oleh Recompute.
+type StatsRecompute struct {
+	MatchScores int `json:"match_scores"`
+	GameResults int `json:"game_results"`
+	GamePatches int `json:"game_patches"`
+}
+
+// StatsRepository menghitung ulang data turunan yang disimpan: skor match
+// dari pemenang game, baris GameResult, dan patch game yang belum tercatat
+// dari tanggal match. Total hero pick/ban diisi manual sehingga tidak
+// dihitung ulang. Repository ini juga menghitung statistik pemain, coach dan tim per
+// tournament, opsional dibatasi ke satu patch.
+type StatsRepository interface {
+	// Recompute memperbaiki data turunan untuk satu tournament, atau semua
+	// tournament jika tournamentID 0, dalam satu transaksi.
+	Recompute(ctx context.Context, tournamentID uint) (StatsRecompute, error)
+
+	PlayerStatistics(ctx context.Context, playerID, tournamentID uint, patchID *uint) (dto.PlayerStats, error)
+	CoachStatistics(ctx context.Context, coachID, tournamentID uint, patchID *uint) (dto.CoachStats, error)
+	// FindTeamMatches mengembalikan Match tim di tournament; jika patchID
+	// diisi, hanya Match yang punya game di patch tersebut.
+	FindTeamMatches(ctx context.Context, teamID, tournamentID uint, patchID *uint) ([]models.Match, error)
+	// FindTeamGames mengembalikan game tim di Match matchIDs.
+	FindTeamGames(ctx context.Context, teamID uint, matchIDs []uint, patchID *uint) ([]models.Game, error)
+}
+
+type statsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+func (r *statsRepository) Recompute(ctx context.Context, tournamentID uint) (StatsRecompute, error) {
+	var result StatsRecompute
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		matchQuery := func() *gorm.DB {
+			query := tx.Model(&models.Match{})
+			if tournamentID != 0 {
+				query = query.Where("tournament_id = ?", tournamentID)
+			}
+			return query
+		}
+
+		var matches []models.Match
+		if err := matchQuery().Find(&matches).Error; err != nil {
+			return err
+		}
+
+		for _, match := range matches {
+			var games []models.Game
+			if err := tx.Where("match_id = ?", match.MatchID).Find(&games).Error; err != nil {
+				return err
+			}
+
+			scoreA, scoreB := 0, 0
+			for _, game := range games {
+				switch game.WinnerTeamID {
+				case match.TeamAID:
+					scoreA++
+				case match.TeamBID:
+					scoreB++
+				}
+
+				fixed, err := recomputeGameResults(tx, match, game)
+				if err != nil {
+					return err
+				}
+				result.GameResults += fixed
+
+				assigned, err := assignGamePatch(tx, match, game)
+				if err != nil {
+					return err
+				}
+				if assigned {
+					result.GamePatches++
+				}
+			}
+
+			// Match tanpa game (misalnya skor diisi manual) dibiarkan.
+			if len(games) > 0 && (scoreA != match.TeamAScore || scoreB != match.TeamBScore) {
+				if err := tx.Model(&match).Updates(map[string]interface{}{"team_a_score": scoreA, "team_b_score": scoreB}).Error; err != nil {
+					return err
+				}
+				result.MatchScores++
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// recomputeGameResults memastikan game punya tepat satu GameResult untuk
+// setiap team di match sesuai WinnerTeamID, dan mengembalikan jumlah baris
+// yang dibuat, diubah atau dihapus.
+func recomputeGameResults(tx *gorm.DB, match models.Match, game models.Game) (int, error) {
+	var existing []models.GameResult
+	if err := tx.Where("game_id = ?", game.GameID).Find(&existing).Error; err != nil {
+		return 0, err
+	}
+
+	fixed := 0
+	seen := map[uint]bool{}
+	for _, gameResult := range existing {
+		if (gameResult.TeamID != match.TeamAID && gameResult.TeamID != match.TeamBID) || seen[gameResult.TeamID] {
+			if err := tx.Delete(&gameResult).Error; err != nil {
+				return fixed, err
+			}
+			fixed++
+			continue
+		}
+		seen[gameResult.TeamID] = true
+
+		want := gameResultFor(game, gameResult.TeamID)
+		if gameResult.Result != want {
+			if err := tx.Model(&gameResult).Update("result", want).Error; err != nil {
+				return fixed, err
+			}
+			fixed++
+		}
+	}
+
+	for _, teamID := range []uint{match.TeamAID, match.TeamBID} {
+		if seen[teamID] {
+			continue
+		}
+		if err := tx.Create(&models.GameResult{GameID: game.GameID, TeamID: teamID, Result: gameResultFor(game, teamID)}).Error; err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+
+	return fixed, nil
+}
+
+func gameResultFor(game models.Game, teamID uint) string {
+	if game.WinnerTeamID == teamID {
+		return "win"
+	}
+	return "lose"
+}
+
+// assignGamePatch mencatat patch yang berlaku pada tanggal match untuk game
+// yang belum punya patch. Game di match tanpa tanggal dibiarkan.
+func assignGamePatch(tx *gorm.DB, match models.Match, game models.Game) (bool, error) {
+	if game.PatchID != nil || match.PlayedAt == nil {
+		return false, nil
+	}
+
+	var patch models.Patch
+	err := tx.Where("release_date <= ?", *match.PlayedAt).Order("release_date DESC, patch_id DESC").First(&patch).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Model(&game).Update("patch_id", patch.PatchID).Error
+}
+
+func (r *statsRepository) PlayerStatistics(ctx context.Context, playerID, tournamentID uint, patchID *uint) (dto.PlayerStats, error) {
+	return r.lineupStatistics(ctx, "players", "player_matches", "player_id", playerID, tournamentID, patchID)
+}
+
+func (r *statsRepository) CoachStatistics(ctx context.Context, coachID, tournamentID uint, patchID *uint) (dto.CoachStats, error) {
+	stats, err := r.lineupStatistics(ctx, "coaches", "coach_matches", "coach_id", coachID, tournamentID, patchID)
+	return dto.CoachStats(stats), err
+}
+
+// lineupStatistics menghitung match dan game yang diikuti seorang pemain atau
+// coach lewat tabel lineup-nya. Menang dihitung untuk tim anggota tersebut
+// saat ini.
+func (r *statsRepository) lineupStatistics(ctx context.Context, members, lineups, idColumn string, id, tournamentID uint, patchID *uint) (dto.PlayerStats, error) {
+	var stats dto.PlayerStats
+	from := fmt.Sprintf(`
+		FROM %[1]s p
+		JOIN teams t ON p.team_id = t.team_id AND t.deleted_at IS NULL
+		JOIN %[2]s pm ON p.%[3]s = pm.%[3]s
+		JOIN match_team_details mtd ON pm.match_team_detail_id = mtd.match_team_detail_id
+		JOIN matches m ON mtd.match_id = m.match_id
+	`, members, lineups, idColumn)
+	where := fmt.Sprintf("WHERE p.%s = ? AND m.tournament_id = ? AND m.deleted_at IS NULL", idColumn)
+
+	matchQuery := `
+		SELECT
+			COUNT(DISTINCT m.match_id) AS total_match,
+			SUM(CASE
+				WHEN (m.team_a_id = t.team_id AND m.team_a_score > m.team_b_score) OR
+					 (m.team_b_id = t.team_id AND m.team_b_score > m.team_a_score)
+				THEN 1 ELSE 0 END) AS total_match_win
+	` + from + where
+	matchArgs := []interface{}{id, tournamentID}
+	if patchID != nil {
+		matchQuery += " AND m.match_id IN (SELECT match_id FROM games WHERE patch_id = ? AND deleted_at IS NULL)"
+		matchArgs = append(matchArgs, *patchID)
+	}
+	if err := r.db.WithContext(ctx).Raw(matchQuery, matchArgs...).Scan(&stats).Error; err != nil {
+		return stats, err
+	}
+
+	var games struct {
+		TotalGame    int
+		TotalGameWin int
+	}
+	gameQuery := `
+		SELECT
+			COUNT(DISTINCT g.game_id) AS total_game,
+			SUM(CASE WHEN g.winner_team_id = t.team_id THEN 1 ELSE 0 END) AS total_game_win
+	` + from + `
+		JOIN games g ON m.match_id = g.match_id
+	` + where + " AND g.deleted_at IS NULL"
+	gameArgs := []interface{}{id, tournamentID}
+	if patchID != nil {
+		gameQuery += " AND g.patch_id = ?"
+		gameArgs = append(gameArgs, *patchID)
+	}
+	if err := r.db.WithContext(ctx).Raw(gameQuery, gameArgs...).Scan(&games).Error; err != nil {
+		return stats, err
+	}
+
+	stats.TotalGame = games.TotalGame
+	stats.TotalGameWin = games.TotalGameWin
+	return stats, nil
+}
+
+func (r *statsRepository) FindTeamMatches(ctx context.Context, teamID, tournamentID uint, patchID *uint) ([]models.Match, error) {
+	var matches []models.Match
+	query := r.db.WithContext(ctx).Where("tournament_id = ? AND (team_a_id = ? OR team_b_id = ?)", tournamentID, teamID, teamID)
+	if patchID != nil {
+		query = query.Where("match_id IN (?)", r.db.Model(&models.Game{}).Select("match_id").Where("patch_id = ?", *patchID))
+	}
+	err := query.Find(&matches).Error
+	return matches, err
+}
+
+func (r *statsRepository) FindTeamGames(ctx context.Context, teamID uint, matchIDs []uint, patchID *uint) ([]models.Game, error) {
+	var games []models.Game
+	query := r.db.WithContext(ctx).Where("match_id IN ? AND (first_pick_team_id = ? OR second_pick_team_id = ?)", matchIDs, teamID, teamID)
+	if patchID != nil {
+		query = query.Where("patch_id = ?", *patchID)
+	}
+	err := query.Find(&games).Error
+	return games, err
+}
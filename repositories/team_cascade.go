@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"fmt"
+	"log"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// deleteTeam melakukan soft delete pada Team beserta Match yang diikutinya.
+// Player, Coach dan logo tim baru dihapus saat Team di-purge.
+func deleteTeam(db *gorm.DB, team models.Team) error {
+	// Mulai transaksi
+	tx := beginSoftDelete(db)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	var matchIDs []uint
+	if err := tx.Model(&models.Match{}).Where("team_a_id = ? OR team_b_id = ?", team.TeamID, team.TeamID).Pluck("match_id", &matchIDs).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal mendapatkan semua Match: %w", err)
+	}
+
+	if err := softDeleteMatches(tx, matchIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Delete(&models.Team{}, team.TeamID).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus Team: %w", err)
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+
+	log.Printf("Team dengan ID %d telah di-soft delete.", team.TeamID)
+	return nil
+}
+
+// purgeTeam menghapus permanen Team beserta semua relasi terkait termasuk
+// Match, Player, Coach dan logo tim.
+func purgeTeam(db *gorm.DB, files storage.Storage, team models.Team) error {
+	// Mulai transaksi
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	matches := []models.Match{}
+	if err := tx.Unscoped().Where("team_a_id = ? OR team_b_id = ?", team.TeamID, team.TeamID).Find(&matches).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal mendapatkan semua Match: %w", err)
+	}
+
+	// 2. Panggil purgeMatch untuk setiap Match terkait
+	for _, match := range matches {
+		if err := purgeMatch(db, files, match.MatchID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	players := []models.Player{}
+	if err := tx.Where("team_id = ?", team.TeamID).Find(&players).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal mendapatkan semua Player: %w", err)
+	}
+
+	//panggil DeletePlayer untuk setiap Player terkait
+	for _, player := range players {
+		if err := deletePlayer(db, files, player); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	coaches := []models.Coach{}
+	if err := tx.Where("team_id = ?", team.TeamID).Find(&coaches).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal mendapatkan semua Coach: %w", err)
+	}
+
+	//panggil DeleteCoach untuk setiap Coach terkait
+	for _, coach := range coaches {
+		if err := deleteCoach(db, files, coach); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Where("team_id = ?", team.TeamID).Delete(&models.TeamAlias{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus TeamAlias: %w", err)
+	}
+
+	// 7. Hapus Team itu sendiri
+	if err := tx.Unscoped().Delete(&models.Team{}, team.TeamID).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("gagal menghapus Team: %w", err)
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+
+	if err := removeImage(db, files, team.Image); err != nil {
+		return err
+	}
+
+	log.Printf("Team dengan ID %d dan semua data terkait telah dihapus permanen.", team.TeamID)
+	return nil
+}
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// TeamRepository menyimpan dan membaca Team beserta Player dan Coach-nya.
+type TeamRepository interface {
+	FindAll(ctx context.Context) ([]models.Team, error)
+	FindByID(ctx context.Context, teamID uint) (models.Team, error)
+	Create(ctx context.Context, team *models.Team) error
+	Update(ctx context.Context, team *models.Team) error
+	// Delete melakukan soft delete pada Team beserta Match yang diikutinya.
+	Delete(ctx context.Context, team models.Team) error
+	Restore(ctx context.Context, teamID uint) error
+	// PurgeDeletedBefore menghapus permanen Team yang di-soft delete sebelum t
+	// dan mengembalikan jumlahnya.
+	PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error)
+
+	FindPlayers(ctx context.Context, teamID uint) ([]models.Player, error)
+	FindPlayerByID(ctx context.Context, playerID uint) (models.Player, error)
+	CreatePlayer(ctx context.Context, player *models.Player) error
+	UpdatePlayer(ctx context.Context, player *models.Player) error
+	DeletePlayer(ctx context.Context, player models.Player) error
+
+	FindCoaches(ctx context.Context, teamID uint) ([]models.Coach, error)
+	FindCoachByID(ctx context.Context, coachID uint) (models.Coach, error)
+	CreateCoach(ctx context.Context, coach *models.Coach) error
+	UpdateCoach(ctx context.Context, coach *models.Coach) error
+	DeleteCoach(ctx context.Context, coach models.Coach) error
+}
+
+type teamRepository struct {
+	db    *gorm.DB
+	files storage.Storage
+}
+
+// NewTeamRepository membuat TeamRepository; gambar di files ikut
+// dihapus saat datanya dihapus permanen.
+func NewTeamRepository(db *gorm.DB, files storage.Storage) TeamRepository {
+	return &teamRepository{db: db, files: files}
+}
+
+func (r *teamRepository) FindAll(ctx context.Context) ([]models.Team, error) {
+	teams := []models.Team{}
+	err := r.db.WithContext(ctx).Find(&teams).Error
+	return teams, err
+}
+
+func (r *teamRepository) FindByID(ctx context.Context, teamID uint) (models.Team, error) {
+	var team models.Team
+	err := r.db.WithContext(ctx).First(&team, teamID).Error
+	return team, translate(err)
+}
+
+func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
+	return r.db.WithContext(ctx).Create(team).Error
+}
+
+func (r *teamRepository) Update(ctx context.Context, team *models.Team) error {
+	return r.db.WithContext(ctx).Save(team).Error
+}
+
+func (r *teamRepository) Delete(ctx context.Context, team models.Team) error {
+	return deleteTeam(r.db.WithContext(ctx), team)
+}
+
+func (r *teamRepository) Restore(ctx context.Context, teamID uint) error {
+	return translate(restoreTeam(r.db.WithContext(ctx), teamID))
+}
+
+func (r *teamRepository) PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error) {
+	db := r.db.WithContext(ctx)
+
+	var teams []models.Team
+	if err := deletedBefore(db, t).Find(&teams).Error; err != nil {
+		return 0, err
+	}
+	for i, team := range teams {
+		if err := purgeTeam(db, r.files, team); err != nil {
+			return i, err
+		}
+	}
+
+	return len(teams), nil
+}
+
+func (r *teamRepository) FindPlayers(ctx context.Context, teamID uint) ([]models.Player, error) {
+	players := []models.Player{}
+	err := r.db.WithContext(ctx).Where("team_id = ?", teamID).Find(&players).Error
+	return players, err
+}
+
+func (r *teamRepository) FindPlayerByID(ctx context.Context, playerID uint) (models.Player, error) {
+	var player models.Player
+	err := r.db.WithContext(ctx).First(&player, playerID).Error
+	return player, translate(err)
+}
+
+func (r *teamRepository) CreatePlayer(ctx context.Context, player *models.Player) error {
+	return r.db.WithContext(ctx).Create(player).Error
+}
+
+func (r *teamRepository) UpdatePlayer(ctx context.Context, player *models.Player) error {
+	return r.db.WithContext(ctx).Save(player).Error
+}
+
+func (r *teamRepository) DeletePlayer(ctx context.Context, player models.Player) error {
+	return deletePlayer(r.db.WithContext(ctx), r.files, player)
+}
+
+func (r *teamRepository) FindCoaches(ctx context.Context, teamID uint) ([]models.Coach, error) {
+	coaches := []models.Coach{}
+	err := r.db.WithContext(ctx).Where("team_id = ?", teamID).Find(&coaches).Error
+	return coaches, err
+}
+
+func (r *teamRepository) FindCoachByID(ctx context.Context, coachID uint) (models.Coach, error) {
+	var coach models.Coach
+	err := r.db.WithContext(ctx).First(&coach, coachID).Error
+	return coach, translate(err)
+}
+
+func (r *teamRepository) CreateCoach(ctx context.Context, coach *models.Coach) error {
+	return r.db.WithContext(ctx).Create(coach).Error
+}
+
+func (r *teamRepository) UpdateCoach(ctx context.Context, coach *models.Coach) error {
+	return r.db.WithContext(ctx).Save(coach).Error
+}
+
+func (r *teamRepository) DeleteCoach(ctx context.Context, coach models.Coach) error {
+	return deleteCoach(r.db.WithContext(ctx), r.files, coach)
+}
@@ -0,0 +1,721 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TournamentBackupRepository membuat dan memulihkan backup JSON sebuah
+// Tournament.
+type TournamentBackupRepository interface {
+	// Export mengembalikan ErrNotFound jika Tournament tidak ada. Match dan
+	// Game yang sudah di-soft delete tidak ikut. Isi gambar dibaca dari
+	// storage; objek yang sudah hilang dilewati.
+	Export(ctx context.Context, tournamentID uint) (dto.TournamentBundleDto, error)
+	// Import membuat Tournament baru dari bundle dalam satu transaksi.
+	// Team dan hero dicocokkan lewat nama atau alias, patch lewat versi,
+	// pemain lewat team dan nama atau alias, dan pelatih lewat team dan
+	// nama; yang belum ada dibuat, yang sudah ada tidak diubah. Team yang
+	// cocok tetapi sudah di-soft delete ditolak dengan DeletedTeamError.
+	// Files tidak dibaca; gambar di bundle harus sudah ditulis ulang ke
+	// storage oleh pemanggil. Bundle harus sudah divalidasi.
+	Import(ctx context.Context, bundle dto.TournamentBundleDto) (dto.TournamentRestoreDto, error)
+}
+
+// DeletedTeamError dikembalikan Import jika team di bundle cocok dengan team
+// yang sudah di-soft delete; team harus di-restore dulu.
+type DeletedTeamError struct {
+	Name string
+}
+
+func (e *DeletedTeamError) Error() string {
+	return "Team " + e.Name + " is deleted, restore it first"
+}
+
+type tournamentBackupRepository struct {
+	db    *gorm.DB
+	files storage.Storage
+}
+
+func NewTournamentBackupRepository(db *gorm.DB, files storage.Storage) TournamentBackupRepository {
+	return &tournamentBackupRepository{db: db, files: files}
+}
+
+// tournamentBackupState adalah semua baris di bawah sebuah Tournament.
+type tournamentBackupState struct {
+	matches       []models.Match
+	details       []models.MatchTeamDetail
+	playerMatches []models.PlayerMatch
+	coachMatches  []models.CoachMatch
+	games         []models.Game
+	gameResults   []models.GameResult
+	heroPicks     []models.HeroPick
+	heroPickGames []models.HeroPickGame
+	heroBans      []models.HeroBan
+	heroBanGames  []models.HeroBanGame
+	priorityPicks []models.PriorityPick
+	priorityBans  []models.PriorityBan
+	flexPicks     []models.FlexPick
+	explaners     []models.Explaner
+	goldlaners    []models.Goldlaner
+	trioMids      []models.TrioMid
+	trioMidHeroes []models.TrioMidHero
+	lordResults   []models.LordResult
+	turtleResults []models.TurtleResult
+}
+
+func (r *tournamentBackupRepository) load(db *gorm.DB, tournamentID uint) (tournamentBackupState, error) {
+	var state tournamentBackupState
+
+	matches := db.Model(&models.Match{}).Select("match_id").Where("tournament_id = ?", tournamentID)
+	details := db.Model(&models.MatchTeamDetail{}).Select("match_team_detail_id").Where("match_id IN (?)", matches)
+	games := db.Model(&models.Game{}).Select("game_id").Where("match_id IN (?)", matches)
+	trioMids := db.Model(&models.TrioMid{}).Select("trio_mid_id").Where("game_id IN (?)", games)
+	heroPicks := db.Model(&models.HeroPick{}).Select("hero_pick_id").Where("match_team_detail_id IN (?)", details)
+	heroBans := db.Model(&models.HeroBan{}).Select("hero_ban_id").Where("match_team_detail_id IN (?)", details)
+
+	queries := []struct {
+		dest  interface{}
+		query *gorm.DB
+	}{
+		{&state.matches, db.Where("tournament_id = ?", tournamentID).Order("match_id")},
+		{&state.details, db.Where("match_id IN (?)", matches).Order("match_team_detail_id")},
+		{&state.playerMatches, db.Where("match_team_detail_id IN (?)", details).Order("player_match_id")},
+		{&state.coachMatches, db.Where("match_team_detail_id IN (?)", details).Order("coach_match_id")},
+		{&state.games, db.Where("match_id IN (?)", matches).Order("game_number, game_id")},
+		{&state.gameResults, db.Where("game_id IN (?)", games).Order("game_result_id")},
+		{&state.heroPicks, db.Where("match_team_detail_id IN (?)", details).Order("hero_pick_id")},
+		{&state.heroPickGames, db.Where("hero_pick_id IN (?) AND game_id IN (?)", heroPicks, games).Order("game_number, hero_pick_game_id")},
+		{&state.heroBans, db.Where("match_team_detail_id IN (?)", details).Order("hero_ban_id")},
+		{&state.heroBanGames, db.Where("hero_ban_id IN (?) AND game_id IN (?)", heroBans, games).Order("game_number, hero_ban_game_id")},
+		{&state.priorityPicks, db.Where("match_team_detail_id IN (?)", details).Order("priority_pick_id")},
+		{&state.priorityBans, db.Where("match_team_detail_id IN (?)", details).Order("priority_ban_id")},
+		{&state.flexPicks, db.Where("match_team_detail_id IN (?)", details).Order("flex_pick_id")},
+		{&state.explaners, db.Where("game_id IN (?)", games).Order("explaner_id")},
+		{&state.goldlaners, db.Where("game_id IN (?)", games).Order("goldlaner_id")},
+		{&state.trioMids, db.Where("game_id IN (?)", games).Order("trio_mid_id")},
+		{&state.trioMidHeroes, db.Where("trio_mid_id IN (?)", trioMids).Order("trio_mid_hero_id")},
+		{&state.lordResults, db.Where("game_id IN (?)", games).Order("lord_result_id")},
+		{&state.turtleResults, db.Where("game_id IN (?)", games).Order("turtle_result_id")},
+	}
+	for _, q := range queries {
+		if err := q.query.Find(q.dest).Error; err != nil {
+			return state, err
+		}
+	}
+	return state, nil
+}
+
+func (r *tournamentBackupRepository) Export(ctx context.Context, tournamentID uint) (dto.TournamentBundleDto, error) {
+	db := r.db.WithContext(ctx)
+	bundle := dto.TournamentBundleDto{
+		Version:    dto.TournamentBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Teams:      []dto.BundleTeamDto{},
+		Players:    []dto.BundlePlayerDto{},
+		Coaches:    []dto.BundleCoachDto{},
+		Heroes:     []dto.BundleHeroDto{},
+		Patches:    []dto.BundlePatchDto{},
+		Matches:    []dto.BundleMatchDto{},
+		Files:      map[string][]byte{},
+	}
+
+	var tournament models.Tournament
+	if err := db.First(&tournament, tournamentID).Error; err != nil {
+		return bundle, translate(err)
+	}
+	bundle.Tournament.Name = tournament.Name
+
+	state, err := r.load(db, tournamentID)
+	if err != nil {
+		return bundle, err
+	}
+
+	// ID master data yang dirujuk, untuk disertakan di bundle
+	var teamIDs, heroIDs, playerIDs, coachIDs, patchIDs []uint
+	var images []models.Image
+
+	var rule models.TournamentRule
+	err = db.Preload("DisabledHeroes").First(&rule, "tournament_id = ?", tournamentID).Error
+	switch {
+	case err == nil:
+		bundle.Tournament.Rule = &dto.BundleRuleDto{
+			DraftFormat:     rule.DraftFormat,
+			BansFirstPhase:  rule.BansFirstPhase,
+			BansSecondPhase: rule.BansSecondPhase,
+			FearlessMode:    rule.FearlessMode,
+			DisabledHeroIDs: []uint{},
+		}
+		for _, hero := range rule.DisabledHeroes {
+			bundle.Tournament.Rule.DisabledHeroIDs = append(bundle.Tournament.Rule.DisabledHeroIDs, hero.HeroID)
+			heroIDs = append(heroIDs, hero.HeroID)
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return bundle, err
+	}
+
+	// Baris anak ditempelkan ke induknya lewat pointer; induk disalin ke
+	// bundle setelah semua anaknya lengkap.
+	games := map[uint]*dto.BundleGameDto{}
+	gameNumbers := map[uint]int{}
+	for _, game := range state.games {
+		gameNumbers[game.GameID] = game.GameNumber
+		games[game.GameID] = &dto.BundleGameDto{
+			GameNumber:       game.GameNumber,
+			FirstPickTeamID:  game.FirstPickTeamID,
+			SecondPickTeamID: game.SecondPickTeamID,
+			WinnerTeamID:     game.WinnerTeamID,
+			VideoLink:        game.VideoLink,
+			FullDraftImage:   string(game.FullDraftImage),
+			PatchID:          game.PatchID,
+			Results:          []dto.BundleGameResultDto{},
+			Explaners:        []dto.BundleLaneDto{},
+			Goldlaners:       []dto.BundleLaneDto{},
+			TrioMids:         []dto.BundleTrioMidDto{},
+			LordResults:      []dto.BundleObjectiveDto{},
+			TurtleResults:    []dto.BundleObjectiveDto{},
+		}
+		teamIDs = append(teamIDs, game.FirstPickTeamID, game.SecondPickTeamID, game.WinnerTeamID)
+		images = append(images, game.FullDraftImage)
+		if game.PatchID != nil {
+			patchIDs = append(patchIDs, *game.PatchID)
+		}
+	}
+	for _, result := range state.gameResults {
+		g := games[result.GameID]
+		g.Results = append(g.Results, dto.BundleGameResultDto{TeamID: result.TeamID, Result: result.Result})
+		teamIDs = append(teamIDs, result.TeamID)
+	}
+	for _, lane := range state.explaners {
+		g := games[lane.GameID]
+		g.Explaners = append(g.Explaners, dto.BundleLaneDto{TeamID: lane.TeamID, HeroID: lane.HeroID, EarlyResult: lane.EarlyResult})
+		teamIDs, heroIDs = append(teamIDs, lane.TeamID), append(heroIDs, lane.HeroID)
+	}
+	for _, lane := range state.goldlaners {
+		g := games[lane.GameID]
+		g.Goldlaners = append(g.Goldlaners, dto.BundleLaneDto{TeamID: lane.TeamID, HeroID: lane.HeroID, EarlyResult: lane.EarlyResult})
+		teamIDs, heroIDs = append(teamIDs, lane.TeamID), append(heroIDs, lane.HeroID)
+	}
+	trioMids := map[uint]*dto.BundleTrioMidDto{}
+	for _, trioMid := range state.trioMids {
+		trioMids[trioMid.TrioMidID] = &dto.BundleTrioMidDto{TeamID: trioMid.TeamID, EarlyResult: trioMid.EarlyResult, Heroes: []dto.BundleTrioMidHeroDto{}}
+		teamIDs = append(teamIDs, trioMid.TeamID)
+	}
+	for _, hero := range state.trioMidHeroes {
+		t := trioMids[hero.TrioMidID]
+		t.Heroes = append(t.Heroes, dto.BundleTrioMidHeroDto{HeroID: hero.HeroID, Role: hero.Role, EarlyResult: hero.EarlyResult})
+		heroIDs = append(heroIDs, hero.HeroID)
+	}
+	for _, trioMid := range state.trioMids {
+		g := games[trioMid.GameID]
+		g.TrioMids = append(g.TrioMids, *trioMids[trioMid.TrioMidID])
+	}
+	for _, objective := range state.lordResults {
+		g := games[objective.GameID]
+		g.LordResults = append(g.LordResults, dto.BundleObjectiveDto{TeamID: objective.TeamID, Phase: objective.Phase, Setup: objective.Setup, Initiate: objective.Initiate, Result: objective.Result})
+		teamIDs = append(teamIDs, objective.TeamID)
+	}
+	for _, objective := range state.turtleResults {
+		g := games[objective.GameID]
+		g.TurtleResults = append(g.TurtleResults, dto.BundleObjectiveDto{TeamID: objective.TeamID, Phase: objective.Phase, Setup: objective.Setup, Initiate: objective.Initiate, Result: objective.Result})
+		teamIDs = append(teamIDs, objective.TeamID)
+	}
+
+	details := map[uint]*dto.BundleMatchTeamDto{}
+	for _, detail := range state.details {
+		details[detail.MatchTeamDetailID] = &dto.BundleMatchTeamDto{
+			TeamID:        detail.TeamID,
+			Players:       []dto.BundlePlayerMatchDto{},
+			Coaches:       []dto.BundleCoachMatchDto{},
+			HeroPicks:     []dto.BundleHeroDraftDto{},
+			HeroBans:      []dto.BundleHeroDraftDto{},
+			PriorityPicks: []dto.BundlePriorityDto{},
+			PriorityBans:  []dto.BundlePriorityDto{},
+			FlexPicks:     []dto.BundlePriorityDto{},
+		}
+		teamIDs = append(teamIDs, detail.TeamID)
+	}
+	for _, player := range state.playerMatches {
+		d := details[player.MatchTeamDetailID]
+		d.Players = append(d.Players, dto.BundlePlayerMatchDto{PlayerID: player.PlayerID, Role: player.Role})
+		playerIDs = append(playerIDs, player.PlayerID)
+	}
+	for _, coach := range state.coachMatches {
+		d := details[coach.MatchTeamDetailID]
+		d.Coaches = append(d.Coaches, dto.BundleCoachMatchDto{CoachID: coach.CoachID, Role: coach.Role})
+		coachIDs = append(coachIDs, coach.CoachID)
+	}
+
+	heroPicks := map[uint]*dto.BundleHeroDraftDto{}
+	for _, pick := range state.heroPicks {
+		heroPicks[pick.HeroPickID] = &dto.BundleHeroDraftDto{HeroID: pick.HeroID, FirstPhase: pick.FirstPhase, SecondPhase: pick.SecondPhase, Total: pick.Total, Games: []dto.BundleHeroDraftGameDto{}}
+		heroIDs = append(heroIDs, pick.HeroID)
+	}
+	for _, game := range state.heroPickGames {
+		p := heroPicks[game.HeroPickID]
+		p.Games = append(p.Games, dto.BundleHeroDraftGameDto{GameNumber: gameNumbers[game.GameID], Drafted: game.IsPicked})
+	}
+	for _, pick := range state.heroPicks {
+		d := details[pick.MatchTeamDetailID]
+		d.HeroPicks = append(d.HeroPicks, *heroPicks[pick.HeroPickID])
+	}
+	heroBans := map[uint]*dto.BundleHeroDraftDto{}
+	for _, ban := range state.heroBans {
+		heroBans[ban.HeroBanID] = &dto.BundleHeroDraftDto{HeroID: ban.HeroID, FirstPhase: ban.FirstPhase, SecondPhase: ban.SecondPhase, Total: ban.Total, Games: []dto.BundleHeroDraftGameDto{}}
+		heroIDs = append(heroIDs, ban.HeroID)
+	}
+	for _, game := range state.heroBanGames {
+		b := heroBans[game.HeroBanID]
+		b.Games = append(b.Games, dto.BundleHeroDraftGameDto{GameNumber: gameNumbers[game.GameID], Drafted: game.IsBanned})
+	}
+	for _, ban := range state.heroBans {
+		d := details[ban.MatchTeamDetailID]
+		d.HeroBans = append(d.HeroBans, *heroBans[ban.HeroBanID])
+	}
+	for _, pick := range state.priorityPicks {
+		d := details[pick.MatchTeamDetailID]
+		d.PriorityPicks = append(d.PriorityPicks, dto.BundlePriorityDto{HeroID: pick.HeroID, Role: pick.Role, Total: pick.Total, Rate: pick.PickRate})
+		heroIDs = append(heroIDs, pick.HeroID)
+	}
+	for _, ban := range state.priorityBans {
+		d := details[ban.MatchTeamDetailID]
+		d.PriorityBans = append(d.PriorityBans, dto.BundlePriorityDto{HeroID: ban.HeroID, Role: ban.Role, Total: ban.Total, Rate: ban.BanRate})
+		heroIDs = append(heroIDs, ban.HeroID)
+	}
+	for _, pick := range state.flexPicks {
+		d := details[pick.MatchTeamDetailID]
+		d.FlexPicks = append(d.FlexPicks, dto.BundlePriorityDto{HeroID: pick.HeroID, Role: pick.Role, Total: pick.Total, Rate: pick.PickRate})
+		heroIDs = append(heroIDs, pick.HeroID)
+	}
+
+	for _, match := range state.matches {
+		m := dto.BundleMatchDto{
+			Stage:      match.Stage,
+			Day:        match.Day,
+			Date:       match.Date,
+			PlayedAt:   match.PlayedAt,
+			TeamAID:    match.TeamAID,
+			TeamBID:    match.TeamBID,
+			TeamAScore: match.TeamAScore,
+			TeamBScore: match.TeamBScore,
+			Teams:      []dto.BundleMatchTeamDto{},
+			Games:      []dto.BundleGameDto{},
+		}
+		teamIDs = append(teamIDs, match.TeamAID, match.TeamBID)
+		for _, detail := range state.details {
+			if detail.MatchID == match.MatchID {
+				m.Teams = append(m.Teams, *details[detail.MatchTeamDetailID])
+			}
+		}
+		for _, game := range state.games {
+			if game.MatchID == match.MatchID {
+				m.Games = append(m.Games, *games[game.GameID])
+			}
+		}
+		bundle.Matches = append(bundle.Matches, m)
+	}
+
+	var teams []models.Team
+	if err := db.Unscoped().Where("team_id IN ?", uniqueIDs(teamIDs)).Order("team_id").Find(&teams).Error; err != nil {
+		return bundle, err
+	}
+	for _, team := range teams {
+		bundle.Teams = append(bundle.Teams, dto.BundleTeamDto{TeamID: team.TeamID, Name: team.Name, Image: string(team.Image)})
+		images = append(images, team.Image)
+	}
+	var players []models.Player
+	if err := db.Where("player_id IN ?", uniqueIDs(playerIDs)).Order("player_id").Find(&players).Error; err != nil {
+		return bundle, err
+	}
+	for _, player := range players {
+		bundle.Players = append(bundle.Players, dto.BundlePlayerDto{PlayerID: player.PlayerID, TeamID: player.TeamID, Name: player.Name, Image: string(player.Image)})
+		images = append(images, player.Image)
+	}
+	var coaches []models.Coach
+	if err := db.Where("coach_id IN ?", uniqueIDs(coachIDs)).Order("coach_id").Find(&coaches).Error; err != nil {
+		return bundle, err
+	}
+	for _, coach := range coaches {
+		bundle.Coaches = append(bundle.Coaches, dto.BundleCoachDto{CoachID: coach.CoachID, TeamID: coach.TeamID, Name: coach.Name, Image: string(coach.Image)})
+		images = append(images, coach.Image)
+	}
+	var heroes []models.Hero
+	if err := db.Preload("Lanes").Preload("Aliases").Where("hero_id IN ?", uniqueIDs(heroIDs)).Order("hero_id").Find(&heroes).Error; err != nil {
+		return bundle, err
+	}
+	for _, hero := range heroes {
+		h := dto.BundleHeroDto{
+			HeroID:         hero.HeroID,
+			Name:           hero.Name,
+			Image:          string(hero.Image),
+			PrimaryClass:   hero.PrimaryClass,
+			SecondaryClass: hero.SecondaryClass,
+			ReleaseDate:    hero.ReleaseDate,
+			Lanes:          []string{},
+			Aliases:        []string{},
+		}
+		for _, lane := range hero.Lanes {
+			h.Lanes = append(h.Lanes, lane.Lane)
+		}
+		for _, alias := range hero.Aliases {
+			h.Aliases = append(h.Aliases, alias.Alias)
+		}
+		bundle.Heroes = append(bundle.Heroes, h)
+		images = append(images, hero.Image)
+	}
+	var patches []models.Patch
+	if err := db.Where("patch_id IN ?", uniqueIDs(patchIDs)).Order("patch_id").Find(&patches).Error; err != nil {
+		return bundle, err
+	}
+	for _, patch := range patches {
+		bundle.Patches = append(bundle.Patches, dto.BundlePatchDto{PatchID: patch.PatchID, Version: patch.Version, ReleaseDate: patch.ReleaseDate})
+	}
+
+	// Hanya file sumber yang disertakan; varian dibuat ulang saat restore
+	for _, image := range images {
+		key, ok := image.Key()
+		if !ok {
+			continue
+		}
+		if _, ok := bundle.Files[key]; ok {
+			continue
+		}
+		body, err := r.readFile(ctx, key)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return bundle, err
+		}
+		bundle.Files[key] = body
+	}
+
+	return bundle, nil
+}
+
+func (r *tournamentBackupRepository) readFile(ctx context.Context, key string) ([]byte, error) {
+	body, err := r.files.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// uniqueIDs membuang ID ganda. Daftar kosong menjadi []uint{0} agar klausa
+// IN tetap valid.
+func uniqueIDs(ids []uint) []uint {
+	seen := map[uint]bool{}
+	unique := []uint{0}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func (r *tournamentBackupRepository) Import(ctx context.Context, bundle dto.TournamentBundleDto) (dto.TournamentRestoreDto, error) {
+	result := dto.TournamentRestoreDto{Created: map[string]int{}, Reused: map[string]int{}}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		im := &bundleImport{tx: tx, result: &result}
+		if err := im.masterData(bundle); err != nil {
+			return err
+		}
+
+		tournament := models.Tournament{Name: bundle.Tournament.Name}
+		if err := im.create("tournament", &tournament); err != nil {
+			return err
+		}
+		result.TournamentID = tournament.TournamentID
+
+		if rule := bundle.Tournament.Rule; rule != nil {
+			saved := models.TournamentRule{
+				TournamentID:    tournament.TournamentID,
+				DraftFormat:     rule.DraftFormat,
+				BansFirstPhase:  rule.BansFirstPhase,
+				BansSecondPhase: rule.BansSecondPhase,
+				FearlessMode:    rule.FearlessMode,
+			}
+			if err := im.create("tournament_rule", &saved); err != nil {
+				return err
+			}
+			for _, heroID := range rule.DisabledHeroIDs {
+				disabled := models.TournamentDisabledHero{TournamentID: tournament.TournamentID, HeroID: im.heroes[heroID]}
+				if err := im.create("tournament_disabled_hero", &disabled); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, match := range bundle.Matches {
+			if err := im.match(tournament.TournamentID, match); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// bundleImport menyimpan isi bundle dan memetakan ID master data dari
+// instance asal ke ID di database ini.
+type bundleImport struct {
+	tx      *gorm.DB
+	result  *dto.TournamentRestoreDto
+	teams   map[uint]uint
+	players map[uint]uint
+	coaches map[uint]uint
+	heroes  map[uint]uint
+	patches map[uint]uint
+}
+
+func (im *bundleImport) create(kind string, value interface{}) error {
+	if err := im.tx.Omit(clause.Associations).Create(value).Error; err != nil {
+		return err
+	}
+	im.result.Created[kind]++
+	return nil
+}
+
+// findOrCreate membaca baris pertama dari query ke model. Jika tidak ada,
+// reset mengisi model lalu baris dibuat beserta asosiasinya.
+func (im *bundleImport) findOrCreate(kind string, query *gorm.DB, model interface{}, reset func()) error {
+	err := query.First(model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		reset()
+		if err := im.tx.Create(model).Error; err != nil {
+			return err
+		}
+		im.result.Created[kind]++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	im.result.Reused[kind]++
+	return nil
+}
+
+func (im *bundleImport) masterData(bundle dto.TournamentBundleDto) error {
+	tx := im.tx
+	im.teams, im.players, im.coaches, im.heroes, im.patches = map[uint]uint{}, map[uint]uint{}, map[uint]uint{}, map[uint]uint{}, map[uint]uint{}
+
+	for _, t := range bundle.Teams {
+		var team models.Team
+		if err := im.findOrCreate("team", nameOrAlias(tx.Unscoped(), models.NameKindTeam, t.Name), &team, func() {
+			team = models.Team{Name: t.Name, Image: models.Image(t.Image)}
+		}); err != nil {
+			return err
+		}
+		if team.DeletedAt.Valid {
+			return &DeletedTeamError{Name: team.Name}
+		}
+		im.teams[t.TeamID] = team.TeamID
+	}
+	for _, p := range bundle.Players {
+		var player models.Player
+		if err := im.findOrCreate("player", nameOrAlias(tx.Where("team_id = ?", im.teams[p.TeamID]), models.NameKindPlayer, p.Name), &player, func() {
+			player = models.Player{TeamID: im.teams[p.TeamID], Name: p.Name, Image: models.Image(p.Image)}
+		}); err != nil {
+			return err
+		}
+		im.players[p.PlayerID] = player.PlayerID
+	}
+	for _, c := range bundle.Coaches {
+		var coach models.Coach
+		if err := im.findOrCreate("coach", tx.Where("team_id = ? AND name = ?", im.teams[c.TeamID], c.Name), &coach, func() {
+			coach = models.Coach{TeamID: im.teams[c.TeamID], Name: c.Name, Image: models.Image(c.Image)}
+		}); err != nil {
+			return err
+		}
+		im.coaches[c.CoachID] = coach.CoachID
+	}
+	for _, h := range bundle.Heroes {
+		var hero models.Hero
+		if err := im.findOrCreate("hero", nameOrAlias(tx, models.NameKindHero, h.Name), &hero, func() {
+			hero = models.Hero{
+				Name:           h.Name,
+				Image:          models.Image(h.Image),
+				PrimaryClass:   h.PrimaryClass,
+				SecondaryClass: h.SecondaryClass,
+				ReleaseDate:    h.ReleaseDate,
+			}
+			for _, lane := range h.Lanes {
+				hero.Lanes = append(hero.Lanes, models.HeroLane{Lane: lane})
+			}
+			for _, alias := range h.Aliases {
+				hero.Aliases = append(hero.Aliases, models.HeroAlias{Alias: alias})
+			}
+		}); err != nil {
+			return err
+		}
+		im.heroes[h.HeroID] = hero.HeroID
+	}
+	for _, p := range bundle.Patches {
+		var patch models.Patch
+		if err := im.findOrCreate("patch", tx.Where("version = ?", p.Version), &patch, func() {
+			patch = models.Patch{Version: p.Version, ReleaseDate: p.ReleaseDate}
+		}); err != nil {
+			return err
+		}
+		im.patches[p.PatchID] = patch.PatchID
+	}
+	return nil
+}
+
+func (im *bundleImport) match(tournamentID uint, m dto.BundleMatchDto) error {
+	match := models.Match{
+		TournamentID: tournamentID,
+		Stage:        m.Stage,
+		Day:          m.Day,
+		Date:         m.Date,
+		PlayedAt:     m.PlayedAt,
+		TeamAID:      im.teams[m.TeamAID],
+		TeamBID:      im.teams[m.TeamBID],
+		TeamAScore:   m.TeamAScore,
+		TeamBScore:   m.TeamBScore,
+	}
+	if err := im.create("match", &match); err != nil {
+		return err
+	}
+
+	gameIDs := map[int]uint{}
+	for _, g := range m.Games {
+		gameID, err := im.game(match.MatchID, g)
+		if err != nil {
+			return err
+		}
+		gameIDs[g.GameNumber] = gameID
+	}
+
+	for _, t := range m.Teams {
+		detail := models.MatchTeamDetail{MatchID: match.MatchID, TeamID: im.teams[t.TeamID]}
+		if err := im.create("match_team_detail", &detail); err != nil {
+			return err
+		}
+		detailID := detail.MatchTeamDetailID
+
+		for _, p := range t.Players {
+			if err := im.create("player_match", &models.PlayerMatch{MatchTeamDetailID: detailID, PlayerID: im.players[p.PlayerID], Role: p.Role}); err != nil {
+				return err
+			}
+		}
+		for _, c := range t.Coaches {
+			if err := im.create("coach_match", &models.CoachMatch{MatchTeamDetailID: detailID, CoachID: im.coaches[c.CoachID], Role: c.Role}); err != nil {
+				return err
+			}
+		}
+		for _, p := range t.PriorityPicks {
+			if err := im.create("priority_pick", &models.PriorityPick{MatchTeamDetailID: detailID, HeroID: im.heroes[p.HeroID], Role: p.Role, Total: p.Total, PickRate: p.Rate}); err != nil {
+				return err
+			}
+		}
+		for _, b := range t.PriorityBans {
+			if err := im.create("priority_ban", &models.PriorityBan{MatchTeamDetailID: detailID, HeroID: im.heroes[b.HeroID], Role: b.Role, Total: b.Total, BanRate: b.Rate}); err != nil {
+				return err
+			}
+		}
+		for _, p := range t.FlexPicks {
+			if err := im.create("flex_pick", &models.FlexPick{MatchTeamDetailID: detailID, HeroID: im.heroes[p.HeroID], Role: p.Role, Total: p.Total, PickRate: p.Rate}); err != nil {
+				return err
+			}
+		}
+
+		for _, p := range t.HeroPicks {
+			pick := models.HeroPick{MatchTeamDetailID: detailID, HeroID: im.heroes[p.HeroID], FirstPhase: p.FirstPhase, SecondPhase: p.SecondPhase, Total: p.Total}
+			if err := im.create("hero_pick", &pick); err != nil {
+				return err
+			}
+			for _, g := range p.Games {
+				game := models.HeroPickGame{HeroPickID: pick.HeroPickID, GameID: gameIDs[g.GameNumber], GameNumber: g.GameNumber, IsPicked: g.Drafted}
+				if err := im.create("hero_pick_game", &game); err != nil {
+					return err
+				}
+			}
+		}
+		for _, b := range t.HeroBans {
+			ban := models.HeroBan{MatchTeamDetailID: detailID, HeroID: im.heroes[b.HeroID], FirstPhase: b.FirstPhase, SecondPhase: b.SecondPhase, Total: b.Total}
+			if err := im.create("hero_ban", &ban); err != nil {
+				return err
+			}
+			for _, g := range b.Games {
+				game := models.HeroBanGame{HeroBanID: ban.HeroBanID, GameID: gameIDs[g.GameNumber], GameNumber: g.GameNumber, IsBanned: g.Drafted}
+				if err := im.create("hero_ban_game", &game); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (im *bundleImport) game(matchID uint, g dto.BundleGameDto) (uint, error) {
+	game := models.Game{
+		MatchID:          matchID,
+		FirstPickTeamID:  im.teams[g.FirstPickTeamID],
+		SecondPickTeamID: im.teams[g.SecondPickTeamID],
+		WinnerTeamID:     im.teams[g.WinnerTeamID],
+		GameNumber:       g.GameNumber,
+		VideoLink:        g.VideoLink,
+		FullDraftImage:   models.Image(g.FullDraftImage),
+	}
+	if g.PatchID != nil {
+		patchID := im.patches[*g.PatchID]
+		game.PatchID = &patchID
+	}
+	if err := im.create("game", &game); err != nil {
+		return 0, err
+	}
+	gameID := game.GameID
+
+	for _, r := range g.Results {
+		if err := im.create("game_result", &models.GameResult{GameID: gameID, TeamID: im.teams[r.TeamID], Result: r.Result}); err != nil {
+			return 0, err
+		}
+	}
+	for _, l := range g.Explaners {
+		if err := im.create("explaner", &models.Explaner{GameID: gameID, TeamID: im.teams[l.TeamID], HeroID: im.heroes[l.HeroID], EarlyResult: l.EarlyResult}); err != nil {
+			return 0, err
+		}
+	}
+	for _, l := range g.Goldlaners {
+		if err := im.create("goldlaner", &models.Goldlaner{GameID: gameID, TeamID: im.teams[l.TeamID], HeroID: im.heroes[l.HeroID], EarlyResult: l.EarlyResult}); err != nil {
+			return 0, err
+		}
+	}
+	for _, t := range g.TrioMids {
+		trioMid := models.TrioMid{GameID: gameID, TeamID: im.teams[t.TeamID], EarlyResult: t.EarlyResult}
+		if err := im.create("trio_mid", &trioMid); err != nil {
+			return 0, err
+		}
+		for _, h := range t.Heroes {
+			if err := im.create("trio_mid_hero", &models.TrioMidHero{TrioMidID: trioMid.TrioMidID, HeroID: im.heroes[h.HeroID], Role: h.Role, EarlyResult: h.EarlyResult}); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for _, o := range g.LordResults {
+		if err := im.create("lord_result", &models.LordResult{GameID: gameID, TeamID: im.teams[o.TeamID], Phase: o.Phase, Setup: o.Setup, Initiate: o.Initiate, Result: o.Result}); err != nil {
+			return 0, err
+		}
+	}
+	for _, o := range g.TurtleResults {
+		if err := im.create("turtle_result", &models.TurtleResult{GameID: gameID, TeamID: im.teams[o.TeamID], Phase: o.Phase, Setup: o.Setup, Initiate: o.Initiate, Result: o.Result}); err != nil {
+			return 0, err
+		}
+	}
+	return gameID, nil
+}
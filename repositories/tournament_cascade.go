@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"log"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// deleteTournament melakukan soft delete pada Tournament beserta semua Match
+// dan Game di dalamnya, sehingga bisa dikembalikan dengan restoreTournament.
+func deleteTournament(db *gorm.DB, tournamentID uint) error {
+	// Mulai transaksi
+	tx := beginSoftDelete(db)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// 1. Temukan semua Match terkait dengan Tournament ini
+	var matchIDs []uint
+	if err := tx.Model(&models.Match{}).Where("tournament_id = ?", tournamentID).Pluck("match_id", &matchIDs).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 2. Soft delete setiap Match terkait
+	if err := softDeleteMatches(tx, matchIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 3. Hapus Tournament itu sendiri
+	if err := tx.Delete(&models.Tournament{}, tournamentID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	log.Printf("Tournament with ID %d has been soft deleted.", tournamentID)
+	return nil
+}
+
+// purgeTournament menghapus permanen Tournament dan semua relasi terkait.
+func purgeTournament(db *gorm.DB, files storage.Storage, tournamentID uint) error {
+	// Mulai transaksi
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// 1. Temukan semua Match terkait dengan Tournament ini
+	var matches []models.Match
+	if err := tx.Unscoped().Where("tournament_id = ?", tournamentID).Find(&matches).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 2. Panggil purgeMatch untuk setiap Match terkait
+	for _, match := range matches {
+		if err := purgeMatch(db, files, match.MatchID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// 3. Hapus aturan draft Tournament
+	if err := tx.Where("tournament_id = ?", tournamentID).Delete(&models.TournamentDisabledHero{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("tournament_id = ?", tournamentID).Delete(&models.TournamentRule{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 4. Hapus Tournament itu sendiri
+	if err := tx.Unscoped().Delete(&models.Tournament{}, tournamentID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	log.Printf("Tournament with ID %d and all related records have been purged.", tournamentID)
+	return nil
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TournamentRepository menyimpan dan membaca Tournament.
+type TournamentRepository interface {
+	FindAll(ctx context.Context) ([]models.Tournament, error)
+	FindByID(ctx context.Context, tournamentID uint) (models.Tournament, error)
+	Create(ctx context.Context, tournament *models.Tournament) error
+	Update(ctx context.Context, tournament *models.Tournament) error
+	// Delete melakukan soft delete pada Tournament beserta Match dan Game-nya.
+	Delete(ctx context.Context, tournamentID uint) error
+	Restore(ctx context.Context, tournamentID uint) error
+	// PurgeDeletedBefore menghapus permanen Tournament yang di-soft delete
+	// sebelum t dan mengembalikan jumlahnya.
+	PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error)
+
+	// FindRule mengembalikan aturan draft Tournament; ErrNotFound jika
+	// belum diatur.
+	FindRule(ctx context.Context, tournamentID uint) (models.TournamentRule, error)
+	// SaveRule menyimpan aturan draft dan mengganti daftar hero yang
+	// dinonaktifkan.
+	SaveRule(ctx context.Context, rule *models.TournamentRule) error
+}
+
+type tournamentRepository struct {
+	db    *gorm.DB
+	files storage.Storage
+}
+
+// NewTournamentRepository membuat TournamentRepository; gambar di files ikut
+// dihapus saat datanya dihapus permanen.
+func NewTournamentRepository(db *gorm.DB, files storage.Storage) TournamentRepository {
+	return &tournamentRepository{db: db, files: files}
+}
+
+func (r *tournamentRepository) FindAll(ctx context.Context) ([]models.Tournament, error) {
+	tournaments := []models.Tournament{}
+	err := r.db.WithContext(ctx).Find(&tournaments).Error
+	return tournaments, err
+}
+
+func (r *tournamentRepository) FindByID(ctx context.Context, tournamentID uint) (models.Tournament, error) {
+	var tournament models.Tournament
+	err := r.db.WithContext(ctx).First(&tournament, tournamentID).Error
+	return tournament, translate(err)
+}
+
+func (r *tournamentRepository) Create(ctx context.Context, tournament *models.Tournament) error {
+	return r.db.WithContext(ctx).Create(tournament).Error
+}
+
+func (r *tournamentRepository) Update(ctx context.Context, tournament *models.Tournament) error {
+	return r.db.WithContext(ctx).Save(tournament).Error
+}
+
+func (r *tournamentRepository) Delete(ctx context.Context, tournamentID uint) error {
+	return deleteTournament(r.db.WithContext(ctx), tournamentID)
+}
+
+func (r *tournamentRepository) Restore(ctx context.Context, tournamentID uint) error {
+	return translate(restoreTournament(r.db.WithContext(ctx), tournamentID))
+}
+
+func (r *tournamentRepository) PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error) {
+	db := r.db.WithContext(ctx)
+
+	var tournaments []models.Tournament
+	if err := deletedBefore(db, t).Find(&tournaments).Error; err != nil {
+		return 0, err
+	}
+	for i, tournament := range tournaments {
+		if err := purgeTournament(db, r.files, tournament.TournamentID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(tournaments), nil
+}
+
+func (r *tournamentRepository) FindRule(ctx context.Context, tournamentID uint) (models.TournamentRule, error) {
+	var rule models.TournamentRule
+	err := r.db.WithContext(ctx).
+		Preload("DisabledHeroes", func(db *gorm.DB) *gorm.DB { return db.Order("hero_id") }).
+		First(&rule, tournamentID).Error
+	return rule, translate(err)
+}
+
+func (r *tournamentRepository) SaveRule(ctx context.Context, rule *models.TournamentRule) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{UpdateAll: true}).Create(rule).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("tournament_id = ?", rule.TournamentID).Delete(&models.TournamentDisabledHero{}).Error; err != nil {
+			return err
+		}
+		for i := range rule.DisabledHeroes {
+			rule.DisabledHeroes[i].TournamentID = rule.TournamentID
+		}
+		if len(rule.DisabledHeroes) == 0 {
+			return nil
+		}
+		return tx.Create(&rule.DisabledHeroes).Error
+	})
+}
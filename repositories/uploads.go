@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	"gorm.io/gorm"
+)
+
+// removeImage menghapus semua varian objek milik image dari storage setelah
+// datanya dihapus permanen. URL eksternal seperti placeholder diabaikan.
+func removeImage(db *gorm.DB, files storage.Storage, image models.Image) error {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, key := range image.VariantKeys() {
+		if err := files.Delete(ctx, key); err != nil {
+			return fmt.Errorf("gagal menghapus gambar lama: %w", err)
+		}
+	}
+	return nil
+}
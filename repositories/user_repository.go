@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// UserRepository menyimpan dan membaca User.
+type UserRepository interface {
+	FindByUsername(ctx context.Context, username string) (models.User, error)
+	Create(ctx context.Context, user *models.User) error
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	return user, translate(err)
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
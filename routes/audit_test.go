@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"ml-master-data/dto"
+)
+
+func TestAuditLogRecordsActor(t *testing.T) {
+	s := newTestServer(t)
+
+	path := fmt.Sprintf("/tournaments/%d", s.fixtures.Tournament.TournamentID)
+	expect(t, s.request(http.MethodPut, path, map[string]string{"name": "MPL Season 1 Final"}), http.StatusOK, nil)
+
+	var logs []dto.AuditLogResponseDto
+	query := fmt.Sprintf("/audit?entity_type=tournaments&entity_id=%d&action=update", s.fixtures.Tournament.TournamentID)
+	expect(t, s.request(http.MethodGet, query, nil), http.StatusOK, &logs)
+	if len(logs) != 1 {
+		t.Fatalf("len(logs) = %d, want 1", len(logs))
+	}
+	if logs[0].UserID != s.fixtures.User.UserID || logs[0].Username != testUsername {
+		t.Fatalf("actor = %d %q, want %d %q", logs[0].UserID, logs[0].Username, s.fixtures.User.UserID, testUsername)
+	}
+
+	expect(t, s.request(http.MethodGet, "/audit?action=upsert", nil), http.StatusBadRequest, nil)
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogin(t *testing.T) {
+	s := newTestServer(t)
+	s.token = ""
+
+	var body struct {
+		Token string `json:"token"`
+	}
+	w := s.request(http.MethodPost, "/login", map[string]string{"username": testUsername, "password": testPassword})
+	expect(t, w, http.StatusOK, &body)
+	if body.Token == "" {
+		t.Fatal("token is empty")
+	}
+
+	w = s.request(http.MethodPost, "/login", map[string]string{"username": testUsername, "password": "wrong"})
+	expect(t, w, http.StatusUnauthorized, nil)
+
+	w = s.request(http.MethodPost, "/login", map[string]string{"username": "nobody", "password": testPassword})
+	expect(t, w, http.StatusUnauthorized, nil)
+
+	// Token dari login harus diterima oleh route yang dilindungi.
+	s.token = body.Token
+	expect(t, s.request(http.MethodGet, "/me", nil), http.StatusOK, nil)
+}
+
+func TestMe(t *testing.T) {
+	s := newTestServer(t)
+
+	var body struct {
+		User struct {
+			UserID   uint   `json:"user_id"`
+			Username string `json:"username"`
+		} `json:"user"`
+	}
+	expect(t, s.request(http.MethodGet, "/me", nil), http.StatusOK, &body)
+	if body.User.UserID != s.fixtures.User.UserID || body.User.Username != testUsername {
+		t.Fatalf("user = %+v, want %d %q", body.User, s.fixtures.User.UserID, testUsername)
+	}
+}
+
+func TestProtectedRoutesRequireToken(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, path := range []string{"/me", "/tournaments", "/teams", "/heroes", "/audit"} {
+		s.token = ""
+		expect(t, s.request(http.MethodGet, path, nil), http.StatusUnauthorized, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api"+path, nil)
+		req.Header.Set("Authorization", "Bearer invalid")
+		expect(t, s.serve(req), http.StatusUnauthorized, nil)
+	}
+}
@@ -0,0 +1,193 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ml-master-data/dto"
+	"ml-master-data/imaging"
+	"ml-master-data/models"
+)
+
+func TestTournamentBackupRestore(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	game := f.Games[0][1]
+
+	// Hero yang hanya ada di instance asal
+	zilong := models.Hero{Name: "Zilong", PrimaryClass: "fighter", Lanes: models.HeroLanes{{Lane: "exp"}}}
+	if err := s.db.Create(&zilong).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	rule := map[string]interface{}{"fearless_mode": "team", "disabled_hero_ids": []uint{f.Heroes[2].HeroID}}
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("/tournaments/%d/rules", f.Tournament.TournamentID), rule), http.StatusOK, nil)
+	pick := map[string]interface{}{
+		"hero_id": zilong.HeroID, "first_phase": 1, "second_phase": 0, "total": 1,
+		"hero_pick_game": []map[string]interface{}{
+			{"game_id": game.GameID, "game_number": 2, "is_picked": true},
+		},
+	}
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/matches/%d/teams/%d/hero-picks", game.MatchID, f.TeamA.TeamID), pick), http.StatusCreated, nil)
+	turtle := map[string]interface{}{"team_id": f.TeamB.TeamID, "phase": "1", "setup": "early", "initiate": "yes", "result": "no"}
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/matches/%d/games/%d/turtle-results", game.MatchID, game.GameID), turtle), http.StatusCreated, nil)
+
+	w := s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/backup", f.Tournament.TournamentID), nil)
+	var bundle dto.TournamentBundleDto
+	expect(t, w, http.StatusOK, &bundle)
+	if cd := w.Header().Get("Content-Disposition"); !strings.Contains(cd, ".json") {
+		t.Fatalf("Content-Disposition = %q", cd)
+	}
+	if bundle.Version != dto.TournamentBundleVersion || len(bundle.Matches) != 2 || len(bundle.Teams) != 2 || len(bundle.Heroes) != 2 {
+		t.Fatalf("bundle = %+v", bundle)
+	}
+	if len(bundle.Players) != 1 || len(bundle.Coaches) != 1 || bundle.Tournament.Rule == nil {
+		t.Fatalf("bundle = %+v", bundle)
+	}
+	picks := bundle.Matches[0].Teams[0].HeroPicks
+	if len(picks) != 1 || len(picks[0].Games) != 1 || picks[0].Games[0] != (dto.BundleHeroDraftGameDto{GameNumber: 2, Drafted: true}) {
+		t.Fatalf("hero picks = %+v", picks)
+	}
+	if turtles := bundle.Matches[0].Games[1].TurtleResults; len(turtles) != 1 || turtles[0].Result != "no" {
+		t.Fatalf("turtle results = %+v", turtles)
+	}
+
+	// Restore ke instance lain: team, pemain, pelatih dan hero yang sudah ada
+	// dipakai ulang, Zilong dibuat
+	other := newTestServer(t)
+	var result dto.TournamentRestoreDto
+	expect(t, other.request(http.MethodPost, "/tournaments/backup?name=MPL%20Archive", bundle), http.StatusCreated, &result)
+	if result.Created["match"] != 2 || result.Created["game"] != 5 || result.Created["hero_pick_game"] != 1 || result.Created["coach_match"] != 1 {
+		t.Fatalf("created = %v", result.Created)
+	}
+	if result.Created["hero"] != 1 || result.Reused["hero"] != 1 || result.Reused["team"] != 2 || result.Created["team"] != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	var tournament models.Tournament
+	expect(t, other.request(http.MethodGet, fmt.Sprintf("/tournaments/%d", result.TournamentID), nil), http.StatusOK, &tournament)
+	if tournament.Name != "MPL Archive" {
+		t.Fatalf("tournament = %+v", tournament)
+	}
+
+	// Kedua instance di-seed dengan urutan yang sama, sehingga backup
+	// tournament hasil restore harus sama persis dengan bundle asal
+	var restored dto.TournamentBundleDto
+	expect(t, other.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/backup", result.TournamentID), nil), http.StatusOK, &restored)
+	for _, part := range [][2]interface{}{
+		{bundle.Matches, restored.Matches},
+		{bundle.Tournament.Rule, restored.Tournament.Rule},
+		{bundle.Heroes, restored.Heroes},
+	} {
+		want, _ := json.Marshal(part[0])
+		got, _ := json.Marshal(part[1])
+		if !bytes.Equal(want, got) {
+			t.Fatalf("restored\n%s\nwant\n%s", got, want)
+		}
+	}
+}
+
+func TestTournamentRestoreValidation(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+
+	var bundle dto.TournamentBundleDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/backup", f.Tournament.TournamentID), nil), http.StatusOK, &bundle)
+
+	bundle.Matches[1].Games[0].WinnerTeamID = 999
+	w := s.request(http.MethodPost, "/tournaments/backup", bundle)
+	expect(t, w, http.StatusBadRequest, nil)
+	if !strings.Contains(w.Body.String(), "matches[1].games[0]") {
+		t.Fatalf("body = %s", w.Body.String())
+	}
+
+	bundle.Matches[1].Games[0].WinnerTeamID = f.TeamB.TeamID
+	bundle.Version = dto.TournamentBundleVersion + 1
+	expect(t, s.request(http.MethodPost, "/tournaments/backup", bundle), http.StatusBadRequest, nil)
+
+	var tournaments []models.Tournament
+	expect(t, s.request(http.MethodGet, "/tournaments", nil), http.StatusOK, &tournaments)
+	if len(tournaments) != 1 {
+		t.Fatalf("tournaments = %d, invalid bundle was saved", len(tournaments))
+	}
+
+	expect(t, s.request(http.MethodGet, "/tournaments/999/backup", nil), http.StatusNotFound, nil)
+}
+
+func TestTournamentBackupFilesAndDeletedTeams(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	ctx := context.Background()
+
+	var team models.Team
+	expect(t, s.requestUpload(http.MethodPut, fmt.Sprintf("/teams/%d", f.TeamA.TeamID), nil, "image", "logo.png", testPNG(t, 20, 20)), http.StatusOK, nil)
+	s.db.First(&team, f.TeamA.TeamID)
+	key := string(team.Image)
+
+	var bundle dto.TournamentBundleDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/backup", f.Tournament.TournamentID), nil), http.StatusOK, &bundle)
+	if len(bundle.Files) != 1 || bundle.Files[key] == nil {
+		t.Fatalf("files = %d, want only %s", len(bundle.Files), key)
+	}
+
+	// Logo diproses ulang dan disimpan dengan key baru di instance lain
+	other := newTestServer(t)
+	var result dto.TournamentRestoreDto
+	expect(t, other.request(http.MethodPost, "/tournaments/backup?name=Copy", bundle), http.StatusCreated, &result)
+	if result.Created["file"] != 1 {
+		t.Fatalf("created = %v", result.Created)
+	}
+	if _, err := other.files.Get(ctx, key); err == nil {
+		t.Fatalf("file stored under the key from the bundle")
+	}
+	objects, err := other.files.List(ctx, "team_")
+	if err != nil || len(objects) != len(imaging.Sizes) {
+		t.Fatalf("objects = %v, err = %v", objects, err)
+	}
+
+	// Isi yang bukan gambar dan key di luar jenis upload ditolak
+	for name, files := range map[string]map[string][]byte{
+		"html": {key: []byte("<html><script>alert(1)</script></html>")},
+		"key":  {"../secret": []byte("x")},
+		"kind": {"hero_x/full.webp": bundle.Files[key]},
+	} {
+		invalid := bundle
+		invalid.Files = files
+		if name == "kind" {
+			invalid.Teams = append([]dto.BundleTeamDto{}, bundle.Teams...)
+			invalid.Teams[0].Image = "hero_x/full.webp"
+		}
+		w := s.request(http.MethodPost, "/tournaments/backup", invalid)
+		expect(t, w, http.StatusBadRequest, nil)
+		if !strings.Contains(w.Body.String(), "files") {
+			t.Fatalf("%s: body = %s", name, w.Body.String())
+		}
+	}
+
+	// Team yang sudah dihapus tidak dipakai diam-diam, dan file yang sudah
+	// diproses ikut dihapus
+	expect(t, other.request(http.MethodDelete, fmt.Sprintf("/teams/%d", f.TeamB.TeamID), nil), http.StatusOK, nil)
+	w := other.request(http.MethodPost, "/tournaments/backup?name=Again", bundle)
+	expect(t, w, http.StatusConflict, nil)
+	if !strings.Contains(w.Body.String(), "Team B is deleted") {
+		t.Fatalf("body = %s", w.Body.String())
+	}
+	if objects, _ := other.files.List(ctx, "team_"); len(objects) != len(imaging.Sizes) {
+		t.Fatalf("objects after failed restore = %v", objects)
+	}
+}
+
+func TestTournamentRestoreBodyLimit(t *testing.T) {
+	s := newTestServer(t)
+
+	body := `{"files":{"team_x/full.webp":"` + strings.Repeat("A", 65<<20) + `"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/tournaments/backup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	expect(t, s.serve(req), http.StatusRequestEntityTooLarge, nil)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ml-master-data/config"
+)
+
+func TestSwaggerFollowsBaseURL(t *testing.T) {
+	s := newTestServer(t)
+	s.cfg.BaseURL = "https://stats.example.com/ml"
+	router := SetupRouter(s.db, s.cfg, s.files)
+	t.Cleanup(func() { SetupRouter(s.db, &config.Config{BaseURL: "http://localhost:8080"}, s.files) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil))
+
+	var doc struct {
+		Host     string   `json:"host"`
+		BasePath string   `json:"basePath"`
+		Schemes  []string `json:"schemes"`
+	}
+	expect(t, w, http.StatusOK, &doc)
+	if doc.Host != "stats.example.com" || doc.BasePath != "/ml/api/" || len(doc.Schemes) != 1 || doc.Schemes[0] != "https" {
+		t.Fatalf("doc = %+v", doc)
+	}
+}
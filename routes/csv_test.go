@@ -0,0 +1,92 @@
+package routes
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ml-master-data/tabular"
+)
+
+// readCSV memastikan respon berupa CSV dengan BOM UTF-8 lalu membaca
+// seluruh barisnya.
+func readCSV(t *testing.T, body string) [][]string {
+	t.Helper()
+
+	if !strings.HasPrefix(body, tabular.BOM) {
+		t.Fatalf("body tidak diawali BOM: %q", body)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(body, tabular.BOM))).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v; body: %s", err, body)
+	}
+	return records
+}
+
+func TestCSVExport(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+
+	w := s.request(http.MethodGet, "/heroes?format=csv", nil)
+	expect(t, w, http.StatusOK, nil)
+	if ct := w.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); cd != `attachment; filename="heroes.csv"` {
+		t.Fatalf("Content-Disposition = %q", cd)
+	}
+	records := readCSV(t, w.Body.String())
+	if len(records) != len(f.Heroes)+1 {
+		t.Fatalf("rows = %d, want %d", len(records), len(f.Heroes)+1)
+	}
+	header := strings.Join(records[0][:3], ",")
+	if header != "hero_id,name,image" {
+		t.Fatalf("header = %v", records[0])
+	}
+	if records[1][1] != f.Heroes[0].Name {
+		t.Fatalf("first row = %v, want hero %q", records[1], f.Heroes[0].Name)
+	}
+
+	// Header Accept juga memilih CSV, dan urutan kolom tidak berubah
+	req := httptest.NewRequest(http.MethodGet, "/api/heroes", nil)
+	req.Header.Set("Accept", "text/csv")
+	again := readCSV(t, s.serve(req).Body.String())
+	if strings.Join(again[0], ",") != strings.Join(records[0], ",") {
+		t.Fatalf("header berubah: %v vs %v", again[0], records[0])
+	}
+
+	// Tanpa permintaan CSV respon tetap JSON
+	if ct := s.request(http.MethodGet, "/heroes", nil).Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Fatalf("Content-Type default = %q", ct)
+	}
+	expect(t, s.request(http.MethodGet, "/heroes?format=xml", nil), http.StatusBadRequest, nil)
+
+	// Daftar kosong tetap punya header
+	records = readCSV(t, s.request(http.MethodGet, "/patches?format=csv", nil).Body.String())
+	if len(records) != 1 || strings.Join(records[0], ",") != "patch_id,version,release_date" {
+		t.Fatalf("patches = %v", records)
+	}
+
+	// Statistik menjadi satu baris
+	path := fmt.Sprintf("/tournaments/%d/teams/%d/team-statistics?format=csv", f.Tournament.TournamentID, f.TeamA.TeamID)
+	records = readCSV(t, s.request(http.MethodGet, path, nil).Body.String())
+	if len(records) != 2 || len(records[0]) != len(records[1]) {
+		t.Fatalf("team statistics = %v", records)
+	}
+
+	path = fmt.Sprintf("/matches/%d/teams/%d/hero-picks?format=csv", f.Matches[0].MatchID, f.TeamA.TeamID)
+	records = readCSV(t, s.request(http.MethodGet, path, nil).Body.String())
+	if len(records) == 0 || len(records[0]) == 0 {
+		t.Fatalf("hero picks = %v", records)
+	}
+
+	// Teks yang akan dibaca sebagai formula di-escape, angka negatif tidak
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{"name": "=HYPERLINK(\"http://x\")"}), http.StatusCreated, nil)
+	records = readCSV(t, s.request(http.MethodGet, "/heroes?format=csv", nil).Body.String())
+	if last := records[len(records)-1]; last[1] != `'=HYPERLINK("http://x")` {
+		t.Fatalf("last hero = %v", last)
+	}
+}
@@ -0,0 +1,154 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"ml-master-data/seeders"
+)
+
+func generateDemo(t *testing.T, s *testServer, seed int64) *seeders.Demo {
+	t.Helper()
+
+	demo, err := seeders.GenerateDemo(s.db, seeders.DemoOptions{Seed: seed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return demo
+}
+
+// demoTeamStatistics menghitung statistik team langsung dari dataset demo
+// dengan key JSON yang sama seperti endpoint team-statistics.
+func demoTeamStatistics(demo *seeders.Demo, team seeders.DemoTeam) map[string]int {
+	id := team.Team.TeamID
+	stats := map[string]int{"teamID": int(id)}
+
+	for _, m := range demo.Matches {
+		if m.Match.TeamAID != id && m.Match.TeamBID != id {
+			continue
+		}
+
+		stats["totalMatch"]++
+		if (m.Match.TeamAID == id) == (m.Match.TeamAScore > m.Match.TeamBScore) {
+			stats["totalMatchAndWin"]++
+		} else {
+			stats["totalMatchAndLose"]++
+		}
+
+		for _, game := range m.Games {
+			won := game.WinnerTeamID == id
+			stats["totalGame"]++
+			if won {
+				stats["totalGameAndWin"]++
+			} else {
+				stats["totalGameAndLose"]++
+			}
+
+			pick := "Second"
+			if game.FirstPickTeamID == id {
+				pick = "First"
+			}
+			stats["total"+pick+"Pick"]++
+			if won {
+				stats["total"+pick+"PickAndWin"]++
+			} else {
+				stats["total"+pick+"PickAndLose"]++
+			}
+		}
+	}
+
+	// Endpoint selalu mengirim semua key, termasuk yang bernilai 0.
+	for _, key := range []string{"totalMatch", "totalMatchAndWin", "totalMatchAndLose", "totalGame", "totalGameAndWin", "totalGameAndLose",
+		"totalFirstPick", "totalFirstPickAndWin", "totalFirstPickAndLose", "totalSecondPick", "totalSecondPickAndWin", "totalSecondPickAndLose"} {
+		stats[key] += 0
+	}
+
+	return stats
+}
+
+func TestStatisticsOnDemoDataset(t *testing.T) {
+	s := newTestServer(t)
+	demo := generateDemo(t, s, 42)
+
+	if len(demo.Teams) != 8 || len(demo.Matches) != 28+3 {
+		t.Fatalf("demo has %d teams and %d matches, want 8 and 31", len(demo.Teams), len(demo.Matches))
+	}
+
+	for _, team := range demo.Teams {
+		want := demoTeamStatistics(demo, team)
+
+		var stats map[string]int
+		path := fmt.Sprintf("/tournaments/%d/teams/%d/team-statistics", demo.Tournament.TournamentID, team.Team.TeamID)
+		expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &stats)
+		if !reflect.DeepEqual(stats, want) {
+			t.Fatalf("%s statistics = %v, want %v", team.Team.Name, stats, want)
+		}
+
+		// Semua pemain demo bermain di setiap match team-nya.
+		playerWant := map[string]int{
+			"total_match":     want["totalMatch"],
+			"total_match_win": want["totalMatchAndWin"],
+			"total_game":      want["totalGame"],
+			"total_game_win":  want["totalGameAndWin"],
+		}
+		var playerStats map[string]int
+		path = fmt.Sprintf("/tournaments/%d/players/%d/player-statistics", demo.Tournament.TournamentID, team.Players[0].PlayerID)
+		expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &playerStats)
+		if !reflect.DeepEqual(playerStats, playerWant) {
+			t.Fatalf("%s player statistics = %v, want %v", team.Team.Name, playerStats, playerWant)
+		}
+	}
+
+	// Setiap game demo punya draft dan hasil early game yang lengkap.
+	m := demo.Matches[0]
+	var picks []map[string]interface{}
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/matches/%d/teams/%d/hero-picks", m.Match.MatchID, m.Match.TeamAID), nil), http.StatusOK, &picks)
+	total := 0
+	for _, pick := range picks {
+		total += int(pick["total"].(float64))
+	}
+	if total != 5*len(m.Games) {
+		t.Fatalf("total hero picks = %d, want %d", total, 5*len(m.Games))
+	}
+
+	var result struct {
+		Win  int `json:"win"`
+		Draw int `json:"draw"`
+		Lose int `json:"lose"`
+	}
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/games/%d/teams/%d/game-results", m.Games[0].GameID, m.Match.TeamAID), nil), http.StatusOK, &result)
+	if result.Win+result.Draw+result.Lose != 3 {
+		t.Fatalf("early results = %+v, want 3 lanes", result)
+	}
+
+	if _, err := seeders.GenerateDemo(s.db, seeders.DemoOptions{Seed: 42}); err == nil {
+		t.Fatal("generating the same seed twice succeeded")
+	}
+}
+
+func TestDemoDatasetIsDeterministic(t *testing.T) {
+	summary := func(demo *seeders.Demo) []string {
+		var lines []string
+		for _, m := range demo.Matches {
+			line := fmt.Sprintf("%s day %d: %d-%d", m.Match.Stage, m.Match.Day, m.Match.TeamAScore, m.Match.TeamBScore)
+			for _, game := range m.Games {
+				line += fmt.Sprintf(" fp=%d w=%d", game.FirstPickTeamID, game.WinnerTeamID)
+			}
+			lines = append(lines, line)
+		}
+		return lines
+	}
+
+	a := summary(generateDemo(t, newTestServer(t), 7))
+	b := summary(generateDemo(t, newTestServer(t), 7))
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("same seed produced different datasets:\n%v\n%v", a, b)
+	}
+
+	c := summary(generateDemo(t, newTestServer(t), 8))
+	if reflect.DeepEqual(a, c) {
+		t.Fatal("different seeds produced the same dataset")
+	}
+}
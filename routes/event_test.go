@@ -0,0 +1,166 @@
+package routes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ml-master-data/dto"
+)
+
+// openEventStream membuka stream SSE path di server HTTP sungguhan dan
+// mengembalikan event yang diterima. Fungsi kembali setelah stream
+// tersambung, jadi perubahan berikutnya pasti terkirim.
+func (s *testServer) openEventStream(path string, header http.Header) <-chan dto.EventDto {
+	s.t.Helper()
+
+	server := httptest.NewServer(s.router)
+	s.t.Cleanup(server.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api"+path, nil)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	req.Header = header
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		resp.Body.Close()
+		s.t.Fatalf("status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	if !scanner.Scan() || scanner.Text() != "retry: 1000" {
+		s.t.Fatalf("first line = %q, want retry", scanner.Text())
+	}
+
+	events := make(chan dto.EventDto, 64)
+	go func() {
+		defer resp.Body.Close()
+		defer close(events)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var event dto.EventDto
+			if err := json.Unmarshal([]byte(data), &event); err == nil {
+				events <- event
+			}
+		}
+	}()
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan dto.EventDto, eventType string) dto.EventDto {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatalf("stream closed, want %s", eventType)
+		}
+		if event.Type != eventType {
+			t.Fatalf("event = %s %+v, want %s", event.Type, event, eventType)
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatalf("no event within a second, want %s", eventType)
+	}
+	return dto.EventDto{}
+}
+
+func TestMatchEvents(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	match, other := f.Matches[0], f.Matches[1]
+	matchPath := fmt.Sprintf("/matches/%d", match.MatchID)
+
+	// EventSource tidak bisa mengirim header, token lewat query
+	matchEvents := s.openEventStream(matchPath+"/events?access_token="+s.token, http.Header{})
+	tournamentEvents := s.openEventStream(fmt.Sprintf("/tournaments/%d/events", f.Tournament.TournamentID), http.Header{"Authorization": {"Bearer " + s.token}})
+
+	form := map[string]string{
+		"first_pick_team_id":  fmt.Sprint(f.TeamA.TeamID),
+		"second_pick_team_id": fmt.Sprint(f.TeamB.TeamID),
+		"winner_team_id":      fmt.Sprint(f.TeamB.TeamID),
+		"game_number":         "4",
+	}
+	expect(t, s.requestForm(http.MethodPost, matchPath+"/games", form), http.StatusCreated, nil)
+	created := nextEvent(t, matchEvents, "game.created")
+	if created.TournamentID != f.Tournament.TournamentID || created.MatchID != match.MatchID || created.GameID == 0 || created.EntityID != created.GameID {
+		t.Fatalf("game.created = %+v", created)
+	}
+	if data, _ := created.Data.(map[string]interface{}); data["winner_team_id"] != float64(f.TeamB.TeamID) {
+		t.Fatalf("game.created data = %v", created.Data)
+	}
+	nextEvent(t, tournamentEvents, "game.created")
+	gamePath := fmt.Sprintf("%s/games/%d", matchPath, created.GameID)
+
+	lord := map[string]interface{}{"team_id": f.TeamA.TeamID, "phase": "1", "setup": "early", "initiate": "yes", "result": "yes"}
+	expect(t, s.request(http.MethodPost, gamePath+"/lord-results", lord), http.StatusCreated, nil)
+	event := nextEvent(t, matchEvents, "lord_result.created")
+	if event.GameID != created.GameID || event.TeamID != f.TeamA.TeamID || event.EntityID == 0 {
+		t.Fatalf("lord_result.created = %+v", event)
+	}
+	lord["result"] = "no"
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("%s/lord-results/%d", gamePath, event.EntityID), lord), http.StatusOK, nil)
+	nextEvent(t, matchEvents, "lord_result.updated")
+
+	expect(t, s.request(http.MethodPost, gamePath+"/turtle-results", lord), http.StatusCreated, nil)
+	nextEvent(t, matchEvents, "turtle_result.created")
+
+	pick := map[string]interface{}{"hero_id": f.Heroes[0].HeroID, "first_phase": 1, "second_phase": 0, "total": 1}
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("%s/teams/%d/hero-picks", matchPath, f.TeamB.TeamID), pick), http.StatusCreated, nil)
+	if event := nextEvent(t, matchEvents, "hero_pick.created"); event.TeamID != f.TeamB.TeamID || event.TournamentID != f.Tournament.TournamentID {
+		t.Fatalf("hero_pick.created = %+v", event)
+	}
+
+	// Perubahan match lain hanya masuk stream tournament
+	otherGame := fmt.Sprintf("/matches/%d/games/%d", other.MatchID, f.Games[1][0].GameID)
+	expect(t, s.requestForm(http.MethodPut, otherGame, map[string]string{"winner_team_id": fmt.Sprint(f.TeamA.TeamID)}), http.StatusOK, nil)
+	score := map[string]interface{}{
+		"stage": match.Stage, "day": match.Day, "date": match.Date,
+		"team_a_id": match.TeamAID, "team_b_id": match.TeamBID,
+		"team_a_score": 3, "team_b_score": 1,
+	}
+	expect(t, s.request(http.MethodPut, matchPath, score), http.StatusOK, nil)
+	nextEvent(t, matchEvents, "match.updated")
+	for _, eventType := range []string{"lord_result.created", "lord_result.updated", "turtle_result.created", "hero_pick.created", "game.updated", "match.updated"} {
+		nextEvent(t, tournamentEvents, eventType)
+	}
+
+	// Client yang tersambung ulang menerima event yang terlewat
+	resumed := s.openEventStream(matchPath+"/events", http.Header{"Authorization": {"Bearer " + s.token}, "Last-Event-ID": {fmt.Sprint(created.ID)}})
+	for _, eventType := range []string{"lord_result.created", "lord_result.updated", "turtle_result.created", "hero_pick.created", "match.updated"} {
+		nextEvent(t, resumed, eventType)
+	}
+
+	expect(t, s.request(http.MethodDelete, gamePath, nil), http.StatusOK, nil)
+	if event := nextEvent(t, matchEvents, "game.deleted"); event.EntityID != created.GameID || event.Data != nil {
+		t.Fatalf("game.deleted = %+v", event)
+	}
+	nextEvent(t, resumed, "game.deleted")
+}
+
+func TestMatchEventsValidation(t *testing.T) {
+	s := newTestServer(t)
+
+	expect(t, s.request(http.MethodGet, "/matches/9999/events", nil), http.StatusNotFound, nil)
+	expect(t, s.request(http.MethodGet, "/tournaments/9999/events", nil), http.StatusNotFound, nil)
+
+	s.token = ""
+	path := fmt.Sprintf("/matches/%d/events", s.fixtures.Matches[0].MatchID)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusUnauthorized, nil)
+	expect(t, s.request(http.MethodGet, path+"?access_token=invalid", nil), http.StatusUnauthorized, nil)
+}
@@ -0,0 +1,88 @@
+package routes
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTournamentWorkbookExport(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	game := f.Games[0][0]
+
+	pick := map[string]interface{}{
+		"hero_id": f.Heroes[0].HeroID, "first_phase": 1, "second_phase": 0, "total": 1,
+		"hero_pick_game": []map[string]interface{}{
+			{"game_id": game.GameID, "game_number": 1, "is_picked": true},
+		},
+	}
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/matches/%d/teams/%d/hero-picks", game.MatchID, f.TeamA.TeamID), pick), http.StatusCreated, nil)
+	lord := map[string]interface{}{"team_id": f.TeamB.TeamID, "phase": "1", "setup": "early", "initiate": "yes", "result": "yes"}
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/matches/%d/games/%d/lord-results", game.MatchID, game.GameID), lord), http.StatusCreated, nil)
+
+	w := s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/export.xlsx", f.Tournament.TournamentID), nil)
+	expect(t, w, http.StatusOK, nil)
+	if cd := w.Header().Get("Content-Disposition"); !strings.Contains(cd, ".xlsx") {
+		t.Fatalf("Content-Disposition = %q", cd)
+	}
+
+	book, err := excelize.OpenReader(bytes.NewReader(w.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer book.Close()
+
+	want := []string{"Matches", "Games", "Drafts", "Hero Picks", "Hero Bans", "Priority Picks", "Priority Bans", "Flex Picks", "Lane Results", "Objectives"}
+	if got := book.GetSheetList(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("sheets = %v", got)
+	}
+
+	rows := func(sheet string) [][]string {
+		t.Helper()
+		rows, err := book.GetRows(sheet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+
+	matches := rows("Matches")
+	if len(matches) != len(f.Matches)+1 || matches[0][0] != "Match ID" || matches[1][5] != f.TeamA.Name {
+		t.Fatalf("Matches = %v", matches)
+	}
+	if games := rows("Games"); len(games) != 6 {
+		t.Fatalf("Games = %v", games)
+	}
+	drafts := rows("Drafts")
+	if len(drafts) != 2 || drafts[1][3] != f.TeamA.Name || drafts[1][4] != "pick" || drafts[1][5] != f.Heroes[0].Name {
+		t.Fatalf("Drafts = %v", drafts)
+	}
+	objectives := rows("Objectives")
+	if len(objectives) != 2 || objectives[1][3] != f.TeamB.Name || objectives[1][4] != "lord" {
+		t.Fatalf("Objectives = %v", objectives)
+	}
+	// Sheet kosong tetap punya header
+	if bans := rows("Hero Bans"); len(bans) != 1 || bans[0][3] != "Hero" {
+		t.Fatalf("Hero Bans = %v", bans)
+	}
+
+	// Nama yang diawali karakter formula ditulis sebagai teks ber-escape
+	s.db.Model(&f.TeamA).Update("name", "@SUM(A1)")
+	w = s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/export.xlsx", f.Tournament.TournamentID), nil)
+	expect(t, w, http.StatusOK, nil)
+	escaped, err := excelize.OpenReader(bytes.NewReader(w.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer escaped.Close()
+	if matches, _ := escaped.GetRows("Matches"); matches[1][5] != "'@SUM(A1)" {
+		t.Fatalf("Matches = %v", matches)
+	}
+
+	expect(t, s.request(http.MethodGet, "/tournaments/9999/export.xlsx", nil), http.StatusNotFound, nil)
+}
@@ -0,0 +1,172 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+)
+
+func TestGameCRUD(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	matchPath := fmt.Sprintf("/matches/%d/games", f.Matches[1].MatchID)
+
+	var games []dto.GameResponseDto
+	expect(t, s.request(http.MethodGet, matchPath, nil), http.StatusOK, &games)
+	if len(games) != 2 {
+		t.Fatalf("len(games) = %d, want 2", len(games))
+	}
+
+	form := map[string]string{
+		"first_pick_team_id":  fmt.Sprint(f.TeamB.TeamID),
+		"second_pick_team_id": fmt.Sprint(f.TeamA.TeamID),
+		"winner_team_id":      fmt.Sprint(f.TeamA.TeamID),
+		"game_number":         "3",
+	}
+	var created models.Game
+	expect(t, s.requestForm(http.MethodPost, matchPath, form), http.StatusCreated, &created)
+	if created.GameID == 0 || created.MatchID != f.Matches[1].MatchID {
+		t.Fatalf("created = %+v", created)
+	}
+	path := fmt.Sprintf("%s/%d", matchPath, created.GameID)
+
+	var detail dto.GameResponseDto
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &detail)
+	if detail.FirstTeam.Name != "Team B" || detail.SecondTeam.Name != "Team A" || detail.WinnerTeam.Name != "Team A" {
+		t.Fatalf("detail teams = %q, %q, %q", detail.FirstTeam.Name, detail.SecondTeam.Name, detail.WinnerTeam.Name)
+	}
+
+	var updated models.Game
+	expect(t, s.requestForm(http.MethodPut, path, map[string]string{"winner_team_id": fmt.Sprint(f.TeamB.TeamID)}), http.StatusOK, &updated)
+	if updated.WinnerTeamID != f.TeamB.TeamID {
+		t.Fatalf("winner_team_id = %d, want %d", updated.WinnerTeamID, f.TeamB.TeamID)
+	}
+
+	expect(t, s.request(http.MethodDelete, path, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusNotFound, nil)
+	expect(t, s.request(http.MethodPost, path+"/restore", nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, nil)
+
+	delete(form, "game_number")
+	expect(t, s.requestForm(http.MethodPost, matchPath, form), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/matches/%d/games/%d", f.Matches[0].MatchID, created.GameID), nil), http.StatusNotFound, nil)
+}
+
+func TestLordAndTurtleResults(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	game := f.Games[0][0]
+	path := fmt.Sprintf("/matches/%d/games/%d", game.MatchID, game.GameID)
+
+	for _, resource := range []string{"lord-results", "turtle-results"} {
+		t.Run(resource, func(t *testing.T) {
+			body := map[string]interface{}{"team_id": f.TeamA.TeamID, "phase": "1", "setup": "early", "initiate": "yes", "result": "yes"}
+			expect(t, s.request(http.MethodPost, path+"/"+resource, body), http.StatusCreated, nil)
+
+			var list []map[string]interface{}
+			expect(t, s.request(http.MethodGet, path+"/"+resource, nil), http.StatusOK, &list)
+			if len(list) != 1 || list[0]["setup"] != "early" {
+				t.Fatalf("list = %v", list)
+			}
+
+			var id float64
+			for key, value := range list[0] {
+				if key != "game_id" && len(key) > 10 && key[len(key)-10:] == "_result_id" {
+					id = value.(float64)
+				}
+			}
+			itemPath := fmt.Sprintf("%s/%s/%d", path, resource, int(id))
+
+			// Match dicari lewat match_id; match yang tidak ada ditolak
+			otherPath := fmt.Sprintf("/matches/9999/games/%d/%s/%d", game.GameID, resource, int(id))
+			expect(t, s.request(http.MethodPut, otherPath, body), http.StatusNotFound, nil)
+
+			body["result"] = "no"
+			expect(t, s.request(http.MethodPut, itemPath, body), http.StatusOK, nil)
+
+			var item map[string]interface{}
+			expect(t, s.request(http.MethodGet, itemPath, nil), http.StatusOK, &item)
+			if item["result"] != "no" {
+				t.Fatalf("result = %v, want no", item["result"])
+			}
+
+			expect(t, s.request(http.MethodDelete, itemPath, nil), http.StatusOK, nil)
+			expect(t, s.request(http.MethodGet, path+"/"+resource, nil), http.StatusOK, &list)
+			if len(list) != 0 {
+				t.Fatalf("len(list) = %d, want 0", len(list))
+			}
+		})
+	}
+}
+
+func TestLanersAndGameResults(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	game := f.Games[0][0]
+	path := fmt.Sprintf("/games/%d/teams/%d", game.GameID, f.TeamA.TeamID)
+
+	expect(t, s.request(http.MethodPost, path+"/explaners", map[string]interface{}{"hero_id": f.Heroes[1].HeroID, "early_result": "win"}), http.StatusCreated, nil)
+	expect(t, s.request(http.MethodPost, path+"/explaners", map[string]interface{}{"hero_id": f.Heroes[1].HeroID, "early_result": "win"}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPost, path+"/goldlaners", map[string]interface{}{"hero_id": f.Heroes[0].HeroID, "early_result": "draw"}), http.StatusCreated, nil)
+	expect(t, s.request(http.MethodPost, path+"/trio-mids", map[string]interface{}{"hero_id": f.Heroes[2].HeroID, "role": "midlaner", "early_result": "win"}), http.StatusCreated, nil)
+
+	var explaners []dto.ExplanerResponseDto
+	expect(t, s.request(http.MethodGet, path+"/explaners", nil), http.StatusOK, &explaners)
+	if len(explaners) != 1 || explaners[0].Hero.Name != "Tigreal" || explaners[0].EarlyResult != "win" {
+		t.Fatalf("explaners = %+v", explaners)
+	}
+
+	var goldlaners []dto.GoldlanerResponseDto
+	expect(t, s.request(http.MethodGet, path+"/goldlaners", nil), http.StatusOK, &goldlaners)
+	if len(goldlaners) != 1 || goldlaners[0].Hero.Name != "Layla" {
+		t.Fatalf("goldlaners = %+v", goldlaners)
+	}
+
+	var trioMids []dto.TrioMidResponseDto
+	expect(t, s.request(http.MethodGet, path+"/trio-mids", nil), http.StatusOK, &trioMids)
+	if len(trioMids) != 1 || trioMids[0].Hero.Name != "Eudora" || trioMids[0].Role != "midlaner" {
+		t.Fatalf("trio mids = %+v", trioMids)
+	}
+
+	// Hasil early game trio mid diambil dari trio_mids, bukan dari hero.
+	resultPath := fmt.Sprintf("%s/trio-mid-results/%d", path, trioMids[0].TrioMidID)
+	expect(t, s.request(http.MethodPut, resultPath, map[string]interface{}{"team_id": f.TeamA.TeamID, "early_result": "win"}), http.StatusOK, nil)
+	expect(t, s.request(http.MethodPut, resultPath, map[string]interface{}{"team_id": f.TeamA.TeamID, "early_result": "maybe"}), http.StatusBadRequest, nil)
+	// Early result yang ditolak tidak ikut tersimpan
+	var trioMid models.TrioMid
+	expect(t, s.request(http.MethodGet, resultPath, nil), http.StatusOK, &trioMid)
+	if trioMid.EarlyResult == nil || *trioMid.EarlyResult != "win" {
+		t.Fatalf("trio mid = %+v, want early result win", trioMid)
+	}
+
+	var result struct {
+		Win    int    `json:"win"`
+		Draw   int    `json:"draw"`
+		Lose   int    `json:"lose"`
+		Result string `json:"result"`
+	}
+	expect(t, s.request(http.MethodGet, path+"/game-results", nil), http.StatusOK, &result)
+	if result.Win != 2 || result.Draw != 1 || result.Lose != 0 || result.Result != "Good Early" {
+		t.Fatalf("game result = %+v, want 2/1/0 Good Early", result)
+	}
+
+	explanerPath := fmt.Sprintf("%s/explaners/%d", path, explaners[0].ExplanerID)
+	expect(t, s.request(http.MethodPut, explanerPath, map[string]interface{}{"hero_id": f.Heroes[1].HeroID, "early_result": "lose"}), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path+"/game-results", nil), http.StatusOK, &result)
+	if result.Result != "Ok Early" {
+		t.Fatalf("result = %q, want Ok Early", result.Result)
+	}
+
+	expect(t, s.request(http.MethodDelete, explanerPath, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodDelete, fmt.Sprintf("%s/goldlaners/%d", path, goldlaners[0].GoldlanerID), nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodDelete, fmt.Sprintf("%s/trio-mids/%d", path, trioMids[0].TrioMidHeroID), nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path+"/game-results", nil), http.StatusOK, &result)
+	if result.Result != "No Result" {
+		t.Fatalf("result = %q, want No Result", result.Result)
+	}
+
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/games/%d/teams/9999/explaners", game.GameID), map[string]interface{}{"hero_id": f.Heroes[1].HeroID}), http.StatusBadRequest, nil)
+}
@@ -0,0 +1,302 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ml-master-data/config"
+	"ml-master-data/migrations"
+	"ml-master-data/models"
+	"ml-master-data/services"
+	"ml-master-data/storage"
+	"ml-master-data/utils"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	testUsername = "admin"
+	testPassword = "secret"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	log.SetOutput(io.Discard)
+
+	os.Exit(m.Run())
+}
+
+// testServer adalah router lengkap di atas database SQLite sementara yang
+// sudah berisi fixtures.
+type testServer struct {
+	t        *testing.T
+	cfg      *config.Config
+	files    storage.Storage
+	db       *gorm.DB
+	router   *gin.Engine
+	token    string
+	fixtures fixtures
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	cfg := &config.Config{
+		Env:       "development",
+		Port:      8080,
+		BaseURL:   "http://localhost:8080",
+		JWTSecret: "test-secret",
+		Database: config.DatabaseConfig{
+			Driver: "sqlite",
+			Name:   filepath.Join(t.TempDir(), "test.db"),
+		},
+		SoftDeleteRetentionDays: 30,
+	}
+	dialector, err := config.Dialector(cfg.Database)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	// SQLite hanya mengizinkan satu penulis; satu koneksi mencegah
+	// "database is locked" saat handler memakai transaksi.
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := migrations.Up(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := services.RegisterAuditCallbacks(db); err != nil {
+		t.Fatal(err)
+	}
+
+	files := storage.NewLocal(t.TempDir(), cfg.BaseURL+"/media")
+	s := &testServer{t: t, cfg: cfg, files: files, db: db, router: SetupRouter(db, cfg, files)}
+	s.fixtures = seedFixtures(t, db)
+
+	s.token, err = utils.GenerateJWT(cfg.JWTSecret, s.fixtures.User)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+// request mengirim request terautentikasi; body selain nil dikirim sebagai
+// JSON.
+func (s *testServer) request(method, path string, body interface{}) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, "/api"+path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	return s.serve(req)
+}
+
+// requestForm mengirim request multipart/form-data terautentikasi tanpa file.
+func (s *testServer) requestForm(method, path string, fields map[string]string) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			s.t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		s.t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(method, "/api"+path, &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return s.serve(req)
+}
+
+// requestUpload seperti requestForm, ditambah satu file pada field file.
+func (s *testServer) requestUpload(method, path string, fields map[string]string, field, filename string, content []byte) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			s.t.Fatal(err)
+		}
+	}
+	part, err := writer.CreateFormFile(field, filename)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	part.Write(content)
+	if err := writer.Close(); err != nil {
+		s.t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(method, "/api"+path, &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return s.serve(req)
+}
+
+func (s *testServer) serve(req *http.Request) *httptest.ResponseRecorder {
+	if s.token != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// expect memastikan status respon dan, jika out tidak nil, men-decode body
+// JSON ke out.
+func expect(t *testing.T, w *httptest.ResponseRecorder, status int, out interface{}) {
+	t.Helper()
+
+	if w.Code != status {
+		t.Fatalf("status = %d, want %d; body: %s", w.Code, status, w.Body.String())
+	}
+	if out != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			t.Fatalf("decode body: %v; body: %s", err, w.Body.String())
+		}
+	}
+}
+
+// fixtures adalah data awal setiap test: satu tournament dengan dua match
+// antara Team A dan Team B.
+//
+//	Match 1: A 2-1 B, game 1 (A first pick, A menang), game 2 (B first pick,
+//	         B menang), game 3 (A first pick, A menang)
+//	Match 2: B 2-0 A, game 1 (A first pick, B menang), game 2 (B first pick,
+//	         B menang)
+//
+// PlayerA dan CoachA hanya tercatat bermain di Match 1.
+type fixtures struct {
+	User       models.User
+	Heroes     []models.Hero
+	TeamA      models.Team
+	TeamB      models.Team
+	PlayerA    models.Player
+	PlayerB    models.Player
+	CoachA     models.Coach
+	Tournament models.Tournament
+	Matches    []models.Match
+	Games      [][]models.Game
+	// TeamDetails[i][0] milik Team A dan TeamDetails[i][1] milik Team B
+	// pada Matches[i].
+	TeamDetails [][2]models.MatchTeamDetail
+}
+
+func seedFixtures(t *testing.T, db *gorm.DB) fixtures {
+	t.Helper()
+
+	var f fixtures
+	create := func(value interface{}) {
+		t.Helper()
+		if err := db.Create(value).Error; err != nil {
+			t.Fatalf("seed %T: %v", value, err)
+		}
+	}
+
+	password, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.User = models.User{Username: testUsername, Password: string(password)}
+	create(&f.User)
+
+	f.Heroes = []models.Hero{{Name: "Layla"}, {Name: "Tigreal"}, {Name: "Eudora"}}
+	create(&f.Heroes)
+
+	f.TeamA = models.Team{Name: "Team A"}
+	f.TeamB = models.Team{Name: "Team B"}
+	create(&f.TeamA)
+	create(&f.TeamB)
+
+	f.PlayerA = models.Player{Name: "Player A", TeamID: f.TeamA.TeamID}
+	f.PlayerB = models.Player{Name: "Player B", TeamID: f.TeamB.TeamID}
+	f.CoachA = models.Coach{Name: "Coach A", TeamID: f.TeamA.TeamID}
+	create(&f.PlayerA)
+	create(&f.PlayerB)
+	create(&f.CoachA)
+
+	f.Tournament = models.Tournament{Name: "MPL Season 1"}
+	create(&f.Tournament)
+
+	a, b := f.TeamA.TeamID, f.TeamB.TeamID
+	f.Matches = []models.Match{
+		{TournamentID: f.Tournament.TournamentID, Stage: "Regular Season", Day: 1, Date: 1, TeamAID: a, TeamBID: b, TeamAScore: 2, TeamBScore: 1},
+		{TournamentID: f.Tournament.TournamentID, Stage: "Regular Season", Day: 2, Date: 2, TeamAID: b, TeamBID: a, TeamAScore: 2, TeamBScore: 0},
+	}
+	create(&f.Matches)
+
+	// pick[i] berisi {first pick, winner} untuk setiap game di Matches[i]
+	picks := [][][2]uint{
+		{{a, a}, {b, b}, {a, a}},
+		{{a, b}, {b, b}},
+	}
+	for i, match := range f.Matches {
+		details := [2]models.MatchTeamDetail{
+			{MatchID: match.MatchID, TeamID: a},
+			{MatchID: match.MatchID, TeamID: b},
+		}
+		create(&details[0])
+		create(&details[1])
+		f.TeamDetails = append(f.TeamDetails, details)
+
+		var games []models.Game
+		for n, pick := range picks[i] {
+			second := a
+			if pick[0] == a {
+				second = b
+			}
+			games = append(games, models.Game{
+				MatchID:          match.MatchID,
+				FirstPickTeamID:  pick[0],
+				SecondPickTeamID: second,
+				WinnerTeamID:     pick[1],
+				GameNumber:       n + 1,
+			})
+		}
+		create(&games)
+		f.Games = append(f.Games, games)
+	}
+
+	create(&models.PlayerMatch{MatchTeamDetailID: f.TeamDetails[0][0].MatchTeamDetailID, PlayerID: f.PlayerA.PlayerID, Role: "goldlaner"})
+	create(&models.CoachMatch{MatchTeamDetailID: f.TeamDetails[0][0].MatchTeamDetailID, CoachID: f.CoachA.CoachID, Role: "head coach"})
+
+	return f
+}
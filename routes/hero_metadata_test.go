@@ -0,0 +1,101 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"ml-master-data/models"
+)
+
+func heroIDs(heroes []models.Hero) []uint {
+	ids := []uint{}
+	for _, hero := range heroes {
+		ids = append(ids, hero.HeroID)
+	}
+	return ids
+}
+
+func TestHeroMetadata(t *testing.T) {
+	s := newTestServer(t)
+
+	var fanny models.Hero
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{
+		"name":            "Fanny",
+		"primary_class":   "assassin",
+		"release_date":    "2016-11-08",
+		"lanes":           []string{"jungler", "exp", "jungler"},
+		"aliases":         []string{"Fan", " fan ", "Fanny"},
+		"secondary_class": "",
+	}), http.StatusCreated, &fanny)
+	if fanny.PrimaryClass != "assassin" || fanny.ReleaseDate == nil || fanny.ReleaseDate.Format("2006-01-02") != "2016-11-08" {
+		t.Fatalf("fanny = %+v", fanny)
+	}
+	if len(fanny.Lanes) != 2 || fanny.Lanes[0].Lane != "exp" || fanny.Lanes[1].Lane != "jungler" {
+		t.Fatalf("lanes = %+v, want exp, jungler", fanny.Lanes)
+	}
+	if len(fanny.Aliases) != 1 || fanny.Aliases[0].Alias != "Fan" {
+		t.Fatalf("aliases = %+v, want Fan", fanny.Aliases)
+	}
+
+	var tigreal models.Hero
+	expect(t, s.requestForm(http.MethodPost, "/heroes", map[string]string{
+		"name":            "Tigreal",
+		"primary_class":   "tank",
+		"secondary_class": "support",
+		"release_date":    "2016-07-14",
+		"lanes":           "roam",
+		"aliases":         "Tiger",
+	}), http.StatusCreated, &tigreal)
+	if tigreal.SecondaryClass != "support" || len(tigreal.Lanes) != 1 || len(tigreal.Aliases) != 1 {
+		t.Fatalf("tigreal = %+v", tigreal)
+	}
+
+	filters := map[string][]uint{
+		"class=assassin":                  {fanny.HeroID},
+		"class=support":                   {tigreal.HeroID},
+		"lane=jungler":                    {fanny.HeroID},
+		"lane=roam&class=tank":            {tigreal.HeroID},
+		"q=TIGER":                         {tigreal.HeroID},
+		"q=fan":                           {fanny.HeroID},
+		"released_after=2016-08-01":       {fanny.HeroID},
+		"released_before=2016-08-01&q=ti": {tigreal.HeroID},
+		"lane=mid&class=assassin":         {},
+	}
+	for query, want := range filters {
+		var list []models.Hero
+		expect(t, s.request(http.MethodGet, "/heroes?"+query, nil), http.StatusOK, &list)
+		if got := heroIDs(list); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("GET /heroes?%s = %v, want %v", query, got, want)
+		}
+	}
+	expect(t, s.request(http.MethodGet, "/heroes?class=healer", nil), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodGet, "/heroes?lane=top", nil), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodGet, "/heroes?released_after=08-2016", nil), http.StatusBadRequest, nil)
+
+	// Field yang tidak dikirim tidak berubah, string kosong mengosongkan
+	path := fmt.Sprintf("/heroes/%d", fanny.HeroID)
+	var updated models.Hero
+	expect(t, s.requestForm(http.MethodPut, path, map[string]string{"lanes": "", "release_date": ""}), http.StatusOK, &updated)
+	if len(updated.Lanes) != 0 || updated.ReleaseDate != nil || updated.PrimaryClass != "assassin" || len(updated.Aliases) != 1 {
+		t.Fatalf("updated = %+v", updated)
+	}
+	var got models.Hero
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &got)
+	if len(got.Lanes) != 0 || len(got.Aliases) != 1 || got.Name != "Fanny" {
+		t.Fatalf("got = %+v", got)
+	}
+
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{"name": "Healer", "primary_class": "healer"}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{"name": "Odd", "secondary_class": "mage"}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{"name": "Lost", "lanes": []string{"top"}}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{"name": "Late", "release_date": "tomorrow"}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{"name": "Copy", "aliases": []string{"tiger"}}), http.StatusConflict, nil)
+
+	expect(t, s.request(http.MethodDelete, fmt.Sprintf("/heroes/%d", tigreal.HeroID), nil), http.StatusOK, nil)
+	var remaining int64
+	s.db.Model(&models.HeroAlias{}).Where("hero_id = ?", tigreal.HeroID).Count(&remaining)
+	if remaining != 0 {
+		t.Fatalf("aliases left after delete = %d", remaining)
+	}
+}
@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"ml-master-data/models"
+)
+
+func TestHeroCRUD(t *testing.T) {
+	s := newTestServer(t)
+
+	var list []models.Hero
+	expect(t, s.request(http.MethodGet, "/heroes", nil), http.StatusOK, &list)
+	if len(list) != len(s.fixtures.Heroes) {
+		t.Fatalf("len(heroes) = %d, want %d", len(list), len(s.fixtures.Heroes))
+	}
+
+	var created models.Hero
+	expect(t, s.requestForm(http.MethodPost, "/heroes", map[string]string{"name": "Fanny"}), http.StatusCreated, &created)
+	if created.HeroID == 0 || created.Name != "Fanny" {
+		t.Fatalf("created = %+v", created)
+	}
+	path := fmt.Sprintf("/heroes/%d", created.HeroID)
+
+	var updated models.Hero
+	expect(t, s.requestForm(http.MethodPut, path, map[string]string{"name": "Fanny Blade"}), http.StatusOK, &updated)
+	if updated.Name != "Fanny Blade" {
+		t.Fatalf("name = %q", updated.Name)
+	}
+
+	var got models.Hero
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &got)
+	if got.Name != "Fanny Blade" {
+		t.Fatalf("name = %q", got.Name)
+	}
+
+	expect(t, s.requestForm(http.MethodPost, "/heroes", map[string]string{}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodDelete, path, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusNotFound, nil)
+	expect(t, s.request(http.MethodGet, "/heroes/abc", nil), http.StatusBadRequest, nil)
+}
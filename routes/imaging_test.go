@@ -0,0 +1,97 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"ml-master-data/imaging"
+	"ml-master-data/models"
+	"ml-master-data/storage"
+
+	_ "golang.org/x/image/webp"
+)
+
+// testPNG membuat PNG berukuran width x height.
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadCreatesWebPVariants(t *testing.T) {
+	s := newTestServer(t)
+
+	var hero models.Hero
+	w := s.requestUpload(http.MethodPost, "/heroes", map[string]string{"name": "Layla"}, "image", "layla.png", testPNG(t, 2000, 1000))
+	expect(t, w, http.StatusCreated, &hero)
+
+	var stored models.Hero
+	s.db.First(&stored, hero.HeroID)
+	if hero.ImageVariants == nil || hero.ImageVariants.Full != stored.Image.URL() {
+		t.Fatalf("image_variants = %+v, want full = %q", hero.ImageVariants, stored.Image.URL())
+	}
+
+	// Ukuran dan format tiap varian diuji di package imaging; di sini cukup
+	// semua varian tersimpan sebagai WebP.
+	keys := stored.Image.VariantKeys()
+	if len(keys) != len(imaging.Sizes) {
+		t.Fatalf("variant keys = %v", keys)
+	}
+	for _, key := range keys {
+		r, err := s.files.Get(context.Background(), key)
+		if err != nil {
+			t.Fatalf("get %s: %v", key, err)
+		}
+		_, format, err := image.DecodeConfig(r)
+		r.Close()
+		if err != nil || format != "webp" {
+			t.Fatalf("%s: format = %q, err = %v", key, format, err)
+		}
+	}
+
+	// Menghapus hero menghapus semua varian.
+	expect(t, s.request(http.MethodDelete, fmt.Sprintf("/heroes/%d", stored.HeroID), nil), http.StatusOK, nil)
+	for _, key := range stored.Image.VariantKeys() {
+		if _, err := s.files.Get(context.Background(), key); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("%s still stored: err = %v", key, err)
+		}
+	}
+}
+
+func TestUploadRejectsNonImageContent(t *testing.T) {
+	s := newTestServer(t)
+
+	// Ekstensi .png tidak cukup; isinya harus gambar.
+	w := s.requestUpload(http.MethodPost, "/heroes", map[string]string{"name": "Fake"}, "image", "fake.png", []byte("<html>not an image</html>"))
+	expect(t, w, http.StatusBadRequest, nil)
+
+	// Gambar valid diterima walau ekstensinya tidak dikenal.
+	w = s.requestUpload(http.MethodPost, "/teams", map[string]string{"name": "RRQ"}, "image", "logo.bin", testPNG(t, 40, 40))
+	var team models.Team
+	expect(t, w, http.StatusCreated, &team)
+
+	// Upload yang ditolak tidak menghapus gambar lama.
+	w = s.requestUpload(http.MethodPut, fmt.Sprintf("/teams/%d", team.TeamID), nil, "image", "logo.png", []byte("GIF89a broken"))
+	expect(t, w, http.StatusBadRequest, nil)
+	var stored models.Team
+	s.db.First(&stored, team.TeamID)
+	if _, err := s.files.Get(context.Background(), string(stored.Image)); err != nil {
+		t.Fatalf("old logo removed after rejected upload: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"ml-master-data/dto"
+)
+
+// liquipediaPage adalah potongan halaman Liquipedia dengan satu match
+// yang sudah dimainkan dan satu yang belum.
+const liquipediaPage = `==Playoffs==
+<!-- Upper bracket -->
+{{Matchlist|id=abc123|title=Upper Bracket
+|M1header=Round 1
+|M1={{Match
+    |opponent1={{TeamOpponent|team a|score=2}}
+    |opponent2={{TeamOpponent|template=team b|name=Team B Esports}}
+    |date=March 15, 2024 - 17:00 {{Abbr/WIB}}
+    |bestof=3
+    |map1={{Map|winner=1|team1side=red|team2side=blue
+        |t1h1=[[Layla]]|t1h2=Tigreal|t2h1=eudora
+        |t1b1=Eudora|t2b1=Layla}}
+    |map2={{Map|winner=1|team1side=blue|team2side=red|t1h1=Layla|t2h1=Eudora}}
+    |map3={{Map|winner=|t1h1=}}
+    |vodgame1=https://youtu.be/game1
+}}
+|M2={{Match
+    |opponent1={{TeamOpponent|team b}}
+    |opponent2={{TeamOpponent|team a}}
+    |date=March 16, 2024
+}}
+}}`
+
+func TestLiquipediaImport(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d/import/liquipedia", f.Tournament.TournamentID)
+	page := []byte(liquipediaPage)
+
+	var result dto.LiquipediaImportResultDto
+	expect(t, s.requestUpload(http.MethodPost, path, map[string]string{"dry_run": "true"}, "file", "mpl.wiki", page), http.StatusOK, &result)
+	if !result.DryRun || result.Applied || result.Matches != 2 || result.Created == 0 {
+		t.Fatalf("dry run = %+v", result)
+	}
+	methods := map[string]string{}
+	for _, name := range result.Names {
+		methods[name.Kind+"/"+name.Name] = name.Method
+	}
+	if methods["team/team a"] != "exact" || methods["team/Team B Esports"] != "normalized" || methods["hero/eudora"] != "exact" {
+		t.Fatalf("names = %+v", result.Names)
+	}
+
+	var matches []dto.MatchResponseDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/matches", f.Tournament.TournamentID), nil), http.StatusOK, &matches)
+	if len(matches) != len(f.Matches) {
+		t.Fatalf("dry run saved matches: %d", len(matches))
+	}
+
+	huge := make([]byte, 12<<20)
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "huge.wiki", huge), http.StatusRequestEntityTooLarge, nil)
+
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "mpl.wiki", page), http.StatusOK, &result)
+	if !result.Applied {
+		t.Fatalf("result = %+v", result)
+	}
+	created := result.Created
+
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/matches", f.Tournament.TournamentID), nil), http.StatusOK, &matches)
+	if len(matches) != len(f.Matches)+2 {
+		t.Fatalf("matches = %d", len(matches))
+	}
+	var played *dto.MatchResponseDto
+	for i := range matches {
+		if matches[i].Stage != nil && *matches[i].Stage == "Upper Bracket" && *matches[i].TeamAID == f.TeamA.TeamID {
+			played = &matches[i]
+		}
+	}
+	if played == nil || *played.TeamAScore != 2 || *played.TeamBScore != 0 || played.PlayedAt == nil {
+		t.Fatalf("played match = %+v", played)
+	}
+
+	var picks []dto.HeroPickResponseDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/matches/%d/teams/%d/hero-picks", *played.MatchID, f.TeamA.TeamID), nil), http.StatusOK, &picks)
+	if len(picks) != 2 {
+		t.Fatalf("picks = %+v", picks)
+	}
+
+	// File yang sama bisa di-import ulang tanpa membuat match baru
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "mpl.wiki", page), http.StatusOK, &result)
+	if result.Created != 0 || result.Updated != 0 || result.Unchanged != created {
+		t.Fatalf("reimport = %+v, created before %d", result, created)
+	}
+}
+
+// Draft Liquipedia diperiksa terhadap aturan draft Tournament seperti
+// input manual.
+func TestLiquipediaImportDraftRules(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d/import/liquipedia", f.Tournament.TournamentID)
+	tigreal := f.Heroes[1]
+
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("/tournaments/%d/rules", f.Tournament.TournamentID), map[string]interface{}{
+		"disabled_hero_ids": []uint{tigreal.HeroID},
+	}), http.StatusOK, nil)
+
+	var result dto.LiquipediaImportResultDto
+	expect(t, s.requestUpload(http.MethodPost, path, map[string]string{"dry_run": "true"}, "file", "mpl.wiki", []byte(liquipediaPage)), http.StatusBadRequest, &result)
+	problems := map[string]bool{}
+	for _, problem := range result.Errors {
+		switch {
+		case strings.Contains(problem.Message, "disabled"):
+			problems["disabled"] = true
+		case strings.Contains(problem.Message, "picked and banned in game 1"):
+			problems["picked and banned"] = true
+		}
+	}
+	if !result.DryRun || result.Applied || !problems["disabled"] || !problems["picked and banned"] {
+		t.Fatalf("result = %+v, want disabled hero and pick/ban conflict", result)
+	}
+}
+
+func TestLiquipediaImportJSON(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d/import/liquipedia", f.Tournament.TournamentID)
+
+	record := `{"result": [{
+		"section": "Week 1",
+		"date": "2024-02-16 18:00:00",
+		"bestof": "3",
+		"match2opponents": [{"name": "Team A", "score": "1"}, {"name": "Team B", "score": 2}],
+		"match2games": [
+			{"winner": "2", "extradata": {"team1hero1": "Layla", "team2hero1": "Tigreal"}},
+			{"winner": 1},
+			{"winner": "2", "vod": "https://youtu.be/game3"}
+		]
+	}]}`
+	var result dto.LiquipediaImportResultDto
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "week1.json", []byte(record)), http.StatusOK, &result)
+	if !result.Applied || result.Matches != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	var matches []dto.MatchResponseDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/matches", f.Tournament.TournamentID), nil), http.StatusOK, &matches)
+	created := matches[len(matches)-1]
+	if *created.Stage != "Week 1" || *created.TeamAScore != 1 || *created.TeamBScore != 2 {
+		t.Fatalf("created match = %+v", created)
+	}
+}
+
+func TestLiquipediaImportValidation(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d/import/liquipedia", f.Tournament.TournamentID)
+
+	page := strings.NewReplacer("|t1h2=Tigreal", "|t1h2=Tigrel", "team b", "Team C").Replace(liquipediaPage)
+	var result dto.LiquipediaImportResultDto
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "mpl.wiki", []byte(page)), http.StatusBadRequest, &result)
+	messages := map[string]string{}
+	for _, problem := range result.Errors {
+		messages[fmt.Sprintf("%d:%s", problem.Row, problem.Column)] = problem.Message
+	}
+	if messages["1:map1"] != `Unknown hero "Tigrel"; did you mean "Tigreal"?` || !strings.HasPrefix(messages["2:opponent1"], `Unknown team "Team C"`) {
+		t.Fatalf("errors = %+v", result.Errors)
+	}
+	if result.Applied {
+		t.Fatalf("result = %+v", result)
+	}
+
+	var matches []dto.MatchResponseDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/matches", f.Tournament.TournamentID), nil), http.StatusOK, &matches)
+	if len(matches) != len(f.Matches) {
+		t.Fatalf("invalid file saved matches: %d", len(matches))
+	}
+
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "notes.wiki", []byte("no matches here")), http.StatusBadRequest, nil)
+	expect(t, s.requestUpload(http.MethodPost, "/tournaments/9999/import/liquipedia", nil, "file", "mpl.wiki", []byte(liquipediaPage)), http.StatusNotFound, nil)
+}
@@ -0,0 +1,121 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+)
+
+func TestCreateUserCanLogin(t *testing.T) {
+	s := newTestServer(t)
+	svc := services.New(repositories.New(s.db, s.files))
+	ctx := context.Background()
+
+	if _, err := svc.Users.Create(ctx, "operator", "long-enough"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.token = ""
+	expect(t, s.request(http.MethodPost, "/login", map[string]string{"username": "operator", "password": "long-enough"}), http.StatusOK, nil)
+
+	var conflict *services.ConflictError
+	if _, err := svc.Users.Create(ctx, "operator", "long-enough"); !errors.As(err, &conflict) {
+		t.Fatalf("duplicate username: err = %v, want ConflictError", err)
+	}
+	var invalid *services.ValidationError
+	if _, err := svc.Users.Create(ctx, "short", "1234567"); !errors.As(err, &invalid) {
+		t.Fatalf("short password: err = %v, want ValidationError", err)
+	}
+}
+
+func TestRecomputeStatsFixesDerivedData(t *testing.T) {
+	s := newTestServer(t)
+	svc := services.New(repositories.New(s.db, s.files))
+	f := s.fixtures
+
+	s.db.Model(&f.Matches[0]).Updates(map[string]interface{}{"team_a_score": 0, "team_b_score": 3})
+	pick := models.HeroPick{MatchTeamDetailID: f.TeamDetails[0][0].MatchTeamDetailID, HeroID: f.Heroes[0].HeroID, FirstPhase: 1, SecondPhase: 1, Total: 5}
+	s.db.Create(&pick)
+	// Match tanpa game dengan skor yang diisi manual.
+	manual := models.Match{TournamentID: f.Tournament.TournamentID, Stage: "Playoffs", TeamAID: f.TeamA.TeamID, TeamBID: f.TeamB.TeamID, TeamAScore: 3, TeamBScore: 1}
+	s.db.Create(&manual)
+
+	result, err := svc.Stats.Recompute(context.Background(), f.Tournament.TournamentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fixtures belum punya GameResult: 5 game x 2 team.
+	want := repositories.StatsRecompute{MatchScores: 1, GameResults: 10}
+	if result != want {
+		t.Fatalf("result = %+v, want %+v", result, want)
+	}
+
+	var match models.Match
+	s.db.First(&match, f.Matches[0].MatchID)
+	if match.TeamAScore != 2 || match.TeamBScore != 1 {
+		t.Fatalf("score = %d-%d, want 2-1", match.TeamAScore, match.TeamBScore)
+	}
+	s.db.First(&manual, manual.MatchID)
+	if manual.TeamAScore != 3 || manual.TeamBScore != 1 {
+		t.Fatalf("manual score = %d-%d, want 3-1 untouched", manual.TeamAScore, manual.TeamBScore)
+	}
+	s.db.First(&pick, pick.HeroPickID)
+	if pick.Total != 5 {
+		t.Fatalf("total = %d, want hand-entered 5", pick.Total)
+	}
+
+	result, err = svc.Stats.Recompute(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (repositories.StatsRecompute{}) {
+		t.Fatalf("second run result = %+v, want nothing to fix", result)
+	}
+}
+
+func TestMasterDataRoundTrip(t *testing.T) {
+	source := newTestServer(t)
+	ctx := context.Background()
+
+	data, err := services.New(repositories.New(source.db, source.files)).MasterData.Export(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Heroes) != 3 || len(data.Teams) != 2 {
+		t.Fatalf("exported %d heroes and %d teams, want 3 and 2", len(data.Heroes), len(data.Teams))
+	}
+
+	// Target sudah berisi fixtures yang sama kecuali satu gambar team.
+	target := newTestServer(t)
+	target.db.Model(&target.fixtures.TeamA).Update("image", "old.png")
+	data.Heroes = append(data.Heroes, dto.MasterDataHeroDto{Name: "Miya"})
+
+	svc := services.New(repositories.New(target.db, target.files))
+	result, err := svc.MasterData.Import(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (repositories.MasterDataImport{Created: 1, Updated: 1}) {
+		t.Fatalf("first import = %+v, want 1 created and 1 updated", result)
+	}
+
+	result, err = svc.MasterData.Import(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (repositories.MasterDataImport{}) {
+		t.Fatalf("second import = %+v, want no changes", result)
+	}
+
+	data.Version = 2
+	var invalid *services.ValidationError
+	if _, err := svc.MasterData.Import(ctx, data); !errors.As(err, &invalid) {
+		t.Fatalf("unknown version: err = %v, want ValidationError", err)
+	}
+}
@@ -0,0 +1,219 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+)
+
+func TestMatchCRUD(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+
+	body := map[string]interface{}{
+		"stage": "Playoffs", "day": 3, "date": 3,
+		"team_a_id": f.TeamA.TeamID, "team_b_id": f.TeamB.TeamID,
+		"team_a_score": 0, "team_b_score": 0, "played_at": "2024-05-01",
+	}
+	tournamentPath := fmt.Sprintf("/tournaments/%d/matches", f.Tournament.TournamentID)
+
+	var created models.Match
+	expect(t, s.request(http.MethodPost, tournamentPath, body), http.StatusCreated, &created)
+	if created.MatchID == 0 || created.TournamentID != f.Tournament.TournamentID {
+		t.Fatalf("created = %+v", created)
+	}
+	path := fmt.Sprintf("/matches/%d", created.MatchID)
+
+	var detail dto.MatchResponseDto
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &detail)
+	if detail.TeamA == nil || *detail.TeamA.Name != "Team A" || detail.TeamB == nil || *detail.TeamB.Name != "Team B" {
+		t.Fatalf("detail teams = %+v, %+v", detail.TeamA, detail.TeamB)
+	}
+	if detail.PlayedAt == nil || detail.PlayedAt.Format("2006-01-02") != "2024-05-01" {
+		t.Fatalf("played_at = %v, want 2024-05-01", detail.PlayedAt)
+	}
+
+	var teams []models.Team
+	expect(t, s.request(http.MethodGet, path+"/teams", nil), http.StatusOK, &teams)
+	if len(teams) != 2 {
+		t.Fatalf("len(teams) = %d, want 2", len(teams))
+	}
+
+	body["team_a_score"] = 2
+	var updated models.Match
+	expect(t, s.request(http.MethodPut, path, body), http.StatusOK, &updated)
+	if updated.TeamAScore != 2 || updated.Stage != "Playoffs" {
+		t.Fatalf("updated = %+v", updated)
+	}
+
+	var matches []dto.MatchResponseDto
+	expect(t, s.request(http.MethodGet, tournamentPath, nil), http.StatusOK, &matches)
+	if len(matches) != 3 {
+		t.Fatalf("len(matches) = %d, want 3", len(matches))
+	}
+
+	expect(t, s.request(http.MethodDelete, path, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusNotFound, nil)
+	expect(t, s.request(http.MethodPost, path+"/restore", nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, nil)
+
+	body["team_b_id"] = 9999
+	expect(t, s.request(http.MethodPost, tournamentPath, body), http.StatusNotFound, nil)
+	expect(t, s.request(http.MethodPost, tournamentPath, map[string]interface{}{"stage": "x"}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPost, "/tournaments/9999/matches", body), http.StatusNotFound, nil)
+}
+
+func TestPlayerAndCoachMatch(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/matches/%d/teams/%d", f.Matches[0].MatchID, f.TeamB.TeamID)
+
+	expect(t, s.request(http.MethodPost, path+"/players", map[string]interface{}{"player_id": f.PlayerB.PlayerID, "role": "roamer"}), http.StatusCreated, nil)
+	expect(t, s.request(http.MethodPost, path+"/players", map[string]interface{}{"player_id": f.PlayerB.PlayerID, "role": "captain"}), http.StatusBadRequest, nil)
+
+	var players []dto.PlayerMatchResponseDto
+	expect(t, s.request(http.MethodGet, path+"/players", nil), http.StatusOK, &players)
+	if len(players) != 1 || *players[0].Role != "roamer" || *players[0].Player.Name != "Player B" {
+		t.Fatalf("players = %+v", players)
+	}
+
+	playerPath := fmt.Sprintf("%s/players/%d", path, f.PlayerB.PlayerID)
+	expect(t, s.request(http.MethodPut, playerPath, map[string]string{"role": "jungler"}), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path+"/players", nil), http.StatusOK, &players)
+	if *players[0].Role != "jungler" {
+		t.Fatalf("role = %q, want jungler", *players[0].Role)
+	}
+	expect(t, s.request(http.MethodDelete, playerPath, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path+"/players", nil), http.StatusOK, &players)
+	if len(players) != 0 {
+		t.Fatalf("len(players) = %d, want 0", len(players))
+	}
+
+	var coaches []dto.CoachMatchResponseDto
+	coachesPath := fmt.Sprintf("/matches/%d/teams/%d/coaches", f.Matches[0].MatchID, f.TeamA.TeamID)
+	expect(t, s.request(http.MethodGet, coachesPath, nil), http.StatusOK, &coaches)
+	if len(coaches) != 1 || *coaches[0].Coach.Name != "Coach A" {
+		t.Fatalf("coaches = %+v", coaches)
+	}
+
+	coachPath := fmt.Sprintf("%s/%d", coachesPath, f.CoachA.CoachID)
+	expect(t, s.request(http.MethodPut, coachPath, map[string]string{"role": "analyst"}), http.StatusOK, nil)
+	expect(t, s.request(http.MethodDelete, coachPath, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, coachesPath, nil), http.StatusOK, &coaches)
+	if len(coaches) != 0 {
+		t.Fatalf("len(coaches) = %d, want 0", len(coaches))
+	}
+
+	expect(t, s.request(http.MethodGet, "/matches/9999/teams/1/players", nil), http.StatusNotFound, nil)
+}
+
+func TestHeroPicksAndBans(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	games := f.Games[0]
+	path := fmt.Sprintf("/matches/%d/teams/%d", f.Matches[0].MatchID, f.TeamA.TeamID)
+
+	pick := map[string]interface{}{
+		"hero_id": f.Heroes[0].HeroID, "first_phase": 2, "second_phase": 0, "total": 2,
+		"hero_pick_game": []map[string]interface{}{
+			{"game_id": games[0].GameID, "game_number": 1, "is_picked": true},
+			{"game_id": games[1].GameID, "game_number": 2, "is_picked": true},
+		},
+	}
+	expect(t, s.request(http.MethodPost, path+"/hero-picks", pick), http.StatusCreated, nil)
+	expect(t, s.request(http.MethodPost, path+"/hero-picks", pick), http.StatusConflict, nil)
+
+	var picks []dto.HeroPickResponseDto
+	expect(t, s.request(http.MethodGet, path+"/hero-picks", nil), http.StatusOK, &picks)
+	if len(picks) != 1 || *picks[0].Hero.Name != "Layla" || len(picks[0].HeroPickGame) != 2 {
+		t.Fatalf("picks = %+v", picks)
+	}
+
+	pickPath := fmt.Sprintf("%s/hero-picks/%d", path, *picks[0].HeroPickID)
+	pick["first_phase"], pick["total"] = 0, 0
+	expect(t, s.request(http.MethodPut, pickPath, pick), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path+"/hero-picks-first-phase-more-than-zero", nil), http.StatusOK, &picks)
+	if len(picks) != 0 {
+		t.Fatalf("len(first phase picks) = %d, want 0", len(picks))
+	}
+	expect(t, s.request(http.MethodDelete, pickPath, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodDelete, pickPath, nil), http.StatusNotFound, nil)
+
+	ban := map[string]interface{}{
+		"hero_id": f.Heroes[1].HeroID, "first_phase": 1, "second_phase": 1, "total": 2,
+		"hero_ban_game": []map[string]interface{}{
+			{"game_id": games[0].GameID, "game_number": 1, "is_banned": true},
+		},
+	}
+	expect(t, s.request(http.MethodPost, path+"/hero-bans", ban), http.StatusCreated, nil)
+	expect(t, s.request(http.MethodPost, path+"/hero-bans", ban), http.StatusConflict, nil)
+
+	var bans []dto.HeroBanResponseDto
+	expect(t, s.request(http.MethodGet, path+"/hero-bans-first-phase-more-than-zero", nil), http.StatusOK, &bans)
+	if len(bans) != 1 || *bans[0].Hero.Name != "Tigreal" || len(bans[0].HeroBanGame) != 1 {
+		t.Fatalf("bans = %+v", bans)
+	}
+
+	banPath := fmt.Sprintf("%s/hero-bans/%d", path, *bans[0].HeroBanID)
+	ban["total"] = 3
+	expect(t, s.request(http.MethodPut, banPath, ban), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path+"/hero-bans", nil), http.StatusOK, &bans)
+	if *bans[0].Total != 3 {
+		t.Fatalf("total = %d, want 3", *bans[0].Total)
+	}
+	expect(t, s.request(http.MethodDelete, banPath, nil), http.StatusOK, nil)
+
+	expect(t, s.request(http.MethodPost, "/matches/9999/teams/1/hero-picks", pick), http.StatusNotFound, nil)
+}
+
+func TestPriorityAndFlexPicks(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/matches/%d/teams/%d", f.Matches[0].MatchID, f.TeamA.TeamID)
+
+	for _, tc := range []struct {
+		resource string
+		rateKey  string
+	}{
+		{"priority-picks", "pick_rate"},
+		{"flex-picks", "pick_rate"},
+		{"priority-bans", "ban_rate"},
+	} {
+		t.Run(tc.resource, func(t *testing.T) {
+			body := map[string]interface{}{"hero_id": f.Heroes[2].HeroID, "total": 2, "role": "mid", tc.rateKey: 66.7}
+			expect(t, s.request(http.MethodPost, path+"/"+tc.resource, body), http.StatusCreated, nil)
+
+			body["role"] = "support"
+			expect(t, s.request(http.MethodPost, path+"/"+tc.resource, body), http.StatusBadRequest, nil)
+
+			var list []map[string]interface{}
+			expect(t, s.request(http.MethodGet, path+"/"+tc.resource, nil), http.StatusOK, &list)
+			if len(list) != 1 || list[0]["role"] != "mid" || list[0][tc.rateKey] != 66.7 {
+				t.Fatalf("list = %v", list)
+			}
+
+			var id float64
+			for key, value := range list[0] {
+				if key != "match_team_detail_id" && len(key) > 3 && key[len(key)-3:] == "_id" {
+					id = value.(float64)
+				}
+			}
+			itemPath := fmt.Sprintf("%s/%s/%d", path, tc.resource, int(id))
+
+			body["role"], body["total"] = "jungler", 3
+			expect(t, s.request(http.MethodPut, itemPath, body), http.StatusOK, nil)
+
+			var item map[string]interface{}
+			expect(t, s.request(http.MethodGet, itemPath, nil), http.StatusOK, &item)
+			if item["role"] != "jungler" || item["total"] != float64(3) {
+				t.Fatalf("item = %v", item)
+			}
+
+			expect(t, s.request(http.MethodDelete, itemPath, nil), http.StatusOK, nil)
+			expect(t, s.request(http.MethodGet, itemPath, nil), http.StatusNotFound, nil)
+		})
+	}
+}
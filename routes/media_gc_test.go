@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"ml-master-data/storage"
+)
+
+func TestMediaGarbageCollection(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	local := s.files.(*storage.Local)
+
+	// put menyimpan objek dengan umur tertentu.
+	put := func(key string, age time.Duration) {
+		if err := s.files.Put(ctx, key, bytes.NewReader([]byte("img")), "image/webp"); err != nil {
+			t.Fatal(err)
+		}
+		old := time.Now().Add(-age)
+		if err := os.Chtimes(filepath.Join(local.Dir, filepath.FromSlash(key)), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Hero dengan semua varian, team lama dengan satu file, dan team yang
+	// di-soft delete tetap dianggap memakai gambarnya.
+	for _, size := range []string{"thumbnail", "card", "full"} {
+		put("hero_1/"+size+".webp", 48*time.Hour)
+	}
+	put("legacy-team.png", 48*time.Hour)
+	put("deleted-team.png", 48*time.Hour)
+	s.db.Create(&models.Hero{Name: "Miya", Image: "hero_1/full.webp"})
+	team := models.Team{Name: "ONIC", Image: "legacy-team.png"}
+	s.db.Create(&team)
+	deleted := models.Team{Name: "Bigetron", Image: "deleted-team.png"}
+	s.db.Create(&deleted)
+	s.db.Delete(&deleted)
+	s.db.Create(&models.Team{Name: "No Logo", Image: models.PlaceholderImage})
+
+	// Yatim: file tanpa referensi dan varian tersisa milik gambar yang
+	// putus. Upload yang baru saja terjadi dilindungi MinAge.
+	put("orphan.png", 48*time.Hour)
+	put("hero_2/card.webp", 48*time.Hour)
+	put("fresh.png", time.Minute)
+	dangling := models.Hero{Name: "Nana", Image: "hero_2/full.webp"}
+	s.db.Create(&dangling)
+	missing := models.Player{TeamID: team.TeamID, Name: "Kiboy", Image: "missing.png"}
+	s.db.Create(&missing)
+
+	media := services.New(repositories.New(s.db, s.files)).Media
+	options := services.MediaGCOptions{MinAge: time.Hour}
+
+	report, err := media.CollectGarbage(ctx, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var orphans []string
+	for _, object := range report.Orphans {
+		orphans = append(orphans, object.Key)
+	}
+	if len(orphans) != 2 || orphans[0] != "hero_2/card.webp" || orphans[1] != "orphan.png" {
+		t.Fatalf("orphans = %v", orphans)
+	}
+	if len(report.Dangling) != 2 || report.Dangling[0].Table != "heros" || report.Dangling[1].Table != "players" {
+		t.Fatalf("dangling = %+v", report.Dangling)
+	}
+	if report.Objects != 8 || report.Deleted != 0 || report.Reset != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	// Tanpa Delete tidak ada yang berubah.
+	if _, err := s.files.Get(ctx, "orphan.png"); err != nil {
+		t.Fatalf("dry run deleted orphan: %v", err)
+	}
+
+	options.Delete = true
+	report, err = media.CollectGarbage(ctx, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Deleted != 2 || report.Reset != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	for _, key := range []string{"orphan.png", "hero_2/card.webp"} {
+		if _, err := s.files.Get(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("%s still stored: err = %v", key, err)
+		}
+	}
+	for _, key := range []string{"fresh.png", "hero_1/thumbnail.webp", "legacy-team.png", "deleted-team.png"} {
+		if _, err := s.files.Get(ctx, key); err != nil {
+			t.Fatalf("%s was removed: %v", key, err)
+		}
+	}
+
+	s.db.First(&dangling, dangling.HeroID)
+	s.db.First(&missing, missing.PlayerID)
+	if dangling.Image != models.PlaceholderImage || missing.Image != models.PlaceholderImage {
+		t.Fatalf("images after reset = %q, %q", dangling.Image, missing.Image)
+	}
+
+	// Putaran berikutnya bersih.
+	report, err = media.CollectGarbage(ctx, options)
+	if err != nil || len(report.Orphans) != 0 || len(report.Dangling) != 0 {
+		t.Fatalf("second run = %+v, err = %v", report, err)
+	}
+}
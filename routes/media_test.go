@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"ml-master-data/storage"
+)
+
+func TestMediaUploadAndAttach(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+
+	// Upload multipart lalu pasang ke hero lewat JSON.
+	var media models.Media
+	expect(t, s.requestUpload(http.MethodPost, "/media?kind=hero", nil, "file", "miya.png", testPNG(t, 300, 300)), http.StatusCreated, &media)
+	if media.MediaID == 0 || media.Kind != "hero" || media.ImageVariants == nil {
+		t.Fatalf("media = %+v", media)
+	}
+	var stored models.Media
+	s.db.First(&stored, media.MediaID)
+
+	var hero models.Hero
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{"name": "Miya", "image_media_id": media.MediaID}), http.StatusCreated, &hero)
+	if string(hero.Image) != stored.Image.URL() {
+		t.Fatalf("hero image = %q, want %q", hero.Image, stored.Image.URL())
+	}
+
+	// Media hanya bisa dipasang sekali.
+	expect(t, s.request(http.MethodPost, "/heroes", map[string]interface{}{"name": "Nana", "image_media_id": media.MediaID}), http.StatusNotFound, nil)
+
+	// Body mentah juga diterima; media team tidak bisa dipasang ke hero.
+	req := httptest.NewRequest(http.MethodPost, "/api/media?kind=team", bytes.NewReader(testPNG(t, 50, 50)))
+	req.Header.Set("Content-Type", "image/png")
+	expect(t, s.serve(req), http.StatusCreated, &media)
+	path := fmt.Sprintf("/heroes/%d", hero.HeroID)
+	expect(t, s.request(http.MethodPut, path, map[string]interface{}{"image_media_id": media.MediaID}), http.StatusNotFound, nil)
+
+	// Mengganti logo team lewat JSON menghapus logo lama.
+	team := f.TeamA
+	s.db.First(&team, team.TeamID)
+	expect(t, s.requestUpload(http.MethodPut, fmt.Sprintf("/teams/%d", team.TeamID), nil, "image", "logo.png", testPNG(t, 20, 20)), http.StatusOK, &team)
+	var old models.Team
+	s.db.First(&old, team.TeamID)
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("/teams/%d", team.TeamID), map[string]interface{}{"image_media_id": media.MediaID}), http.StatusOK, &team)
+	if string(team.Image) == old.Image.URL() || team.Name != "Team A" {
+		t.Fatalf("team = %+v", team)
+	}
+	if _, err := s.files.Get(context.Background(), string(old.Image)); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("old logo still stored: err = %v", err)
+	}
+
+	// Draft game lewat JSON.
+	expect(t, s.requestUpload(http.MethodPost, "/media?kind=draft", nil, "file", "draft.png", testPNG(t, 64, 36)), http.StatusCreated, &media)
+	game := f.Games[0][0]
+	var updated models.Game
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("/matches/%d/games/%d", game.MatchID, game.GameID), map[string]interface{}{"full_draft_image_media_id": media.MediaID, "game_number": 7}), http.StatusOK, &updated)
+	if updated.FullDraftImage == "" || updated.GameNumber != 7 || updated.WinnerTeamID != game.WinnerTeamID {
+		t.Fatalf("game = %+v", updated)
+	}
+}
+
+func TestMediaUploadPolicies(t *testing.T) {
+	s := newTestServer(t)
+
+	expect(t, s.requestUpload(http.MethodPost, "/media?kind=tournament", nil, "file", "a.png", testPNG(t, 10, 10)), http.StatusBadRequest, nil)
+	expect(t, s.requestUpload(http.MethodPost, "/media?kind=hero", nil, "other", "a.png", testPNG(t, 10, 10)), http.StatusBadRequest, nil)
+	expect(t, s.requestUpload(http.MethodPost, "/media?kind=hero", nil, "file", "a.png", []byte("not an image")), http.StatusBadRequest, nil)
+
+	// Batas ukuran berbeda per entity.
+	big := make([]byte, services.HeroUploads.MaxSize+1)
+	copy(big, testPNG(t, 10, 10))
+	w := s.requestUpload(http.MethodPost, "/media?kind=hero", nil, "file", "big.png", big)
+	expect(t, w, http.StatusBadRequest, nil)
+	if !bytes.Contains(w.Body.Bytes(), []byte("2 MB")) {
+		t.Fatalf("body = %s", w.Body.String())
+	}
+	w = s.requestUpload(http.MethodPost, "/heroes", map[string]string{"name": "Big"}, "image", "big.png", big)
+	expect(t, w, http.StatusBadRequest, nil)
+
+	// Body yang jauh melebihi batas dihentikan sebelum form selesai dibaca.
+	huge := make([]byte, 2*services.TeamUploads.MaxSize)
+	w = s.requestUpload(http.MethodPost, "/teams", map[string]string{"name": "Huge"}, "image", "huge.png", huge)
+	expect(t, w, http.StatusBadRequest, nil)
+	if !bytes.Contains(w.Body.Bytes(), []byte("2 MB")) {
+		t.Fatalf("body = %s", w.Body.String())
+	}
+
+	// Upload yang tidak pernah dipasang kedaluwarsa bersama objeknya.
+	var media models.Media
+	expect(t, s.requestUpload(http.MethodPost, "/media?kind=coach", nil, "file", "c.png", testPNG(t, 10, 10)), http.StatusCreated, &media)
+	s.db.Model(&models.Media{}).Where("media_id = ?", media.MediaID).Update("created_at", time.Now().Add(-48*time.Hour))
+	var stored models.Media
+	s.db.First(&stored, media.MediaID)
+
+	report, err := services.New(repositories.New(s.db, s.files)).Media.CollectGarbage(context.Background(), services.MediaGCOptions{Delete: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Expired != 1 || report.Deleted != 3 {
+		t.Fatalf("report = %+v", report)
+	}
+	if _, err := s.files.Get(context.Background(), string(stored.Image)); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("expired upload still stored: err = %v", err)
+	}
+}
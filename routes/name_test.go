@@ -0,0 +1,202 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/tabular"
+)
+
+func TestNameAliases(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/teams/%d/aliases", f.TeamA.TeamID)
+
+	var aliases dto.AliasesDto
+	expect(t, s.request(http.MethodPut, path, dto.AliasesDto{Aliases: []string{" RRQ Hoshi ", "", "team a", "rrq hoshi", "Kings"}}), http.StatusOK, &aliases)
+	if strings.Join(aliases.Aliases, ",") != "RRQ Hoshi,Kings" {
+		t.Fatalf("aliases = %v", aliases.Aliases)
+	}
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &aliases)
+	if len(aliases.Aliases) != 2 {
+		t.Fatalf("aliases = %v", aliases.Aliases)
+	}
+
+	// Alias yang dipakai tim lain, atau nama tim lain, ditolak
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("/teams/%d/aliases", f.TeamB.TeamID), dto.AliasesDto{Aliases: []string{"kings"}}), http.StatusConflict, nil)
+	expect(t, s.request(http.MethodPut, path, dto.AliasesDto{Aliases: []string{"Team B"}}), http.StatusConflict, nil)
+	expect(t, s.request(http.MethodPut, "/teams/9999/aliases", dto.AliasesDto{Aliases: []string{}}), http.StatusNotFound, nil)
+
+	// Team baru dengan nama atau alias yang sudah ada ditolak
+	expect(t, s.requestForm(http.MethodPost, "/teams", map[string]string{"name": "TEAM A"}), http.StatusConflict, nil)
+	expect(t, s.requestForm(http.MethodPost, "/teams", map[string]string{"name": "RRQ Hoshi"}), http.StatusConflict, nil)
+	expect(t, s.requestForm(http.MethodPut, fmt.Sprintf("/teams/%d", f.TeamB.TeamID), map[string]string{"name": "Kings Esports"}), http.StatusConflict, nil)
+	expect(t, s.requestForm(http.MethodPut, fmt.Sprintf("/teams/%d", f.TeamA.TeamID), map[string]string{"name": "Team A"}), http.StatusOK, nil)
+
+	playerPath := fmt.Sprintf("/players/%d/aliases", f.PlayerA.PlayerID)
+	expect(t, s.request(http.MethodPut, playerPath, dto.AliasesDto{Aliases: []string{"Old IGN"}}), http.StatusOK, &aliases)
+	expect(t, s.requestForm(http.MethodPost, fmt.Sprintf("/teams/%d/players", f.TeamA.TeamID), map[string]string{"name": "old ign"}), http.StatusConflict, nil)
+	// Pemain tim lain boleh memakai nama yang sama
+	expect(t, s.requestForm(http.MethodPost, fmt.Sprintf("/teams/%d/players", f.TeamB.TeamID), map[string]string{"name": "Old IGN"}), http.StatusCreated, nil)
+
+	// Menghapus semua alias
+	expect(t, s.request(http.MethodPut, path, dto.AliasesDto{Aliases: []string{}}), http.StatusOK, &aliases)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &aliases)
+	if len(aliases.Aliases) != 0 {
+		t.Fatalf("aliases = %v", aliases.Aliases)
+	}
+}
+
+func TestNameResolve(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("/teams/%d/aliases", f.TeamA.TeamID), dto.AliasesDto{Aliases: []string{"RRQ"}}), http.StatusOK, nil)
+
+	resolve := func(kind, name string, teamID uint) dto.NameResolutionDto {
+		t.Helper()
+		query := url.Values{"kind": {kind}, "name": {name}}
+		if teamID != 0 {
+			query.Set("team_id", fmt.Sprint(teamID))
+		}
+		var result dto.NameResolutionDto
+		expect(t, s.request(http.MethodGet, "/names/resolve?"+query.Encode(), nil), http.StatusOK, &result)
+		return result
+	}
+
+	tests := []struct {
+		kind, name string
+		teamID     uint
+		status     string
+		want       uint
+		method     string
+	}{
+		{models.NameKindTeam, "team a", 0, "matched", f.TeamA.TeamID, "exact"},
+		{models.NameKindTeam, "rrq", 0, "matched", f.TeamA.TeamID, "alias"},
+		{models.NameKindTeam, "Team-B Esports", 0, "matched", f.TeamB.TeamID, "normalized"},
+		{models.NameKindTeam, "RRQ Hoshi", 0, "ambiguous", f.TeamA.TeamID, "fuzzy"},
+		{models.NameKindHero, "Tigrel", 0, "ambiguous", f.Heroes[1].HeroID, "fuzzy"},
+		{models.NameKindHero, "Zilong", 0, "unknown", 0, ""},
+		{models.NameKindPlayer, "player b", 0, "matched", f.PlayerB.PlayerID, "exact"},
+		// Di Team A, "player b" hanya mirip Player A
+		{models.NameKindPlayer, "player b", f.TeamA.TeamID, "ambiguous", f.PlayerA.PlayerID, "fuzzy"},
+	}
+	for _, tt := range tests {
+		result := resolve(tt.kind, tt.name, tt.teamID)
+		if result.Status != tt.status {
+			t.Fatalf("%s %q: status = %s, want %s; result = %+v", tt.kind, tt.name, result.Status, tt.status, result)
+		}
+		switch {
+		case tt.status == "matched":
+			if result.Match == nil || result.Match.ID != tt.want || result.Match.Method != tt.method {
+				t.Fatalf("%s %q: match = %+v", tt.kind, tt.name, result.Match)
+			}
+		case tt.want != 0:
+			if len(result.Candidates) == 0 || result.Candidates[0].ID != tt.want || result.Candidates[0].Method != tt.method {
+				t.Fatalf("%s %q: candidates = %+v", tt.kind, tt.name, result.Candidates)
+			}
+		}
+	}
+	expect(t, s.request(http.MethodGet, "/names/resolve?kind=coach&name=x", nil), http.StatusBadRequest, nil)
+
+	var results []dto.NameCandidateDto
+	expect(t, s.request(http.MethodGet, "/search?q=tig", nil), http.StatusOK, &results)
+	if len(results) == 0 || results[0].Kind != "hero" || results[0].ID != f.Heroes[1].HeroID {
+		t.Fatalf("search = %+v", results)
+	}
+	expect(t, s.request(http.MethodGet, "/search?q=rrq&kind=team", nil), http.StatusOK, &results)
+	if len(results) != 1 || results[0].ID != f.TeamA.TeamID || results[0].Score != 1 {
+		t.Fatalf("search = %+v", results)
+	}
+	expect(t, s.request(http.MethodGet, "/search", nil), http.StatusBadRequest, nil)
+}
+
+func TestNameReviewQueue(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d/import", f.Tournament.TournamentID)
+	match := fmt.Sprint(f.Matches[0].MatchID)
+
+	csv := []byte(tabular.BOM + strings.Join([]string{
+		"match_id,team,hero,first_phase,second_phase,total",
+		match + "," + f.TeamA.Name + ",Tigrel,1,0,1",
+		match + ",Team A Gaming,Layla,1,0,1",
+		match + ",Kings,Eudora,1,0,1",
+	}, "\n"))
+
+	// Dry run tidak mengisi antrean
+	var result dto.SheetImportResultDto
+	expect(t, s.requestUpload(http.MethodPost, path, map[string]string{"dry_run": "true"}, "file", "hero-picks.csv", csv), http.StatusBadRequest, &result)
+	var reviews []dto.NameReviewDto
+	expect(t, s.request(http.MethodGet, "/name-reviews", nil), http.StatusOK, &reviews)
+	if len(reviews) != 0 {
+		t.Fatalf("reviews = %+v", reviews)
+	}
+
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "hero-picks.csv", csv), http.StatusBadRequest, &result)
+	if len(result.Errors) != 2 || result.Errors[0].Message != `Unknown hero "Tigrel"; did you mean "Tigreal"?` {
+		t.Fatalf("errors = %+v", result.Errors)
+	}
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "hero-picks.csv", csv), http.StatusBadRequest, nil)
+
+	expect(t, s.request(http.MethodGet, "/name-reviews", nil), http.StatusOK, &reviews)
+	if len(reviews) != 2 {
+		t.Fatalf("reviews = %+v", reviews)
+	}
+	byName := map[string]dto.NameReviewDto{}
+	for _, review := range reviews {
+		byName[review.Name] = review
+	}
+	tigrel, kings := byName["Tigrel"], byName["Kings"]
+	if tigrel.Kind != "hero" || tigrel.Occurrences != 2 || tigrel.Source != "sheet import" || len(tigrel.Candidates) == 0 || tigrel.Candidates[0].ID != f.Heroes[1].HeroID {
+		t.Fatalf("review = %+v", tigrel)
+	}
+
+	// Resolve menyimpan nama sebagai alias; entity harus sesuai kind
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/name-reviews/%d/resolve", tigrel.NameReviewID), map[string]uint{"entity_id": 9999}), http.StatusNotFound, nil)
+	var resolved models.NameReview
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/name-reviews/%d/resolve", tigrel.NameReviewID), map[string]uint{"entity_id": f.Heroes[1].HeroID}), http.StatusOK, &resolved)
+	if resolved.Status != "resolved" || resolved.EntityID == nil || *resolved.EntityID != f.Heroes[1].HeroID {
+		t.Fatalf("resolved = %+v", resolved)
+	}
+	var hero models.Hero
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/heroes/%d", f.Heroes[1].HeroID), nil), http.StatusOK, &hero)
+	if len(hero.Aliases) != 1 || hero.Aliases[0].Alias != "Tigrel" {
+		t.Fatalf("hero aliases = %+v", hero.Aliases)
+	}
+
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/name-reviews/%d/resolve", kings.NameReviewID), map[string]uint{"entity_id": f.TeamB.TeamID}), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, "/name-reviews", nil), http.StatusOK, &reviews)
+	if len(reviews) != 0 {
+		t.Fatalf("pending reviews = %+v", reviews)
+	}
+	expect(t, s.request(http.MethodGet, "/name-reviews?status=resolved&kind=team", nil), http.StatusOK, &reviews)
+	if len(reviews) != 1 || reviews[0].Name != "Kings" {
+		t.Fatalf("resolved reviews = %+v", reviews)
+	}
+
+	// Setelah direview, file yang sama bisa di-import
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "hero-picks.csv", csv), http.StatusOK, &result)
+	if !result.Applied || result.Created != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	// Nama yang di-dismiss tidak masuk antrean lagi
+	bad := []byte("match_id,team,hero,first_phase,second_phase,total\n" + match + ",Nobody,Layla,1,0,1")
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "hero-picks.csv", bad), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodGet, "/name-reviews", nil), http.StatusOK, &reviews)
+	if len(reviews) != 1 || reviews[0].Name != "Nobody" || len(reviews[0].Candidates) != 0 {
+		t.Fatalf("reviews = %+v", reviews)
+	}
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/name-reviews/%d/dismiss", reviews[0].NameReviewID), nil), http.StatusOK, nil)
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "hero-picks.csv", bad), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodGet, "/name-reviews", nil), http.StatusOK, &reviews)
+	if len(reviews) != 0 {
+		t.Fatalf("reviews = %+v", reviews)
+	}
+	expect(t, s.request(http.MethodPost, "/name-reviews/9999/dismiss", nil), http.StatusNotFound, nil)
+}
@@ -0,0 +1,194 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+)
+
+func TestPatchScopedStatistics(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+
+	var before, after models.Patch
+	expect(t, s.request(http.MethodPost, "/patches", map[string]string{"version": "1.8.0", "release_date": "2024-01-01"}), http.StatusCreated, &before)
+	expect(t, s.request(http.MethodPost, "/patches", map[string]string{"version": "1.8.20", "release_date": "2024-03-01"}), http.StatusCreated, &after)
+	expect(t, s.request(http.MethodPost, "/patches", map[string]string{"version": "1.8.0", "release_date": "2024-01-02"}), http.StatusConflict, nil)
+	expect(t, s.request(http.MethodPost, "/patches", map[string]string{"version": "1.9.0"}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPost, "/patches", map[string]string{"version": "1.9.0", "release_date": "March"}), http.StatusBadRequest, nil)
+
+	var patches []models.Patch
+	expect(t, s.request(http.MethodGet, "/patches", nil), http.StatusOK, &patches)
+	if len(patches) != 2 || patches[0].PatchID != after.PatchID {
+		t.Fatalf("patches = %+v, want newest first", patches)
+	}
+
+	// Game fixtures belum punya patch; recompute mengisinya dari tanggal match
+	played := func(date string) *time.Time {
+		value, _ := time.Parse("2006-01-02", date)
+		return &value
+	}
+	s.db.Model(&f.Matches[0]).Update("played_at", played("2024-02-10"))
+	s.db.Model(&f.Matches[1]).Update("played_at", played("2024-03-05"))
+	result, err := services.New(repositories.New(s.db, s.files)).Stats.Recompute(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.GamePatches != 5 {
+		t.Fatalf("GamePatches = %d, want 5", result.GamePatches)
+	}
+
+	teamPath := fmt.Sprintf("/tournaments/%d/teams/%d/team-statistics", f.Tournament.TournamentID, f.TeamA.TeamID)
+	playerPath := fmt.Sprintf("/tournaments/%d/players/%d/player-statistics", f.Tournament.TournamentID, f.PlayerA.PlayerID)
+	coachPath := fmt.Sprintf("/tournaments/%d/coachs/%d/coach-statistics", f.Tournament.TournamentID, f.CoachA.CoachID)
+	cases := []struct {
+		patch         string
+		teamMatches   int
+		teamGames     int
+		playerMatches int
+		playerGames   int
+	}{
+		{"", 2, 5, 1, 3},
+		{"1.8.0", 1, 3, 1, 3},
+		{"1.8.20", 1, 2, 0, 0},
+	}
+	for _, c := range cases {
+		query := "?patch=" + c.patch
+		var team map[string]int
+		expect(t, s.request(http.MethodGet, teamPath+query, nil), http.StatusOK, &team)
+		if team["totalMatch"] != c.teamMatches || team["totalGame"] != c.teamGames {
+			t.Errorf("patch %q team: totalMatch = %d, totalGame = %d, want %d, %d", c.patch, team["totalMatch"], team["totalGame"], c.teamMatches, c.teamGames)
+		}
+		for _, path := range []string{playerPath, coachPath} {
+			var stats map[string]int
+			expect(t, s.request(http.MethodGet, path+query, nil), http.StatusOK, &stats)
+			if stats["total_match"] != c.playerMatches || stats["total_game"] != c.playerGames {
+				t.Errorf("patch %q %s: total_match = %d, total_game = %d, want %d, %d", c.patch, path, stats["total_match"], stats["total_game"], c.playerMatches, c.playerGames)
+			}
+		}
+	}
+	expect(t, s.request(http.MethodGet, teamPath+"?patch=0.1", nil), http.StatusNotFound, nil)
+
+	// Daftar draft per match: hero pick/ban mengikuti patch Game tempat hero
+	// di-pick/di-ban, priority dan flex pick mengikuti Match-nya
+	teamDetail := f.TeamDetails[0][0].MatchTeamDetailID
+	hero := f.Heroes[0].HeroID
+	heroPick := models.HeroPick{MatchTeamDetailID: teamDetail, HeroID: hero, FirstPhase: 1, Total: 1}
+	heroBan := models.HeroBan{MatchTeamDetailID: teamDetail, HeroID: hero, FirstPhase: 1, Total: 1}
+	s.db.Create(&heroPick)
+	s.db.Create(&heroBan)
+	s.db.Create(&[]models.HeroPickGame{
+		{HeroPickID: heroPick.HeroPickID, GameID: f.Games[0][0].GameID, GameNumber: 1, IsPicked: true},
+		{HeroPickID: heroPick.HeroPickID, GameID: f.Games[0][1].GameID, GameNumber: 2},
+	})
+	s.db.Create(&[]models.HeroBanGame{
+		{HeroBanID: heroBan.HeroBanID, GameID: f.Games[0][0].GameID, GameNumber: 1, IsBanned: true},
+		{HeroBanID: heroBan.HeroBanID, GameID: f.Games[0][1].GameID, GameNumber: 2},
+	})
+	s.db.Create(&models.PriorityPick{MatchTeamDetailID: teamDetail, HeroID: hero, Total: 1, Role: "gold"})
+	s.db.Create(&models.PriorityBan{MatchTeamDetailID: teamDetail, HeroID: hero, Total: 1, Role: "gold"})
+	s.db.Create(&models.FlexPick{MatchTeamDetailID: teamDetail, HeroID: hero, Total: 1, Role: "gold"})
+	s.db.Model(&f.Games[0][1]).Update("patch_id", after.PatchID)
+
+	matchPath := fmt.Sprintf("/matches/%d/teams/%d/", f.Matches[0].MatchID, f.TeamA.TeamID)
+	draftCases := []struct {
+		patch string
+		draft int
+		games int
+		other int
+	}{
+		{"", 1, 2, 1},
+		{"1.8.0", 1, 1, 1},
+		{"1.8.20", 0, 0, 1},
+	}
+	for _, c := range draftCases {
+		query := "?patch=" + c.patch
+		for _, path := range []string{"hero-picks", "hero-picks-first-phase-more-than-zero", "hero-bans", "hero-bans-first-phase-more-than-zero"} {
+			var entries []map[string]interface{}
+			expect(t, s.request(http.MethodGet, matchPath+path+query, nil), http.StatusOK, &entries)
+			if len(entries) != c.draft {
+				t.Errorf("patch %q %s: %d entries, want %d", c.patch, path, len(entries), c.draft)
+				continue
+			}
+			for _, entry := range entries {
+				games, _ := entry["hero_pick_game"].([]interface{})
+				if bans, ok := entry["hero_ban_game"].([]interface{}); ok {
+					games = bans
+				}
+				if len(games) != c.games {
+					t.Errorf("patch %q %s: %d games, want %d", c.patch, path, len(games), c.games)
+				}
+			}
+		}
+		for _, path := range []string{"priority-picks", "priority-bans", "flex-picks"} {
+			var entries []map[string]interface{}
+			expect(t, s.request(http.MethodGet, matchPath+path+query, nil), http.StatusOK, &entries)
+			if len(entries) != c.other {
+				t.Errorf("patch %q %s: %d entries, want %d", c.patch, path, len(entries), c.other)
+			}
+		}
+	}
+
+	// Match kedua hanya dimainkan di 1.8.20
+	s.db.Create(&models.PriorityPick{MatchTeamDetailID: f.TeamDetails[1][0].MatchTeamDetailID, HeroID: hero, Total: 1, Role: "gold"})
+	var other []map[string]interface{}
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/matches/%d/teams/%d/priority-picks?patch=1.8.0", f.Matches[1].MatchID, f.TeamA.TeamID), nil), http.StatusOK, &other)
+	if len(other) != 0 {
+		t.Errorf("priority picks of a match without the patch = %d, want 0", len(other))
+	}
+	expect(t, s.request(http.MethodGet, matchPath+"hero-picks?patch=0.1", nil), http.StatusNotFound, nil)
+
+	// Game baru mengikuti tanggal match kecuali patch_id dikirim
+	gamesPath := fmt.Sprintf("/matches/%d/games", f.Matches[1].MatchID)
+	newGame := func(patchID uint) map[string]interface{} {
+		body := map[string]interface{}{
+			"first_pick_team_id":  f.TeamA.TeamID,
+			"second_pick_team_id": f.TeamB.TeamID,
+			"winner_team_id":      f.TeamA.TeamID,
+			"game_number":         3,
+		}
+		if patchID != 0 {
+			body["patch_id"] = patchID
+		}
+		return body
+	}
+	var game models.Game
+	expect(t, s.request(http.MethodPost, gamesPath, newGame(0)), http.StatusCreated, &game)
+	if game.PatchID == nil || *game.PatchID != after.PatchID {
+		t.Fatalf("default patch = %v, want %d", game.PatchID, after.PatchID)
+	}
+	expect(t, s.request(http.MethodPost, gamesPath, newGame(before.PatchID)), http.StatusCreated, &game)
+	if game.PatchID == nil || *game.PatchID != before.PatchID {
+		t.Fatalf("explicit patch = %v, want %d", game.PatchID, before.PatchID)
+	}
+	expect(t, s.request(http.MethodPost, gamesPath, newGame(9999)), http.StatusNotFound, nil)
+
+	// Match tanpa tanggal tidak dianggap dimainkan hari ini
+	undated := models.Match{TournamentID: f.Tournament.TournamentID, TeamAID: f.TeamA.TeamID, TeamBID: f.TeamB.TeamID}
+	s.db.Create(&undated)
+	var undatedGame models.Game
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/matches/%d/games", undated.MatchID), newGame(0)), http.StatusCreated, &undatedGame)
+	if undatedGame.PatchID != nil {
+		t.Fatalf("undated match patch = %d, want none", *undatedGame.PatchID)
+	}
+
+	var detail dto.GameResponseDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("%s/%d", gamesPath, game.GameID), nil), http.StatusOK, &detail)
+	if detail.PatchVersion == nil || *detail.PatchVersion != "1.8.0" {
+		t.Fatalf("patch_version = %v, want 1.8.0", detail.PatchVersion)
+	}
+
+	// Patch yang sudah dipakai game tidak bisa dihapus
+	expect(t, s.request(http.MethodDelete, fmt.Sprintf("/patches/%d", before.PatchID), nil), http.StatusConflict, nil)
+	var unused models.Patch
+	expect(t, s.request(http.MethodPost, "/patches", map[string]string{"version": "2.0.0", "release_date": "2025-01-01"}), http.StatusCreated, &unused)
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("/patches/%d", unused.PatchID), map[string]string{"version": "1.8.20"}), http.StatusConflict, nil)
+	expect(t, s.request(http.MethodDelete, fmt.Sprintf("/patches/%d", unused.PatchID), nil), http.StatusOK, nil)
+}
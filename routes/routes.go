@@ -1,166 +1,240 @@
 package routes
 
 import (
+	"net/url"
+	"strings"
+
+	"ml-master-data/config"
 	"ml-master-data/controllers"
 	"ml-master-data/middlewares"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+	"ml-master-data/storage"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
-	_ "ml-master-data/docs"
+	"ml-master-data/docs"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRouter() *gin.Engine {
+// SetupRouter membuat router untuk semua endpoint API dan Swagger UI. URL
+// gambar di semua respon dibentuk oleh files.
+func SetupRouter(db *gorm.DB, cfg *config.Config, files storage.Storage) *gin.Engine {
+	models.ImageURL = files.URL
+	svc := services.New(repositories.New(db, files))
+
+	auth := controllers.NewAuthController(svc.Users, cfg.JWTSecret)
+	audit := controllers.NewAuditController(svc.Audits)
+	tournament := controllers.NewTournamentController(svc.Tournaments)
+	match := controllers.NewMatchController(svc.Matches, svc.Drafts, svc.Lineups, svc.Priorities, svc.Patches)
+	game := controllers.NewGameController(svc.Games, svc.Objectives, svc.Lanes, svc.Media)
+	team := controllers.NewTeamController(svc.Teams, svc.Stats, svc.Media, svc.Patches)
+	hero := controllers.NewHeroController(svc.Heroes, svc.Media)
+	media := controllers.NewMediaController(svc.Media)
+	patch := controllers.NewPatchController(svc.Patches)
+	export := controllers.NewExportController(svc.Exports)
+	sheetImport := controllers.NewSheetImportController(svc.SheetImport)
+	liquipediaImport := controllers.NewLiquipediaImportController(svc.Liquipedia)
+	backup := controllers.NewBackupController(svc.Backups)
+	names := controllers.NewNameController(svc.Names)
+	events := controllers.NewEventController(svc.Events, svc.Matches, svc.Tournaments)
 
 	r := gin.Default()
 
 	// swagger
-	url := ginSwagger.URL("http://localhost:8080/swagger/doc.json")
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
+	configureSwagger(cfg.BaseURL)
+	swaggerURL := ginSwagger.URL(cfg.BaseURL + "/swagger/doc.json")
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, swaggerURL))
+
+	// Media di storage lokal dilayani langsung oleh server
+	if local, ok := files.(*storage.Local); ok {
+		r.Static("/media", local.Dir)
+	}
 
 	// CORS middleware
-	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
-	config.AllowHeaders = append(config.AllowHeaders, "Authorization")
-	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
-	r.Use(cors.New(config))
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowAllOrigins = true
+	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Authorization")
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+	r.Use(cors.New(corsConfig))
 
 	// Public routes
-	r.POST("/api/login", controllers.Login)
+	r.POST("/api/login", auth.Login)
+
+	// Stream SSE juga menerima token lewat ?access_token= untuk EventSource
+	streams := r.Group("/api")
+	streams.Use(middlewares.QueryTokenMiddleware(), middlewares.AuthMiddleware(cfg.JWTSecret))
+	{
+		streams.GET("/matches/:matchID/events", events.MatchEvents)
+		streams.GET("/tournaments/:tournamentID/events", events.TournamentEvents)
+	}
 
 	// Protected routes
 	protected := r.Group("/api")
-	protected.Use(middlewares.AuthMiddleware())
+	protected.Use(middlewares.AuthMiddleware(cfg.JWTSecret))
 	{
 
-		protected.GET("/me", controllers.Me)
-
-		protected.GET("/tournaments", controllers.GetAllTournaments)
-		protected.GET(("/tournaments/:tournamentID"), controllers.GetTournamentByID)
-		protected.POST("/tournaments", controllers.CreateTournament)              //ok
-		protected.PUT("/tournaments/:tournamentID", controllers.UpdateTournament) //ok
-		protected.DELETE("/tournaments/:tournamentID", controllers.DeleteTournament)
-
-		protected.GET("/tournaments/:tournamentID/matches", controllers.GetMatchesByTournamentID)
-		protected.POST("/tournaments/:tournamentID/matches", controllers.CreateTournamentMatch) //ok
-		protected.GET("/matches/:matchID", controllers.GetMatchByID)
-		protected.PUT("/matches/:matchID", controllers.UpdateMatch) //ok
-		protected.DELETE("/matches/:matchID", controllers.DeleteMatch)
-
-		protected.GET("/matches/:matchID/teams", controllers.GetTeamsByMatchID)
-
-		protected.POST("matches/:matchID/teams/:teamID/players", controllers.AddPlayerMatch) //ok
-		protected.PUT("matches/:matchID/teams/:teamID/players/:playerID", controllers.UpdatePlayerMatch)
-		protected.DELETE("matches/:matchID/teams/:teamID/players/:playerID", controllers.RemovePlayerMatch)
-		protected.GET("matches/:matchID/teams/:teamID/players", controllers.GetAllPlayersMatch)
-
-		protected.POST("matches/:matchID/teams/:teamID/coaches", controllers.AddCoachMatch) //ok
-		protected.PUT("matches/:matchID/teams/:teamID/coaches/:coachID", controllers.UpdateCoachMatch)
-		protected.DELETE("matches/:matchID/teams/:teamID/coaches/:coachID", controllers.RemoveCoachMatch)
-		protected.GET("matches/:matchID/teams/:teamID/coaches", controllers.GetAllCoachesMatch)
-
-		protected.POST("matches/:matchID/teams/:teamID/hero-picks", controllers.AddHeroPick) //ok
-		protected.DELETE("matches/:matchID/teams/:teamID/hero-picks/:heroPickID", controllers.RemoveHeroPick)
-		protected.PUT("matches/:matchID/teams/:teamID/hero-picks/:heroPickID", controllers.UpdateHeroPick)
-		protected.GET("matches/:matchID/teams/:teamID/hero-picks", controllers.GetAllHeroPicks)
-
-		protected.GET("matches/:matchID/teams/:teamID/hero-picks-first-phase-more-than-zero", controllers.GetAllHeroPicksWithFirstPhaseMoreThanZero)
-
-		protected.POST("matches/:matchID/teams/:teamID/hero-bans", controllers.AddHeroBan) //ok
-		protected.DELETE("matches/:matchID/teams/:teamID/hero-bans/:HeroBanID", controllers.RemoveHeroBan)
-		protected.PUT("matches/:matchID/teams/:teamID/hero-bans/:HeroBanID", controllers.UpdateHeroBan)
-		protected.GET("matches/:matchID/teams/:teamID/hero-bans", controllers.GetAllHeroBans)
-
-		protected.GET("matches/:matchID/teams/:teamID/hero-bans-first-phase-more-than-zero", controllers.GetAllHeroBansWithFirstPhaseMoreThanZero)
-
-		protected.POST("matches/:matchID/teams/:teamID/priority-picks", controllers.AddPriorityPick) //ok
-		protected.DELETE("matches/:matchID/teams/:teamID/priority-picks/:priorityPickID", controllers.RemovePriorityPick)
-		protected.PUT("matches/:matchID/teams/:teamID/priority-picks/:priorityPickID", controllers.UpdatePriorityPick)
-		protected.GET("matches/:matchID/teams/:teamID/priority-picks/:priorityPickID", controllers.GetPriorityPickByID)
-		protected.GET("matches/:matchID/teams/:teamID/priority-picks", controllers.GetAllPriorityPicks)
-
-		protected.POST("matches/:matchID/teams/:teamID/flex-picks", controllers.AddFlexPick) //ok
-		protected.DELETE("matches/:matchID/teams/:teamID/flex-picks/:flexPickID", controllers.DeleteFlexPick)
-		protected.PUT("matches/:matchID/teams/:teamID/flex-picks/:flexPickID", controllers.UpdateFlexPick)
-		protected.GET("matches/:matchID/teams/:teamID/flex-picks/:flexPickID", controllers.GetFlexPickByID)
-		protected.GET("matches/:matchID/teams/:teamID/flex-picks", controllers.GetAllFlexPicks)
-
-		protected.POST("/matches/:matchID/teams/:teamID/priority-bans", controllers.AddPriorityBan)
-		protected.PUT("/matches/:matchID/teams/:teamID/priority-bans/:priorityBanID", controllers.UpdatePriorityBan)
-		protected.GET("/matches/:matchID/teams/:teamID/priority-bans", controllers.GetAllPriorityBans)
-		protected.GET("/matches/:matchID/teams/:teamID/priority-bans/:priorityBanID", controllers.GetPriorityBanByID)
-		protected.DELETE("/matches/:matchID/teams/:teamID/priority-bans/:priorityBanID", controllers.DeletePriorityBan)
-
-		protected.POST("matches/:matchID/games", controllers.CreateGame)        //ok
-		protected.PUT("matches/:matchID/games/:gameID", controllers.UpdateGame) //ok
-		protected.GET("matches/:matchID/games", controllers.GetAllGames)
-		protected.GET("matches/:matchID/games/:gameID", controllers.GetGameByID)
-		protected.DELETE("matches/:matchID/games/:gameID", controllers.RemoveGame)
-
-		protected.POST("matches/:matchID/games/:gameID/lord-results", controllers.AddLordResult)                 //ok
-		protected.PUT("matches/:matchID/games/:gameID/lord-results/:lordResultID", controllers.UpdateLordResult) //ok
-		protected.GET("matches/:matchID/games/:gameID/lord-results", controllers.GetAllLordResults)
-		protected.GET("matches/:matchID/games/:gameID/lord-results/:lordResultID", controllers.GetLordResultByID)
-		protected.DELETE("matches/:matchID/games/:gameID/lord-results/:lordResultID", controllers.RemoveLordResult)
-
-		protected.POST("matches/:matchID/games/:gameID/turtle-results", controllers.AddTurtleResult)
-		protected.PUT("matches/:matchID/games/:gameID/turtle-results/:turtleResultID", controllers.UpdateTurtleResult)
-		protected.GET("matches/:matchID/games/:gameID/turtle-results", controllers.GetAllTurtleResults)
-		protected.GET("matches/:matchID/games/:gameID/turtle-results/:turtleResultID", controllers.GetTurtleResultByID)
-		protected.DELETE("matches/:matchID/games/:gameID/turtle-results/:turtleResultID", controllers.RemoveTurtleResult)
-
-		protected.POST("games/:gameID/teams/:teamID/explaners", controllers.AddExplaner)
-		protected.PUT("games/:gameID/teams/:teamID/explaners/:explanerID", controllers.UpdateExplaner)
-		protected.GET("games/:gameID/teams/:teamID/explaners", controllers.GetAllExplaners)
-		protected.GET("games/:gameID/teams/:teamID/explaners/:explanerID", controllers.GetExplanerByID)
-		protected.DELETE("games/:gameID/teams/:teamID/explaners/:explanerID", controllers.RemoveExplaner)
-
-		protected.POST("games/:gameID/teams/:teamID/goldlaners", controllers.AddGoldlaner)
-		protected.PUT("games/:gameID/teams/:teamID/goldlaners/:goldlanerID", controllers.UpdateGoldlaner)
-		protected.GET("games/:gameID/teams/:teamID/goldlaners", controllers.GetAllGoldlaners)
-		protected.GET("games/:gameID/teams/:teamID/goldlaners/:goldlanerID", controllers.GetGoldlanerByID)
-		protected.DELETE("games/:gameID/teams/:teamID/goldlaners/:goldlanerID", controllers.RemoveGoldlaner)
-
-		protected.POST("games/:gameID/teams/:teamID/trio-mids", controllers.AddTrioMid)
-		protected.PUT("games/:gameID/teams/:teamID/trio-mids/:trioMidHeroID", controllers.UpdateTrioMid)
-		protected.GET("games/:gameID/teams/:teamID/trio-mids", controllers.GetAllTrioMids)
-		protected.GET("games/:gameID/teams/:teamID/trio-mids/:trioMidHeroID", controllers.GetTrioMidByID)
-		protected.DELETE("games/:gameID/teams/:teamID/trio-mids/:trioMidHeroID", controllers.RemoveTrioMid)
-
-		protected.PUT("games/:gameID/teams/:teamID/trio-mid-results/:trioMidID", controllers.UpdateTrioMidResult)
-		protected.GET("games/:gameID/teams/:teamID/trio-mid-results/:trioMidID", controllers.GetTrioMidResultByID)
-
-		protected.GET("games/:gameID/teams/:teamID/game-results", controllers.GetAllGameResults)
-
-		protected.GET("/tournaments/:tournamentID/teams/:teamID/team-statistics", controllers.GetTeamStatistics)
-		protected.GET("/teams", controllers.GetAllTeams)
-		protected.GET("/teams/:teamID", controllers.GetTeamByID)
-		protected.POST("/teams", controllers.CreateTeam)        //ok image ok
-		protected.PUT("/teams/:teamID", controllers.UpdateTeam) //ok image ok
-		protected.DELETE("/teams/:teamID", controllers.DeleteTeam)
-
-		protected.GET("/tournaments/:tournamentID/coachs/:coachID/coach-statistics", controllers.CoachStatistics)
-		protected.GET("teams/:teamID/coaches", controllers.GetAllCoachesInTeam)
-		protected.GET("coaches/:coachID", controllers.GetCoachByID)
-		protected.POST("teams/:teamID/coaches", controllers.CreateCoachInTeam) //ok image ok
-		protected.PUT("coaches/:coachID", controllers.UpdateCoachInTeam)       //ok image ok
-		protected.DELETE("coaches/:coachID", controllers.DeleteCoachInTeam)
-
-		protected.GET("/tournaments/:tournamentID/players/:playerID/player-statistics", controllers.PlayerStatistics)
-		protected.GET("teams/:teamID/players", controllers.GetAllPlayersInTeam)
-		protected.GET("players/:playerID", controllers.GetPlayerByID)
-		protected.POST("teams/:teamID/players", controllers.CreatePlayerInTeam) //ok image ok
-		protected.PUT("players/:playerID", controllers.UpdatePlayerInTeam)      //ok image ok
-		protected.DELETE("players/:playerID", controllers.DeletePlayerInTeam)
-
-		protected.GET("heroes", controllers.GetAllHeroes)
-		protected.GET("heroes/:heroID", controllers.GetHeroByID)
-		protected.POST("heroes", controllers.CreateHero)        //ok image ok
-		protected.PUT("heroes/:heroID", controllers.UpdateHero) //ok image ok
-		protected.DELETE("heroes/:heroID", controllers.DeleteHero)
+		protected.GET("/me", auth.Me)
+
+		protected.GET("/audit", audit.GetAuditLogs)
+
+		protected.POST("/media", media.UploadMedia)
+
+		protected.GET("/tournaments", tournament.GetAllTournaments)
+		protected.GET(("/tournaments/:tournamentID"), tournament.GetTournamentByID)
+		protected.POST("/tournaments", tournament.CreateTournament)              //ok
+		protected.PUT("/tournaments/:tournamentID", tournament.UpdateTournament) //ok
+		protected.DELETE("/tournaments/:tournamentID", tournament.DeleteTournament)
+		protected.POST("/tournaments/:tournamentID/restore", tournament.RestoreTournament)
+		protected.GET("/tournaments/:tournamentID/rules", tournament.GetTournamentRule)
+		protected.PUT("/tournaments/:tournamentID/rules", tournament.UpdateTournamentRule)
+		protected.GET("/tournaments/:tournamentID/export.xlsx", export.ExportTournament)
+		protected.POST("/tournaments/:tournamentID/import", sheetImport.ImportTournament)
+		protected.POST("/tournaments/:tournamentID/import/liquipedia", liquipediaImport.ImportLiquipedia)
+		protected.GET("/tournaments/:tournamentID/backup", backup.BackupTournament)
+		protected.POST("/tournaments/backup", backup.RestoreTournamentBackup)
+
+		protected.GET("/tournaments/:tournamentID/matches", match.GetMatchesByTournamentID)
+		protected.POST("/tournaments/:tournamentID/matches", match.CreateTournamentMatch) //ok
+		protected.GET("/matches/:matchID", match.GetMatchByID)
+		protected.PUT("/matches/:matchID", match.UpdateMatch) //ok
+		protected.DELETE("/matches/:matchID", match.DeleteMatch)
+		protected.POST("/matches/:matchID/restore", match.RestoreMatch)
+
+		protected.GET("/matches/:matchID/teams", match.GetTeamsByMatchID)
+
+		protected.POST("matches/:matchID/teams/:teamID/players", match.AddPlayerMatch) //ok
+		protected.PUT("matches/:matchID/teams/:teamID/players/:playerID", match.UpdatePlayerMatch)
+		protected.DELETE("matches/:matchID/teams/:teamID/players/:playerID", match.RemovePlayerMatch)
+		protected.GET("matches/:matchID/teams/:teamID/players", match.GetAllPlayersMatch)
+
+		protected.POST("matches/:matchID/teams/:teamID/coaches", match.AddCoachMatch) //ok
+		protected.PUT("matches/:matchID/teams/:teamID/coaches/:coachID", match.UpdateCoachMatch)
+		protected.DELETE("matches/:matchID/teams/:teamID/coaches/:coachID", match.RemoveCoachMatch)
+		protected.GET("matches/:matchID/teams/:teamID/coaches", match.GetAllCoachesMatch)
+
+		protected.POST("matches/:matchID/teams/:teamID/hero-picks", match.AddHeroPick) //ok
+		protected.DELETE("matches/:matchID/teams/:teamID/hero-picks/:heroPickID", match.RemoveHeroPick)
+		protected.PUT("matches/:matchID/teams/:teamID/hero-picks/:heroPickID", match.UpdateHeroPick)
+		protected.GET("matches/:matchID/teams/:teamID/hero-picks", match.GetAllHeroPicks)
+
+		protected.GET("matches/:matchID/teams/:teamID/hero-picks-first-phase-more-than-zero", match.GetAllHeroPicksWithFirstPhaseMoreThanZero)
+
+		protected.POST("matches/:matchID/teams/:teamID/hero-bans", match.AddHeroBan) //ok
+		protected.DELETE("matches/:matchID/teams/:teamID/hero-bans/:HeroBanID", match.RemoveHeroBan)
+		protected.PUT("matches/:matchID/teams/:teamID/hero-bans/:HeroBanID", match.UpdateHeroBan)
+		protected.GET("matches/:matchID/teams/:teamID/hero-bans", match.GetAllHeroBans)
+
+		protected.GET("matches/:matchID/teams/:teamID/hero-bans-first-phase-more-than-zero", match.GetAllHeroBansWithFirstPhaseMoreThanZero)
+
+		protected.POST("matches/:matchID/teams/:teamID/priority-picks", match.AddPriorityPick) //ok
+		protected.DELETE("matches/:matchID/teams/:teamID/priority-picks/:priorityPickID", match.RemovePriorityPick)
+		protected.PUT("matches/:matchID/teams/:teamID/priority-picks/:priorityPickID", match.UpdatePriorityPick)
+		protected.GET("matches/:matchID/teams/:teamID/priority-picks/:priorityPickID", match.GetPriorityPickByID)
+		protected.GET("matches/:matchID/teams/:teamID/priority-picks", match.GetAllPriorityPicks)
+
+		protected.POST("matches/:matchID/teams/:teamID/flex-picks", match.AddFlexPick) //ok
+		protected.DELETE("matches/:matchID/teams/:teamID/flex-picks/:flexPickID", match.DeleteFlexPick)
+		protected.PUT("matches/:matchID/teams/:teamID/flex-picks/:flexPickID", match.UpdateFlexPick)
+		protected.GET("matches/:matchID/teams/:teamID/flex-picks/:flexPickID", match.GetFlexPickByID)
+		protected.GET("matches/:matchID/teams/:teamID/flex-picks", match.GetAllFlexPicks)
+
+		protected.POST("/matches/:matchID/teams/:teamID/priority-bans", match.AddPriorityBan)
+		protected.PUT("/matches/:matchID/teams/:teamID/priority-bans/:priorityBanID", match.UpdatePriorityBan)
+		protected.GET("/matches/:matchID/teams/:teamID/priority-bans", match.GetAllPriorityBans)
+		protected.GET("/matches/:matchID/teams/:teamID/priority-bans/:priorityBanID", match.GetPriorityBanByID)
+		protected.DELETE("/matches/:matchID/teams/:teamID/priority-bans/:priorityBanID", match.DeletePriorityBan)
+
+		protected.POST("matches/:matchID/games", game.CreateGame)        //ok
+		protected.PUT("matches/:matchID/games/:gameID", game.UpdateGame) //ok
+		protected.GET("matches/:matchID/games", game.GetAllGames)
+		protected.GET("matches/:matchID/games/:gameID", game.GetGameByID)
+		protected.DELETE("matches/:matchID/games/:gameID", game.RemoveGame)
+		protected.POST("matches/:matchID/games/:gameID/restore", game.RestoreGame)
+
+		protected.POST("matches/:matchID/games/:gameID/lord-results", game.AddLordResult)                 //ok
+		protected.PUT("matches/:matchID/games/:gameID/lord-results/:lordResultID", game.UpdateLordResult) //ok
+		protected.GET("matches/:matchID/games/:gameID/lord-results", game.GetAllLordResults)
+		protected.GET("matches/:matchID/games/:gameID/lord-results/:lordResultID", game.GetLordResultByID)
+		protected.DELETE("matches/:matchID/games/:gameID/lord-results/:lordResultID", game.RemoveLordResult)
+
+		protected.POST("matches/:matchID/games/:gameID/turtle-results", game.AddTurtleResult)
+		protected.PUT("matches/:matchID/games/:gameID/turtle-results/:turtleResultID", game.UpdateTurtleResult)
+		protected.GET("matches/:matchID/games/:gameID/turtle-results", game.GetAllTurtleResults)
+		protected.GET("matches/:matchID/games/:gameID/turtle-results/:turtleResultID", game.GetTurtleResultByID)
+		protected.DELETE("matches/:matchID/games/:gameID/turtle-results/:turtleResultID", game.RemoveTurtleResult)
+
+		protected.POST("games/:gameID/teams/:teamID/explaners", game.AddExplaner)
+		protected.PUT("games/:gameID/teams/:teamID/explaners/:explanerID", game.UpdateExplaner)
+		protected.GET("games/:gameID/teams/:teamID/explaners", game.GetAllExplaners)
+		protected.GET("games/:gameID/teams/:teamID/explaners/:explanerID", game.GetExplanerByID)
+		protected.DELETE("games/:gameID/teams/:teamID/explaners/:explanerID", game.RemoveExplaner)
+
+		protected.POST("games/:gameID/teams/:teamID/goldlaners", game.AddGoldlaner)
+		protected.PUT("games/:gameID/teams/:teamID/goldlaners/:goldlanerID", game.UpdateGoldlaner)
+		protected.GET("games/:gameID/teams/:teamID/goldlaners", game.GetAllGoldlaners)
+		protected.GET("games/:gameID/teams/:teamID/goldlaners/:goldlanerID", game.GetGoldlanerByID)
+		protected.DELETE("games/:gameID/teams/:teamID/goldlaners/:goldlanerID", game.RemoveGoldlaner)
+
+		protected.POST("games/:gameID/teams/:teamID/trio-mids", game.AddTrioMid)
+		protected.PUT("games/:gameID/teams/:teamID/trio-mids/:trioMidHeroID", game.UpdateTrioMid)
+		protected.GET("games/:gameID/teams/:teamID/trio-mids", game.GetAllTrioMids)
+		protected.GET("games/:gameID/teams/:teamID/trio-mids/:trioMidHeroID", game.GetTrioMidByID)
+		protected.DELETE("games/:gameID/teams/:teamID/trio-mids/:trioMidHeroID", game.RemoveTrioMid)
+
+		protected.PUT("games/:gameID/teams/:teamID/trio-mid-results/:trioMidID", game.UpdateTrioMidResult)
+		protected.GET("games/:gameID/teams/:teamID/trio-mid-results/:trioMidID", game.GetTrioMidResultByID)
+
+		protected.GET("games/:gameID/teams/:teamID/game-results", game.GetAllGameResults)
+
+		protected.GET("/tournaments/:tournamentID/teams/:teamID/team-statistics", team.GetTeamStatistics)
+		protected.GET("/teams", team.GetAllTeams)
+		protected.GET("/teams/:teamID", team.GetTeamByID)
+		protected.POST("/teams", team.CreateTeam)        //ok image ok
+		protected.PUT("/teams/:teamID", team.UpdateTeam) //ok image ok
+		protected.DELETE("/teams/:teamID", team.DeleteTeam)
+		protected.POST("/teams/:teamID/restore", team.RestoreTeam)
+		protected.GET("/teams/:teamID/aliases", names.GetTeamAliases)
+		protected.PUT("/teams/:teamID/aliases", names.SetTeamAliases)
+
+		protected.GET("/tournaments/:tournamentID/coachs/:coachID/coach-statistics", team.CoachStatistics)
+		protected.GET("teams/:teamID/coaches", team.GetAllCoachesInTeam)
+		protected.GET("coaches/:coachID", team.GetCoachByID)
+		protected.POST("teams/:teamID/coaches", team.CreateCoachInTeam) //ok image ok
+		protected.PUT("coaches/:coachID", team.UpdateCoachInTeam)       //ok image ok
+		protected.DELETE("coaches/:coachID", team.DeleteCoachInTeam)
+
+		protected.GET("/tournaments/:tournamentID/players/:playerID/player-statistics", team.PlayerStatistics)
+		protected.GET("teams/:teamID/players", team.GetAllPlayersInTeam)
+		protected.GET("players/:playerID", team.GetPlayerByID)
+		protected.POST("teams/:teamID/players", team.CreatePlayerInTeam) //ok image ok
+		protected.PUT("players/:playerID", team.UpdatePlayerInTeam)      //ok image ok
+		protected.DELETE("players/:playerID", team.DeletePlayerInTeam)
+		protected.GET("/players/:playerID/aliases", names.GetPlayerAliases)
+		protected.PUT("/players/:playerID/aliases", names.SetPlayerAliases)
+
+		protected.GET("/names/resolve", names.ResolveName)
+		protected.GET("/search", names.Search)
+		protected.GET("/name-reviews", names.GetNameReviews)
+		protected.POST("/name-reviews/:reviewID/resolve", names.ResolveNameReview)
+		protected.POST("/name-reviews/:reviewID/dismiss", names.DismissNameReview)
+
+		protected.GET("/patches", patch.GetAllPatches)
+		protected.GET("/patches/:patchID", patch.GetPatchByID)
+		protected.POST("/patches", patch.CreatePatch)
+		protected.PUT("/patches/:patchID", patch.UpdatePatch)
+		protected.DELETE("/patches/:patchID", patch.DeletePatch)
+
+		protected.GET("heroes", hero.GetAllHeroes)
+		protected.GET("heroes/:heroID", hero.GetHeroByID)
+		protected.POST("heroes", hero.CreateHero)        //ok image ok
+		protected.PUT("heroes/:heroID", hero.UpdateHero) //ok image ok
+		protected.DELETE("heroes/:heroID", hero.DeleteHero)
 
 	}
 
@@ -170,3 +244,15 @@ func SetupRouter() *gin.Engine {
 
 	return r
 }
+
+// configureSwagger menyesuaikan host, scheme dan base path dokumen Swagger
+// dengan BASE_URL agar "Try it out" bekerja di balik host atau port lain.
+func configureSwagger(baseURL string) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return
+	}
+	docs.SwaggerInfo.Host = u.Host
+	docs.SwaggerInfo.Schemes = []string{u.Scheme}
+	docs.SwaggerInfo.BasePath = strings.TrimSuffix(u.Path, "/") + "/api/"
+}
@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"ml-master-data/models"
+	"ml-master-data/seeders"
+)
+
+func TestSeedersAreIdempotent(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+
+	// Gambar hasil upload tidak ditimpa, placeholder diganti gambar seeder
+	uploaded := models.Image("heroes/layla.webp")
+	s.db.Model(&f.Heroes[0]).Update("image", uploaded)
+	s.db.Model(&f.Heroes[1]).Update("image", models.PlaceholderImage)
+
+	var heroes, teams []map[string]interface{}
+	var count int
+	for i := 0; i < 2; i++ {
+		if err := seeders.Seed(s.db, s.cfg, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		expect(t, s.request(http.MethodGet, "/heroes", nil), http.StatusOK, &heroes)
+		expect(t, s.request(http.MethodGet, "/teams", nil), http.StatusOK, &teams)
+		if i == 0 {
+			count = len(heroes)
+		}
+		if len(heroes) != count {
+			t.Fatalf("run %d: len(heroes) = %d, want %d", i+1, len(heroes), count)
+		}
+	}
+
+	// Fixtures berisi 3 hero dan 2 team; Layla, Tigreal dan Eudora sudah
+	// ada sehingga tidak dibuat ulang.
+	if len(heroes) != 99 || len(teams) != 2+9 {
+		t.Fatalf("len(heroes) = %d, len(teams) = %d, want 99 and 11", len(heroes), len(teams))
+	}
+	var images []models.Image
+	s.db.Model(&models.Hero{}).Where("hero_id IN ?", []uint{f.Heroes[0].HeroID, f.Heroes[1].HeroID, f.Heroes[2].HeroID}).Order("hero_id").Pluck("image", &images)
+	if len(images) != 3 || images[0] != uploaded {
+		t.Fatalf("uploaded image = %v, want %q", images, uploaded)
+	}
+	for _, image := range images[1:] {
+		if !strings.HasPrefix(string(image), "https://res.cloudinary.com/") {
+			t.Errorf("placeholder or empty image replaced with %q, want the seeder image", image)
+		}
+	}
+
+	var users int64
+	s.db.Model(&models.User{}).Count(&users)
+	if users != 1+5 {
+		t.Fatalf("users = %d, want 6", users)
+	}
+}
+
+func TestSeedUsersRefusesDefaultPasswordOutsideDevelopment(t *testing.T) {
+	s := newTestServer(t)
+	s.cfg.Env = "production"
+
+	if err := seeders.Seed(s.db, s.cfg, []string{"users"}); err == nil {
+		t.Fatal("seeding users with the default password succeeded in production")
+	}
+
+	s.cfg.SeedUserPassword = "a-strong-password"
+	if err := seeders.Seed(s.db, s.cfg, []string{"users"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := seeders.Seed(s.db, s.cfg, []string{"villains"}); err == nil {
+		t.Fatal("unknown seeder was accepted")
+	}
+}
@@ -0,0 +1,250 @@
+package routes
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"ml-master-data/dto"
+	"ml-master-data/tabular"
+)
+
+// workbook membuat file XLSX dari sheet berisi header dan baris teks.
+func workbook(t *testing.T, sheets map[string][][]string, order ...string) []byte {
+	t.Helper()
+
+	var list []tabular.Sheet
+	for _, name := range order {
+		records := sheets[name]
+		list = append(list, tabular.Sheet{Name: name, Table: tabular.Table{Columns: records[0], Rows: records[1:]}})
+	}
+	var buf bytes.Buffer
+	if err := tabular.WriteXLSX(&buf, list...); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSheetImportRoundTrip(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	game := f.Games[0][0]
+
+	pick := map[string]interface{}{
+		"hero_id": f.Heroes[0].HeroID, "first_phase": 1, "second_phase": 0, "total": 1,
+		"hero_pick_game": []map[string]interface{}{{"game_id": game.GameID, "game_number": 1, "is_picked": true}},
+	}
+	expect(t, s.request(http.MethodPost, fmt.Sprintf("/matches/%d/teams/%d/hero-picks", game.MatchID, f.TeamA.TeamID), pick), http.StatusCreated, nil)
+
+	// Workbook hasil export bisa di-import kembali tanpa perubahan
+	export := s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/export.xlsx", f.Tournament.TournamentID), nil)
+	expect(t, export, http.StatusOK, nil)
+
+	var result dto.SheetImportResultDto
+	path := fmt.Sprintf("/tournaments/%d/import", f.Tournament.TournamentID)
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "export.xlsx", export.Body.Bytes()), http.StatusOK, &result)
+	if result.Created != 0 || result.Updated != 0 || result.Unchanged == 0 || len(result.Changes) != 0 {
+		t.Fatalf("result = %+v, want everything unchanged", result)
+	}
+}
+
+func TestSheetImport(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d/import", f.Tournament.TournamentID)
+	layla, tigreal := f.Heroes[0].Name, f.Heroes[1].Name
+
+	sheets := map[string][][]string{
+		"Matches": {
+			{"Match ID", "Stage", "Day", "Date", "Played At", "Team A", "Team B", "Team A Score", "Team B Score"},
+			{"new-1", "Playoffs", "3", "15", "2024-03-15", f.TeamA.Name, strings.ToUpper(f.TeamB.Name), "2", "0"},
+			// Match lama: hanya skor yang berubah
+			{fmt.Sprint(f.Matches[0].MatchID), f.Matches[0].Stage, fmt.Sprint(f.Matches[0].Day), fmt.Sprint(f.Matches[0].Date), "", f.TeamA.Name, f.TeamB.Name, "2", "2"},
+		},
+		"Games": {
+			{"match_id", "game_number", "first_pick_team", "second_pick_team", "winner_team"},
+			{"new-1", "1", f.TeamA.Name, f.TeamB.Name, f.TeamA.Name},
+			{"new-1", "2", fmt.Sprint(f.TeamB.TeamID), f.TeamA.Name, f.TeamA.Name},
+		},
+		"Hero Picks": {
+			{"match_id", "team", "hero", "first_phase", "second_phase", "total"},
+			{"new-1", f.TeamA.Name, layla, "2", "0", "2"},
+		},
+		"Drafts": {
+			{"match_id", "game_number", "team", "action", "hero"},
+			{"new-1", "1", f.TeamA.Name, "pick", layla},
+			{"new-1", "2", f.TeamA.Name, "pick", layla},
+		},
+		"Lane Results": {
+			{"match_id", "game_number", "team", "lane", "role", "hero", "early_result"},
+			{"new-1", "1", f.TeamA.Name, "explaner", "", tigreal, "win"},
+			{"new-1", "1", f.TeamB.Name, "trio_mid", "jungler", layla, "lose"},
+			{"new-1", "1", f.TeamB.Name, "trio_mid", "roamer", tigreal, "draw"},
+		},
+		"Objectives": {
+			{"match_id", "game_number", "team", "objective", "phase", "setup", "initiate", "result"},
+			{"new-1", "2", f.TeamB.Name, "turtle", "1", "early", "yes", "no"},
+		},
+	}
+	order := []string{"Objectives", "Lane Results", "Drafts", "Hero Picks", "Games", "Matches"}
+	file := workbook(t, sheets, order...)
+
+	// Dry run menampilkan diff tanpa menyimpan
+	var result dto.SheetImportResultDto
+	expect(t, s.requestUpload(http.MethodPost, path, map[string]string{"dry_run": "true"}, "file", "scouting.xlsx", file), http.StatusOK, &result)
+	if !result.DryRun || result.Applied || result.Updated != 1 {
+		t.Fatalf("dry run = %+v", result)
+	}
+	// match + 2 game + hero pick + 2 draft + explaner + trio mid + 2 hero trio mid + turtle
+	if result.Created != 11 {
+		t.Fatalf("created = %d, want 11; changes = %+v", result.Created, result.Changes)
+	}
+	var update *dto.SheetImportChangeDto
+	for i := range result.Changes {
+		if result.Changes[i].Action == "update" {
+			update = &result.Changes[i]
+		}
+	}
+	if update == nil || update.Sheet != "Matches" || len(update.Fields) != 1 || update.Fields[0] != (dto.SheetImportFieldDto{Field: "team_b_score", Before: "1", After: "2"}) {
+		t.Fatalf("update = %+v", update)
+	}
+
+	var matches []dto.MatchResponseDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/matches", f.Tournament.TournamentID), nil), http.StatusOK, &matches)
+	if len(matches) != len(f.Matches) {
+		t.Fatalf("dry run saved matches: %d", len(matches))
+	}
+
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "scouting.xlsx", file), http.StatusOK, &result)
+	if !result.Applied || result.Created != 11 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d/matches", f.Tournament.TournamentID), nil), http.StatusOK, &matches)
+	if len(matches) != len(f.Matches)+1 || *matches[0].TeamBScore != 2 {
+		t.Fatalf("matches = %d, first team_b_score = %d", len(matches), *matches[0].TeamBScore)
+	}
+	created := matches[len(matches)-1]
+	if *created.Stage != "Playoffs" || created.PlayedAt == nil {
+		t.Fatalf("created match = %+v", created)
+	}
+
+	var picks []dto.HeroPickResponseDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/matches/%d/teams/%d/hero-picks", *created.MatchID, f.TeamA.TeamID), nil), http.StatusOK, &picks)
+	if len(picks) != 1 || len(picks[0].HeroPickGame) != 2 || picks[0].HeroPickGame[0].GameID == 0 {
+		t.Fatalf("picks = %+v", picks)
+	}
+
+	// Import ulang file yang sama tidak mengubah apa pun; kunci "new-1"
+	// dicocokkan dengan match yang dibuat sebelumnya
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "scouting.xlsx", file), http.StatusOK, &result)
+	if result.Created != 0 || result.Updated != 0 || result.Unchanged != 11 {
+		t.Fatalf("reimport = %+v", result)
+	}
+}
+
+func TestSheetImportValidation(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d/import", f.Tournament.TournamentID)
+	match := fmt.Sprint(f.Matches[0].MatchID)
+
+	csv := strings.Join([]string{
+		"match_id,team,hero,first_phase,second_phase,total",
+		match + "," + f.TeamA.Name + "," + f.Heroes[0].Name + ",1,0,1",
+		match + "," + f.TeamA.Name + ",Nobody,1,0,1",
+		match + ",Unknown Team," + f.Heroes[1].Name + ",1,0,1",
+		match + "," + f.TeamA.Name + "," + f.Heroes[0].Name + ",1,0,1",
+		"999," + f.TeamA.Name + "," + f.Heroes[0].Name + ",x,0,1",
+	}, "\n")
+
+	var result dto.SheetImportResultDto
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "hero-picks.csv", []byte(tabular.BOM+csv)), http.StatusBadRequest, &result)
+	problems := map[string]bool{}
+	for _, problem := range result.Errors {
+		problems[fmt.Sprintf("%d:%s", problem.Row, problem.Column)] = true
+	}
+	for _, want := range []string{"3:hero", "4:team", "5:", "6:match_id", "6:first_phase"} {
+		if !problems[want] {
+			t.Fatalf("missing problem %s; errors = %+v", want, result.Errors)
+		}
+	}
+	if len(result.Errors) != 5 || result.Applied {
+		t.Fatalf("result = %+v", result)
+	}
+
+	// Baris valid di file yang sama tidak ikut tersimpan
+	var picks []dto.HeroPickResponseDto
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/matches/%s/teams/%d/hero-picks", match, f.TeamA.TeamID), nil), http.StatusOK, &picks)
+	if len(picks) != 0 {
+		t.Fatalf("picks = %+v", picks)
+	}
+
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "notes.csv", []byte("a,b\n1,2")), http.StatusBadRequest, &result)
+	if len(result.Errors) != 1 || result.Errors[0].Message != "Unknown sheet" {
+		t.Fatalf("errors = %+v", result.Errors)
+	}
+	expect(t, s.requestUpload(http.MethodPost, path, map[string]string{"sheet": "Hero Picks"}, "file", "notes.csv", []byte("match_id,team\n")), http.StatusBadRequest, &result)
+	if len(result.Errors) != 4 || result.Errors[0].Column != "hero" {
+		t.Fatalf("errors = %+v", result.Errors)
+	}
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "notes.txt", []byte("x")), http.StatusBadRequest, nil)
+	expect(t, s.requestUpload(http.MethodPost, "/tournaments/9999/import", nil, "file", "hero-picks.csv", []byte(csv)), http.StatusNotFound, nil)
+
+	// Body di atas batas import dihentikan sebelum form selesai dibaca
+	huge := make([]byte, 12<<20)
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "huge.csv", huge), http.StatusRequestEntityTooLarge, nil)
+}
+
+func TestSheetImportDraftRules(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d/import", f.Tournament.TournamentID)
+	match := fmt.Sprint(f.Matches[0].MatchID)
+	layla, eudora := f.Heroes[0], f.Heroes[2]
+
+	expect(t, s.request(http.MethodPut, fmt.Sprintf("/tournaments/%d/rules", f.Tournament.TournamentID), map[string]interface{}{
+		"fearless_mode":     "full",
+		"disabled_hero_ids": []uint{eudora.HeroID},
+	}), http.StatusOK, nil)
+
+	sheets := map[string][][]string{
+		"Hero Picks": {
+			{"match_id", "team", "hero", "first_phase", "second_phase", "total"},
+			{match, f.TeamA.Name, layla.Name, "1", "0", "1"},
+			{match, f.TeamB.Name, layla.Name, "1", "0", "1"},
+			{match, f.TeamA.Name, eudora.Name, "1", "0", "1"},
+		},
+		"Drafts": {
+			{"match_id", "game_number", "team", "action", "hero"},
+			{match, "1", f.TeamA.Name, "pick", layla.Name},
+			{match, "2", f.TeamB.Name, "pick", layla.Name},
+		},
+	}
+	file := workbook(t, sheets, "Hero Picks", "Drafts")
+
+	// Pelanggaran aturan draft dilaporkan di dry run pada baris hero pick-nya
+	var result dto.SheetImportResultDto
+	expect(t, s.requestUpload(http.MethodPost, path, map[string]string{"dry_run": "true"}, "file", "drafts.xlsx", file), http.StatusBadRequest, &result)
+	if !result.DryRun || result.Applied || len(result.Errors) != 3 {
+		t.Fatalf("result = %+v, want 3 draft rule errors", result)
+	}
+	for i, problem := range result.Errors {
+		if problem.Sheet != "Hero Picks" || problem.Row != i+2 || problem.Column != "hero" {
+			t.Fatalf("error %d = %+v", i, problem)
+		}
+	}
+	if !strings.Contains(result.Errors[2].Message, "disabled") {
+		t.Fatalf("eudora error = %q, want disabled hero", result.Errors[2].Message)
+	}
+
+	// Tanpa hero yang dilarang dan pick ganda, file yang sama lolos
+	sheets["Hero Picks"] = sheets["Hero Picks"][:2]
+	sheets["Drafts"] = sheets["Drafts"][:2]
+	expect(t, s.requestUpload(http.MethodPost, path, nil, "file", "drafts.xlsx", workbook(t, sheets, "Hero Picks", "Drafts")), http.StatusOK, &result)
+	if !result.Applied || result.Created != 2 {
+		t.Fatalf("result = %+v, want hero pick and draft created", result)
+	}
+}
@@ -0,0 +1,98 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ml-master-data/migrations"
+	"ml-master-data/models"
+	"ml-master-data/storage"
+)
+
+func TestUploadedImagesAreStoredAsKeys(t *testing.T) {
+	s := newTestServer(t)
+
+	var hero struct {
+		HeroID uint   `json:"hero_id"`
+		Image  string `json:"image"`
+	}
+	w := s.requestUpload(http.MethodPost, "/heroes", map[string]string{"name": "Miya"}, "image", "miya.png", testPNG(t, 64, 64))
+	expect(t, w, http.StatusCreated, &hero)
+
+	var stored models.Hero
+	s.db.First(&stored, hero.HeroID)
+	key, ok := stored.Image.Key()
+	if !ok || strings.HasPrefix(key, "/") || strings.Contains(key, "public/images") {
+		t.Fatalf("stored image = %q, want a storage key", stored.Image)
+	}
+	if hero.Image != s.cfg.BaseURL+"/media/"+key {
+		t.Fatalf("image = %q, want URL of %q", hero.Image, key)
+	}
+
+	// Media lokal dilayani router.
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/media/"+key, nil))
+	if w.Code != http.StatusOK || http.DetectContentType(w.Body.Bytes()) != "image/webp" {
+		t.Fatalf("GET media = %d %q", w.Code, w.Body.String())
+	}
+
+	// Gambar baru menggantikan objek lama di storage.
+	w = s.requestUpload(http.MethodPut, fmt.Sprintf("/heroes/%d", hero.HeroID), nil, "image", "miya.jpg", testPNG(t, 32, 32))
+	expect(t, w, http.StatusOK, &hero)
+	if _, err := s.files.Get(context.Background(), key); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("old image still stored: err = %v", err)
+	}
+
+	// URL mengikuti BASE_URL tanpa mengubah data.
+	s.cfg.BaseURL = "https://cdn.example.com"
+	moved := storage.NewLocal(t.TempDir(), s.cfg.BaseURL+"/media")
+	s.router = SetupRouter(s.db, s.cfg, moved)
+	t.Cleanup(func() { models.ImageURL = s.files.URL })
+
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/heroes/%d", hero.HeroID), nil), http.StatusOK, &hero)
+	if !strings.HasPrefix(hero.Image, "https://cdn.example.com/media/") {
+		t.Fatalf("image = %q after BASE_URL change", hero.Image)
+	}
+
+	// Gambar eksternal tidak diubah.
+	expect(t, s.requestForm(http.MethodPost, "/teams", map[string]string{"name": "No Logo"}), http.StatusCreated, &hero)
+	if hero.Image != "https://placehold.co/400x600" {
+		t.Fatalf("placeholder image = %q", hero.Image)
+	}
+}
+
+func TestImageKeysMigration(t *testing.T) {
+	s := newTestServer(t)
+	t.Setenv("BASE_URL", "http://old-host:8080")
+
+	hero := models.Hero{Name: "Miya", Image: "hero-1.png"}
+	s.db.Create(&hero)
+
+	// Migration sesudah 0003 ikut dibatalkan.
+	var steps int
+	for i, migration := range migrations.All() {
+		if migration.ID == "0003_image_keys" {
+			steps = len(migrations.All()) - i
+		}
+	}
+	if err := migrations.Down(s.db, steps); err != nil {
+		t.Fatal(err)
+	}
+	s.db.First(&hero, hero.HeroID)
+	if hero.Image != "http://old-host:8080/public/images/hero-1.png" {
+		t.Fatalf("image after down = %q", hero.Image)
+	}
+
+	if err := migrations.Up(s.db); err != nil {
+		t.Fatal(err)
+	}
+	s.db.First(&hero, hero.HeroID)
+	if hero.Image != "hero-1.png" {
+		t.Fatalf("image after up = %q", hero.Image)
+	}
+}
@@ -0,0 +1,162 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"ml-master-data/models"
+)
+
+func TestTeamCRUD(t *testing.T) {
+	s := newTestServer(t)
+
+	var created models.Team
+	expect(t, s.requestForm(http.MethodPost, "/teams", map[string]string{"name": "Team C"}), http.StatusCreated, &created)
+	if created.TeamID == 0 || created.Name != "Team C" {
+		t.Fatalf("created = %+v", created)
+	}
+	path := fmt.Sprintf("/teams/%d", created.TeamID)
+
+	var list []models.Team
+	expect(t, s.request(http.MethodGet, "/teams", nil), http.StatusOK, &list)
+	if len(list) != 3 {
+		t.Fatalf("len(teams) = %d, want 3", len(list))
+	}
+
+	var updated models.Team
+	expect(t, s.requestForm(http.MethodPut, path, map[string]string{"name": "Team C Esports"}), http.StatusOK, &updated)
+	if updated.Name != "Team C Esports" {
+		t.Fatalf("name = %q", updated.Name)
+	}
+
+	expect(t, s.requestForm(http.MethodPost, "/teams", map[string]string{}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodDelete, path, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusNotFound, nil)
+	expect(t, s.request(http.MethodPost, path+"/restore", nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, "/teams/9999", nil), http.StatusNotFound, nil)
+}
+
+func TestPlayerAndCoachCRUD(t *testing.T) {
+	s := newTestServer(t)
+	teamPath := fmt.Sprintf("/teams/%d", s.fixtures.TeamA.TeamID)
+
+	var player models.Player
+	expect(t, s.requestForm(http.MethodPost, teamPath+"/players", map[string]string{"name": "Player A2"}), http.StatusCreated, &player)
+	if player.TeamID != s.fixtures.TeamA.TeamID {
+		t.Fatalf("team_id = %d, want %d", player.TeamID, s.fixtures.TeamA.TeamID)
+	}
+
+	var players []models.Player
+	expect(t, s.request(http.MethodGet, teamPath+"/players", nil), http.StatusOK, &players)
+	if len(players) != 2 {
+		t.Fatalf("len(players) = %d, want 2", len(players))
+	}
+
+	playerPath := fmt.Sprintf("/players/%d", player.PlayerID)
+	expect(t, s.requestForm(http.MethodPut, playerPath, map[string]string{"name": "Player A3"}), http.StatusOK, &player)
+	if player.Name != "Player A3" {
+		t.Fatalf("name = %q", player.Name)
+	}
+	expect(t, s.request(http.MethodDelete, playerPath, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, playerPath, nil), http.StatusNotFound, nil)
+
+	var coach models.Coach
+	expect(t, s.requestForm(http.MethodPost, teamPath+"/coaches", map[string]string{"name": "Coach A2"}), http.StatusCreated, &coach)
+
+	var coaches []models.Coach
+	expect(t, s.request(http.MethodGet, teamPath+"/coaches", nil), http.StatusOK, &coaches)
+	if len(coaches) != 2 {
+		t.Fatalf("len(coaches) = %d, want 2", len(coaches))
+	}
+
+	coachPath := fmt.Sprintf("/coaches/%d", coach.CoachID)
+	expect(t, s.requestForm(http.MethodPut, coachPath, map[string]string{"name": "Coach A3"}), http.StatusOK, &coach)
+	if coach.Name != "Coach A3" {
+		t.Fatalf("name = %q", coach.Name)
+	}
+	expect(t, s.request(http.MethodDelete, coachPath, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, coachPath, nil), http.StatusNotFound, nil)
+
+	expect(t, s.requestForm(http.MethodPost, "/teams/9999/players", map[string]string{"name": "x"}), http.StatusNotFound, nil)
+}
+
+// Statistik di bawah dipakai langsung oleh frontend; nama key JSON dan
+// angkanya dikunci terhadap fixtures.
+func TestTeamStatistics(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+
+	var stats map[string]int
+	path := fmt.Sprintf("/tournaments/%d/teams/%d/team-statistics", f.Tournament.TournamentID, f.TeamA.TeamID)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &stats)
+
+	want := map[string]int{
+		"teamID":                 int(f.TeamA.TeamID),
+		"totalMatch":             2,
+		"totalMatchAndWin":       1,
+		"totalMatchAndLose":      1,
+		"totalGame":              5,
+		"totalGameAndWin":        2,
+		"totalGameAndLose":       3,
+		"totalFirstPick":         3,
+		"totalFirstPickAndWin":   2,
+		"totalFirstPickAndLose":  1,
+		"totalSecondPick":        2,
+		"totalSecondPickAndWin":  0,
+		"totalSecondPickAndLose": 2,
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Fatalf("team statistics = %v, want %v", stats, want)
+	}
+
+	// Match yang dihapus tidak ikut dihitung.
+	expect(t, s.request(http.MethodDelete, fmt.Sprintf("/matches/%d", f.Matches[1].MatchID), nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &stats)
+	if stats["totalMatch"] != 1 || stats["totalGame"] != 3 {
+		t.Fatalf("after delete: totalMatch = %d, totalGame = %d, want 1, 3", stats["totalMatch"], stats["totalGame"])
+	}
+
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/abc/teams/%d/team-statistics", f.TeamA.TeamID), nil), http.StatusBadRequest, nil)
+}
+
+func TestPlayerAndCoachStatistics(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+
+	want := map[string]int{
+		"total_match":     1,
+		"total_match_win": 1,
+		"total_game":      3,
+		"total_game_win":  2,
+	}
+
+	var stats map[string]int
+	path := fmt.Sprintf("/tournaments/%d/players/%d/player-statistics", f.Tournament.TournamentID, f.PlayerA.PlayerID)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &stats)
+	if !reflect.DeepEqual(stats, want) {
+		t.Fatalf("player statistics = %v, want %v", stats, want)
+	}
+
+	path = fmt.Sprintf("/tournaments/%d/coachs/%d/coach-statistics", f.Tournament.TournamentID, f.CoachA.CoachID)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &stats)
+	if !reflect.DeepEqual(stats, want) {
+		t.Fatalf("coach statistics = %v, want %v", stats, want)
+	}
+
+	path = fmt.Sprintf("/tournaments/%d/players/%d/player-statistics", f.Tournament.TournamentID, f.PlayerB.PlayerID)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &stats)
+	if stats["total_match"] != 0 {
+		t.Fatalf("player without matches: total_match = %d, want 0", stats["total_match"])
+	}
+
+	// Tim yang sudah di-soft delete tidak lagi dihitung
+	s.db.Delete(&f.TeamA)
+	path = fmt.Sprintf("/tournaments/%d/players/%d/player-statistics", f.Tournament.TournamentID, f.PlayerA.PlayerID)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &stats)
+	if stats["total_match"] != 0 || stats["total_game"] != 0 {
+		t.Fatalf("player of deleted team = %v, want no matches", stats)
+	}
+}
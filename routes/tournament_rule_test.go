@@ -0,0 +1,114 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+)
+
+func TestTournamentDraftRules(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	games := f.Games[0]
+	layla, tigreal, eudora := f.Heroes[0].HeroID, f.Heroes[1].HeroID, f.Heroes[2].HeroID
+	extra := []models.Hero{{Name: "Franco"}, {Name: "Saber"}}
+	s.db.Create(&extra)
+
+	rulesPath := fmt.Sprintf("/tournaments/%d/rules", f.Tournament.TournamentID)
+	var rule models.TournamentRule
+	expect(t, s.request(http.MethodGet, rulesPath, nil), http.StatusOK, &rule)
+	if rule.DraftFormat != models.DraftStandard || rule.FearlessMode != models.FearlessOff || len(rule.DisabledHeroes) != 0 {
+		t.Fatalf("default rule = %+v", rule)
+	}
+
+	expect(t, s.request(http.MethodPut, rulesPath, map[string]interface{}{
+		"draft_format":      "global_ban",
+		"fearless_mode":     "full",
+		"bans_first_phase":  1,
+		"bans_second_phase": 1,
+		"disabled_hero_ids": []uint{eudora, eudora},
+	}), http.StatusOK, &rule)
+	if rule.DraftFormat != models.DraftGlobalBan || rule.FearlessMode != models.FearlessFull || len(rule.DisabledHeroes) != 1 {
+		t.Fatalf("rule = %+v", rule)
+	}
+	expect(t, s.request(http.MethodPut, rulesPath, map[string]interface{}{"fearless_mode": "maybe"}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPut, rulesPath, map[string]interface{}{"disabled_hero_ids": []uint{9999}}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodGet, "/tournaments/9999/rules", nil), http.StatusNotFound, nil)
+
+	draft := func(key, flag string, heroID uint, firstPhase, secondPhase int, numbers ...int) map[string]interface{} {
+		rows := []map[string]interface{}{}
+		for _, n := range numbers {
+			rows = append(rows, map[string]interface{}{"game_id": games[n-1].GameID, "game_number": n, flag: true})
+		}
+		return map[string]interface{}{
+			"hero_id": heroID, "first_phase": firstPhase, "second_phase": secondPhase,
+			"total": firstPhase + secondPhase, key: rows,
+		}
+	}
+	pick := func(heroID uint, numbers ...int) map[string]interface{} {
+		return draft("hero_pick_game", "is_picked", heroID, len(numbers), 0, numbers...)
+	}
+	ban := func(heroID uint, firstPhase, secondPhase int, numbers ...int) map[string]interface{} {
+		return draft("hero_ban_game", "is_banned", heroID, firstPhase, secondPhase, numbers...)
+	}
+	teamA := fmt.Sprintf("/matches/%d/teams/%d", f.Matches[0].MatchID, f.TeamA.TeamID)
+	teamB := fmt.Sprintf("/matches/%d/teams/%d", f.Matches[0].MatchID, f.TeamB.TeamID)
+
+	steps := []struct {
+		name   string
+		path   string
+		body   map[string]interface{}
+		status int
+	}{
+		{"disabled hero", teamA + "/hero-picks", pick(eudora, 1), http.StatusBadRequest},
+		{"disabled hero ban", teamA + "/hero-bans", ban(eudora, 1, 0, 1), http.StatusBadRequest},
+		{"first pick", teamA + "/hero-picks", pick(layla, 1), http.StatusCreated},
+		{"same game", teamB + "/hero-picks", pick(layla, 1), http.StatusBadRequest},
+		{"full fearless", teamB + "/hero-picks", pick(layla, 2), http.StatusBadRequest},
+		{"fearless repeat", teamA + "/hero-picks", pick(tigreal, 1, 2), http.StatusBadRequest},
+		{"picked and banned", teamB + "/hero-bans", ban(layla, 1, 0, 1), http.StatusBadRequest},
+		{"ban", teamA + "/hero-bans", ban(tigreal, 1, 0, 1), http.StatusCreated},
+		{"global ban twice", teamB + "/hero-bans", ban(tigreal, 1, 0, 2), http.StatusBadRequest},
+		{"pick after global ban", teamB + "/hero-picks", pick(tigreal, 3), http.StatusBadRequest},
+		{"first phase limit", teamA + "/hero-bans", ban(extra[0].HeroID, 1, 0, 1), http.StatusBadRequest},
+		{"second phase ban", teamA + "/hero-bans", ban(extra[0].HeroID, 0, 1, 1), http.StatusCreated},
+		{"bans per game", teamA + "/hero-bans", ban(extra[1].HeroID, 0, 1, 1), http.StatusBadRequest},
+		{"other team bans", teamB + "/hero-bans", ban(extra[1].HeroID, 0, 1, 1), http.StatusCreated},
+	}
+	for _, step := range steps {
+		w := s.request(http.MethodPost, step.path, step.body)
+		if w.Code != step.status {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, w.Code, step.status, w.Body.String())
+		}
+	}
+
+	// Tanpa fearless dan global ban, hero yang sama boleh di-pick di game lain
+	expect(t, s.request(http.MethodPut, rulesPath, map[string]interface{}{"draft_format": "standard", "fearless_mode": "off"}), http.StatusOK, &rule)
+	if len(rule.DisabledHeroes) != 1 || rule.BansFirstPhase != 1 {
+		t.Fatalf("partial update changed other fields: %+v", rule)
+	}
+	expect(t, s.request(http.MethodPost, teamB+"/hero-picks", pick(layla, 2)), http.StatusCreated, nil)
+	expect(t, s.request(http.MethodPost, teamB+"/hero-picks", pick(tigreal, 3)), http.StatusCreated, nil)
+
+	var picks []dto.HeroPickResponseDto
+	expect(t, s.request(http.MethodGet, teamB+"/hero-picks", nil), http.StatusOK, &picks)
+	for _, p := range picks {
+		if *p.HeroID == layla {
+			// Update tetap memakai game lama yang tidak dikirim
+			expect(t, s.request(http.MethodPut, fmt.Sprintf("%s/hero-picks/%d", teamB, *p.HeroPickID), pick(layla, 1)), http.StatusBadRequest, nil)
+		}
+	}
+
+	// Batas fase pertama tetap berlaku per game walaupun fase kedua tidak
+	// dibatasi
+	expect(t, s.request(http.MethodPut, rulesPath, map[string]interface{}{"bans_second_phase": 0}), http.StatusOK, &rule)
+	games = f.Games[1]
+	secondMatch := fmt.Sprintf("/matches/%d/teams/%d/hero-bans", f.Matches[1].MatchID, f.TeamA.TeamID)
+	expect(t, s.request(http.MethodPost, secondMatch, ban(layla, 1, 0, 1)), http.StatusCreated, nil)
+	expect(t, s.request(http.MethodPost, secondMatch, ban(tigreal, 0, 1, 2)), http.StatusCreated, nil)
+	expect(t, s.request(http.MethodPost, secondMatch, ban(extra[0].HeroID, 1, 0, 1)), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodPost, secondMatch, ban(extra[0].HeroID, 1, 0, 2)), http.StatusCreated, nil)
+}
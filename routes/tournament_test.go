@@ -0,0 +1,113 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/services"
+)
+
+func TestTournamentCRUD(t *testing.T) {
+	s := newTestServer(t)
+
+	var created models.Tournament
+	expect(t, s.request(http.MethodPost, "/tournaments", map[string]string{"name": "MPL Season 2"}), http.StatusCreated, &created)
+	if created.TournamentID == 0 || created.Name != "MPL Season 2" {
+		t.Fatalf("created = %+v", created)
+	}
+	path := fmt.Sprintf("/tournaments/%d", created.TournamentID)
+
+	var list []models.Tournament
+	expect(t, s.request(http.MethodGet, "/tournaments", nil), http.StatusOK, &list)
+	if len(list) != 2 {
+		t.Fatalf("len(tournaments) = %d, want 2", len(list))
+	}
+
+	var updated models.Tournament
+	expect(t, s.request(http.MethodPut, path, map[string]string{"name": "MPL Season 2 Playoffs"}), http.StatusOK, &updated)
+	if updated.Name != "MPL Season 2 Playoffs" {
+		t.Fatalf("name = %q", updated.Name)
+	}
+
+	var got models.Tournament
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, &got)
+	if got.Name != updated.Name {
+		t.Fatalf("name = %q, want %q", got.Name, updated.Name)
+	}
+
+	expect(t, s.request(http.MethodPost, "/tournaments", map[string]string{}), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodGet, "/tournaments/abc", nil), http.StatusBadRequest, nil)
+	expect(t, s.request(http.MethodGet, "/tournaments/9999", nil), http.StatusNotFound, nil)
+	expect(t, s.request(http.MethodPut, "/tournaments/9999", map[string]string{"name": "x"}), http.StatusNotFound, nil)
+}
+
+func TestTournamentDeleteAndRestore(t *testing.T) {
+	s := newTestServer(t)
+	f := s.fixtures
+	path := fmt.Sprintf("/tournaments/%d", f.Tournament.TournamentID)
+
+	expect(t, s.request(http.MethodPost, path+"/restore", nil), http.StatusConflict, nil)
+	expect(t, s.request(http.MethodDelete, path, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusNotFound, nil)
+
+	// Menghapus tournament ikut menghapus match di dalamnya.
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/matches/%d", f.Matches[0].MatchID), nil), http.StatusNotFound, nil)
+
+	expect(t, s.request(http.MethodPost, path+"/restore", nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, path, nil), http.StatusOK, nil)
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/matches/%d", f.Matches[0].MatchID), nil), http.StatusOK, nil)
+
+	var matches []map[string]interface{}
+	expect(t, s.request(http.MethodGet, path+"/matches", nil), http.StatusOK, &matches)
+	if len(matches) != len(f.Matches) {
+		t.Fatalf("len(matches) = %d, want %d", len(matches), len(f.Matches))
+	}
+}
+
+func TestPurgeDeletedBefore(t *testing.T) {
+	s := newTestServer(t)
+
+	now := time.Now()
+	expired := models.Tournament{Name: "Expired"}
+	recent := models.Tournament{Name: "Recent"}
+	expiredTeam := models.Team{Name: "Expired Team"}
+	recentTeam := models.Team{Name: "Recent Team"}
+	s.db.Create(&expired)
+	s.db.Create(&recent)
+	s.db.Create(&expiredTeam)
+	s.db.Create(&recentTeam)
+	s.db.Model(&expired).Update("deleted_at", now.AddDate(0, 0, -31))
+	s.db.Model(&recent).Update("deleted_at", now.AddDate(0, 0, -1))
+	s.db.Model(&expiredTeam).Update("deleted_at", now.AddDate(0, 0, -31))
+	s.db.Model(&recentTeam).Update("deleted_at", now.AddDate(0, 0, -1))
+
+	purge := services.New(repositories.New(s.db, s.files)).Purge
+	if err := purge.PurgeDeletedBefore(context.Background(), now.AddDate(0, 0, -s.cfg.SoftDeleteRetentionDays)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Yang melewati retensi dihapus permanen, yang lebih baru tetap bisa
+	// di-restore
+	for _, c := range []struct {
+		model interface{}
+		id    uint
+		kept  bool
+	}{
+		{&models.Tournament{}, expired.TournamentID, false},
+		{&models.Tournament{}, recent.TournamentID, true},
+		{&models.Team{}, expiredTeam.TeamID, false},
+		{&models.Team{}, recentTeam.TeamID, true},
+	} {
+		var count int64
+		s.db.Unscoped().Model(c.model).Where(c.id).Count(&count)
+		if (count == 1) != c.kept {
+			t.Errorf("%T %d: count = %d, kept = %v", c.model, c.id, count, c.kept)
+		}
+	}
+	expect(t, s.request(http.MethodGet, fmt.Sprintf("/tournaments/%d", s.fixtures.Tournament.TournamentID), nil), http.StatusOK, nil)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/seeders"
+	"strings"
+)
+
+// runSeed menjalankan subcommand `seed [-only users,heroes,teams]`. Tanpa
+// -only, seeder diambil dari env SEEDERS atau semua seeder jika kosong.
+func runSeed(cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	only := flags.String("only", strings.Join(cfg.Seeders, ","), "comma separated seeders to run ("+strings.Join(seeders.Names(), ", ")+")")
+	flags.Parse(args)
+
+	config.ConnectDatabase(cfg.Database)
+
+	if err := seeders.Seed(config.DB, cfg, seeders.ParseNames(*only)); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Seeding completed successfully!")
+}
@@ -0,0 +1,333 @@
+package seeders
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"ml-master-data/models"
+
+	"gorm.io/gorm"
+)
+
+// DemoOptions mengatur dataset demo. Seed yang sama selalu menghasilkan
+// tournament, jadwal, hasil dan draft yang sama.
+type DemoOptions struct {
+	Seed int64
+	// Teams adalah jumlah team peserta, antara 4 dan len(demoTeamNames).
+	// Nilai 0 berarti 8 team.
+	Teams int
+}
+
+// Demo berisi data yang dibuat oleh GenerateDemo.
+type Demo struct {
+	Tournament models.Tournament
+	Heroes     []models.Hero
+	Teams      []DemoTeam
+	Matches    []DemoMatch
+}
+
+// DemoTeam adalah team demo beserta lima pemain (urut sesuai demoRoles) dan
+// pelatihnya. Semua pemain dan pelatih tercatat di setiap match team.
+type DemoTeam struct {
+	Team    models.Team
+	Players []models.Player
+	Coach   models.Coach
+}
+
+type DemoMatch struct {
+	Match models.Match
+	Games []models.Game
+}
+
+// demoRoles adalah role pemain sesuai player_matches.role.
+var demoRoles = []string{"goldlaner", "explaner", "roamer", "midlaner", "jungler"}
+
+// draftRoles adalah nama role yang sama untuk priority pick, flex pick dan
+// priority ban.
+var draftRoles = []string{"gold", "exp", "roam", "mid", "jungler"}
+
+var demoTeamNames = []string{
+	"Garuda Esports",
+	"Komodo Gaming",
+	"Rajawali Team",
+	"Nusantara Legion",
+	"Merapi Warriors",
+	"Borneo Hornbills",
+	"Krakatau Fire",
+	"Sriwijaya Knights",
+	"Majapahit Reign",
+	"Bali Tridents",
+	"Toba Serpents",
+	"Lombok Storm",
+}
+
+var demoNameSyllables = []string{
+	"ka", "ri", "zen", "lo", "vi", "ra", "mo", "xi", "ta", "ne",
+	"ro", "ly", "shi", "do", "ar", "ky", "fa", "nu", "el", "jo",
+}
+
+const demoImage = "https://placehold.co/400x600"
+
+// GenerateDemo membuat tournament sintetis lengkap dari options.Seed: team,
+// pemain, pelatih, regular season round robin (Bo3), playoff (Bo5) dan
+// grand final (Bo7), termasuk draft, hasil early game setiap lane serta
+// hasil lord dan turtle. Semua data dibuat dalam satu transaksi.
+func GenerateDemo(db *gorm.DB, options DemoOptions) (*Demo, error) {
+	if options.Teams == 0 {
+		options.Teams = 8
+	}
+	if options.Teams < 4 || options.Teams > len(demoTeamNames) {
+		return nil, fmt.Errorf("number of teams must be between 4 and %d", len(demoTeamNames))
+	}
+
+	name := fmt.Sprintf("Demo League #%d", options.Seed)
+
+	var count int64
+	if err := db.Model(&models.Tournament{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("tournament %q already exists", name)
+	}
+
+	g := &demoGenerator{
+		rng:  rand.New(rand.NewSource(options.Seed)),
+		demo: &Demo{Tournament: models.Tournament{Name: name}},
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		g.tx = tx
+
+		if err := g.createHeroes(); err != nil {
+			return err
+		}
+		if err := tx.Create(&g.demo.Tournament).Error; err != nil {
+			return err
+		}
+		if err := g.createTeams(options.Teams); err != nil {
+			return err
+		}
+		if err := g.playRegularSeason(); err != nil {
+			return err
+		}
+		return g.playPlayoffs()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g.demo, nil
+}
+
+type demoGenerator struct {
+	rng     *rand.Rand
+	tx      *gorm.DB
+	demo    *Demo
+	heroes  []demoHero
+	ratings []float64
+	// day adalah hari pertandingan terakhir yang sudah dijadwalkan.
+	day int
+}
+
+type demoHero struct {
+	hero models.Hero
+	// roles berisi indeks demoRoles yang bisa dimainkan hero; role pertama
+	// adalah role utama.
+	roles []int
+	// weight menentukan seberapa sering hero dipick atau diban.
+	weight float64
+}
+
+func (h demoHero) plays(role int) bool {
+	for _, r := range h.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// createHeroes memakai hero yang sudah ada berdasarkan nama dan membuat yang
+// belum ada dari heroImages.
+func (g *demoGenerator) createHeroes() error {
+	for i, url := range heroImages {
+		hero := models.Hero{}
+		if err := g.tx.Where("name = ?", extractHeroName(url)).
+			Attrs(models.Hero{Name: extractHeroName(url), Image: models.Image(url)}).
+			FirstOrCreate(&hero).Error; err != nil {
+			return err
+		}
+
+		// Sebagian hero bisa dimainkan di dua role agar ada flex pick.
+		roles := []int{i % len(demoRoles)}
+		if i%6 == 0 {
+			roles = append(roles, (i+1)%len(demoRoles))
+		}
+
+		g.heroes = append(g.heroes, demoHero{hero: hero, roles: roles, weight: g.rng.ExpFloat64()})
+		g.demo.Heroes = append(g.demo.Heroes, hero)
+	}
+
+	return nil
+}
+
+func (g *demoGenerator) createTeams(n int) error {
+	usedNames := map[string]bool{}
+
+	for _, i := range g.rng.Perm(len(demoTeamNames))[:n] {
+		team := DemoTeam{Team: models.Team{Name: demoTeamNames[i], Image: demoImage}}
+		if err := g.tx.Create(&team.Team).Error; err != nil {
+			return err
+		}
+
+		for range demoRoles {
+			team.Players = append(team.Players, models.Player{TeamID: team.Team.TeamID, Name: g.nickname(usedNames), Image: demoImage})
+		}
+		if err := g.tx.Create(&team.Players).Error; err != nil {
+			return err
+		}
+
+		team.Coach = models.Coach{TeamID: team.Team.TeamID, Name: g.nickname(usedNames), Image: demoImage}
+		if err := g.tx.Create(&team.Coach).Error; err != nil {
+			return err
+		}
+
+		g.demo.Teams = append(g.demo.Teams, team)
+		g.ratings = append(g.ratings, 0.5+g.rng.Float64())
+	}
+
+	return nil
+}
+
+// nickname membuat nama panggilan unik dari dua atau tiga suku kata.
+func (g *demoGenerator) nickname(used map[string]bool) string {
+	for {
+		var name string
+		for i := 0; i < 2+g.rng.Intn(2); i++ {
+			name += demoNameSyllables[g.rng.Intn(len(demoNameSyllables))]
+		}
+		name = strings.ToUpper(name[:1]) + name[1:]
+
+		if !used[name] {
+			used[name] = true
+			return name
+		}
+	}
+}
+
+// playRegularSeason menjadwalkan round robin dengan metode lingkaran; setiap
+// putaran dimainkan pada satu hari pertandingan.
+func (g *demoGenerator) playRegularSeason() error {
+	order := make([]int, len(g.demo.Teams))
+	for i := range order {
+		order[i] = i
+	}
+	if len(order)%2 == 1 {
+		order = append(order, -1) // bye
+	}
+
+	for round := 0; round < len(order)-1; round++ {
+		g.day++
+		for i := 0; i < len(order)/2; i++ {
+			a, b := order[i], order[len(order)-1-i]
+			if a < 0 || b < 0 {
+				continue
+			}
+			if round%2 == 1 {
+				a, b = b, a
+			}
+			if err := g.playSeries("Regular Season", a, b, 3); err != nil {
+				return err
+			}
+		}
+
+		// Team pertama tetap, sisanya berputar satu posisi.
+		last := order[len(order)-1]
+		copy(order[2:], order[1:len(order)-1])
+		order[1] = last
+	}
+
+	return nil
+}
+
+// playPlayoffs mempertemukan empat team teratas regular season: semifinal
+// 1 vs 4 dan 2 vs 3, lalu grand final antara kedua pemenang.
+func (g *demoGenerator) playPlayoffs() error {
+	wins := make([]int, len(g.demo.Teams))
+	gameDiff := make([]int, len(g.demo.Teams))
+	for _, m := range g.demo.Matches {
+		a, b := g.teamIndex(m.Match.TeamAID), g.teamIndex(m.Match.TeamBID)
+		if m.Match.TeamAScore > m.Match.TeamBScore {
+			wins[a]++
+		} else {
+			wins[b]++
+		}
+		gameDiff[a] += m.Match.TeamAScore - m.Match.TeamBScore
+		gameDiff[b] += m.Match.TeamBScore - m.Match.TeamAScore
+	}
+
+	standings := make([]int, len(g.demo.Teams))
+	for i := range standings {
+		standings[i] = i
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if wins[a] != wins[b] {
+			return wins[a] > wins[b]
+		}
+		return gameDiff[a] > gameDiff[b]
+	})
+
+	g.day++
+	var finalists []int
+	for _, pair := range [][2]int{{standings[0], standings[3]}, {standings[1], standings[2]}} {
+		if err := g.playSeries("Playoffs", pair[0], pair[1], 5); err != nil {
+			return err
+		}
+		m := g.demo.Matches[len(g.demo.Matches)-1].Match
+		if m.TeamAScore > m.TeamBScore {
+			finalists = append(finalists, pair[0])
+		} else {
+			finalists = append(finalists, pair[1])
+		}
+	}
+
+	g.day++
+	return g.playSeries("Grand Final", finalists[0], finalists[1], 7)
+}
+
+func (g *demoGenerator) teamIndex(teamID uint) int {
+	for i, team := range g.demo.Teams {
+		if team.Team.TeamID == teamID {
+			return i
+		}
+	}
+	return -1
+}
+
+// playSeries memainkan satu match best-of-n antara team a dan b. Team yang
+// kalah di game sebelumnya mendapat first pick.
+func (g *demoGenerator) playSeries(stage string, a, b, bestOf int) error {
+	need := bestOf/2 + 1
+	rating := [2]float64{g.ratings[a], g.ratings[b]}
+	firstPick := g.rng.Intn(2)
+
+	var games []demoGame
+	var wins [2]int
+	for wins[0] < need && wins[1] < need {
+		game := g.simulateGame(rating, firstPick)
+		games = append(games, game)
+		wins[game.winner]++
+		firstPick = 1 - game.winner
+	}
+
+	match, err := g.createMatch(stage, [2]int{a, b}, games)
+	if err != nil {
+		return err
+	}
+
+	g.demo.Matches = append(g.demo.Matches, match)
+	return nil
+}
@@ -0,0 +1,404 @@
+package seeders
+
+import (
+	"math"
+	"strconv"
+
+	"ml-master-data/models"
+)
+
+// demoGame adalah hasil simulasi satu game sebelum disimpan. Indeks 0 dan 1
+// merujuk ke team A dan team B pada match.
+type demoGame struct {
+	firstPick int
+	winner    int
+	picks     [2][]demoDraft
+	bans      [2][]demoDraft
+	// early berisi hasil early game explaner, goldlaner dan trio mid.
+	early [2][3]string
+	// turtles dan lords berisi sisi yang mengambil setiap objektif.
+	turtles []int
+	lords   []int
+}
+
+type demoDraft struct {
+	hero       int
+	role       int
+	firstPhase bool
+}
+
+func (g *demoGenerator) simulateGame(rating [2]float64, firstPick int) demoGame {
+	game := demoGame{firstPick: firstPick, winner: 1}
+	if g.rng.Float64() < rating[0]/(rating[0]+rating[1]) {
+		game.winner = 0
+	}
+
+	g.draft(&game)
+
+	// Team yang menang lebih sering unggul di early game.
+	for lane := range game.early[0] {
+		winChance := 0.3
+		if game.winner == 0 {
+			winChance = 0.5
+		}
+
+		r := g.rng.Float64()
+		switch {
+		case r < winChance:
+			game.early[0][lane], game.early[1][lane] = "win", "lose"
+		case r < winChance+0.2:
+			game.early[0][lane], game.early[1][lane] = "draw", "draw"
+		default:
+			game.early[0][lane], game.early[1][lane] = "lose", "win"
+		}
+	}
+
+	for i := 0; i < 1+g.rng.Intn(3); i++ {
+		game.turtles = append(game.turtles, g.objectiveTaker(game.winner, 0.6))
+	}
+	for i := 0; i < g.rng.Intn(3); i++ {
+		game.lords = append(game.lords, g.objectiveTaker(game.winner, 0.75))
+	}
+
+	return game
+}
+
+func (g *demoGenerator) objectiveTaker(winner int, chance float64) int {
+	if g.rng.Float64() < chance {
+		return winner
+	}
+	return 1 - winner
+}
+
+// draft mengikuti urutan draft ranked: tiga ban per team, pick 1-2-2-1,
+// dua ban per team lalu pick 1-2-1. Setiap team mengisi kelima role.
+func (g *demoGenerator) draft(game *demoGame) {
+	used := map[uint]bool{}
+	fp, sp := game.firstPick, 1-game.firstPick
+	roleOrder := [2][]int{g.rng.Perm(len(demoRoles)), g.rng.Perm(len(demoRoles))}
+
+	ban := func(side int, firstPhase bool) {
+		hero := g.chooseHero(used, -1)
+		game.bans[side] = append(game.bans[side], demoDraft{hero: hero, role: g.heroes[hero].roles[0], firstPhase: firstPhase})
+	}
+	pick := func(side int, firstPhase bool) {
+		role := roleOrder[side][len(game.picks[side])]
+		game.picks[side] = append(game.picks[side], demoDraft{hero: g.chooseHero(used, role), role: role, firstPhase: firstPhase})
+	}
+
+	for i := 0; i < 3; i++ {
+		ban(fp, true)
+		ban(sp, true)
+	}
+	for _, side := range []int{fp, sp, sp, fp, fp, sp} {
+		pick(side, true)
+	}
+	for i := 0; i < 2; i++ {
+		ban(sp, false)
+		ban(fp, false)
+	}
+	for _, side := range []int{sp, fp, fp, sp} {
+		pick(side, false)
+	}
+}
+
+// chooseHero memilih hero yang belum dipakai secara acak berbobot weight.
+// role -1 berarti semua role.
+func (g *demoGenerator) chooseHero(used map[uint]bool, role int) int {
+	var candidates []int
+	var total float64
+	for i, hero := range g.heroes {
+		if used[hero.hero.HeroID] || (role >= 0 && !hero.plays(role)) {
+			continue
+		}
+		candidates = append(candidates, i)
+		total += hero.weight
+	}
+
+	r := g.rng.Float64() * total
+	chosen := candidates[len(candidates)-1]
+	for _, i := range candidates {
+		r -= g.heroes[i].weight
+		if r < 0 {
+			chosen = i
+			break
+		}
+	}
+
+	used[g.heroes[chosen].hero.HeroID] = true
+	return chosen
+}
+
+func (g *demoGenerator) createMatch(stage string, teams [2]int, games []demoGame) (DemoMatch, error) {
+	var score [2]int
+	for _, game := range games {
+		score[game.winner]++
+	}
+
+	result := DemoMatch{Match: models.Match{
+		TournamentID: g.demo.Tournament.TournamentID,
+		Stage:        stage,
+		Day:          g.day,
+		Date:         (g.day-1)*2%28 + 1,
+		TeamAID:      g.demo.Teams[teams[0]].Team.TeamID,
+		TeamBID:      g.demo.Teams[teams[1]].Team.TeamID,
+		TeamAScore:   score[0],
+		TeamBScore:   score[1],
+	}}
+	if err := g.tx.Create(&result.Match).Error; err != nil {
+		return result, err
+	}
+
+	teamIDs := [2]uint{result.Match.TeamAID, result.Match.TeamBID}
+	for i, game := range games {
+		result.Games = append(result.Games, models.Game{
+			MatchID:          result.Match.MatchID,
+			FirstPickTeamID:  teamIDs[game.firstPick],
+			SecondPickTeamID: teamIDs[1-game.firstPick],
+			WinnerTeamID:     teamIDs[game.winner],
+			GameNumber:       i + 1,
+		})
+	}
+	if err := g.tx.Create(&result.Games).Error; err != nil {
+		return result, err
+	}
+
+	for side, index := range teams {
+		team := g.demo.Teams[index]
+
+		detail := models.MatchTeamDetail{MatchID: result.Match.MatchID, TeamID: team.Team.TeamID}
+		if err := g.tx.Create(&detail).Error; err != nil {
+			return result, err
+		}
+
+		var players []models.PlayerMatch
+		for role, player := range team.Players {
+			players = append(players, models.PlayerMatch{MatchTeamDetailID: detail.MatchTeamDetailID, PlayerID: player.PlayerID, Role: demoRoles[role]})
+		}
+		if err := g.tx.Create(&players).Error; err != nil {
+			return result, err
+		}
+		if err := g.tx.Create(&models.CoachMatch{MatchTeamDetailID: detail.MatchTeamDetailID, CoachID: team.Coach.CoachID, Role: "head coach"}).Error; err != nil {
+			return result, err
+		}
+
+		if err := g.createDraft(detail, side, games, result.Games); err != nil {
+			return result, err
+		}
+	}
+
+	for i, game := range games {
+		if err := g.createGameResults(result.Games[i], teamIDs, game); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// demoHeroUsage merangkum pemakaian satu hero oleh satu team dalam satu
+// match.
+type demoHeroUsage struct {
+	hero        int
+	role        int
+	firstPhase  int
+	secondPhase int
+	games       []bool
+}
+
+func summarizeDraft(drafts func(game demoGame) []demoDraft, games []demoGame) []*demoHeroUsage {
+	var usages []*demoHeroUsage
+	byHero := map[int]*demoHeroUsage{}
+
+	for i, game := range games {
+		for _, d := range drafts(game) {
+			usage, ok := byHero[d.hero]
+			if !ok {
+				usage = &demoHeroUsage{hero: d.hero, role: d.role, games: make([]bool, len(games))}
+				byHero[d.hero] = usage
+				usages = append(usages, usage)
+			}
+			if d.firstPhase {
+				usage.firstPhase++
+			} else {
+				usage.secondPhase++
+			}
+			usage.games[i] = true
+		}
+	}
+
+	return usages
+}
+
+// rate mengembalikan persentase dengan dua angka desimal.
+func rate(count, total int) float64 {
+	return math.Round(float64(count)/float64(total)*10000) / 100
+}
+
+// createDraft menyimpan hero pick, hero ban, priority pick, flex pick dan
+// priority ban satu team untuk seluruh game di match.
+func (g *demoGenerator) createDraft(detail models.MatchTeamDetail, side int, games []demoGame, records []models.Game) error {
+	picks := summarizeDraft(func(game demoGame) []demoDraft { return game.picks[side] }, games)
+	for _, usage := range picks {
+		hero := g.heroes[usage.hero]
+		total := usage.firstPhase + usage.secondPhase
+
+		pick := models.HeroPick{
+			MatchTeamDetailID: detail.MatchTeamDetailID,
+			HeroID:            hero.hero.HeroID,
+			FirstPhase:        usage.firstPhase,
+			SecondPhase:       usage.secondPhase,
+			Total:             total,
+		}
+		if err := g.tx.Create(&pick).Error; err != nil {
+			return err
+		}
+
+		var pickGames []models.HeroPickGame
+		for i, picked := range usage.games {
+			pickGames = append(pickGames, models.HeroPickGame{HeroPickID: pick.HeroPickID, GameID: records[i].GameID, GameNumber: records[i].GameNumber, IsPicked: picked})
+		}
+		if err := g.tx.Create(&pickGames).Error; err != nil {
+			return err
+		}
+
+		if err := g.tx.Create(&models.PriorityPick{
+			MatchTeamDetailID: detail.MatchTeamDetailID,
+			HeroID:            hero.hero.HeroID,
+			Total:             total,
+			Role:              draftRoles[usage.role],
+			PickRate:          rate(total, len(games)),
+		}).Error; err != nil {
+			return err
+		}
+
+		if len(hero.roles) > 1 {
+			if err := g.tx.Create(&models.FlexPick{
+				MatchTeamDetailID: detail.MatchTeamDetailID,
+				HeroID:            hero.hero.HeroID,
+				Total:             total,
+				Role:              draftRoles[usage.role],
+				PickRate:          rate(total, len(games)),
+			}).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	bans := summarizeDraft(func(game demoGame) []demoDraft { return game.bans[side] }, games)
+	for _, usage := range bans {
+		hero := g.heroes[usage.hero]
+		total := usage.firstPhase + usage.secondPhase
+
+		ban := models.HeroBan{
+			MatchTeamDetailID: detail.MatchTeamDetailID,
+			HeroID:            hero.hero.HeroID,
+			FirstPhase:        usage.firstPhase,
+			SecondPhase:       usage.secondPhase,
+			Total:             total,
+		}
+		if err := g.tx.Create(&ban).Error; err != nil {
+			return err
+		}
+
+		var banGames []models.HeroBanGame
+		for i, banned := range usage.games {
+			banGames = append(banGames, models.HeroBanGame{HeroBanID: ban.HeroBanID, GameID: records[i].GameID, GameNumber: records[i].GameNumber, IsBanned: banned})
+		}
+		if err := g.tx.Create(&banGames).Error; err != nil {
+			return err
+		}
+
+		if err := g.tx.Create(&models.PriorityBan{
+			MatchTeamDetailID: detail.MatchTeamDetailID,
+			HeroID:            hero.hero.HeroID,
+			Total:             total,
+			Role:              draftRoles[usage.role],
+			BanRate:           rate(total, len(games)),
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createGameResults menyimpan hasil game, early game setiap lane serta hasil
+// turtle dan lord.
+func (g *demoGenerator) createGameResults(record models.Game, teamIDs [2]uint, game demoGame) error {
+	for side, teamID := range teamIDs {
+		result := "lose"
+		if side == game.winner {
+			result = "win"
+		}
+		if err := g.tx.Create(&models.GameResult{GameID: record.GameID, TeamID: teamID, Result: result}).Error; err != nil {
+			return err
+		}
+
+		heroes := map[string]uint{}
+		for _, pick := range game.picks[side] {
+			heroes[demoRoles[pick.role]] = g.heroes[pick.hero].hero.HeroID
+		}
+
+		if err := g.tx.Create(&models.Explaner{GameID: record.GameID, TeamID: teamID, HeroID: heroes["explaner"], EarlyResult: game.early[side][0]}).Error; err != nil {
+			return err
+		}
+		if err := g.tx.Create(&models.Goldlaner{GameID: record.GameID, TeamID: teamID, HeroID: heroes["goldlaner"], EarlyResult: game.early[side][1]}).Error; err != nil {
+			return err
+		}
+
+		trioMid := models.TrioMid{GameID: record.GameID, TeamID: teamID, EarlyResult: &game.early[side][2]}
+		if err := g.tx.Create(&trioMid).Error; err != nil {
+			return err
+		}
+		var trioMidHeroes []models.TrioMidHero
+		for _, role := range []string{"jungler", "midlaner", "roamer"} {
+			trioMidHeroes = append(trioMidHeroes, models.TrioMidHero{TrioMidID: trioMid.TrioMidID, HeroID: heroes[role], Role: role, EarlyResult: game.early[side][2]})
+		}
+		if err := g.tx.Create(&trioMidHeroes).Error; err != nil {
+			return err
+		}
+	}
+
+	var turtles []models.TurtleResult
+	for phase, taker := range game.turtles {
+		for side, teamID := range teamIDs {
+			setup, initiate, result := g.objectiveResult(side == taker)
+			turtles = append(turtles, models.TurtleResult{GameID: record.GameID, TeamID: teamID, Phase: strconv.Itoa(phase + 1), Setup: setup, Initiate: initiate, Result: result})
+		}
+	}
+	if len(turtles) > 0 {
+		if err := g.tx.Create(&turtles).Error; err != nil {
+			return err
+		}
+	}
+
+	var lords []models.LordResult
+	for phase, taker := range game.lords {
+		for side, teamID := range teamIDs {
+			setup, initiate, result := g.objectiveResult(side == taker)
+			lords = append(lords, models.LordResult{GameID: record.GameID, TeamID: teamID, Phase: strconv.Itoa(phase + 1), Setup: setup, Initiate: initiate, Result: result})
+		}
+	}
+	if len(lords) > 0 {
+		if err := g.tx.Create(&lords).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *demoGenerator) objectiveResult(taken bool) (setup, initiate, result string) {
+	setup = []string{"early", "late", "no"}[g.rng.Intn(3)]
+
+	initiate, result = "no", "no"
+	if taken {
+		result = "yes"
+	}
+	if (taken && g.rng.Float64() < 0.7) || (!taken && g.rng.Float64() < 0.3) {
+		initiate = "yes"
+	}
+
+	return setup, initiate, result
+}
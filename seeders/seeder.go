@@ -1,41 +1,78 @@
 package seeders
 
 import (
+	"fmt"
 	"log"
+	"ml-master-data/config"
+	"strings"
+
+	"gorm.io/gorm"
 )
 
-func Seed() {
-	// Clear existing data
-	// config.DB.Exec("SET FOREIGN_KEY_CHECKS = 0")
-	// config.DB.Unscoped().Delete(&models.User{})
-	// config.DB.Unscoped().Delete(&models.Tournament{})
-	// config.DB.Unscoped().Delete(&models.Team{})
-	// config.DB.Unscoped().Delete(&models.Match{})
-	// config.DB.Unscoped().Delete(&models.TournamentTeam{})
-	// config.DB.Exec("SET FOREIGN_KEY_CHECKS = 1")
-
-	// config.DB.Exec("SET FOREIGN_KEY_CHECKS = 0")
-	// config.DB.Unscoped().Where("1 = 1").Delete(&models.User{})
-	// config.DB.Unscoped().Where("1 = 1").Delete(&models.Tournament{})
-	// config.DB.Unscoped().Where("1 = 1").Delete(&models.Team{})
-	// config.DB.Unscoped().Where("1 = 1").Delete(&models.Match{})
-	// config.DB.Unscoped().Where("1 = 1").Delete(&models.TournamentTeam{})
-	// config.DB.Exec("SET FOREIGN_KEY_CHECKS = 1")
-
-	// config.DB.Exec("SET FOREIGN_KEY_CHECKS = 0")
-	// config.DB.Exec("DELETE FROM users")
-	// config.DB.Exec("DELETE FROM heros")
-	// config.DB.Exec("DELETE FROM teams")
-	// config.DB.Exec("DELETE FROM tournaments")
-	// config.DB.Exec("DELETE FROM teams")
-	// config.DB.Exec("DELETE FROM matches")
-	// config.DB.Exec("DELETE FROM tournament_teams")
-	// config.DB.Exec("SET FOREIGN_KEY_CHECKS = 1")
-
-	// Seed Users
-	// seedUsers()
-	// seedHeroes()
-	// seedTeams()
-
-	log.Println("Seeding completed successfully!")
+// seeder mengisi satu jenis data master. Setiap seeder aman dijalankan
+// berulang kali: data dicocokkan lewat natural key lalu dibuat atau
+// diperbarui, tidak pernah diduplikasi.
+type seeder struct {
+	name string
+	run  func(db *gorm.DB, cfg *config.Config) error
+}
+
+var registry = []seeder{
+	{"users", seedUsers},
+	{"heroes", seedHeroes},
+	{"teams", seedTeams},
+}
+
+// Names mengembalikan nama semua seeder sesuai urutan eksekusi.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for _, s := range registry {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// ParseNames memecah daftar nama seeder yang dipisah koma dari flag -only.
+func ParseNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Seed menjalankan seeder yang disebut di names sesuai urutan registry.
+// names kosong berarti semua seeder.
+func Seed(db *gorm.DB, cfg *config.Config, names []string) error {
+	selected := map[string]bool{}
+	for _, name := range names {
+		if !isSeeder(name) {
+			return fmt.Errorf("unknown seeder %q, use %s", name, strings.Join(Names(), ", "))
+		}
+		selected[name] = true
+	}
+
+	for _, s := range registry {
+		if len(selected) > 0 && !selected[s.name] {
+			continue
+		}
+
+		if err := s.run(db, cfg); err != nil {
+			return fmt.Errorf("seeder %s: %w", s.name, err)
+		}
+		log.Printf("Seeder %s completed", s.name)
+	}
+
+	return nil
+}
+
+func isSeeder(name string) bool {
+	for _, s := range registry {
+		if s.name == name {
+			return true
+		}
+	}
+	return false
 }
@@ -1,27 +1,44 @@
 package seeders
 
 import (
+	"errors"
 	"ml-master-data/config"
 	"ml-master-data/models"
 
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
-// Fungsi untuk seeding heroes
-func seedUsers() []models.User {
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+// defaultSeedPassword hanya boleh dipakai saat APP_ENV=development.
+const defaultSeedPassword = "password123"
 
-	users := []models.User{
-		{Username: "admin1", Password: string(hashedPassword)},
-		{Username: "admin2", Password: string(hashedPassword)},
-		{Username: "admin3", Password: string(hashedPassword)},
-		{Username: "admin4", Password: string(hashedPassword)},
-		{Username: "admin5", Password: string(hashedPassword)},
+var seedUsernames = []string{"admin1", "admin2", "admin3", "admin4", "admin5"}
+
+// seedUsers membuat user admin yang belum ada. Password user yang sudah ada
+// tidak pernah ditimpa. Password diambil dari SEED_USER_PASSWORD; di luar
+// development password default ditolak.
+func seedUsers(db *gorm.DB, cfg *config.Config) error {
+	password := cfg.SeedUserPassword
+	if password == "" {
+		password = defaultSeedPassword
+	}
+	if password == defaultSeedPassword && !cfg.IsDevelopment() {
+		return errors.New("refusing to create users with the default password outside development, set SEED_USER_PASSWORD")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
 	}
 
-	for i := range users {
-		config.DB.Create(&users[i])
+	for _, username := range seedUsernames {
+		user := models.User{}
+		if err := db.Where("username = ?", username).
+			Attrs(models.User{Username: username, Password: string(hashedPassword)}).
+			FirstOrCreate(&user).Error; err != nil {
+			return err
+		}
 	}
 
-	return users
+	return nil
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"log"
+	"ml-master-data/config"
+	"ml-master-data/repositories"
+	"ml-master-data/routes"
+	"ml-master-data/seeders"
+	"ml-master-data/services"
+	"time"
+)
+
+// runServe menjalankan subcommand `serve`, yaitu server HTTP beserta job
+// purge soft delete dan pembersih media.
+func runServe(cfg *config.Config, args []string) {
+	config.ConnectDatabase(cfg.Database)
+
+	// Seeder hanya dijalankan saat boot jika disebut di SEEDERS
+	if len(cfg.Seeders) > 0 {
+		if err := seeders.Seed(config.DB, cfg, cfg.Seeders); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	files := openStorage(cfg)
+
+	// Data yang di-soft delete dihapus permanen setelah masa retensi
+	repos := repositories.New(config.DB, files)
+	purge := services.NewPurgeService(repos)
+	purge.Start(time.Duration(cfg.SoftDeleteRetentionDays)*24*time.Hour, 24*time.Hour)
+
+	// Media yatim dan link gambar yang putus dicari secara berkala
+	if cfg.MediaGC.IntervalHours > 0 {
+		media := services.NewMediaService(repos.Media)
+		media.Start(time.Duration(cfg.MediaGC.IntervalHours)*time.Hour, services.MediaGCOptions{
+			Delete: cfg.MediaGC.Delete,
+			MinAge: time.Duration(cfg.MediaGC.MinAgeHours) * time.Hour,
+		})
+	}
+
+	r := routes.SetupRouter(config.DB, cfg, files)
+	r.Static("/public", "./public")
+	if err := r.Run(cfg.Addr()); err != nil {
+		log.Fatal(err)
+	}
+}
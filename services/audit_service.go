@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const auditSnapshotKey = "audit:snapshots"
+
+// defaultAuditLimit adalah jumlah AuditLog yang dikembalikan jika limit tidak
+// diisi.
+const defaultAuditLimit = 50
+
+type AuditService struct {
+	audits repositories.AuditRepository
+}
+
+func NewAuditService(audits repositories.AuditRepository) *AuditService {
+	return &AuditService{audits: audits}
+}
+
+// GetLogs mengembalikan riwayat perubahan terbaru lebih dulu.
+func (s *AuditService) GetLogs(ctx context.Context, query dto.AuditLogQueryDto) ([]models.AuditLog, error) {
+	if query.Limit == 0 {
+		query.Limit = defaultAuditLimit
+	}
+	return s.audits.FindAll(ctx, query)
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor menandai context dengan user yang melakukan perubahan.
+// AuthMiddleware memasangnya di context request HTTP; CLI dan background job
+// memasangnya sendiri.
+func WithAuditActor(ctx context.Context, user models.User) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, user)
+}
+
+// RegisterAuditCallbacks memasang callback GORM yang mencatat setiap
+// create/update/delete ke tabel audit_logs, di dalam transaksi yang sama
+// dengan perubahan datanya.
+func RegisterAuditCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+
+	if err := callback.Create().After("gorm:create").Register("audit:after_create", auditAfterCreate); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("audit:before_update", auditBeforeChange); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("audit:after_update", auditAfterUpdate); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("audit:before_delete", auditBeforeChange); err != nil {
+		return err
+	}
+	return callback.Delete().After("gorm:delete").Register("audit:after_delete", auditAfterDelete)
+}
+
+func auditAfterCreate(db *gorm.DB) {
+	if !isAuditable(db) {
+		return
+	}
+
+	for _, record := range auditRecords(db.Statement.ReflectValue) {
+		writeAuditLog(db, "create", auditEntityID(db, record), "", marshalAudit(db, record.Interface()))
+	}
+}
+
+// auditBeforeChange menyimpan snapshot baris yang akan diubah/dihapus
+// agar bisa dibandingkan setelah query dijalankan.
+func auditBeforeChange(db *gorm.DB) {
+	if !isAuditable(db) {
+		return
+	}
+
+	db.InstanceSet(auditSnapshotKey, loadAuditSnapshots(db, nil))
+}
+
+func auditAfterUpdate(db *gorm.DB) {
+	if !isAuditable(db) {
+		return
+	}
+
+	before := auditSnapshots(db)
+	if len(before) == 0 {
+		return
+	}
+
+	ids := make([]interface{}, 0, len(before))
+	for id := range before {
+		ids = append(ids, id)
+	}
+
+	after := loadAuditSnapshots(db, ids)
+	for id, snapshot := range before {
+		if after[id] == snapshot {
+			continue
+		}
+		writeAuditLog(db, "update", id, snapshot, after[id])
+	}
+}
+
+func auditAfterDelete(db *gorm.DB) {
+	if !isAuditable(db) {
+		return
+	}
+
+	for id, snapshot := range auditSnapshots(db) {
+		writeAuditLog(db, "delete", id, snapshot, "")
+	}
+}
+
+func isAuditable(db *gorm.DB) bool {
+	schema := db.Statement.Schema
+	return db.Error == nil && !db.DryRun && schema != nil &&
+		schema.PrioritizedPrimaryField != nil &&
+		schema.ModelType != reflect.TypeOf(models.AuditLog{})
+}
+
+func auditSnapshots(db *gorm.DB) map[uint]string {
+	value, ok := db.InstanceGet(auditSnapshotKey)
+	if !ok {
+		return nil
+	}
+	snapshots, _ := value.(map[uint]string)
+	return snapshots
+}
+
+// loadAuditSnapshots membaca ulang baris yang terkena statement, baik lewat
+// primary key pada model maupun kondisi WHERE. Jika ids diberikan, hanya
+// baris dengan primary key tersebut yang dibaca.
+func loadAuditSnapshots(db *gorm.DB, ids []interface{}) map[uint]string {
+	stmt := db.Statement
+	primaryField := stmt.Schema.PrioritizedPrimaryField
+	query := db.Session(&gorm.Session{NewDB: true})
+	if stmt.Unscoped {
+		query = query.Unscoped()
+	}
+
+	if ids == nil {
+		hasCondition := false
+		if where, ok := stmt.Clauses["WHERE"]; ok {
+			if expr, ok := where.Expression.(clause.Where); ok && len(expr.Exprs) > 0 {
+				query = query.Clauses(expr)
+				hasCondition = true
+			}
+		}
+
+		for _, record := range auditRecords(stmt.ReflectValue) {
+			if value, isZero := primaryField.ValueOf(stmt.Context, record); !isZero {
+				ids = append(ids, value)
+			}
+		}
+
+		if !hasCondition && len(ids) == 0 {
+			return nil
+		}
+	}
+
+	if len(ids) > 0 {
+		query = query.Where(clause.IN{Column: clause.Column{Name: primaryField.DBName}, Values: ids})
+	}
+
+	rows := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	if err := query.Find(rows.Interface()).Error; err != nil {
+		db.AddError(fmt.Errorf("gagal membaca data untuk audit: %w", err))
+		return nil
+	}
+
+	snapshots := map[uint]string{}
+	for _, record := range auditRecords(rows) {
+		snapshots[auditEntityID(db, record)] = marshalAudit(db, record.Interface())
+	}
+	return snapshots
+}
+
+func auditRecords(value reflect.Value) []reflect.Value {
+	value = reflect.Indirect(value)
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		records := make([]reflect.Value, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if record := reflect.Indirect(value.Index(i)); record.Kind() == reflect.Struct {
+				records = append(records, record)
+			}
+		}
+		return records
+	case reflect.Struct:
+		return []reflect.Value{value}
+	}
+
+	return nil
+}
+
+func auditEntityID(db *gorm.DB, record reflect.Value) uint {
+	value, _ := db.Statement.Schema.PrioritizedPrimaryField.ValueOf(db.Statement.Context, record)
+
+	id := reflect.ValueOf(value)
+	switch id.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(id.Uint())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint(id.Int())
+	}
+
+	return 0
+}
+
+func marshalAudit(db *gorm.DB, record interface{}) string {
+	data, err := json.Marshal(record)
+	if err != nil {
+		db.AddError(fmt.Errorf("gagal membuat snapshot audit: %w", err))
+		return ""
+	}
+	return string(data)
+}
+
+func writeAuditLog(db *gorm.DB, action string, entityID uint, before, after string) {
+	actor := auditActor(db.Statement.Context)
+
+	auditLog := models.AuditLog{
+		UserID:     actor.UserID,
+		Username:   actor.Username,
+		EntityType: db.Statement.Schema.Table,
+		EntityID:   entityID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	}
+
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&auditLog).Error; err != nil {
+		db.AddError(fmt.Errorf("gagal mencatat audit log: %w", err))
+	}
+}
+
+func auditActor(ctx context.Context) models.User {
+	if ctx == nil {
+		return models.User{}
+	}
+
+	user, _ := ctx.Value(auditActorKey{}).(models.User)
+	return user
+}
@@ -0,0 +1,20 @@
+package services
+
+import "time"
+
+// dateLayout adalah format tanggal tanpa jam di request, misalnya
+// release_date hero dan patch.
+const dateLayout = "2006-01-02"
+
+// parseDate membaca tanggal berformat dateLayout; string kosong berarti
+// tidak ada tanggal.
+func parseDate(value, field string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, &ValidationError{Message: field + " must be a date in YYYY-MM-DD format"}
+	}
+	return &date, nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// draftCandidate adalah hero pick atau hero ban yang akan disimpan. Games
+// berisi nomor game tempat hero di-pick atau di-ban setelah perubahan.
+type draftCandidate struct {
+	Ban         bool
+	EntryID     uint
+	TeamID      uint
+	HeroID      uint
+	FirstPhase  int
+	SecondPhase int
+	Games       []int
+}
+
+// checkDraft memeriksa candidate terhadap aturan tournament dan pick/ban
+// lain di series yang sama. entries boleh berisi candidate versi lama;
+// baris itu diabaikan.
+func checkDraft(rule models.TournamentRule, entries []repositories.DraftEntry, candidate draftCandidate) error {
+	action := "picked"
+	if candidate.Ban {
+		action = "banned"
+	}
+	if rule.HeroDisabled(candidate.HeroID) {
+		return &ValidationError{Message: fmt.Sprintf("Hero %d is disabled in this tournament and cannot be %s", candidate.HeroID, action)}
+	}
+
+	var others []repositories.DraftEntry
+	for _, entry := range entries {
+		if entry.Ban == candidate.Ban && entry.EntryID == candidate.EntryID {
+			continue
+		}
+		others = append(others, entry)
+	}
+
+	// Dalam satu game, hero hanya bisa di-pick satu tim atau di-ban satu kali
+	for _, game := range candidate.Games {
+		for _, entry := range others {
+			if entry.HeroID != candidate.HeroID || entry.GameNumber != game {
+				continue
+			}
+			switch {
+			case entry.Ban && candidate.Ban:
+				return &ValidationError{Message: fmt.Sprintf("Hero %d is already banned in game %d", candidate.HeroID, game)}
+			case entry.Ban || candidate.Ban:
+				return &ValidationError{Message: fmt.Sprintf("Hero %d cannot be picked and banned in game %d", candidate.HeroID, game)}
+			default:
+				return &ValidationError{Message: fmt.Sprintf("Hero %d is already picked in game %d", candidate.HeroID, game)}
+			}
+		}
+	}
+
+	if rule.DraftFormat == models.DraftGlobalBan {
+		if err := checkGlobalBan(others, candidate); err != nil {
+			return err
+		}
+	}
+	if !candidate.Ban && rule.FearlessMode != models.FearlessOff && rule.FearlessMode != "" {
+		if err := checkFearless(rule.FearlessMode, others, candidate); err != nil {
+			return err
+		}
+	}
+	if candidate.Ban {
+		return checkBanLimits(rule, others, candidate)
+	}
+	return nil
+}
+
+// checkGlobalBan memastikan hero yang sudah di-ban tidak di-ban lagi atau
+// di-pick di game berikutnya dalam series.
+func checkGlobalBan(others []repositories.DraftEntry, candidate draftCandidate) error {
+	for _, game := range candidate.Games {
+		for _, entry := range others {
+			if entry.HeroID != candidate.HeroID {
+				continue
+			}
+			switch {
+			case entry.Ban && candidate.Ban:
+				return &ValidationError{Message: fmt.Sprintf("Hero %d is already banned for the series since game %d", candidate.HeroID, entry.GameNumber)}
+			case entry.Ban && entry.GameNumber < game:
+				return &ValidationError{Message: fmt.Sprintf("Hero %d was banned in game %d and cannot be picked in game %d", candidate.HeroID, entry.GameNumber, game)}
+			case candidate.Ban && !entry.Ban && entry.GameNumber > game:
+				return &ValidationError{Message: fmt.Sprintf("Hero %d is picked in game %d and cannot be banned for the series in game %d", candidate.HeroID, entry.GameNumber, game)}
+			}
+		}
+	}
+	if candidate.Ban && len(candidate.Games) > 1 {
+		return &ValidationError{Message: fmt.Sprintf("Hero %d can only be banned once in a global ban series", candidate.HeroID)}
+	}
+	return nil
+}
+
+// checkFearless memastikan hero tidak di-pick lagi di series: oleh tim yang
+// sama pada FearlessTeam, atau oleh tim mana pun pada FearlessFull.
+func checkFearless(mode string, others []repositories.DraftEntry, candidate draftCandidate) error {
+	if len(candidate.Games) > 1 || candidate.FirstPhase+candidate.SecondPhase > 1 {
+		return &ValidationError{Message: fmt.Sprintf("Hero %d can only be picked once per series in fearless draft", candidate.HeroID)}
+	}
+	if mode != models.FearlessFull {
+		return nil
+	}
+	for _, entry := range others {
+		if !entry.Ban && entry.HeroID == candidate.HeroID {
+			return &ValidationError{Message: fmt.Sprintf("Hero %d was already picked in game %d of this series", candidate.HeroID, entry.GameNumber)}
+		}
+	}
+	return nil
+}
+
+// checkBanLimits memastikan jumlah ban tim per game dan per fase tidak
+// melebihi aturan tournament.
+func checkBanLimits(rule models.TournamentRule, others []repositories.DraftEntry, candidate draftCandidate) error {
+	var teamBans []repositories.DraftEntry
+	for _, entry := range others {
+		if entry.Ban && entry.TeamID == candidate.TeamID {
+			teamBans = append(teamBans, entry)
+		}
+	}
+
+	games := slices.Clone(candidate.Games)
+	for _, entry := range teamBans {
+		if !slices.Contains(games, entry.GameNumber) {
+			games = append(games, entry.GameNumber)
+		}
+	}
+
+	if rule.BansFirstPhase > 0 && rule.BansSecondPhase > 0 {
+		perGame := rule.BansFirstPhase + rule.BansSecondPhase
+		for _, game := range candidate.Games {
+			count := 1
+			for _, entry := range teamBans {
+				if entry.GameNumber == game {
+					count++
+				}
+			}
+			if count > perGame {
+				return &ValidationError{Message: fmt.Sprintf("A team can ban at most %d heroes per game, game %d would have %d", perGame, game, count)}
+			}
+		}
+	}
+
+	// Batas tiap fase berlaku sendiri-sendiri. Ban yang hanya tercatat di
+	// satu fase pasti masuk fase itu di setiap game-nya, jadi bisa dihitung
+	// per game; ban di kedua fase hanya ikut hitungan total di bawah.
+	limits := map[int]int{1: rule.BansFirstPhase, 2: rule.BansSecondPhase}
+	phase := banPhase(candidate.FirstPhase, candidate.SecondPhase)
+	if limit := limits[phase]; limit > 0 {
+		for _, game := range candidate.Games {
+			count := 1
+			for _, entry := range teamBans {
+				if entry.GameNumber == game && banPhase(entry.FirstPhase, entry.SecondPhase) == phase {
+					count++
+				}
+			}
+			if count > limit {
+				return &ValidationError{Message: fmt.Sprintf("A team can ban at most %d heroes in the %s phase of each game, game %d would have %d", limit, phaseNames[phase], game, count)}
+			}
+		}
+	}
+
+	// Total fase dihitung sekali untuk setiap HeroBan
+	firstPhase, secondPhase := candidate.FirstPhase, candidate.SecondPhase
+	counted := map[uint]bool{}
+	for _, entry := range teamBans {
+		if counted[entry.EntryID] {
+			continue
+		}
+		counted[entry.EntryID] = true
+		firstPhase += entry.FirstPhase
+		secondPhase += entry.SecondPhase
+	}
+	if len(games) == 0 {
+		return nil
+	}
+	if rule.BansFirstPhase > 0 && firstPhase > rule.BansFirstPhase*len(games) {
+		return &ValidationError{Message: fmt.Sprintf("A team can ban at most %d heroes in the first phase of each game", rule.BansFirstPhase)}
+	}
+	if rule.BansSecondPhase > 0 && secondPhase > rule.BansSecondPhase*len(games) {
+		return &ValidationError{Message: fmt.Sprintf("A team can ban at most %d heroes in the second phase of each game", rule.BansSecondPhase)}
+	}
+	return nil
+}
+
+// phaseNames adalah nama fase ban untuk pesan error.
+var phaseNames = map[int]string{1: "first", 2: "second"}
+
+// banPhase mengembalikan fase (1 atau 2) sebuah HeroBan jika hero hanya
+// di-ban di satu fase, atau 0 jika di kedua fase atau belum sama sekali.
+func banPhase(firstPhase, secondPhase int) int {
+	switch {
+	case firstPhase > 0 && secondPhase == 0:
+		return 1
+	case secondPhase > 0 && firstPhase == 0:
+		return 2
+	}
+	return 0
+}
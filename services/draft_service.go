@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// DraftService mengelola hero pick dan hero ban sebuah tim dalam Match.
+// Pick dan ban divalidasi terhadap aturan draft tournament Match.
+type DraftService struct {
+	drafts      repositories.DraftRepository
+	matches     repositories.MatchRepository
+	tournaments repositories.TournamentRepository
+	events      *EventBus
+}
+
+func NewDraftService(drafts repositories.DraftRepository, matches repositories.MatchRepository, tournaments repositories.TournamentRepository, events *EventBus) *DraftService {
+	return &DraftService{drafts: drafts, matches: matches, tournaments: tournaments, events: events}
+}
+
+func (s *DraftService) GetHeroPicks(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroPickResponseDto, error) {
+	return s.drafts.FindHeroPicks(ctx, matchID, teamID, patchID)
+}
+
+func (s *DraftService) GetHeroPicksInFirstPhase(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroPickResponseDto, error) {
+	return s.drafts.FindHeroPicksInFirstPhase(ctx, matchID, teamID, patchID)
+}
+
+// AddHeroPick menambahkan hero pick untuk tim; satu hero hanya boleh di-pick
+// sekali oleh tim yang sama dalam satu Match.
+func (s *DraftService) AddHeroPick(ctx context.Context, matchID, teamID uint, input dto.HeroPickRequestDto) (models.HeroPick, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.HeroPick{}, err
+	}
+
+	exists, err := s.drafts.HeroPickExists(ctx, matchTeamDetail.MatchTeamDetailID, *input.HeroID, 0)
+	if err != nil {
+		return models.HeroPick{}, err
+	}
+	if exists {
+		return models.HeroPick{}, &ConflictError{Message: "Hero pick for this match and team already exists"}
+	}
+
+	candidate := draftCandidate{TeamID: teamID, HeroID: *input.HeroID, FirstPhase: *input.FirstPhase, SecondPhase: *input.SecondPhase}
+	check, err := s.draftRules(ctx, matchID, candidate, pickedGames(input))
+	if err != nil {
+		return models.HeroPick{}, err
+	}
+
+	heroPick := models.HeroPick{
+		MatchTeamDetailID: matchTeamDetail.MatchTeamDetailID,
+		HeroID:            *input.HeroID,
+		FirstPhase:        *input.FirstPhase,
+		SecondPhase:       *input.SecondPhase,
+		Total:             *input.Total,
+	}
+
+	if err := s.drafts.CreateHeroPick(ctx, &heroPick, heroPickGames(input), check); err != nil {
+		return heroPick, err
+	}
+	s.publish(ctx, EventHeroPick, EventCreated, matchID, teamID, heroPick.HeroPickID, heroPick)
+	return heroPick, nil
+}
+
+func (s *DraftService) UpdateHeroPick(ctx context.Context, matchID, teamID, heroPickID uint, input dto.HeroPickRequestDto) (models.HeroPick, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.HeroPick{}, err
+	}
+
+	heroPick, err := s.drafts.FindHeroPick(ctx, matchTeamDetail.MatchTeamDetailID, heroPickID)
+	if err != nil {
+		return heroPick, notFound(err, "Hero pick")
+	}
+
+	exists, err := s.drafts.HeroPickExists(ctx, matchTeamDetail.MatchTeamDetailID, *input.HeroID, heroPick.HeroPickID)
+	if err != nil {
+		return heroPick, err
+	}
+	if exists {
+		return heroPick, &ConflictError{Message: "Duplicate hero pick detected"}
+	}
+
+	candidate := draftCandidate{EntryID: heroPick.HeroPickID, TeamID: teamID, HeroID: *input.HeroID, FirstPhase: *input.FirstPhase, SecondPhase: *input.SecondPhase}
+	check, err := s.draftRules(ctx, matchID, candidate, pickedGames(input))
+	if err != nil {
+		return heroPick, err
+	}
+
+	heroPick.HeroID = *input.HeroID
+	heroPick.FirstPhase = *input.FirstPhase
+	heroPick.SecondPhase = *input.SecondPhase
+	heroPick.Total = *input.Total
+
+	if err := s.drafts.UpdateHeroPick(ctx, &heroPick, heroPickGames(input), check); err != nil {
+		return heroPick, err
+	}
+	s.publish(ctx, EventHeroPick, EventUpdated, matchID, teamID, heroPick.HeroPickID, heroPick)
+	return heroPick, nil
+}
+
+func (s *DraftService) RemoveHeroPick(ctx context.Context, matchID, teamID, heroPickID uint) error {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return err
+	}
+
+	heroPick, err := s.drafts.FindHeroPick(ctx, matchTeamDetail.MatchTeamDetailID, heroPickID)
+	if err != nil {
+		return notFound(err, "Hero pick")
+	}
+
+	if err := s.drafts.DeleteHeroPick(ctx, heroPick); err != nil {
+		return err
+	}
+	s.publish(ctx, EventHeroPick, EventDeleted, matchID, teamID, heroPick.HeroPickID, nil)
+	return nil
+}
+
+func (s *DraftService) GetHeroBans(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroBanResponseDto, error) {
+	return s.drafts.FindHeroBans(ctx, matchID, teamID, patchID)
+}
+
+func (s *DraftService) GetHeroBansInFirstPhase(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.HeroBanResponseDto, error) {
+	return s.drafts.FindHeroBansInFirstPhase(ctx, matchID, teamID, patchID)
+}
+
+// AddHeroBan menambahkan hero ban untuk tim; satu hero hanya boleh di-ban
+// sekali oleh tim yang sama dalam satu Match.
+func (s *DraftService) AddHeroBan(ctx context.Context, matchID, teamID uint, input dto.HeroBanRequestDto) (models.HeroBan, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.HeroBan{}, err
+	}
+
+	exists, err := s.drafts.HeroBanExists(ctx, matchTeamDetail.MatchTeamDetailID, *input.HeroID, 0)
+	if err != nil {
+		return models.HeroBan{}, err
+	}
+	if exists {
+		return models.HeroBan{}, &ConflictError{Message: "Hero ban for this match and team already exists"}
+	}
+
+	candidate := draftCandidate{Ban: true, TeamID: teamID, HeroID: *input.HeroID, FirstPhase: *input.FirstPhase, SecondPhase: *input.SecondPhase}
+	check, err := s.draftRules(ctx, matchID, candidate, bannedGames(input))
+	if err != nil {
+		return models.HeroBan{}, err
+	}
+
+	heroBan := models.HeroBan{
+		MatchTeamDetailID: matchTeamDetail.MatchTeamDetailID,
+		HeroID:            *input.HeroID,
+		FirstPhase:        *input.FirstPhase,
+		SecondPhase:       *input.SecondPhase,
+		Total:             *input.Total,
+	}
+
+	if err := s.drafts.CreateHeroBan(ctx, &heroBan, heroBanGames(input), check); err != nil {
+		return heroBan, err
+	}
+	s.publish(ctx, EventHeroBan, EventCreated, matchID, teamID, heroBan.HeroBanID, heroBan)
+	return heroBan, nil
+}
+
+func (s *DraftService) UpdateHeroBan(ctx context.Context, matchID, teamID, heroBanID uint, input dto.HeroBanRequestDto) (models.HeroBan, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.HeroBan{}, err
+	}
+
+	heroBan, err := s.drafts.FindHeroBan(ctx, matchTeamDetail.MatchTeamDetailID, heroBanID)
+	if err != nil {
+		return heroBan, notFound(err, "Hero ban")
+	}
+
+	exists, err := s.drafts.HeroBanExists(ctx, matchTeamDetail.MatchTeamDetailID, *input.HeroID, heroBan.HeroBanID)
+	if err != nil {
+		return heroBan, err
+	}
+	if exists {
+		return heroBan, &ConflictError{Message: "Duplicate hero ban detected"}
+	}
+
+	candidate := draftCandidate{Ban: true, EntryID: heroBan.HeroBanID, TeamID: teamID, HeroID: *input.HeroID, FirstPhase: *input.FirstPhase, SecondPhase: *input.SecondPhase}
+	check, err := s.draftRules(ctx, matchID, candidate, bannedGames(input))
+	if err != nil {
+		return heroBan, err
+	}
+
+	heroBan.HeroID = *input.HeroID
+	heroBan.FirstPhase = *input.FirstPhase
+	heroBan.SecondPhase = *input.SecondPhase
+	heroBan.Total = *input.Total
+
+	if err := s.drafts.UpdateHeroBan(ctx, &heroBan, heroBanGames(input), check); err != nil {
+		return heroBan, err
+	}
+	s.publish(ctx, EventHeroBan, EventUpdated, matchID, teamID, heroBan.HeroBanID, heroBan)
+	return heroBan, nil
+}
+
+func (s *DraftService) RemoveHeroBan(ctx context.Context, matchID, teamID, heroBanID uint) error {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return err
+	}
+
+	heroBan, err := s.drafts.FindHeroBan(ctx, matchTeamDetail.MatchTeamDetailID, heroBanID)
+	if err != nil {
+		return notFound(err, "Hero ban")
+	}
+
+	if err := s.drafts.DeleteHeroBan(ctx, heroBan); err != nil {
+		return err
+	}
+	s.publish(ctx, EventHeroBan, EventDeleted, matchID, teamID, heroBan.HeroBanID, nil)
+	return nil
+}
+
+// draftRules mengembalikan pemeriksaan candidate terhadap aturan draft
+// tournament Match, yang dijalankan repository di dalam transaksi
+// penyimpanan; tournament tanpa aturan tidak diperiksa (nil). games berisi
+// status pick/ban per nomor game dari request; game lama yang tidak dikirim
+// tetap berlaku karena tidak dihapus saat update.
+func (s *DraftService) draftRules(ctx context.Context, matchID uint, candidate draftCandidate, games map[int]bool) (repositories.DraftCheck, error) {
+	match, err := s.matches.FindByID(ctx, matchID)
+	if err != nil {
+		return nil, notFound(err, "Match")
+	}
+	rule, err := s.tournaments.FindRule(ctx, match.TournamentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return func(entries []repositories.DraftEntry) error {
+		effective := map[int]bool{}
+		for _, entry := range entries {
+			if candidate.EntryID != 0 && entry.Ban == candidate.Ban && entry.EntryID == candidate.EntryID {
+				effective[entry.GameNumber] = true
+			}
+		}
+		for number, set := range games {
+			effective[number] = set
+		}
+		checked := candidate
+		checked.Games = nil
+		for number, set := range effective {
+			if set {
+				checked.Games = append(checked.Games, number)
+			}
+		}
+		slices.Sort(checked.Games)
+
+		return checkDraft(rule, entries, checked)
+	}, nil
+}
+
+// publish mengirim event hero pick atau hero ban tim teamID di Match
+// matchID. Perubahan sudah tersimpan, jadi Match yang gagal dibaca hanya
+// membuat TournamentID event kosong.
+func (s *DraftService) publish(ctx context.Context, entity, action string, matchID, teamID, entityID uint, data interface{}) {
+	match, err := s.matches.FindByID(ctx, matchID)
+	if err != nil {
+		match = models.Match{MatchID: matchID}
+	}
+	event := NewEvent(entity, action, match, entityID, data)
+	event.TeamID = teamID
+	s.events.Publish(event)
+}
+
+func (s *DraftService) teamDetail(ctx context.Context, matchID, teamID uint) (models.MatchTeamDetail, error) {
+	matchTeamDetail, err := s.matches.FindTeamDetail(ctx, matchID, teamID)
+	return matchTeamDetail, notFound(err, "Match or team")
+}
+
+func heroPickGames(input dto.HeroPickRequestDto) []models.HeroPickGame {
+	games := make([]models.HeroPickGame, 0, len(input.HeroPickGame))
+	for _, game := range input.HeroPickGame {
+		games = append(games, models.HeroPickGame{
+			GameID:     *game.GameID,
+			GameNumber: *game.GameNumber,
+			IsPicked:   *game.IsPicked,
+		})
+	}
+	return games
+}
+
+func heroBanGames(input dto.HeroBanRequestDto) []models.HeroBanGame {
+	games := make([]models.HeroBanGame, 0, len(input.HeroBanGame))
+	for _, game := range input.HeroBanGame {
+		games = append(games, models.HeroBanGame{
+			GameID:     *game.GameID,
+			GameNumber: *game.GameNumber,
+			IsBanned:   *game.IsBanned,
+		})
+	}
+	return games
+}
+
+// pickedGames dan bannedGames mengembalikan status per nomor game dari
+// request.
+func pickedGames(input dto.HeroPickRequestDto) map[int]bool {
+	games := map[int]bool{}
+	for _, game := range input.HeroPickGame {
+		games[*game.GameNumber] = *game.IsPicked
+	}
+	return games
+}
+
+func bannedGames(input dto.HeroBanRequestDto) map[int]bool {
+	games := map[int]bool{}
+	for _, game := range input.HeroBanGame {
+		games[*game.GameNumber] = *game.IsBanned
+	}
+	return games
+}
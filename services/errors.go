@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+
+	"ml-master-data/repositories"
+)
+
+// NotFoundError menandakan data yang dibutuhkan sebuah operasi tidak ada.
+type NotFoundError struct {
+	Entity string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Entity + " not found"
+}
+
+// ConflictError menandakan operasi bertentangan dengan data yang sudah ada,
+// misalnya hero yang sudah di-pick atau data yang belum dihapus.
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// ValidationError menandakan input sebuah operasi tidak valid.
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// notFound mengganti repositories.ErrNotFound dengan NotFoundError untuk
+// entity; error lain dikembalikan apa adanya.
+func notFound(err error, entity string) error {
+	if errors.Is(err, repositories.ErrNotFound) {
+		return &NotFoundError{Entity: entity}
+	}
+	return err
+}
+
+// restoreError menerjemahkan error dari Restore di repository.
+func restoreError(err error, entity string) error {
+	switch {
+	case errors.Is(err, repositories.ErrNotDeleted):
+		return &ConflictError{Message: entity + " is not deleted"}
+	case errors.Is(err, repositories.ErrParentDeleted):
+		return &ConflictError{Message: err.Error()}
+	}
+	return notFound(err, entity)
+}
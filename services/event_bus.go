@@ -0,0 +1,143 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+)
+
+// Entity dan aksi yang membentuk tipe event, misalnya "game.updated".
+const (
+	EventMatch        = "match"
+	EventGame         = "game"
+	EventLordResult   = "lord_result"
+	EventTurtleResult = "turtle_result"
+	EventHeroPick     = "hero_pick"
+	EventHeroBan      = "hero_ban"
+
+	EventCreated  = "created"
+	EventUpdated  = "updated"
+	EventDeleted  = "deleted"
+	EventRestored = "restored"
+)
+
+const (
+	// eventHistory adalah jumlah event terakhir yang disimpan untuk client
+	// yang tersambung ulang dengan Last-Event-ID.
+	eventHistory = 1024
+	// eventBuffer adalah jumlah event yang boleh antre per subscriber.
+	// Subscriber yang tertinggal lebih jauh diputus agar tidak menahan
+	// request yang mengubah data.
+	eventBuffer = 256
+)
+
+// EventFilter memilih event untuk satu stream; field bernilai nol tidak
+// membatasi.
+type EventFilter struct {
+	TournamentID uint
+	MatchID      uint
+}
+
+func (f EventFilter) match(event dto.EventDto) bool {
+	return (f.TournamentID == 0 || f.TournamentID == event.TournamentID) &&
+		(f.MatchID == 0 || f.MatchID == event.MatchID)
+}
+
+type eventSubscriber struct {
+	filter EventFilter
+	events chan dto.EventDto
+}
+
+// EventBus menyebarkan perubahan data ke stream SSE di proses ini. Event
+// dikirim setelah perubahan tersimpan, jadi client yang menerimanya bisa
+// langsung membaca data terbaru.
+type EventBus struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []dto.EventDto
+	subscribers map[*eventSubscriber]struct{}
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: map[*eventSubscriber]struct{}{}}
+}
+
+// NewEvent membuat event entity.action untuk entity entityID di Match match.
+// data berisi data terbaru entity dan nil untuk aksi deleted.
+func NewEvent(entity, action string, match models.Match, entityID uint, data interface{}) dto.EventDto {
+	return dto.EventDto{
+		Type:         entity + "." + action,
+		TournamentID: match.TournamentID,
+		MatchID:      match.MatchID,
+		EntityID:     entityID,
+		Data:         data,
+	}
+}
+
+// Publish memberi event nomor urut lalu mengirimnya ke semua subscriber yang
+// filternya cocok.
+func (b *EventBus) Publish(event dto.EventDto) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	event.CreatedAt = time.Now()
+
+	if len(b.history) == eventHistory {
+		b.history = append(b.history[:0], b.history[1:]...)
+	}
+	b.history = append(b.history, event)
+
+	for sub := range b.subscribers {
+		if !sub.filter.match(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			b.drop(sub)
+		}
+	}
+}
+
+// Subscribe mengembalikan channel event yang cocok dengan filter, diawali
+// event di riwayat yang nomornya setelah lastID. cancel wajib dipanggil saat
+// stream selesai. Channel ditutup jika subscriber tertinggal terlalu jauh;
+// client sebaiknya tersambung ulang dengan Last-Event-ID.
+func (b *EventBus) Subscribe(filter EventFilter, lastID uint64) (events <-chan dto.EventDto, cancel func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []dto.EventDto
+	if lastID > 0 {
+		for _, event := range b.history {
+			if event.ID > lastID && filter.match(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	sub := &eventSubscriber{filter: filter, events: make(chan dto.EventDto, eventBuffer+len(replay))}
+	for _, event := range replay {
+		sub.events <- event
+	}
+	b.subscribers[sub] = struct{}{}
+
+	return sub.events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.drop(sub)
+	}
+}
+
+// drop melepas sub dan menutup channel-nya; aman dipanggil lebih dari sekali.
+func (b *EventBus) drop(sub *eventSubscriber) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.events)
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+
+	"ml-master-data/repositories"
+	"ml-master-data/tabular"
+)
+
+type ExportService struct {
+	exports     repositories.ExportRepository
+	tournaments repositories.TournamentRepository
+}
+
+func NewExportService(exports repositories.ExportRepository, tournaments repositories.TournamentRepository) *ExportService {
+	return &ExportService{exports: exports, tournaments: tournaments}
+}
+
+// TournamentWorkbook mengumpulkan semua data Tournament sebagai sheet
+// workbook: match, game, draft, hero pick/ban, priority dan flex pick, hasil
+// lane dan objective.
+func (s *ExportService) TournamentWorkbook(ctx context.Context, tournamentID uint) ([]tabular.Sheet, error) {
+	if _, err := s.tournaments.FindByID(ctx, tournamentID); err != nil {
+		return nil, notFound(err, "Tournament")
+	}
+
+	sheets := []struct {
+		name string
+		find func() (interface{}, error)
+	}{
+		{"Matches", func() (interface{}, error) { return s.exports.FindMatches(ctx, tournamentID) }},
+		{"Games", func() (interface{}, error) { return s.exports.FindGames(ctx, tournamentID) }},
+		{"Drafts", func() (interface{}, error) { return s.exports.FindDrafts(ctx, tournamentID) }},
+		{"Hero Picks", func() (interface{}, error) { return s.exports.FindHeroPicks(ctx, tournamentID) }},
+		{"Hero Bans", func() (interface{}, error) { return s.exports.FindHeroBans(ctx, tournamentID) }},
+		{"Priority Picks", func() (interface{}, error) { return s.exports.FindPriorityPicks(ctx, tournamentID) }},
+		{"Priority Bans", func() (interface{}, error) { return s.exports.FindPriorityBans(ctx, tournamentID) }},
+		{"Flex Picks", func() (interface{}, error) { return s.exports.FindFlexPicks(ctx, tournamentID) }},
+		{"Lane Results", func() (interface{}, error) { return s.exports.FindLaneResults(ctx, tournamentID) }},
+		{"Objectives", func() (interface{}, error) { return s.exports.FindObjectives(ctx, tournamentID) }},
+	}
+
+	workbook := make([]tabular.Sheet, 0, len(sheets))
+	for _, sheet := range sheets {
+		data, err := sheet.find()
+		if err != nil {
+			return nil, err
+		}
+		table, err := tabular.New(data)
+		if err != nil {
+			return nil, err
+		}
+		workbook = append(workbook, tabular.Sheet{Name: sheet.name, Table: table})
+	}
+	return workbook, nil
+}
@@ -1,151 +1,145 @@
 package services
 
 import (
-	"fmt"
-	"log"
-	"os"
-	"strings"
+	"context"
+	"errors"
 
+	"ml-master-data/dto"
 	"ml-master-data/models"
-
-	"gorm.io/gorm"
+	"ml-master-data/repositories"
 )
 
-func DeleteGame(db *gorm.DB, game models.Game) error {
-	// Mulai transaksi
-	tx := db.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
+type GameService struct {
+	games   repositories.GameRepository
+	matches repositories.MatchRepository
+	patches repositories.PatchRepository
+	events  *EventBus
+}
 
-	// Hapus TrioMidHero terkait
-	if err := tx.Where("trio_mid_id IN (SELECT trio_mid_id FROM trio_mids WHERE game_id = ?)", game.GameID).Delete(&models.TrioMidHero{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+func NewGameService(games repositories.GameRepository, matches repositories.MatchRepository, patches repositories.PatchRepository, events *EventBus) *GameService {
+	return &GameService{games: games, matches: matches, patches: patches, events: events}
+}
 
-	// Hapus TrioMid terkait
-	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.TrioMid{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+func (s *GameService) GetByMatch(ctx context.Context, matchID uint) ([]dto.GameResponseDto, error) {
+	return s.games.FindByMatch(ctx, matchID)
+}
 
-	// Hapus Goldlaner terkait
-	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.Goldlaner{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+func (s *GameService) GetDetail(ctx context.Context, matchID, gameID uint) (dto.GameResponseDto, error) {
+	game, err := s.games.FindDetail(ctx, matchID, gameID)
+	return game, notFound(err, "Game")
+}
 
-	// Hapus Explaner terkait
-	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.Explaner{}).Error; err != nil {
-		tx.Rollback()
-		return err
+// GetByID mengembalikan Game di Match matchID, dipakai sebelum mengubah Game.
+func (s *GameService) GetByID(ctx context.Context, matchID, gameID uint) (models.Game, error) {
+	if _, err := s.matches.FindByID(ctx, matchID); err != nil {
+		return models.Game{}, notFound(err, "Match")
 	}
 
-	// Hapus LordResult terkait
-	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.LordResult{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+	game, err := s.games.FindByID(ctx, matchID, gameID)
+	return game, notFound(err, "Game")
+}
 
-	// Hapus TurtleResult terkait
-	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.TurtleResult{}).Error; err != nil {
-		tx.Rollback()
-		return err
+// Create menyimpan Game baru di Match game.MatchID. Jika PatchID kosong,
+// Game dicatat di patch yang berlaku pada tanggal match.
+func (s *GameService) Create(ctx context.Context, game *models.Game) error {
+	match, err := s.matches.FindByID(ctx, game.MatchID)
+	if err != nil {
+		return notFound(err, "Match")
 	}
-
-	// Hapus GameResult terkait
-	if err := tx.Where("game_id = ?", game.GameID).Delete(&models.GameResult{}).Error; err != nil {
-		tx.Rollback()
+	if err := s.assignPatch(ctx, match, game); err != nil {
 		return err
 	}
-
-	// update heropick
-	var heroPickGames []models.HeroPickGame
-	if err := tx.Where("game_number = ? AND game_id = ?", game.GameNumber, game.GameID).Find(&heroPickGames).Error; err != nil {
-		tx.Rollback()
+	if err := s.games.Create(ctx, game); err != nil {
 		return err
 	}
+	s.publish(EventCreated, match, game.GameID, game)
+	return nil
+}
 
-	for _, heroPickGame := range heroPickGames {
-		if heroPickGame.IsPicked {
-			heroPick := models.HeroPick{}
-
-			if err := tx.Where("hero_pick_id = ?", heroPickGame.HeroPickID).First(&heroPick).Error; err != nil {
-				tx.Rollback()
-				return err
-			}
-
-			heroPick.Total = heroPick.Total - 1
-			if err := tx.Save(&heroPick).Error; err != nil {
-				tx.Rollback()
-				return err
-			}
-
-		}
+func (s *GameService) Update(ctx context.Context, game *models.Game) error {
+	match, err := s.matches.FindByID(ctx, game.MatchID)
+	if err != nil {
+		return notFound(err, "Match")
 	}
-
-	// Hapus HeroPickGame terkait
-	if err := tx.Where("game_number = ? AND game_id = ?", game.GameNumber, game.GameID).Delete(&models.HeroPickGame{}).Error; err != nil {
-		tx.Rollback()
+	if err := s.assignPatch(ctx, match, game); err != nil {
 		return err
 	}
-
-	// update heroban
-	var heroBanGames []models.HeroBanGame
-	if err := tx.Where("game_number = ? AND game_id = ?", game.GameNumber, game.GameID).Find(&heroBanGames).Error; err != nil {
-		tx.Rollback()
+	if err := s.games.Update(ctx, game); err != nil {
 		return err
 	}
+	s.publish(EventUpdated, match, game.GameID, game)
+	return nil
+}
 
-	for _, heroBanGame := range heroBanGames {
-		if heroBanGame.IsBanned {
-			heroBan := models.HeroBan{}
-
-			if err := tx.Where("hero_ban_id = ?", heroBanGame.HeroBanID).First(&heroBan).Error; err != nil {
-				tx.Rollback()
-				return err
-			}
-
-			heroBan.Total = heroBan.Total - 1
-			if err := tx.Save(&heroBan).Error; err != nil {
-				tx.Rollback()
-				return err
-			}
-		}
+// assignPatch memastikan game.PatchID menunjuk Patch yang ada. Jika kosong,
+// dipakai patch yang berlaku pada match.PlayedAt; tetap kosong jika tanggal
+// match belum diisi atau belum ada patch yang rilis. recompute-stats mengisinya
+// nanti setelah tanggal match diisi.
+func (s *GameService) assignPatch(ctx context.Context, match models.Match, game *models.Game) error {
+	if game.PatchID != nil {
+		_, err := s.patches.FindByID(ctx, *game.PatchID)
+		return notFound(err, "Patch")
 	}
 
-	// Hapus HeroBanGame terkait
-	if err := tx.Where("game_number = ? AND game_id = ?", game.GameNumber, game.GameID).Delete(&models.HeroBanGame{}).Error; err != nil {
-		tx.Rollback()
+	if match.PlayedAt == nil {
+		return nil
+	}
+	patch, err := s.patches.FindReleasedBy(ctx, *match.PlayedAt)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
 		return err
 	}
+	game.PatchID = &patch.PatchID
+	return nil
+}
 
-	// Hapus Game itu sendiri
-	if err := tx.Delete(&models.Game{}, game.GameID).Error; err != nil {
-		tx.Rollback()
+func (s *GameService) Delete(ctx context.Context, matchID, gameID uint) error {
+	match, err := s.matches.FindByID(ctx, matchID)
+	if err != nil {
+		return notFound(err, "Match")
+	}
+	game, err := s.games.FindByID(ctx, matchID, gameID)
+	if err != nil {
+		return notFound(err, "Game")
+	}
+	if err := s.games.Delete(ctx, game); err != nil {
 		return err
 	}
+	s.publish(EventDeleted, match, gameID, nil)
+	return nil
+}
 
-	// Commit transaksi
-	if err := tx.Commit().Error; err != nil {
+func (s *GameService) Restore(ctx context.Context, matchID, gameID uint) error {
+	exists, err := s.games.ExistsUnscoped(ctx, matchID, gameID)
+	if err != nil {
 		return err
 	}
-
-	if game.FullDraftImage != "" && game.FullDraftImage != "https://placehold.co/400x600" && strings.HasPrefix(game.FullDraftImage, os.Getenv("BASE_URL")) {
-		game.FullDraftImage = strings.Replace(game.FullDraftImage, os.Getenv("BASE_URL")+"/", "", 1)
-		// Cek apakah file Image lama ada di sistem
-		if _, err := os.Stat(game.FullDraftImage); err == nil {
-			// Jika file ada, hapus file Image lama dari folder images
-			if err := os.Remove(game.FullDraftImage); err != nil {
-				return fmt.Errorf("gagal menghapus gambar lama: %w", err)
-			}
-		} else if os.IsNotExist(err) {
-			// Jika file tidak ada, lanjutkan ke tahap selanjutnya
-			log.Printf("File gambar lama tidak ditemukan: %s", game.FullDraftImage)
-		}
+	if !exists {
+		return &NotFoundError{Entity: "Game"}
+	}
+	if err := s.games.Restore(ctx, gameID); err != nil {
+		return restoreError(err, "Game")
 	}
 
-	log.Printf("Game with ID %d and all related records have been deleted.", game.GameID)
+	// Game sudah dipulihkan; event dilewati jika datanya gagal dibaca ulang
+	match, err := s.matches.FindByID(ctx, matchID)
+	if err != nil {
+		return nil
+	}
+	if game, err := s.games.FindByID(ctx, matchID, gameID); err == nil {
+		s.publish(EventRestored, match, gameID, &game)
+	}
 	return nil
 }
+
+// publish mengirim event perubahan Game gameID di match.
+func (s *GameService) publish(action string, match models.Match, gameID uint, game *models.Game) {
+	event := NewEvent(EventGame, action, match, gameID, nil)
+	if game != nil {
+		event.Data = game
+	}
+	event.GameID = gameID
+	s.events.Publish(event)
+}
@@ -1,136 +1,145 @@
 package services
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
+	"slices"
 	"strings"
 
+	"ml-master-data/dto"
 	"ml-master-data/models"
-
-	"gorm.io/gorm"
+	"ml-master-data/repositories"
 )
 
-// Fungsi untuk menghapus Hero dan semua relasi terkait
-func DeleteHero(db *gorm.DB, hero models.Hero) error {
-	// Mulai transaksi
-	tx := db.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
-
-	//hapus priority pick
-	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.PriorityPick{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus PriorityPick: %w", err)
-	}
+type HeroService struct {
+	heroes repositories.HeroRepository
+}
 
-	// hapus priorityban
-	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.PriorityBan{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus PriorityBan: %w", err)
-	}
+func NewHeroService(heroes repositories.HeroRepository) *HeroService {
+	return &HeroService{heroes: heroes}
+}
 
-	// hapus flexpick
-	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.FlexPick{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus FlexPick: %w", err)
+func (s *HeroService) GetAll(ctx context.Context, query dto.HeroQueryDto) ([]models.Hero, error) {
+	filter := repositories.HeroFilter{
+		Class:  query.Class,
+		Lane:   query.Lane,
+		Search: strings.TrimSpace(query.Query),
 	}
-
-	// 1. Hapus HeroPickGame terkait
-	if err := tx.Where("hero_pick_id IN (SELECT hero_pick_id FROM hero_picks WHERE hero_id = ?)", hero.HeroID).Delete(&models.HeroPickGame{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	var err error
+	if filter.ReleasedAfter, err = parseDate(query.ReleasedAfter, "released_after"); err != nil {
+		return nil, err
 	}
-
-	// hapus heropikc
-	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.HeroPick{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus HeroPick: %w", err)
+	if filter.ReleasedBefore, err = parseDate(query.ReleasedBefore, "released_before"); err != nil {
+		return nil, err
 	}
+	return s.heroes.FindAll(ctx, filter)
+}
 
-	//  hapus herobangame
-	if err := tx.Where("hero_ban_id IN (SELECT hero_ban_id FROM hero_bans WHERE hero_id = ?)", hero.HeroID).Delete(&models.HeroBanGame{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+func (s *HeroService) GetByID(ctx context.Context, heroID uint) (models.Hero, error) {
+	hero, err := s.heroes.FindByID(ctx, heroID)
+	return hero, notFound(err, "Hero")
+}
 
-	// hapus heroban
-	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.HeroBan{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus HeroBan: %w", err)
+// SetMetadata menerapkan kelas, tanggal rilis, lanes dan aliases dari input
+// ke hero. Field yang tidak dikirim dibiarkan; lanes diurutkan seperti
+// models.Lanes dan duplikat dibuang.
+func (s *HeroService) SetMetadata(hero *models.Hero, input dto.HeroRequestDto) error {
+	if input.PrimaryClass != nil {
+		hero.PrimaryClass = strings.TrimSpace(*input.PrimaryClass)
 	}
-
-	// hapus explaner
-	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.Explaner{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus Explaner: %w", err)
+	if input.SecondaryClass != nil {
+		hero.SecondaryClass = strings.TrimSpace(*input.SecondaryClass)
 	}
-
-	// hapus goldlaner
-	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.Goldlaner{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus Goldlaner: %w", err)
+	for _, class := range []string{hero.PrimaryClass, hero.SecondaryClass} {
+		if class != "" && !slices.Contains(models.HeroClasses, class) {
+			return &ValidationError{Message: fmt.Sprintf("Invalid hero class %q, must be one of %s", class, strings.Join(models.HeroClasses, ", "))}
+		}
 	}
-
-	// hapus triomidhero
-	type trioMidID struct {
-		TrioMidID uint
+	if hero.SecondaryClass != "" && hero.PrimaryClass == "" {
+		return &ValidationError{Message: "Secondary class requires a primary class"}
 	}
-
-	trioMidIDs := []trioMidID{}
-	if err := tx.Raw("SELECT trio_mid_id FROM trio_mid_heros WHERE hero_id = ?", hero.HeroID).Scan(&trioMidIDs).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal mengambil trioMidID: %w", err)
+	if hero.SecondaryClass == hero.PrimaryClass {
+		hero.SecondaryClass = ""
 	}
 
-	// hapus trioMidHero
-	if err := tx.Where("hero_id = ?", hero.HeroID).Delete(&models.TrioMidHero{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus TrioMidHero: %w", err)
+	if input.ReleaseDate != nil {
+		releaseDate, err := parseDate(strings.TrimSpace(*input.ReleaseDate), "release_date")
+		if err != nil {
+			return err
+		}
+		hero.ReleaseDate = releaseDate
 	}
 
-	for _, trioMidID := range trioMidIDs {
-		var trioMidHeroCount int64
-		if err := tx.Model(&models.TrioMidHero{}).Where("trio_mid_id = ?", trioMidID.TrioMidID).Count(&trioMidHeroCount).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("gagal menghitung trioMidHeroCount: %w", err)
+	if input.Lanes != nil {
+		lanes := models.HeroLanes{}
+		for _, lane := range models.Lanes {
+			if slices.Contains(input.Lanes, lane) {
+				lanes = append(lanes, models.HeroLane{Lane: lane})
+			}
 		}
-		if trioMidHeroCount == 0 {
-			// hapus trioMid
-			if err := tx.Where("trio_mid_id = ?", trioMidID.TrioMidID).Delete(&models.TrioMid{}).Error; err != nil {
-				tx.Rollback()
-				return fmt.Errorf("gagal menghapus TrioMid: %w", err)
+		for _, lane := range input.Lanes {
+			if lane != "" && !slices.Contains(models.Lanes, lane) {
+				return &ValidationError{Message: fmt.Sprintf("Invalid lane %q, must be one of %s", lane, strings.Join(models.Lanes, ", "))}
 			}
 		}
+		hero.Lanes = lanes
+	}
 
+	if input.Aliases != nil {
+		aliases := models.HeroAliases{}
+		seen := map[string]bool{}
+		for _, alias := range input.Aliases {
+			alias = strings.TrimSpace(alias)
+			key := strings.ToLower(alias)
+			if alias == "" || seen[key] || strings.EqualFold(alias, hero.Name) {
+				continue
+			}
+			if len(alias) > 100 {
+				return &ValidationError{Message: "Hero alias must not exceed 100 characters"}
+			}
+			seen[key] = true
+			aliases = append(aliases, models.HeroAlias{Alias: alias})
+		}
+		hero.Aliases = aliases
 	}
+	return nil
+}
 
-	// 6. Hapus Hero itu sendiri
-	if err := tx.Delete(&models.Hero{}, hero.HeroID).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus Hero: %w", err)
+func (s *HeroService) Create(ctx context.Context, hero *models.Hero) error {
+	if err := s.checkAliases(ctx, *hero); err != nil {
+		return err
 	}
+	return s.heroes.Create(ctx, hero)
+}
 
-	// Commit transaksi
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("gagal commit transaksi: %w", err)
+func (s *HeroService) Update(ctx context.Context, hero *models.Hero) error {
+	if err := s.checkAliases(ctx, *hero); err != nil {
+		return err
 	}
+	return s.heroes.Update(ctx, hero)
+}
 
-	if hero.Image != "" && hero.Image != "https://placehold.co/400x600" && strings.HasPrefix(hero.Image, os.Getenv("BASE_URL")) {
-		hero.Image = strings.Replace(hero.Image, os.Getenv("BASE_URL")+"/", "", 1)
-		// Cek apakah file Image lama ada di sistem
-		if _, err := os.Stat(hero.Image); err == nil {
-			// Jika file ada, hapus file Image lama dari folder images
-			if err := os.Remove(hero.Image); err != nil {
-				return fmt.Errorf("gagal menghapus gambar lama: %w", err)
+// checkAliases memastikan alias hero tidak sama dengan nama atau alias hero
+// lain, agar nama hero dari sumber luar bisa dicocokkan tanpa ambigu.
+func (s *HeroService) checkAliases(ctx context.Context, hero models.Hero) error {
+	for _, alias := range hero.Aliases {
+		others, err := s.heroes.FindByName(ctx, alias.Alias)
+		if err != nil {
+			return err
+		}
+		for _, other := range others {
+			if other.HeroID != hero.HeroID {
+				return &ConflictError{Message: fmt.Sprintf("Alias %q is already used by hero %s", alias.Alias, other.Name)}
 			}
-		} else if os.IsNotExist(err) {
-			// Jika file tidak ada, lanjutkan ke tahap selanjutnya
-			log.Printf("File gambar lama tidak ditemukan: %s", hero.Image)
 		}
 	}
-
-	log.Printf("Hero dengan ID %d dan semua data terkait telah dihapus.", hero.HeroID)
 	return nil
 }
+
+func (s *HeroService) Delete(ctx context.Context, heroID uint) error {
+	hero, err := s.GetByID(ctx, heroID)
+	if err != nil {
+		return err
+	}
+	return s.heroes.Delete(ctx, hero)
+}
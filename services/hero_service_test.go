@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// fakeHeroRepository menyimpan Hero di memori.
+type fakeHeroRepository struct {
+	heroes map[uint]models.Hero
+	nextID uint
+}
+
+func (r *fakeHeroRepository) FindAll(ctx context.Context, filter repositories.HeroFilter) ([]models.Hero, error) {
+	var heroes []models.Hero
+	for _, hero := range r.heroes {
+		heroes = append(heroes, hero)
+	}
+	return heroes, nil
+}
+
+func (r *fakeHeroRepository) FindByID(ctx context.Context, heroID uint) (models.Hero, error) {
+	hero, ok := r.heroes[heroID]
+	if !ok {
+		return hero, repositories.ErrNotFound
+	}
+	return hero, nil
+}
+
+func (r *fakeHeroRepository) FindByName(ctx context.Context, name string) ([]models.Hero, error) {
+	var heroes []models.Hero
+	for _, hero := range r.heroes {
+		names := []string{hero.Name}
+		for _, alias := range hero.Aliases {
+			names = append(names, alias.Alias)
+		}
+		for _, other := range names {
+			if strings.EqualFold(other, name) {
+				heroes = append(heroes, hero)
+				break
+			}
+		}
+	}
+	return heroes, nil
+}
+
+func (r *fakeHeroRepository) Create(ctx context.Context, hero *models.Hero) error {
+	r.nextID++
+	hero.HeroID = r.nextID
+	r.heroes[hero.HeroID] = *hero
+	return nil
+}
+
+func (r *fakeHeroRepository) Update(ctx context.Context, hero *models.Hero) error {
+	r.heroes[hero.HeroID] = *hero
+	return nil
+}
+
+func (r *fakeHeroRepository) Delete(ctx context.Context, hero models.Hero) error {
+	delete(r.heroes, hero.HeroID)
+	return nil
+}
+
+func TestHeroServiceMetadataAndAliases(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeHeroRepository{heroes: map[uint]models.Hero{}}
+	heroes := NewHeroService(repo)
+
+	// Lanes diurutkan seperti models.Lanes, alias duplikat dan alias yang
+	// sama dengan nama hero dibuang
+	primary, secondary := " fighter ", "fighter"
+	hero := models.Hero{Name: "Chou"}
+	err := heroes.SetMetadata(&hero, dto.HeroRequestDto{
+		PrimaryClass:   &primary,
+		SecondaryClass: &secondary,
+		Lanes:          []string{"roam", "exp", "roam"},
+		Aliases:        []string{"Kungfu", " kungfu ", "chou", ""},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hero.PrimaryClass != "fighter" || hero.SecondaryClass != "" {
+		t.Errorf("classes = %q, %q, want fighter and none", hero.PrimaryClass, hero.SecondaryClass)
+	}
+	if len(hero.Lanes) != 2 || hero.Lanes[0].Lane != "exp" || hero.Lanes[1].Lane != "roam" {
+		t.Errorf("lanes = %+v, want exp, roam", hero.Lanes)
+	}
+	if len(hero.Aliases) != 1 || hero.Aliases[0].Alias != "Kungfu" {
+		t.Errorf("aliases = %+v, want Kungfu", hero.Aliases)
+	}
+
+	var validation *ValidationError
+	invalid := "healer"
+	for name, input := range map[string]dto.HeroRequestDto{
+		"class":     {PrimaryClass: &invalid},
+		"lane":      {Lanes: []string{"carry"}},
+		"secondary": {SecondaryClass: &secondary},
+	} {
+		if err := heroes.SetMetadata(&models.Hero{Name: "Layla"}, input); !errors.As(err, &validation) {
+			t.Errorf("invalid %s: err = %v, want ValidationError", name, err)
+		}
+	}
+
+	if err := heroes.Create(ctx, &hero); err != nil {
+		t.Fatal(err)
+	}
+	// Hero sendiri boleh disimpan ulang dengan alias yang sama
+	if err := heroes.Update(ctx, &hero); err != nil {
+		t.Fatalf("update with own alias: %v", err)
+	}
+
+	var conflict *ConflictError
+	other := models.Hero{Name: "Zilong", Aliases: models.HeroAliases{{Alias: "KUNGFU"}}}
+	if err := heroes.Create(ctx, &other); !errors.As(err, &conflict) {
+		t.Fatalf("alias used by another hero: err = %v, want ConflictError", err)
+	}
+	other.Aliases = models.HeroAliases{{Alias: "chou"}}
+	if err := heroes.Create(ctx, &other); !errors.As(err, &conflict) {
+		t.Fatalf("alias equal to another hero name: err = %v, want ConflictError", err)
+	}
+	if len(repo.heroes) != 1 {
+		t.Fatalf("%d heroes stored, want 1", len(repo.heroes))
+	}
+
+	var missing *NotFoundError
+	if err := heroes.Delete(ctx, 99); !errors.As(err, &missing) {
+		t.Fatalf("delete missing hero: err = %v, want NotFoundError", err)
+	}
+	if err := heroes.Delete(ctx, hero.HeroID); err != nil || len(repo.heroes) != 0 {
+		t.Fatalf("delete: err = %v, %d heroes left", err, len(repo.heroes))
+	}
+}
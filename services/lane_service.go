@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// LaneService mengelola hero dan hasil early game explaner, goldlaner dan
+// trio mid sebuah tim di Game. Tim harus salah satu tim Match Game.
+type LaneService struct {
+	lanes   repositories.LaneRepository
+	games   repositories.GameRepository
+	matches repositories.MatchRepository
+}
+
+func NewLaneService(lanes repositories.LaneRepository, games repositories.GameRepository, matches repositories.MatchRepository) *LaneService {
+	return &LaneService{lanes: lanes, games: games, matches: matches}
+}
+
+func (s *LaneService) GetExplaners(ctx context.Context, gameID, teamID uint) ([]dto.ExplanerResponseDto, error) {
+	if err := s.game(ctx, gameID); err != nil {
+		return nil, err
+	}
+	return s.lanes.FindExplaners(ctx, gameID, teamID)
+}
+
+func (s *LaneService) GetExplaner(ctx context.Context, gameID, teamID, explanerID uint) (dto.ExplanerResponseDto, error) {
+	if err := s.game(ctx, gameID); err != nil {
+		return dto.ExplanerResponseDto{}, err
+	}
+	explaner, err := s.lanes.FindExplanerDetail(ctx, gameID, teamID, explanerID)
+	return explaner, notFound(err, "Explaner")
+}
+
+// AddExplaner menambahkan explaner tim; satu hero hanya boleh sekali menjadi
+// explaner tim yang sama dalam satu Game.
+func (s *LaneService) AddExplaner(ctx context.Context, gameID, teamID uint, input dto.ExplanerRequestDto) (models.Explaner, error) {
+	if err := s.teamInGame(ctx, gameID, teamID); err != nil {
+		return models.Explaner{}, err
+	}
+	found, err := s.lanes.ExplanerExists(ctx, gameID, teamID, input.HeroID, 0)
+	if err != nil {
+		return models.Explaner{}, err
+	}
+	if found {
+		return models.Explaner{}, &ValidationError{Message: "Explaner already exists"}
+	}
+
+	explaner := models.Explaner{
+		GameID:      gameID,
+		TeamID:      teamID,
+		HeroID:      input.HeroID,
+		EarlyResult: input.EarlyResult,
+	}
+	return explaner, s.lanes.CreateExplaner(ctx, &explaner)
+}
+
+func (s *LaneService) UpdateExplaner(ctx context.Context, gameID, teamID, explanerID uint, input dto.ExplanerRequestDto) (models.Explaner, error) {
+	if err := s.teamInGame(ctx, gameID, teamID); err != nil {
+		return models.Explaner{}, err
+	}
+	explaner, err := s.lanes.FindExplaner(ctx, gameID, explanerID)
+	if err != nil {
+		return explaner, notFound(err, "Explaner")
+	}
+	found, err := s.lanes.ExplanerExists(ctx, gameID, teamID, input.HeroID, explanerID)
+	if err != nil {
+		return explaner, err
+	}
+	if found {
+		return explaner, &ValidationError{Message: "Explaner already exists"}
+	}
+
+	explaner.TeamID = teamID
+	explaner.HeroID = input.HeroID
+	explaner.EarlyResult = input.EarlyResult
+	return explaner, s.lanes.UpdateExplaner(ctx, &explaner)
+}
+
+func (s *LaneService) RemoveExplaner(ctx context.Context, gameID, explanerID uint) error {
+	if err := s.game(ctx, gameID); err != nil {
+		return err
+	}
+	explaner, err := s.lanes.FindExplaner(ctx, gameID, explanerID)
+	if err != nil {
+		return notFound(err, "Explaner")
+	}
+	return s.lanes.DeleteExplaner(ctx, explaner)
+}
+
+func (s *LaneService) GetGoldlaners(ctx context.Context, gameID, teamID uint) ([]dto.GoldlanerResponseDto, error) {
+	if err := s.game(ctx, gameID); err != nil {
+		return nil, err
+	}
+	return s.lanes.FindGoldlaners(ctx, gameID, teamID)
+}
+
+func (s *LaneService) GetGoldlaner(ctx context.Context, gameID, teamID, goldlanerID uint) (dto.GoldlanerResponseDto, error) {
+	if err := s.game(ctx, gameID); err != nil {
+		return dto.GoldlanerResponseDto{}, err
+	}
+	goldlaner, err := s.lanes.FindGoldlanerDetail(ctx, gameID, teamID, goldlanerID)
+	return goldlaner, notFound(err, "Goldlaner")
+}
+
+// AddGoldlaner menambahkan goldlaner tim; satu hero hanya boleh sekali
+// menjadi goldlaner tim yang sama dalam satu Game.
+func (s *LaneService) AddGoldlaner(ctx context.Context, gameID, teamID uint, input dto.GoldlanerRequestDto) (models.Goldlaner, error) {
+	if err := s.teamInGame(ctx, gameID, teamID); err != nil {
+		return models.Goldlaner{}, err
+	}
+	found, err := s.lanes.GoldlanerExists(ctx, gameID, teamID, input.HeroID, 0)
+	if err != nil {
+		return models.Goldlaner{}, err
+	}
+	if found {
+		return models.Goldlaner{}, &ValidationError{Message: "Goldlaner already exists"}
+	}
+
+	goldlaner := models.Goldlaner{
+		GameID:      gameID,
+		TeamID:      teamID,
+		HeroID:      input.HeroID,
+		EarlyResult: input.EarlyResult,
+	}
+	return goldlaner, s.lanes.CreateGoldlaner(ctx, &goldlaner)
+}
+
+func (s *LaneService) UpdateGoldlaner(ctx context.Context, gameID, teamID, goldlanerID uint, input dto.GoldlanerRequestDto) (models.Goldlaner, error) {
+	if err := s.teamInGame(ctx, gameID, teamID); err != nil {
+		return models.Goldlaner{}, err
+	}
+	goldlaner, err := s.lanes.FindGoldlaner(ctx, gameID, goldlanerID)
+	if err != nil {
+		return goldlaner, notFound(err, "Goldlaner")
+	}
+	found, err := s.lanes.GoldlanerExists(ctx, gameID, teamID, input.HeroID, goldlanerID)
+	if err != nil {
+		return goldlaner, err
+	}
+	if found {
+		return goldlaner, &ValidationError{Message: "Goldlaner already exists"}
+	}
+
+	goldlaner.TeamID = teamID
+	goldlaner.HeroID = input.HeroID
+	goldlaner.EarlyResult = input.EarlyResult
+	return goldlaner, s.lanes.UpdateGoldlaner(ctx, &goldlaner)
+}
+
+func (s *LaneService) RemoveGoldlaner(ctx context.Context, gameID, goldlanerID uint) error {
+	if err := s.game(ctx, gameID); err != nil {
+		return err
+	}
+	goldlaner, err := s.lanes.FindGoldlaner(ctx, gameID, goldlanerID)
+	if err != nil {
+		return notFound(err, "Goldlaner")
+	}
+	return s.lanes.DeleteGoldlaner(ctx, goldlaner)
+}
+
+func (s *LaneService) GetTrioMids(ctx context.Context, gameID uint) ([]dto.TrioMidResponseDto, error) {
+	if err := s.game(ctx, gameID); err != nil {
+		return nil, err
+	}
+	return s.lanes.FindTrioMids(ctx, gameID)
+}
+
+func (s *LaneService) GetTrioMid(ctx context.Context, gameID, trioMidID uint) (dto.TrioMidResponseDto, error) {
+	if err := s.game(ctx, gameID); err != nil {
+		return dto.TrioMidResponseDto{}, err
+	}
+	trioMid, err := s.lanes.FindTrioMidDetail(ctx, gameID, trioMidID)
+	return trioMid, notFound(err, "TrioMid")
+}
+
+// AddTrioMid menambahkan hero ke trio mid tim; satu hero hanya boleh sekali
+// ada di trio mid tim yang sama dalam satu Game.
+func (s *LaneService) AddTrioMid(ctx context.Context, gameID, teamID uint, input dto.TrioMidRequestDto) (models.TrioMidHero, error) {
+	if err := s.teamInGame(ctx, gameID, teamID); err != nil {
+		return models.TrioMidHero{}, err
+	}
+	found, err := s.lanes.TrioMidHeroExists(ctx, gameID, teamID, input.HeroID, 0)
+	if err != nil {
+		return models.TrioMidHero{}, err
+	}
+	if found {
+		return models.TrioMidHero{}, &ValidationError{Message: "TrioMidHero already exists"}
+	}
+
+	hero := models.TrioMidHero{
+		HeroID:      input.HeroID,
+		Role:        input.Role,
+		EarlyResult: input.EarlyResult,
+	}
+	return hero, s.lanes.CreateTrioMidHero(ctx, gameID, teamID, &hero)
+}
+
+func (s *LaneService) UpdateTrioMid(ctx context.Context, gameID, teamID, trioMidHeroID uint, input dto.TrioMidRequestDto) (models.TrioMidHero, error) {
+	if err := s.teamInGame(ctx, gameID, teamID); err != nil {
+		return models.TrioMidHero{}, err
+	}
+	hero, err := s.lanes.FindTrioMidHero(ctx, gameID, trioMidHeroID)
+	if err != nil {
+		return hero, notFound(err, "TrioMidHero")
+	}
+	found, err := s.lanes.TrioMidHeroExists(ctx, gameID, teamID, input.HeroID, trioMidHeroID)
+	if err != nil {
+		return hero, err
+	}
+	if found {
+		return hero, &ValidationError{Message: "TrioMidHero already exists"}
+	}
+
+	hero.HeroID = input.HeroID
+	hero.Role = input.Role
+	hero.EarlyResult = input.EarlyResult
+	return hero, s.lanes.UpdateTrioMidHero(ctx, &hero)
+}
+
+// RemoveTrioMid menghapus hero dari trio mid tim. TrioMid ikut dihapus jika
+// hero tersebut yang terakhir; trioMidDeleted bernilai true pada kasus itu.
+func (s *LaneService) RemoveTrioMid(ctx context.Context, gameID, teamID, trioMidHeroID uint) (trioMidDeleted bool, err error) {
+	if err := s.teamInGame(ctx, gameID, teamID); err != nil {
+		return false, err
+	}
+	hero, err := s.lanes.FindTrioMidHero(ctx, gameID, trioMidHeroID)
+	if err != nil {
+		return false, notFound(err, "TrioMidHero")
+	}
+	return s.lanes.DeleteTrioMidHero(ctx, hero)
+}
+
+func (s *LaneService) GetTrioMidResult(ctx context.Context, gameID, trioMidID uint) (models.TrioMid, error) {
+	trioMid, err := s.lanes.FindTrioMidInGame(ctx, gameID, trioMidID)
+	return trioMid, notFound(err, "TrioMid")
+}
+
+// UpdateTrioMidResult mengisi hasil early game trio mid milik input.TeamID.
+func (s *LaneService) UpdateTrioMidResult(ctx context.Context, gameID, trioMidID uint, input dto.TrioMidResultDto) (models.TrioMid, error) {
+	if input.EarlyResult != "win" && input.EarlyResult != "draw" && input.EarlyResult != "lose" {
+		return models.TrioMid{}, &ValidationError{Message: "Invalid early result"}
+	}
+
+	trioMid, err := s.lanes.FindTrioMid(ctx, gameID, input.TeamID, trioMidID)
+	if err != nil {
+		return trioMid, notFound(err, "TrioMid")
+	}
+
+	trioMid.EarlyResult = &input.EarlyResult
+	return trioMid, s.lanes.UpdateTrioMid(ctx, &trioMid)
+}
+
+// GetGameResult menghitung hasil early game tim di Game dari hasil ketiga
+// lane-nya.
+func (s *LaneService) GetGameResult(ctx context.Context, gameID, teamID uint) (dto.GameResultDto, error) {
+	results, err := s.lanes.FindEarlyResults(ctx, gameID, teamID)
+	if err != nil {
+		return dto.GameResultDto{}, err
+	}
+
+	var gameResult dto.GameResultDto
+	for _, result := range results {
+		switch result {
+		case "win":
+			gameResult.Win++
+		case "draw":
+			gameResult.Draw++
+		case "lose":
+			gameResult.Lose++
+		}
+	}
+	gameResult.Result = earlyGameResult(gameResult.Win, gameResult.Draw, gameResult.Lose)
+	return gameResult, nil
+}
+
+// earlyGameResult menilai early game dari jumlah lane yang menang, seri dan
+// kalah.
+func earlyGameResult(win, draw, lose int) string {
+	switch {
+	case win == 3,
+		win == 2 && draw == 1,
+		win == 1 && draw == 2:
+		return "Good Early"
+	case win == 2 && lose == 1,
+		win == 1 && draw == 1 && lose == 1:
+		return "Ok Early"
+	case win == 1 && lose == 2,
+		draw == 2 && lose == 1,
+		draw == 1 && lose == 2,
+		lose == 3:
+		return "Bad Early"
+	}
+	return "No Result"
+}
+
+func (s *LaneService) game(ctx context.Context, gameID uint) error {
+	_, err := s.games.FindByGameID(ctx, gameID)
+	return notFound(err, "Game")
+}
+
+// teamInGame memastikan Game gameID ada dan teamID salah satu tim Match-nya.
+func (s *LaneService) teamInGame(ctx context.Context, gameID, teamID uint) error {
+	game, err := s.games.FindByGameID(ctx, gameID)
+	if err != nil {
+		return notFound(err, "Game")
+	}
+	match, err := s.matches.FindByID(ctx, game.MatchID)
+	if err != nil {
+		return notFound(err, "Match")
+	}
+	if match.TeamAID != teamID && match.TeamBID != teamID {
+		return &ValidationError{Message: "Team ID is not part of the match"}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// LineupService mengelola pemain dan coach yang diturunkan sebuah tim di
+// Match.
+type LineupService struct {
+	lineups repositories.LineupRepository
+	matches repositories.MatchRepository
+	teams   repositories.TeamRepository
+}
+
+func NewLineupService(lineups repositories.LineupRepository, matches repositories.MatchRepository, teams repositories.TeamRepository) *LineupService {
+	return &LineupService{lineups: lineups, matches: matches, teams: teams}
+}
+
+func (s *LineupService) GetPlayers(ctx context.Context, matchID, teamID uint) ([]dto.PlayerMatchResponseDto, error) {
+	if _, err := s.teamDetail(ctx, matchID, teamID); err != nil {
+		return nil, err
+	}
+	return s.lineups.FindPlayerMatches(ctx, matchID, teamID)
+}
+
+// AddPlayer menurunkan pemain untuk tim; satu pemain hanya boleh sekali
+// tercatat di tim yang sama dalam satu Match.
+func (s *LineupService) AddPlayer(ctx context.Context, matchID, teamID uint, input dto.PlayerMatchRequestDto) (models.PlayerMatch, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.PlayerMatch{}, err
+	}
+	player, err := s.teams.FindPlayerByID(ctx, *input.PlayerID)
+	if err != nil {
+		return models.PlayerMatch{}, notFound(err, "Player")
+	}
+
+	_, err = s.lineups.FindPlayerMatch(ctx, matchTeamDetail.MatchTeamDetailID, player.PlayerID)
+	if err == nil {
+		return models.PlayerMatch{}, &ConflictError{Message: "Player is already added to the match"}
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return models.PlayerMatch{}, err
+	}
+
+	playerMatch := models.PlayerMatch{
+		MatchTeamDetailID: matchTeamDetail.MatchTeamDetailID,
+		PlayerID:          player.PlayerID,
+		Role:              *input.Role,
+	}
+	return playerMatch, s.lineups.CreatePlayerMatch(ctx, &playerMatch)
+}
+
+func (s *LineupService) UpdatePlayer(ctx context.Context, matchID, teamID, playerID uint, input dto.UpdatePlayerMatchRequestDto) (models.PlayerMatch, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.PlayerMatch{}, err
+	}
+	playerMatch, err := s.lineups.FindPlayerMatch(ctx, matchTeamDetail.MatchTeamDetailID, playerID)
+	if err != nil {
+		return playerMatch, notFound(err, "Player in the match")
+	}
+
+	if input.Role != nil {
+		playerMatch.Role = *input.Role
+	}
+	return playerMatch, s.lineups.UpdatePlayerMatch(ctx, &playerMatch)
+}
+
+func (s *LineupService) RemovePlayer(ctx context.Context, matchID, teamID, playerID uint) error {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return err
+	}
+	playerMatch, err := s.lineups.FindPlayerMatch(ctx, matchTeamDetail.MatchTeamDetailID, playerID)
+	if err != nil {
+		return notFound(err, "Player match")
+	}
+	return s.lineups.DeletePlayerMatch(ctx, playerMatch)
+}
+
+func (s *LineupService) GetCoaches(ctx context.Context, matchID, teamID uint) ([]dto.CoachMatchResponseDto, error) {
+	if _, err := s.teamDetail(ctx, matchID, teamID); err != nil {
+		return nil, err
+	}
+	return s.lineups.FindCoachMatches(ctx, matchID, teamID)
+}
+
+// AddCoach mencatat coach tim; satu coach hanya boleh sekali tercatat di tim
+// yang sama dalam satu Match.
+func (s *LineupService) AddCoach(ctx context.Context, matchID, teamID uint, input dto.CoachMatchRequestDto) (models.CoachMatch, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.CoachMatch{}, err
+	}
+	coach, err := s.teams.FindCoachByID(ctx, *input.CoachID)
+	if err != nil {
+		return models.CoachMatch{}, notFound(err, "Coach")
+	}
+
+	_, err = s.lineups.FindCoachMatch(ctx, matchTeamDetail.MatchTeamDetailID, coach.CoachID)
+	if err == nil {
+		return models.CoachMatch{}, &ConflictError{Message: "Coach is already added to the match"}
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return models.CoachMatch{}, err
+	}
+
+	coachMatch := models.CoachMatch{
+		MatchTeamDetailID: matchTeamDetail.MatchTeamDetailID,
+		CoachID:           coach.CoachID,
+		Role:              *input.Role,
+	}
+	return coachMatch, s.lineups.CreateCoachMatch(ctx, &coachMatch)
+}
+
+func (s *LineupService) UpdateCoach(ctx context.Context, matchID, teamID, coachID uint, input dto.UpdateCoachMatchRequestDto) (models.CoachMatch, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.CoachMatch{}, err
+	}
+	coachMatch, err := s.lineups.FindCoachMatch(ctx, matchTeamDetail.MatchTeamDetailID, coachID)
+	if err != nil {
+		return coachMatch, notFound(err, "Coach in the match")
+	}
+
+	if input.Role != nil {
+		coachMatch.Role = *input.Role
+	}
+	return coachMatch, s.lineups.UpdateCoachMatch(ctx, &coachMatch)
+}
+
+func (s *LineupService) RemoveCoach(ctx context.Context, matchID, teamID, coachID uint) error {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return err
+	}
+	coachMatch, err := s.lineups.FindCoachMatch(ctx, matchTeamDetail.MatchTeamDetailID, coachID)
+	if err != nil {
+		return notFound(err, "Coach match")
+	}
+	return s.lineups.DeleteCoachMatch(ctx, coachMatch)
+}
+
+func (s *LineupService) teamDetail(ctx context.Context, matchID, teamID uint) (models.MatchTeamDetail, error) {
+	matchTeamDetail, err := s.matches.FindTeamDetail(ctx, matchID, teamID)
+	return matchTeamDetail, notFound(err, "Match or team")
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"ml-master-data/dto"
+	"ml-master-data/liquipedia"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/tabular"
+)
+
+// liquipediaSheet adalah nama sheet di error yang berasal dari file
+// Liquipedia, bukan dari sheet hasil konversi.
+const liquipediaSheet = "Liquipedia"
+
+// liquipediaSource adalah asal nama di antrean review.
+const liquipediaSource = "liquipedia"
+
+// LiquipediaImportService meng-import match Liquipedia dengan mengubahnya
+// menjadi sheet import spreadsheet.
+type LiquipediaImportService struct {
+	sheets      *SheetImportService
+	tournaments repositories.TournamentRepository
+	names       *NameResolverService
+}
+
+func NewLiquipediaImportService(sheets *SheetImportService, tournaments repositories.TournamentRepository, names *NameResolverService) *LiquipediaImportService {
+	return &LiquipediaImportService{sheets: sheets, tournaments: tournaments, names: names}
+}
+
+// Import mencocokkan nama team dan hero di matches lalu meng-import match,
+// game, hero pick, hero ban dan draft per game ke Tournament seperti
+// SheetImportService.Import. Tim di sisi blue dianggap first pick; pick
+// dan ban 1-3 masuk fase pertama, sisanya fase kedua. Match yang sudah
+// pernah di-import dicocokkan lewat stage, tanggal dan tim sehingga file
+// yang sama bisa di-import ulang. Pick dan ban diperiksa terhadap aturan
+// draft Tournament oleh SheetImportService, sama seperti input manual. Nama
+// yang tidak cocok masuk antrean review kecuali dryRun.
+func (s *LiquipediaImportService) Import(ctx context.Context, tournamentID uint, matches []liquipedia.Match, dryRun bool) (dto.LiquipediaImportResultDto, error) {
+	result := dto.LiquipediaImportResultDto{
+		SheetImportResultDto: dto.SheetImportResultDto{DryRun: dryRun, Changes: []dto.SheetImportChangeDto{}, Errors: []dto.SheetImportErrorDto{}},
+		Matches:              len(matches),
+		Names:                []dto.LiquipediaNameDto{},
+	}
+	if _, err := s.tournaments.FindByID(ctx, tournamentID); err != nil {
+		return result, notFound(err, "Tournament")
+	}
+
+	teamNames, err := s.names.matchers(ctx, models.NameKindTeam)
+	if err != nil {
+		return result, err
+	}
+	heroNames, err := s.names.matchers(ctx, models.NameKindHero)
+	if err != nil {
+		return result, err
+	}
+
+	c := &liquipediaConversion{result: &result, teams: teamNames.of(0), heroes: heroNames.of(0), names: map[string]int{}}
+	sheets := c.convert(matches)
+	if len(result.Errors) > 0 {
+		if !dryRun {
+			var unmatched []dto.NameResolutionDto
+			for _, name := range result.Names {
+				if name.Method == "" {
+					unmatched = append(unmatched, dto.NameResolutionDto{Kind: name.Kind, Name: name.Name, Status: nameUnknown})
+				}
+			}
+			if err := s.names.Queue(ctx, liquipediaSource, unmatched); err != nil {
+				return result, err
+			}
+		}
+		return result, &ValidationError{Message: fmt.Sprintf("%d problems found, nothing was imported", len(result.Errors))}
+	}
+
+	imported, err := s.sheets.Import(ctx, tournamentID, sheets, dryRun)
+	names := result.Names
+	result.SheetImportResultDto = imported
+	result.Names = names
+	return result, err
+}
+
+// liquipediaConversion mengubah match Liquipedia menjadi sheet import.
+type liquipediaConversion struct {
+	result *dto.LiquipediaImportResultDto
+	teams  *nameMatcher
+	heroes *nameMatcher
+	// names berisi indeks result.Names per jenis dan nama.
+	names map[string]int
+}
+
+func (c *liquipediaConversion) fail(match int, column, format string, args ...interface{}) {
+	c.result.Errors = append(c.result.Errors, dto.SheetImportErrorDto{Sheet: liquipediaSheet, Row: match, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+// resolve mencocokkan nama pertama yang tidak kosong di candidates yang
+// cocok, dan mencatat hasilnya sekali per nama di result.Names.
+func (c *liquipediaConversion) resolve(kind string, matcher *nameMatcher, candidates ...string) (uint, bool) {
+	var label string
+	for _, name := range candidates {
+		if name == "" {
+			continue
+		}
+		if label == "" {
+			label = name
+		}
+		if id, method, ok := matcher.match(name); ok {
+			c.record(kind, label, dto.LiquipediaNameDto{MatchedID: id, Matched: matcher.names[id], Method: method})
+			return id, true
+		}
+	}
+	if label != "" {
+		c.record(kind, label, dto.LiquipediaNameDto{Suggestions: matcher.suggest(label)})
+	}
+	return 0, false
+}
+
+func (c *liquipediaConversion) record(kind, name string, entry dto.LiquipediaNameDto) {
+	key := kind + "/" + name
+	if _, ok := c.names[key]; ok {
+		return
+	}
+	entry.Kind, entry.Name = kind, name
+	c.names[key] = len(c.result.Names)
+	c.result.Names = append(c.result.Names, entry)
+}
+
+// unknown menulis pesan nama yang tidak cocok beserta sarannya.
+func (c *liquipediaConversion) unknown(kind, name string) string {
+	message := fmt.Sprintf("Unknown %s %q", kind, name)
+	if i, ok := c.names[kind+"/"+name]; ok && len(c.result.Names[i].Suggestions) > 0 {
+		message += fmt.Sprintf("; did you mean %q?", c.result.Names[i].Suggestions[0])
+	}
+	return message
+}
+
+// heroDraft adalah jumlah pick atau ban satu hero oleh satu tim di sebuah
+// match.
+type heroDraft struct {
+	key    string
+	teamID uint
+	heroID uint
+	phases [2]int
+}
+
+func (c *liquipediaConversion) convert(matches []liquipedia.Match) []tabular.Sheet {
+	sheet := func(name string, columns ...string) *tabular.Sheet {
+		return &tabular.Sheet{Name: name, Table: tabular.Table{Columns: columns}}
+	}
+	matchSheet := sheet("Matches", "match_id", "stage", "day", "date", "played_at", "team_a", "team_b", "team_a_score", "team_b_score")
+	gameSheet := sheet("Games", "match_id", "game_number", "first_pick_team", "second_pick_team", "winner_team", "video_link")
+	pickSheet := sheet("Hero Picks", "match_id", "team", "hero", "first_phase", "second_phase", "total")
+	banSheet := sheet("Hero Bans", "match_id", "team", "hero", "first_phase", "second_phase", "total")
+	draftSheet := sheet("Drafts", "match_id", "game_number", "team", "action", "hero")
+
+	// Day adalah urutan tanggal bertanding di file, mulai dari 1
+	var days []string
+	for _, match := range matches {
+		if match.Date != nil {
+			days = append(days, match.Date.Format(tabular.DateLayout))
+		}
+	}
+	sort.Strings(days)
+	dayNumbers := map[string]int{}
+	for _, day := range days {
+		if _, ok := dayNumbers[day]; !ok {
+			dayNumbers[day] = len(dayNumbers) + 1
+		}
+	}
+
+	id := func(value uint) string { return strconv.FormatUint(uint64(value), 10) }
+	for i, match := range matches {
+		number := i + 1
+		key := fmt.Sprintf("lp-%d", number)
+
+		var teamIDs [2]uint
+		for side, opponent := range match.Opponents {
+			column := fmt.Sprintf("opponent%d", side+1)
+			if opponent.Label() == "" {
+				c.fail(number, column, "Opponent is missing")
+				continue
+			}
+			teamID, ok := c.resolve("team", c.teams, opponent.Name, opponent.Template)
+			if !ok {
+				c.fail(number, column, "%s", c.unknown("team", opponent.Label()))
+			}
+			teamIDs[side] = teamID
+		}
+		if teamIDs[0] != 0 && teamIDs[0] == teamIDs[1] {
+			c.fail(number, "opponent2", "Both opponents are team %s", c.teams.names[teamIDs[0]])
+		}
+
+		stage := match.Section
+		if stage == "" {
+			stage = liquipediaSheet
+		}
+		day, date, playedAt := 0, 0, ""
+		if match.Date != nil {
+			playedAt = match.Date.Format(tabular.DateLayout)
+			day, date = dayNumbers[playedAt], match.Date.Day()
+		}
+		matchSheet.Table.Rows = append(matchSheet.Table.Rows, []string{key, stage, strconv.Itoa(day), strconv.Itoa(date), playedAt,
+			id(teamIDs[0]), id(teamIDs[1]), strconv.Itoa(match.Score(0)), strconv.Itoa(match.Score(1))})
+
+		var picks, bans []*heroDraft
+		for _, game := range match.Games {
+			column := fmt.Sprintf("map%d", game.Number)
+			if game.Winner == 0 {
+				c.fail(number, column, "Map %d has no winner", game.Number)
+				continue
+			}
+			first := 0
+			if game.Sides[1] == "blue" || game.Sides[0] == "red" {
+				first = 1
+			}
+			gameSheet.Table.Rows = append(gameSheet.Table.Rows, []string{key, strconv.Itoa(game.Number),
+				id(teamIDs[first]), id(teamIDs[1-first]), id(teamIDs[game.Winner-1]), game.VOD})
+
+			for side := range teamIDs {
+				drafts := []struct {
+					action string
+					heroes []string
+					totals *[]*heroDraft
+				}{
+					{"pick", game.Picks[side], &picks},
+					{"ban", game.Bans[side], &bans},
+				}
+				for _, draft := range drafts {
+					if len(draft.heroes) > 5 {
+						c.fail(number, column, "Team %d has %d %ss, at most 5 allowed", side+1, len(draft.heroes), draft.action)
+					}
+					for slot, name := range draft.heroes {
+						heroID, ok := c.resolve("hero", c.heroes, name)
+						if !ok {
+							c.fail(number, column, "%s", c.unknown("hero", name))
+							continue
+						}
+						draftSheet.Table.Rows = append(draftSheet.Table.Rows, []string{key, strconv.Itoa(game.Number), id(teamIDs[side]), draft.action, id(heroID)})
+						*draft.totals = countDraft(*draft.totals, key, teamIDs[side], heroID, slot)
+					}
+				}
+			}
+		}
+
+		for _, totals := range []struct {
+			sheet  *tabular.Sheet
+			drafts []*heroDraft
+		}{{pickSheet, picks}, {banSheet, bans}} {
+			for _, draft := range totals.drafts {
+				totals.sheet.Table.Rows = append(totals.sheet.Table.Rows, []string{draft.key, id(draft.teamID), id(draft.heroID),
+					strconv.Itoa(draft.phases[0]), strconv.Itoa(draft.phases[1]), strconv.Itoa(draft.phases[0] + draft.phases[1])})
+			}
+		}
+	}
+
+	return []tabular.Sheet{*matchSheet, *gameSheet, *pickSheet, *banSheet, *draftSheet}
+}
+
+// countDraft menambah jumlah pick atau ban hero di fase sesuai slot: slot
+// 0-2 fase pertama, sisanya fase kedua.
+func countDraft(drafts []*heroDraft, key string, teamID, heroID uint, slot int) []*heroDraft {
+	phase := 0
+	if slot >= 3 {
+		phase = 1
+	}
+	for _, draft := range drafts {
+		if draft.teamID == teamID && draft.heroID == heroID {
+			draft.phases[phase]++
+			return drafts
+		}
+	}
+	draft := &heroDraft{key: key, teamID: teamID, heroID: heroID}
+	draft.phases[phase]++
+	return append(drafts, draft)
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"ml-master-data/dto"
+	"ml-master-data/repositories"
+)
+
+type MasterDataService struct {
+	masterData repositories.MasterDataRepository
+}
+
+func NewMasterDataService(masterData repositories.MasterDataRepository) *MasterDataService {
+	return &MasterDataService{masterData: masterData}
+}
+
+func (s *MasterDataService) Export(ctx context.Context) (dto.MasterDataDto, error) {
+	return s.masterData.Export(ctx)
+}
+
+// Import memvalidasi seluruh data sebelum menyimpannya, sehingga data yang
+// tidak valid tidak tersimpan sebagian.
+func (s *MasterDataService) Import(ctx context.Context, data dto.MasterDataDto) (repositories.MasterDataImport, error) {
+	if data.Version != dto.MasterDataVersion {
+		return repositories.MasterDataImport{}, &ValidationError{Message: fmt.Sprintf("Unsupported master data version %d", data.Version)}
+	}
+
+	for i, hero := range data.Heroes {
+		if hero.Name == "" {
+			return repositories.MasterDataImport{}, &ValidationError{Message: fmt.Sprintf("Hero #%d has no name", i+1)}
+		}
+	}
+	for i, team := range data.Teams {
+		if team.Name == "" {
+			return repositories.MasterDataImport{}, &ValidationError{Message: fmt.Sprintf("Team #%d has no name", i+1)}
+		}
+		for j, player := range team.Players {
+			if player.Name == "" {
+				return repositories.MasterDataImport{}, &ValidationError{Message: fmt.Sprintf("Player #%d of team %q has no name", j+1, team.Name)}
+			}
+		}
+		for j, coach := range team.Coaches {
+			if coach.Name == "" {
+				return repositories.MasterDataImport{}, &ValidationError{Message: fmt.Sprintf("Coach #%d of team %q has no name", j+1, team.Name)}
+			}
+		}
+	}
+
+	return s.masterData.Import(ctx, data)
+}
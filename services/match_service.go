@@ -1,113 +1,183 @@
 package services
 
 import (
-	"log"
+	"context"
+	"errors"
+	"time"
 
+	"ml-master-data/dto"
 	"ml-master-data/models"
-
-	"gorm.io/gorm"
+	"ml-master-data/repositories"
 )
 
-// Fungsi untuk menghapus Match dan semua relasi terkait
-func DeleteMatch(db *gorm.DB, matchID uint) error {
-	// Mulai transaksi
-	tx := db.Begin()
-	if tx.Error != nil {
-		return tx.Error
+type MatchService struct {
+	matches     repositories.MatchRepository
+	tournaments repositories.TournamentRepository
+	teams       repositories.TeamRepository
+	events      *EventBus
+}
+
+func NewMatchService(matches repositories.MatchRepository, tournaments repositories.TournamentRepository, teams repositories.TeamRepository, events *EventBus) *MatchService {
+	return &MatchService{matches: matches, tournaments: tournaments, teams: teams, events: events}
+}
+
+func (s *MatchService) GetByID(ctx context.Context, matchID uint) (dto.MatchResponseDto, error) {
+	match, err := s.matches.FindDetail(ctx, matchID)
+	return match, notFound(err, "Match")
+}
+
+func (s *MatchService) GetByTournament(ctx context.Context, tournamentID uint) ([]dto.MatchResponseDto, error) {
+	if _, err := s.tournaments.FindByID(ctx, tournamentID); err != nil {
+		return nil, notFound(err, "Tournament")
 	}
+	return s.matches.FindByTournament(ctx, tournamentID)
+}
 
-	// 1. Hapus semua Game terkait dengan Match ini
-	var games []models.Game
-	if err := tx.Where("match_id = ?", matchID).Find(&games).Error; err != nil {
-		tx.Rollback()
-		return err
+// GetTeams mengembalikan Team A dan Team B sebuah Match; tim yang sudah
+// dihapus dilewati.
+func (s *MatchService) GetTeams(ctx context.Context, matchID uint) ([]models.Team, error) {
+	match, err := s.matches.FindByID(ctx, matchID)
+	if err != nil {
+		return nil, notFound(err, "Match")
 	}
 
-	// Panggil DeleteGame untuk setiap Game terkait
-	for _, game := range games {
-		if err := DeleteGame(db, game); err != nil {
-			tx.Rollback()
-			return err
+	teams := []models.Team{}
+	for _, teamID := range []uint{match.TeamAID, match.TeamBID} {
+		if len(teams) > 0 && teams[0].TeamID == teamID {
+			continue
 		}
+		team, err := s.teams.FindByID(ctx, teamID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
 	}
+	return teams, nil
+}
 
-	// 5. Hapus HeroPick dan HeroPickGame terkait
-	if err := tx.Where("hero_pick_id IN (SELECT hero_pick_id FROM hero_picks WHERE match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?))", matchID).
-		Delete(&models.HeroPickGame{}).Error; err != nil {
-		tx.Rollback()
-		return err
+// Create membuat Match di Tournament tournamentID antara Team A dan Team B.
+func (s *MatchService) Create(ctx context.Context, tournamentID uint, input dto.MatchRequestDto) (models.Match, error) {
+	if _, err := s.tournaments.FindByID(ctx, tournamentID); err != nil {
+		return models.Match{}, notFound(err, "Tournament")
 	}
-
-	if err := tx.Where("match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?)", matchID).
-		Delete(&models.HeroPick{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	if err := s.requireTeams(ctx, input.TeamAID, input.TeamBID); err != nil {
+		return models.Match{}, err
 	}
 
-	// 6. Hapus HeroBan dan HeroBanGame terkait
-
-	if err := tx.Where("hero_ban_id IN (SELECT hero_ban_id FROM hero_bans WHERE match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?))", matchID).
-		Delete(&models.HeroBanGame{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	playedAt, err := parsePlayedAt(input.PlayedAt)
+	if err != nil {
+		return models.Match{}, err
 	}
 
-	if err := tx.Where("match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?)", matchID).
-		Delete(&models.HeroBan{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	match := models.Match{
+		TournamentID: tournamentID,
+		PlayedAt:     playedAt,
+		Stage:        *input.Stage,
+		Day:          *input.Day,
+		Date:         *input.Date,
+		TeamAID:      *input.TeamAID,
+		TeamBID:      *input.TeamBID,
+		TeamAScore:   *input.TeamAScore,
+		TeamBScore:   *input.TeamBScore,
 	}
 
-	// 4. Hapus FlexPick terkait
-	if err := tx.Where("match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?)", matchID).
-		Delete(&models.FlexPick{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	if err := s.matches.Create(ctx, &match); err != nil {
+		return match, err
 	}
+	s.events.Publish(NewEvent(EventMatch, EventCreated, match, match.MatchID, match))
+	return match, nil
+}
 
-	// 7. Hapus PriorityPick dan PriorityBan terkait
-	if err := tx.Where("match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?)", matchID).
-		Delete(&models.PriorityPick{}).Error; err != nil {
-		tx.Rollback()
-		return err
+// Update hanya mengganti field yang diisi pada input.
+func (s *MatchService) Update(ctx context.Context, matchID uint, input dto.MatchRequestDto) (models.Match, error) {
+	match, err := s.matches.FindByID(ctx, matchID)
+	if err != nil {
+		return match, notFound(err, "Match")
 	}
-	if err := tx.Where("match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?)", matchID).
-		Delete(&models.PriorityBan{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	if err := s.requireTeams(ctx, input.TeamAID, input.TeamBID); err != nil {
+		return match, err
 	}
 
-	// 3. Hapus CoachMatch terkait
-	if err := tx.Where("match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?)", matchID).
-		Delete(&models.CoachMatch{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	if input.TeamAID != nil {
+		match.TeamAID = *input.TeamAID
 	}
-
-	// 8. Hapus PlayerMatch terkait
-	if err := tx.Where("match_team_detail_id IN (SELECT match_team_detail_id FROM match_team_details WHERE match_id = ?)", matchID).
-		Delete(&models.PlayerMatch{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	if input.TeamBID != nil {
+		match.TeamBID = *input.TeamBID
+	}
+	if input.Stage != nil {
+		match.Stage = *input.Stage
+	}
+	if input.Day != nil {
+		match.Day = *input.Day
+	}
+	if input.Date != nil {
+		match.Date = *input.Date
+	}
+	if input.PlayedAt != nil {
+		if match.PlayedAt, err = parsePlayedAt(input.PlayedAt); err != nil {
+			return match, err
+		}
+	}
+	if input.TeamAScore != nil {
+		match.TeamAScore = *input.TeamAScore
+	}
+	if input.TeamBScore != nil {
+		match.TeamBScore = *input.TeamBScore
 	}
 
-	// 2. Hapus MatchTeamDetail terkait
-	if err := tx.Where("match_id = ?", matchID).Delete(&models.MatchTeamDetail{}).Error; err != nil {
-		tx.Rollback()
-		return err
+	if err := s.matches.Update(ctx, &match); err != nil {
+		return match, err
 	}
+	s.events.Publish(NewEvent(EventMatch, EventUpdated, match, match.MatchID, match))
+	return match, nil
+}
 
-	// 9. Hapus Match itu sendiri
-	if err := tx.Delete(&models.Match{}, matchID).Error; err != nil {
-		tx.Rollback()
+func (s *MatchService) Delete(ctx context.Context, matchID uint) error {
+	match, err := s.matches.FindByID(ctx, matchID)
+	if err != nil {
+		return notFound(err, "Match")
+	}
+	if err := s.matches.Delete(ctx, matchID); err != nil {
 		return err
 	}
+	s.events.Publish(NewEvent(EventMatch, EventDeleted, match, matchID, nil))
+	return nil
+}
 
-	// Commit transaksi
-	if err := tx.Commit().Error; err != nil {
-		return err
+func (s *MatchService) Restore(ctx context.Context, matchID uint) error {
+	if err := s.matches.Restore(ctx, matchID); err != nil {
+		return restoreError(err, "Match")
+	}
+	// Match sudah dipulihkan; event dilewati jika datanya gagal dibaca ulang
+	if match, err := s.matches.FindByID(ctx, matchID); err == nil {
+		s.events.Publish(NewEvent(EventMatch, EventRestored, match, matchID, match))
 	}
+	return nil
+}
 
-	log.Printf("Match with ID %d and all related records have been deleted.", matchID)
+// requireTeams memastikan Team A dan Team B yang diisi memang ada.
+func (s *MatchService) requireTeams(ctx context.Context, teamAID, teamBID *uint) error {
+	if teamAID != nil {
+		if _, err := s.teams.FindByID(ctx, *teamAID); err != nil {
+			return notFound(err, "Team A")
+		}
+	}
+	if teamBID != nil {
+		if _, err := s.teams.FindByID(ctx, *teamBID); err != nil {
+			return notFound(err, "Team B")
+		}
+	}
 	return nil
 }
+
+// parsePlayedAt membaca played_at dari request; nil dan string kosong
+// berarti tanggal match tidak diketahui.
+func parsePlayedAt(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return parseDate(*value, "played_at")
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"ml-master-data/imaging"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/storage"
+	"ml-master-data/utils"
+)
+
+// MediaService menangani upload gambar untuk semua entity dan merekonsiliasi
+// objek di storage dengan referensi gambar di database.
+type MediaService struct {
+	media repositories.MediaRepository
+}
+
+func NewMediaService(media repositories.MediaRepository) *MediaService {
+	return &MediaService{media: media}
+}
+
+// UploadPolicy mengatur upload gambar untuk satu jenis entity.
+type UploadPolicy struct {
+	// Kind adalah jenis entity, sekaligus awalan key objek di storage.
+	Kind string
+	// MaxSize adalah ukuran file sumber maksimum dalam byte.
+	MaxSize int64
+}
+
+// Policy upload untuk setiap entity. Screenshot draft jauh lebih besar dari
+// foto atau logo sehingga batasnya lebih longgar.
+var (
+	HeroUploads   = UploadPolicy{Kind: "hero", MaxSize: 2 << 20}
+	TeamUploads   = UploadPolicy{Kind: "team", MaxSize: 2 << 20}
+	PlayerUploads = UploadPolicy{Kind: "player", MaxSize: 2 << 20}
+	CoachUploads  = UploadPolicy{Kind: "coach", MaxSize: 2 << 20}
+	DraftUploads  = UploadPolicy{Kind: "draft", MaxSize: 8 << 20}
+)
+
+// UploadPolicies berisi semua policy menurut Kind.
+var UploadPolicies = map[string]UploadPolicy{
+	HeroUploads.Kind:   HeroUploads,
+	TeamUploads.Kind:   TeamUploads,
+	PlayerUploads.Kind: PlayerUploads,
+	CoachUploads.Kind:  CoachUploads,
+	DraftUploads.Kind:  DraftUploads,
+}
+
+// TooLarge adalah error untuk file yang melebihi MaxSize.
+func (p UploadPolicy) TooLarge() error {
+	return &ValidationError{Message: fmt.Sprintf("File size must not exceed %d MB", p.MaxSize>>20)}
+}
+
+// Store memproses gambar dari r lewat pipeline imaging lalu menyimpan semua
+// variannya di bawah key unik berawalan policy.Kind. Image yang
+// dikembalikan menunjuk varian full.
+func (s *MediaService) Store(ctx context.Context, policy UploadPolicy, r io.Reader) (models.Image, error) {
+	// Body dibaca paling banyak MaxSize+1 byte sehingga upload yang terlalu
+	// besar berhenti tanpa dibaca seluruhnya.
+	data, err := io.ReadAll(io.LimitReader(r, policy.MaxSize+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) > policy.MaxSize {
+		return "", policy.TooLarge()
+	}
+
+	variants, err := imaging.Process(bytes.NewReader(data))
+	switch {
+	case errors.Is(err, imaging.ErrUnsupported):
+		return "", &ValidationError{Message: "Invalid file type"}
+	case errors.Is(err, imaging.ErrTooLarge):
+		return "", &ValidationError{Message: "Image dimensions are too large"}
+	case err != nil:
+		return "", err
+	}
+
+	image := models.Image(utils.GenerateUniqueFileName(policy.Kind) + "/" + imaging.Full + imaging.Ext)
+	for _, variant := range variants {
+		key := string(image.Variant(variant.Name))
+		if err := s.media.PutObject(ctx, key, bytes.NewReader(variant.Data), imaging.ContentType); err != nil {
+			// Varian yang sudah tersimpan dibersihkan agar tidak jadi yatim.
+			_ = s.Remove(ctx, image)
+			return "", fmt.Errorf("failed to store image: %w", err)
+		}
+	}
+	return image, nil
+}
+
+// Upload menyimpan gambar seperti Store lalu mencatatnya sebagai media yang
+// menunggu dipasang ke entity lewat ID-nya.
+func (s *MediaService) Upload(ctx context.Context, policy UploadPolicy, r io.Reader) (models.Media, error) {
+	image, err := s.Store(ctx, policy, r)
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	media := models.Media{Kind: policy.Kind, Image: image}
+	if err := s.media.Create(ctx, &media); err != nil {
+		_ = s.Remove(ctx, image)
+		return models.Media{}, err
+	}
+	return media, nil
+}
+
+// Take memakai media hasil Upload untuk entity dengan policy tersebut dan
+// mengembalikan gambarnya. Media hanya bisa dipakai sekali.
+func (s *MediaService) Take(ctx context.Context, id uint, policy UploadPolicy) (models.Image, error) {
+	media, err := s.media.Take(ctx, id, policy.Kind)
+	if err != nil {
+		return "", notFound(err, "Media")
+	}
+	return media.Image, nil
+}
+
+// Remove menghapus semua varian gambar dari storage. Gambar eksternal
+// seperti placeholder diabaikan.
+func (s *MediaService) Remove(ctx context.Context, image models.Image) error {
+	for _, key := range image.VariantKeys() {
+		if err := s.media.DeleteObject(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MediaGCOptions mengatur CollectGarbage.
+type MediaGCOptions struct {
+	// Delete menghapus objek yatim, upload kedaluwarsa dan mengganti link
+	// yang putus. Jika false, CollectGarbage hanya melaporkan.
+	Delete bool
+	// MinAge melindungi objek yang baru diupload tetapi datanya belum
+	// tersimpan: objek yang lebih muda tidak dianggap yatim. Upload lewat
+	// POST /media yang tidak dipasang selama MinAge kedaluwarsa.
+	MinAge time.Duration
+}
+
+// MediaReport adalah hasil CollectGarbage.
+type MediaReport struct {
+	Objects    int                     `json:"objects"`
+	References int                     `json:"references"`
+	Orphans    []storage.Object        `json:"orphans"`
+	Dangling   []repositories.ImageRef `json:"dangling"`
+	Deleted    int                     `json:"deleted"`
+	Reset      int                     `json:"reset"`
+	Expired    int                     `json:"expired"`
+}
+
+// CollectGarbage mencari objek storage yang tidak dirujuk database (yatim)
+// dan referensi gambar yang objeknya tidak ada (link putus), lalu
+// membersihkannya jika options.Delete.
+func (s *MediaService) CollectGarbage(ctx context.Context, options MediaGCOptions) (MediaReport, error) {
+	cutoff := time.Now().Add(-options.MinAge)
+
+	// Upload yang tidak pernah dipasang dihapus lebih dulu agar objeknya
+	// ikut terhapus sebagai yatim di putaran yang sama.
+	expired := 0
+	if options.Delete {
+		var err error
+		if expired, err = s.media.ExpireBefore(ctx, cutoff); err != nil {
+			return MediaReport{}, err
+		}
+	}
+
+	// Referensi dibaca sebelum objek: upload selalu disimpan ke storage
+	// sebelum datanya, jadi referensi baru tidak akan terlihat putus.
+	refs, err := s.media.ImageRefs(ctx)
+	if err != nil {
+		return MediaReport{}, err
+	}
+	objects, err := s.media.Objects(ctx)
+	if err != nil {
+		return MediaReport{}, err
+	}
+	report := MediaReport{Objects: len(objects), References: len(refs), Expired: expired}
+
+	exists := make(map[string]bool, len(objects))
+	for _, object := range objects {
+		exists[object.Key] = true
+	}
+
+	// Referensi dengan varian yang hilang dianggap putus; varian lain
+	// miliknya ikut menjadi yatim agar terhapus bersama.
+	referenced := map[string]bool{}
+	for _, ref := range refs {
+		keys := ref.Image.VariantKeys()
+		if keys == nil {
+			continue
+		}
+		complete := true
+		for _, key := range keys {
+			complete = complete && exists[key]
+		}
+		if !complete {
+			report.Dangling = append(report.Dangling, ref)
+			continue
+		}
+		for _, key := range keys {
+			referenced[key] = true
+		}
+	}
+
+	for _, object := range objects {
+		if !referenced[object.Key] && object.ModTime.Before(cutoff) {
+			report.Orphans = append(report.Orphans, object)
+		}
+	}
+
+	if !options.Delete {
+		return report, nil
+	}
+
+	var errs []error
+	for _, ref := range report.Dangling {
+		reset, err := s.media.ResetImage(ctx, ref)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reset %s %d: %w", ref.Table, ref.ID, err))
+			continue
+		}
+		if reset {
+			report.Reset++
+		}
+	}
+	for _, object := range report.Orphans {
+		if err := s.media.DeleteObject(ctx, object.Key); err != nil {
+			errs = append(errs, fmt.Errorf("delete %s: %w", object.Key, err))
+			continue
+		}
+		report.Deleted++
+	}
+	return report, errors.Join(errs...)
+}
+
+// Start menjalankan CollectGarbage di background setiap interval dan
+// mencatat ringkasannya di log.
+func (s *MediaService) Start(interval time.Duration, options MediaGCOptions) {
+	ctx := WithAuditActor(context.Background(), models.User{Username: "media-gc-job"})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			report, err := s.CollectGarbage(ctx, options)
+			if err != nil {
+				log.Printf("Media GC job gagal: %v", err)
+			}
+			log.Printf("Media GC: %d objek, %d referensi, %d yatim (%d dihapus), %d link putus (%d direset), %d upload kedaluwarsa",
+				report.Objects, report.References, len(report.Orphans), report.Deleted, len(report.Dangling), report.Reset, report.Expired)
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// nameMatcher mencocokkan nama dari sumber luar, misalnya "onic ph" atau
+// "Yi Sun-shin", dengan team, pemain atau hero yang ada.
+type nameMatcher struct {
+	// ignore berisi kata yang dibuang saat normalisasi, misalnya "esports"
+	// pada nama team.
+	ignore map[string]bool
+	names  map[uint]string
+	exact  map[string][]uint
+	alias  map[string][]uint
+	loose  map[string][]uint
+}
+
+// Cara nama dicocokkan, dari yang paling ketat. Nama yang hanya cocok
+// secara fuzzy tidak pernah dianggap pasti.
+const (
+	matchExact      = "exact"
+	matchAlias      = "alias"
+	matchNormalized = "normalized"
+	matchFuzzy      = "fuzzy"
+	// matchPartial hanya dipakai pencarian: query adalah potongan nama.
+	matchPartial = "partial"
+)
+
+// Status hasil pencocokan nama.
+const (
+	nameMatched   = "matched"
+	nameAmbiguous = "ambiguous"
+	nameUnknown   = "unknown"
+)
+
+// nameCandidate adalah id yang mungkin dimaksud sebuah nama. score bernilai
+// 1 untuk nama yang cocok pasti dan makin kecil untuk nama yang makin
+// berbeda.
+type nameCandidate struct {
+	id     uint
+	method string
+	score  float64
+}
+
+// nameResolution adalah hasil resolve: id jika status nameMatched, dan
+// kandidat jika tidak.
+type nameResolution struct {
+	status     string
+	match      nameCandidate
+	candidates []nameCandidate
+}
+
+func newNameMatcher(ignore ...string) *nameMatcher {
+	m := &nameMatcher{ignore: map[string]bool{}, names: map[uint]string{}, exact: map[string][]uint{}, alias: map[string][]uint{}, loose: map[string][]uint{}}
+	for _, word := range ignore {
+		m.ignore[word] = true
+	}
+	return m
+}
+
+// add mendaftarkan nama utama id beserta nama lainnya.
+func (m *nameMatcher) add(id uint, name string, aliases ...string) {
+	m.names[id] = name
+	m.exact[strings.ToLower(name)] = appendID(m.exact[strings.ToLower(name)], id)
+	for _, alias := range aliases {
+		m.alias[strings.ToLower(alias)] = appendID(m.alias[strings.ToLower(alias)], id)
+	}
+	for _, value := range append([]string{name}, aliases...) {
+		if key := m.normalize(value); key != "" {
+			m.loose[key] = appendID(m.loose[key], id)
+		}
+	}
+}
+
+func appendID(ids []uint, id uint) []uint {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+// match mengembalikan id yang cocok pasti dengan name dan caranya.
+func (m *nameMatcher) match(name string) (uint, string, bool) {
+	r := m.resolve(name)
+	return r.match.id, r.match.method, r.status == nameMatched
+}
+
+// resolve mencocokkan name lewat nama, alias lalu normalisasi. Nama yang
+// cocok dengan lebih dari satu id di langkah yang sama ambigu, dan
+// kandidatnya adalah id tersebut; nama yang tidak cocok sama sekali
+// mendapat kandidat dari pencocokan fuzzy.
+func (m *nameMatcher) resolve(name string) nameResolution {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	for _, step := range []struct {
+		method string
+		ids    []uint
+	}{
+		{matchExact, m.exact[lower]},
+		{matchAlias, m.alias[lower]},
+		{matchNormalized, m.loose[m.normalize(name)]},
+	} {
+		if len(step.ids) == 1 {
+			return nameResolution{status: nameMatched, match: nameCandidate{step.ids[0], step.method, 1}}
+		}
+		if len(step.ids) > 1 {
+			r := nameResolution{status: nameAmbiguous}
+			for _, id := range step.ids {
+				r.candidates = append(r.candidates, nameCandidate{id, step.method, 1})
+			}
+			sort.Slice(r.candidates, func(i, j int) bool { return m.names[r.candidates[i].id] < m.names[r.candidates[j].id] })
+			return r
+		}
+	}
+
+	r := nameResolution{status: nameUnknown, candidates: m.fuzzy(name)}
+	if len(r.candidates) > 0 {
+		r.status = nameAmbiguous
+	}
+	return r
+}
+
+// normalize menulis name dengan huruf kecil tanpa spasi dan tanda baca,
+// tanpa kata di ignore kecuali semua katanya ada di ignore.
+func (m *nameMatcher) normalize(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var kept []string
+	for _, word := range words {
+		if !m.ignore[word] {
+			kept = append(kept, word)
+		}
+	}
+	if len(kept) == 0 {
+		kept = words
+	}
+	return strings.Join(kept, "")
+}
+
+// fuzzy mengembalikan id yang nama atau aliasnya mirip name: salah satunya
+// memuat yang lain dan panjangnya minimal tiga huruf, misalnya "RRQ" dan
+// "RRQ Hoshi", atau jarak edit-nya paling banyak sepertiga panjang name.
+// Yang paling mirip lebih dulu.
+func (m *nameMatcher) fuzzy(name string) []nameCandidate {
+	key := m.normalize(name)
+	if key == "" {
+		return nil
+	}
+	type rank struct {
+		contains bool
+		distance int
+		score    float64
+	}
+	ranks := map[uint]rank{}
+	for candidate, ids := range m.loose {
+		distance := levenshtein(key, candidate)
+		contains := min(len(key), len(candidate)) >= 3 && (strings.Contains(candidate, key) || strings.Contains(key, candidate))
+		if !contains && distance > len(key)/3+1 {
+			continue
+		}
+		longest := max(len([]rune(key)), len([]rune(candidate)))
+		r := rank{contains, distance, math.Round((1-float64(distance)/float64(longest))*100) / 100}
+		for _, id := range ids {
+			if best, ok := ranks[id]; !ok || better(r.contains, r.distance, best.contains, best.distance) {
+				ranks[id] = r
+			}
+		}
+	}
+
+	candidates := make([]nameCandidate, 0, len(ranks))
+	for id, r := range ranks {
+		candidates = append(candidates, nameCandidate{id, matchFuzzy, r.score})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := ranks[candidates[i].id], ranks[candidates[j].id]
+		if a != b {
+			return better(a.contains, a.distance, b.contains, b.distance)
+		}
+		return m.names[candidates[i].id] < m.names[candidates[j].id]
+	})
+	return candidates
+}
+
+// better membandingkan dua kandidat fuzzy: yang memuat nama lebih dulu,
+// lalu yang jarak edit-nya lebih kecil.
+func better(contains bool, distance int, otherContains bool, otherDistance int) bool {
+	if contains != otherContains {
+		return contains
+	}
+	return distance < otherDistance
+}
+
+// suggest mengembalikan paling banyak tiga nama yang mirip dengan name.
+func (m *nameMatcher) suggest(name string) []string {
+	var names []string
+	for _, candidate := range m.resolve(name).candidates {
+		if len(names) == 3 {
+			break
+		}
+		names = append(names, m.names[candidate.id])
+	}
+	return names
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
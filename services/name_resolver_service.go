@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// teamNameNoise adalah kata yang diabaikan saat mencocokkan nama team,
+// sehingga "ONIC Esports" cocok dengan "ONIC".
+var teamNameNoise = []string{"esports", "esport", "gaming", "team", "club"}
+
+// maxSearchResults membatasi jumlah hasil GET /search.
+const maxSearchResults = 20
+
+// NameResolverService mencocokkan nama team, pemain dan hero dari import
+// atau pencarian dengan nama dan alias yang ada, dan mengelola antrean
+// review untuk nama yang tidak bisa dicocokkan dengan pasti.
+type NameResolverService struct {
+	names repositories.NameRepository
+}
+
+func NewNameResolverService(names repositories.NameRepository) *NameResolverService {
+	return &NameResolverService{names: names}
+}
+
+// nameMatchers adalah nameMatcher satu kind per tim; team dan hero hanya
+// memakai tim 0.
+type nameMatchers struct {
+	kind     string
+	byTeam   map[uint]*nameMatcher
+	entities map[uint]repositories.NamedEntity
+}
+
+// of mengembalikan matcher tim teamID, atau matcher kosong.
+func (n *nameMatchers) of(teamID uint) *nameMatcher {
+	if m := n.byTeam[teamID]; m != nil {
+		return m
+	}
+	return newNameMatcher()
+}
+
+// candidate mengubah c menjadi NameCandidateDto.
+func (n *nameMatchers) candidate(c nameCandidate) dto.NameCandidateDto {
+	entity := n.entities[c.id]
+	return dto.NameCandidateDto{Kind: n.kind, ID: c.id, Name: entity.Name, TeamID: entity.TeamID, Method: c.method, Score: c.score}
+}
+
+// resolution mengubah hasil resolve name menjadi NameResolutionDto.
+func (n *nameMatchers) resolution(name string, teamID uint, r nameResolution) dto.NameResolutionDto {
+	result := dto.NameResolutionDto{Kind: n.kind, Name: name, TeamID: teamID, Status: r.status, Candidates: []dto.NameCandidateDto{}}
+	if r.status == nameMatched {
+		match := n.candidate(r.match)
+		result.Match = &match
+	}
+	for _, c := range r.candidates {
+		result.Candidates = append(result.Candidates, n.candidate(c))
+	}
+	return result
+}
+
+func (n *nameMatchers) resolve(name string, teamID uint) dto.NameResolutionDto {
+	return n.resolution(name, teamID, n.of(teamID).resolve(name))
+}
+
+// matchers membaca semua entity kind dan membuat matcher-nya.
+func (s *NameResolverService) matchers(ctx context.Context, kind string) (*nameMatchers, error) {
+	entities, err := s.names.FindEntities(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	n := &nameMatchers{kind: kind, byTeam: map[uint]*nameMatcher{}, entities: map[uint]repositories.NamedEntity{}}
+	for _, entity := range entities {
+		m := n.byTeam[entity.TeamID]
+		if m == nil {
+			if kind == models.NameKindTeam {
+				m = newNameMatcher(teamNameNoise...)
+			} else {
+				m = newNameMatcher()
+			}
+			n.byTeam[entity.TeamID] = m
+		}
+		m.add(entity.ID, entity.Name, entity.Aliases...)
+		n.entities[entity.ID] = entity
+	}
+	return n, nil
+}
+
+// Resolve mencocokkan name dengan team, pemain atau hero. Nama pemain
+// dicocokkan di tim teamID, atau di semua tim jika teamID 0.
+func (s *NameResolverService) Resolve(ctx context.Context, kind, name string, teamID uint) (dto.NameResolutionDto, error) {
+	n, err := s.matchers(ctx, kind)
+	if err != nil {
+		return dto.NameResolutionDto{}, err
+	}
+	name = strings.TrimSpace(name)
+	if kind != models.NameKindPlayer || teamID != 0 {
+		return n.resolve(name, teamID), nil
+	}
+
+	// Pemain di semua tim: hasil pasti dari lebih dari satu tim ambigu
+	var matched []dto.NameCandidateDto
+	result := dto.NameResolutionDto{Kind: kind, Name: name, Status: nameUnknown, Candidates: []dto.NameCandidateDto{}}
+	for _, team := range sortedTeams(n.byTeam) {
+		r := n.resolve(name, team)
+		if r.Match != nil {
+			matched = append(matched, *r.Match)
+		}
+		result.Candidates = append(result.Candidates, r.Candidates...)
+	}
+	switch {
+	case len(matched) == 1:
+		result.Status, result.Match, result.Candidates = nameMatched, &matched[0], []dto.NameCandidateDto{}
+	case len(matched) > 1:
+		result.Status, result.Candidates = nameAmbiguous, matched
+	case len(result.Candidates) > 0:
+		result.Status = nameAmbiguous
+		sortCandidates(result.Candidates)
+	}
+	return result, nil
+}
+
+func sortedTeams(byTeam map[uint]*nameMatcher) []uint {
+	teams := make([]uint, 0, len(byTeam))
+	for team := range byTeam {
+		teams = append(teams, team)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i] < teams[j] })
+	return teams
+}
+
+// sortCandidates mengurutkan kandidat dari skor tertinggi, lalu nama dan
+// ID.
+func sortCandidates(candidates []dto.NameCandidateDto) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].Name != candidates[j].Name {
+			return candidates[i].Name < candidates[j].Name
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+}
+
+// Search mencari team, pemain dan hero yang nama atau aliasnya cocok atau
+// mirip dengan query, dari yang paling mirip. kind kosong mencari di semua
+// jenis.
+func (s *NameResolverService) Search(ctx context.Context, query, kind string) ([]dto.NameCandidateDto, error) {
+	kinds := models.NameKinds
+	if kind != "" {
+		kinds = []string{kind}
+	}
+	query = strings.TrimSpace(query)
+	lower := strings.ToLower(query)
+
+	results := []dto.NameCandidateDto{}
+	for _, kind := range kinds {
+		n, err := s.matchers(ctx, kind)
+		if err != nil {
+			return nil, err
+		}
+		seen := map[uint]bool{}
+		add := func(c dto.NameCandidateDto) {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				results = append(results, c)
+			}
+		}
+		for _, team := range sortedTeams(n.byTeam) {
+			r := n.resolve(query, team)
+			if r.Match != nil {
+				add(*r.Match)
+			}
+			for _, c := range r.Candidates {
+				add(c)
+			}
+		}
+		// Potongan nama juga dicari, seperti filter q di GET /heroes
+		for _, entity := range n.entities {
+			for _, name := range append([]string{entity.Name}, entity.Aliases...) {
+				if strings.Contains(strings.ToLower(name), lower) {
+					score := math.Round(float64(len([]rune(query)))/float64(len([]rune(name)))*100) / 100
+					add(dto.NameCandidateDto{Kind: kind, ID: entity.ID, Name: entity.Name, TeamID: entity.TeamID, Method: matchPartial, Score: score})
+					break
+				}
+			}
+		}
+	}
+
+	sortCandidates(results)
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+	return results, nil
+}
+
+// Aliases mengembalikan alias team atau pemain.
+func (s *NameResolverService) Aliases(ctx context.Context, kind string, id uint) ([]string, error) {
+	entity, err := s.names.FindEntity(ctx, kind, id)
+	if err != nil {
+		return nil, notFound(err, entityName(kind))
+	}
+	if entity.Aliases == nil {
+		return []string{}, nil
+	}
+	return entity.Aliases, nil
+}
+
+// SetAliases mengganti alias team atau pemain. Alias kosong, duplikat dan
+// yang sama dengan namanya sendiri dibuang; alias yang sudah dipakai
+// sebagai nama atau alias entity lain ditolak.
+func (s *NameResolverService) SetAliases(ctx context.Context, kind string, id uint, aliases []string) ([]string, error) {
+	entity, err := s.names.FindEntity(ctx, kind, id)
+	if err != nil {
+		return nil, notFound(err, entityName(kind))
+	}
+	n, err := s.matchers(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	cleaned := []string{}
+	seen := map[string]bool{strings.ToLower(entity.Name): true}
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		if len(alias) > 100 {
+			return nil, &ValidationError{Message: fmt.Sprintf("Alias %q is longer than 100 characters", alias)}
+		}
+		if err := n.checkFree(alias, entity); err != nil {
+			return nil, err
+		}
+		cleaned = append(cleaned, alias)
+	}
+
+	if err := s.names.SetAliases(ctx, kind, id, cleaned); err != nil {
+		return nil, err
+	}
+	return cleaned, nil
+}
+
+// checkFree mengembalikan ConflictError jika name adalah nama atau alias
+// entity lain di tim yang sama dengan entity.
+func (n *nameMatchers) checkFree(name string, entity repositories.NamedEntity) error {
+	m := n.of(entity.TeamID)
+	lower := strings.ToLower(name)
+	for _, ids := range [][]uint{m.exact[lower], m.alias[lower]} {
+		for _, id := range ids {
+			if id != entity.ID {
+				return &ConflictError{Message: fmt.Sprintf("%q is already used by %s %q", name, n.kind, n.entities[id].Name)}
+			}
+		}
+	}
+	return nil
+}
+
+// CheckName mengembalikan ConflictError jika name cocok pasti dengan team
+// atau pemain lain di tim teamID, agar tidak ada entity ganda. id adalah
+// entity yang sedang diubah, atau 0 untuk entity baru.
+func (s *NameResolverService) CheckName(ctx context.Context, kind string, teamID, id uint, name string) error {
+	n, err := s.matchers(ctx, kind)
+	if err != nil {
+		return err
+	}
+	r := n.of(teamID).resolve(name)
+	if r.status != nameMatched || r.match.id == id {
+		return nil
+	}
+	existing := n.entities[r.match.id].Name
+	if r.match.method == matchExact {
+		return &ConflictError{Message: fmt.Sprintf("%s %q already exists", entityName(kind), existing)}
+	}
+	return &ConflictError{Message: fmt.Sprintf("%s %q already exists as %q", entityName(kind), name, existing)}
+}
+
+// Queue memasukkan nama yang tidak cocok pasti di resolutions ke antrean
+// review, dengan source sebagai asal namanya. Setiap nama dihitung sekali
+// per pemanggilan; nama berupa angka dianggap ID yang salah dan tidak
+// dimasukkan.
+func (s *NameResolverService) Queue(ctx context.Context, source string, resolutions []dto.NameResolutionDto) error {
+	seen := map[string]bool{}
+	for _, r := range resolutions {
+		key := fmt.Sprintf("%s/%d/%s", r.Kind, r.TeamID, r.Name)
+		if r.Status == nameMatched || r.Name == "" || len(r.Name) > 100 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if _, err := strconv.ParseUint(r.Name, 10, 64); err == nil {
+			continue
+		}
+		review := models.NameReview{Kind: r.Kind, Name: r.Name, TeamID: r.TeamID, Source: source}
+		if err := s.names.QueueReview(ctx, review); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reviews mengembalikan antrean review beserta kandidat saat ini. status
+// kosong berarti pending dan "all" berarti semua.
+func (s *NameResolverService) Reviews(ctx context.Context, kind, status string) ([]dto.NameReviewDto, error) {
+	switch status {
+	case "":
+		status = models.NameReviewPending
+	case "all":
+		status = ""
+	}
+	reviews, err := s.names.FindReviews(ctx, repositories.NameReviewFilter{Kind: kind, Status: status})
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := map[string]*nameMatchers{}
+	result := make([]dto.NameReviewDto, 0, len(reviews))
+	for _, review := range reviews {
+		n := matchers[review.Kind]
+		if n == nil {
+			if n, err = s.matchers(ctx, review.Kind); err != nil {
+				return nil, err
+			}
+			matchers[review.Kind] = n
+		}
+		r := n.resolve(review.Name, review.TeamID)
+		candidates := r.Candidates
+		if r.Match != nil {
+			candidates = []dto.NameCandidateDto{*r.Match}
+		}
+		result = append(result, dto.NameReviewDto{NameReview: review, Candidates: candidates})
+	}
+	return result, nil
+}
+
+// ResolveReview memutuskan bahwa nama review adalah entityID dan
+// menyimpannya sebagai alias, sehingga import berikutnya langsung cocok.
+func (s *NameResolverService) ResolveReview(ctx context.Context, reviewID, entityID uint) (models.NameReview, error) {
+	review, err := s.names.FindReviewByID(ctx, reviewID)
+	if err != nil {
+		return review, notFound(err, "Name review")
+	}
+	entity, err := s.names.FindEntity(ctx, review.Kind, entityID)
+	if err != nil {
+		return review, notFound(err, entityName(review.Kind))
+	}
+	if review.TeamID != 0 && entity.TeamID != review.TeamID {
+		return review, &ValidationError{Message: fmt.Sprintf("Player %q is not in team %d", entity.Name, review.TeamID)}
+	}
+	n, err := s.matchers(ctx, review.Kind)
+	if err != nil {
+		return review, err
+	}
+	if err := n.checkFree(review.Name, entity); err != nil {
+		return review, err
+	}
+
+	// Nama yang sudah cocok pasti dengan entity tidak perlu alias baru
+	id, _, ok := n.of(entity.TeamID).match(review.Name)
+	alias := !ok || id != entity.ID
+	review.Status = models.NameReviewResolved
+	review.EntityID = &entity.ID
+	if err := s.names.SaveReview(ctx, &review, alias); err != nil {
+		return review, err
+	}
+	return review, nil
+}
+
+// DismissReview menandai nama review bukan team, pemain atau hero yang
+// ada, sehingga tidak masuk antrean lagi.
+func (s *NameResolverService) DismissReview(ctx context.Context, reviewID uint) (models.NameReview, error) {
+	review, err := s.names.FindReviewByID(ctx, reviewID)
+	if err != nil {
+		return review, notFound(err, "Name review")
+	}
+	review.Status = models.NameReviewDismissed
+	review.EntityID = nil
+	if err := s.names.SaveReview(ctx, &review, false); err != nil {
+		return review, err
+	}
+	return review, nil
+}
+
+// entityName adalah nama entity kind di pesan error.
+func entityName(kind string) string {
+	return strings.ToUpper(kind[:1]) + kind[1:]
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// ObjectiveService mengelola hasil lord dan turtle di Game sebuah Match.
+// Setiap perubahan dikirim ke EventBus setelah tersimpan.
+type ObjectiveService struct {
+	objectives repositories.ObjectiveRepository
+	games      repositories.GameRepository
+	matches    repositories.MatchRepository
+	events     *EventBus
+}
+
+func NewObjectiveService(objectives repositories.ObjectiveRepository, games repositories.GameRepository, matches repositories.MatchRepository, events *EventBus) *ObjectiveService {
+	return &ObjectiveService{objectives: objectives, games: games, matches: matches, events: events}
+}
+
+func (s *ObjectiveService) GetLordResults(ctx context.Context, matchID, gameID uint) ([]dto.LordResultResponseDto, error) {
+	if _, err := s.game(ctx, matchID, gameID); err != nil {
+		return nil, err
+	}
+	return s.objectives.FindLordResults(ctx, gameID)
+}
+
+func (s *ObjectiveService) GetLordResult(ctx context.Context, matchID, gameID, lordResultID uint) (dto.LordResultResponseDto, error) {
+	if _, err := s.game(ctx, matchID, gameID); err != nil {
+		return dto.LordResultResponseDto{}, err
+	}
+	result, err := s.objectives.FindLordResultDetail(ctx, gameID, lordResultID)
+	return result, notFound(err, "Lord result")
+}
+
+func (s *ObjectiveService) AddLordResult(ctx context.Context, matchID, gameID uint, input dto.LordResultRequestDto) (models.LordResult, error) {
+	match, err := s.game(ctx, matchID, gameID)
+	if err != nil {
+		return models.LordResult{}, err
+	}
+
+	lordResult := models.LordResult{
+		GameID:   gameID,
+		TeamID:   input.TeamID,
+		Phase:    input.Phase,
+		Setup:    input.Setup,
+		Initiate: input.Initiate,
+		Result:   input.Result,
+	}
+	if err := s.objectives.CreateLordResult(ctx, &lordResult); err != nil {
+		return lordResult, err
+	}
+	s.publish(EventLordResult, EventCreated, match, gameID, lordResult.TeamID, lordResult.LordResultID, lordResult)
+	return lordResult, nil
+}
+
+func (s *ObjectiveService) UpdateLordResult(ctx context.Context, matchID, gameID, lordResultID uint, input dto.LordResultRequestDto) (models.LordResult, error) {
+	match, err := s.game(ctx, matchID, gameID)
+	if err != nil {
+		return models.LordResult{}, err
+	}
+	lordResult, err := s.objectives.FindLordResult(ctx, gameID, lordResultID)
+	if err != nil {
+		return lordResult, notFound(err, "Lord result")
+	}
+
+	lordResult.TeamID = input.TeamID
+	lordResult.Phase = input.Phase
+	lordResult.Setup = input.Setup
+	lordResult.Initiate = input.Initiate
+	lordResult.Result = input.Result
+	if err := s.objectives.UpdateLordResult(ctx, &lordResult); err != nil {
+		return lordResult, err
+	}
+	s.publish(EventLordResult, EventUpdated, match, gameID, lordResult.TeamID, lordResult.LordResultID, lordResult)
+	return lordResult, nil
+}
+
+func (s *ObjectiveService) RemoveLordResult(ctx context.Context, matchID, gameID, lordResultID uint) error {
+	match, err := s.game(ctx, matchID, gameID)
+	if err != nil {
+		return err
+	}
+	lordResult, err := s.objectives.FindLordResult(ctx, gameID, lordResultID)
+	if err != nil {
+		return notFound(err, "Lord result")
+	}
+
+	if err := s.objectives.DeleteLordResult(ctx, lordResult); err != nil {
+		return err
+	}
+	s.publish(EventLordResult, EventDeleted, match, gameID, lordResult.TeamID, lordResult.LordResultID, nil)
+	return nil
+}
+
+func (s *ObjectiveService) GetTurtleResults(ctx context.Context, matchID, gameID uint) ([]dto.TurtleResultResponseDto, error) {
+	if _, err := s.game(ctx, matchID, gameID); err != nil {
+		return nil, err
+	}
+	return s.objectives.FindTurtleResults(ctx, gameID)
+}
+
+func (s *ObjectiveService) GetTurtleResult(ctx context.Context, matchID, gameID, turtleResultID uint) (dto.TurtleResultResponseDto, error) {
+	if _, err := s.game(ctx, matchID, gameID); err != nil {
+		return dto.TurtleResultResponseDto{}, err
+	}
+	result, err := s.objectives.FindTurtleResultDetail(ctx, gameID, turtleResultID)
+	return result, notFound(err, "Turtle result")
+}
+
+func (s *ObjectiveService) AddTurtleResult(ctx context.Context, matchID, gameID uint, input dto.TurtleResultRequestDto) (models.TurtleResult, error) {
+	match, err := s.game(ctx, matchID, gameID)
+	if err != nil {
+		return models.TurtleResult{}, err
+	}
+
+	turtleResult := models.TurtleResult{
+		GameID:   gameID,
+		TeamID:   input.TeamID,
+		Phase:    input.Phase,
+		Setup:    input.Setup,
+		Initiate: input.Initiate,
+		Result:   input.Result,
+	}
+	if err := s.objectives.CreateTurtleResult(ctx, &turtleResult); err != nil {
+		return turtleResult, err
+	}
+	s.publish(EventTurtleResult, EventCreated, match, gameID, turtleResult.TeamID, turtleResult.TurtleResultID, turtleResult)
+	return turtleResult, nil
+}
+
+func (s *ObjectiveService) UpdateTurtleResult(ctx context.Context, matchID, gameID, turtleResultID uint, input dto.TurtleResultRequestDto) (models.TurtleResult, error) {
+	match, err := s.game(ctx, matchID, gameID)
+	if err != nil {
+		return models.TurtleResult{}, err
+	}
+	turtleResult, err := s.objectives.FindTurtleResult(ctx, gameID, turtleResultID)
+	if err != nil {
+		return turtleResult, notFound(err, "Turtle result")
+	}
+
+	turtleResult.TeamID = input.TeamID
+	turtleResult.Phase = input.Phase
+	turtleResult.Setup = input.Setup
+	turtleResult.Initiate = input.Initiate
+	turtleResult.Result = input.Result
+	if err := s.objectives.UpdateTurtleResult(ctx, &turtleResult); err != nil {
+		return turtleResult, err
+	}
+	s.publish(EventTurtleResult, EventUpdated, match, gameID, turtleResult.TeamID, turtleResult.TurtleResultID, turtleResult)
+	return turtleResult, nil
+}
+
+func (s *ObjectiveService) RemoveTurtleResult(ctx context.Context, matchID, gameID, turtleResultID uint) error {
+	match, err := s.game(ctx, matchID, gameID)
+	if err != nil {
+		return err
+	}
+	turtleResult, err := s.objectives.FindTurtleResult(ctx, gameID, turtleResultID)
+	if err != nil {
+		return notFound(err, "Turtle result")
+	}
+
+	if err := s.objectives.DeleteTurtleResult(ctx, turtleResult); err != nil {
+		return err
+	}
+	s.publish(EventTurtleResult, EventDeleted, match, gameID, turtleResult.TeamID, turtleResult.TurtleResultID, nil)
+	return nil
+}
+
+// game memastikan Game gameID berada di Match matchID dan mengembalikan
+// Match-nya untuk event.
+func (s *ObjectiveService) game(ctx context.Context, matchID, gameID uint) (models.Match, error) {
+	match, err := s.matches.FindByID(ctx, matchID)
+	if err != nil {
+		return match, notFound(err, "Match")
+	}
+	if _, err := s.games.FindByID(ctx, matchID, gameID); err != nil {
+		return match, notFound(err, "Game")
+	}
+	return match, nil
+}
+
+// publish mengirim event lord atau turtle result id milik teamID di Game
+// gameID.
+func (s *ObjectiveService) publish(entity, action string, match models.Match, gameID, teamID, id uint, data interface{}) {
+	event := NewEvent(entity, action, match, id, data)
+	event.GameID = gameID
+	event.TeamID = teamID
+	s.events.Publish(event)
+}
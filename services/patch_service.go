@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+type PatchService struct {
+	patches repositories.PatchRepository
+}
+
+func NewPatchService(patches repositories.PatchRepository) *PatchService {
+	return &PatchService{patches: patches}
+}
+
+func (s *PatchService) GetAll(ctx context.Context) ([]models.Patch, error) {
+	return s.patches.FindAll(ctx)
+}
+
+func (s *PatchService) GetByID(ctx context.Context, patchID uint) (models.Patch, error) {
+	patch, err := s.patches.FindByID(ctx, patchID)
+	return patch, notFound(err, "Patch")
+}
+
+// GetByVersion mencari Patch dengan versi persis version.
+func (s *PatchService) GetByVersion(ctx context.Context, version string) (models.Patch, error) {
+	patch, err := s.patches.FindByVersion(ctx, strings.TrimSpace(version))
+	return patch, notFound(err, "Patch")
+}
+
+func (s *PatchService) Create(ctx context.Context, input dto.PatchRequestDto) (models.Patch, error) {
+	patch := models.Patch{Version: strings.TrimSpace(input.Version)}
+	if patch.Version == "" || input.ReleaseDate == "" {
+		return patch, &ValidationError{Message: "Patch version and release date are required"}
+	}
+	if err := s.apply(ctx, &patch, input); err != nil {
+		return patch, err
+	}
+	err := s.patches.Create(ctx, &patch)
+	return patch, err
+}
+
+// Update hanya mengganti field yang diisi pada input. Game yang sudah
+// tercatat di patch ini tidak dipindahkan.
+func (s *PatchService) Update(ctx context.Context, patchID uint, input dto.PatchRequestDto) (models.Patch, error) {
+	patch, err := s.GetByID(ctx, patchID)
+	if err != nil {
+		return patch, err
+	}
+	if version := strings.TrimSpace(input.Version); version != "" {
+		patch.Version = version
+	}
+	if err := s.apply(ctx, &patch, input); err != nil {
+		return patch, err
+	}
+	err = s.patches.Update(ctx, &patch)
+	return patch, err
+}
+
+// apply mengisi tanggal rilis dari input dan memastikan versi patch unik.
+func (s *PatchService) apply(ctx context.Context, patch *models.Patch, input dto.PatchRequestDto) error {
+	releaseDate, err := parseDate(input.ReleaseDate, "release_date")
+	if err != nil {
+		return err
+	}
+	if releaseDate != nil {
+		patch.ReleaseDate = *releaseDate
+	}
+
+	existing, err := s.patches.FindByVersion(ctx, patch.Version)
+	if err == nil && existing.PatchID != patch.PatchID {
+		return &ConflictError{Message: fmt.Sprintf("Patch %s already exists", patch.Version)}
+	}
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Delete hanya menghapus Patch yang belum dipakai Game mana pun.
+func (s *PatchService) Delete(ctx context.Context, patchID uint) error {
+	if _, err := s.GetByID(ctx, patchID); err != nil {
+		return err
+	}
+	games, err := s.patches.CountGames(ctx, patchID)
+	if err != nil {
+		return err
+	}
+	if games > 0 {
+		return &ConflictError{Message: fmt.Sprintf("Patch is used by %d games", games)}
+	}
+	return s.patches.Delete(ctx, patchID)
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// fakePatchRepository menyimpan Patch di memori. games berisi jumlah Game
+// per PatchID.
+type fakePatchRepository struct {
+	patches map[uint]models.Patch
+	games   map[uint]int64
+	nextID  uint
+}
+
+func newFakePatchRepository() *fakePatchRepository {
+	return &fakePatchRepository{patches: map[uint]models.Patch{}, games: map[uint]int64{}}
+}
+
+func (r *fakePatchRepository) FindAll(ctx context.Context) ([]models.Patch, error) {
+	var patches []models.Patch
+	for _, patch := range r.patches {
+		patches = append(patches, patch)
+	}
+	return patches, nil
+}
+
+func (r *fakePatchRepository) FindByID(ctx context.Context, patchID uint) (models.Patch, error) {
+	patch, ok := r.patches[patchID]
+	if !ok {
+		return patch, repositories.ErrNotFound
+	}
+	return patch, nil
+}
+
+func (r *fakePatchRepository) FindByVersion(ctx context.Context, version string) (models.Patch, error) {
+	for _, patch := range r.patches {
+		if patch.Version == version {
+			return patch, nil
+		}
+	}
+	return models.Patch{}, repositories.ErrNotFound
+}
+
+func (r *fakePatchRepository) FindReleasedBy(ctx context.Context, t time.Time) (models.Patch, error) {
+	var found models.Patch
+	for _, patch := range r.patches {
+		if !patch.ReleaseDate.After(t) && patch.ReleaseDate.After(found.ReleaseDate) {
+			found = patch
+		}
+	}
+	if found.PatchID == 0 {
+		return found, repositories.ErrNotFound
+	}
+	return found, nil
+}
+
+func (r *fakePatchRepository) CountGames(ctx context.Context, patchID uint) (int64, error) {
+	return r.games[patchID], nil
+}
+
+func (r *fakePatchRepository) Create(ctx context.Context, patch *models.Patch) error {
+	r.nextID++
+	patch.PatchID = r.nextID
+	r.patches[patch.PatchID] = *patch
+	return nil
+}
+
+func (r *fakePatchRepository) Update(ctx context.Context, patch *models.Patch) error {
+	r.patches[patch.PatchID] = *patch
+	return nil
+}
+
+func (r *fakePatchRepository) Delete(ctx context.Context, patchID uint) error {
+	delete(r.patches, patchID)
+	return nil
+}
+
+func TestPatchService(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakePatchRepository()
+	patches := NewPatchService(repo)
+
+	var validation *ValidationError
+	var conflict *ConflictError
+	var missing *NotFoundError
+
+	if _, err := patches.Create(ctx, dto.PatchRequestDto{Version: " ", ReleaseDate: "2024-01-01"}); !errors.As(err, &validation) {
+		t.Fatalf("empty version: err = %v, want ValidationError", err)
+	}
+	if _, err := patches.Create(ctx, dto.PatchRequestDto{Version: "1.8.0", ReleaseDate: "01/01/2024"}); !errors.As(err, &validation) {
+		t.Fatalf("invalid release date: err = %v, want ValidationError", err)
+	}
+
+	first, err := patches.Create(ctx, dto.PatchRequestDto{Version: " 1.8.0 ", ReleaseDate: "2024-01-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Version != "1.8.0" || first.ReleaseDate.Format("2006-01-02") != "2024-01-01" {
+		t.Fatalf("created = %+v", first)
+	}
+	if _, err := patches.Create(ctx, dto.PatchRequestDto{Version: "1.8.0", ReleaseDate: "2024-02-01"}); !errors.As(err, &conflict) {
+		t.Fatalf("duplicate version: err = %v, want ConflictError", err)
+	}
+
+	second, err := patches.Create(ctx, dto.PatchRequestDto{Version: "1.8.20", ReleaseDate: "2024-03-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Update tanpa version mempertahankan version lama, dan menyimpan
+	// version sendiri bukan konflik
+	updated, err := patches.Update(ctx, second.PatchID, dto.PatchRequestDto{ReleaseDate: "2024-03-02"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Version != "1.8.20" || repo.patches[second.PatchID].ReleaseDate.Format("2006-01-02") != "2024-03-02" {
+		t.Fatalf("updated = %+v", repo.patches[second.PatchID])
+	}
+	if _, err := patches.Update(ctx, second.PatchID, dto.PatchRequestDto{Version: "1.8.0"}); !errors.As(err, &conflict) {
+		t.Fatalf("rename to existing version: err = %v, want ConflictError", err)
+	}
+	if _, err := patches.Update(ctx, 99, dto.PatchRequestDto{Version: "2.0.0"}); !errors.As(err, &missing) {
+		t.Fatalf("update missing patch: err = %v, want NotFoundError", err)
+	}
+	if _, err := patches.GetByVersion(ctx, " 1.8.20 "); err != nil {
+		t.Fatalf("GetByVersion trims the version: err = %v", err)
+	}
+
+	// Patch yang sudah dipakai Game tidak boleh dihapus
+	repo.games[first.PatchID] = 3
+	if err := patches.Delete(ctx, first.PatchID); !errors.As(err, &conflict) {
+		t.Fatalf("delete used patch: err = %v, want ConflictError", err)
+	}
+	if _, ok := repo.patches[first.PatchID]; !ok {
+		t.Fatal("used patch was deleted")
+	}
+	if err := patches.Delete(ctx, second.PatchID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := repo.patches[second.PatchID]; ok {
+		t.Fatal("unused patch was not deleted")
+	}
+	if err := patches.Delete(ctx, second.PatchID); !errors.As(err, &missing) {
+		t.Fatalf("delete missing patch: err = %v, want NotFoundError", err)
+	}
+}
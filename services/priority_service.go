@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// PriorityService mengelola priority pick, priority ban dan flex pick sebuah
+// tim di Match.
+type PriorityService struct {
+	priorities repositories.PriorityRepository
+	matches    repositories.MatchRepository
+	heroes     repositories.HeroRepository
+}
+
+func NewPriorityService(priorities repositories.PriorityRepository, matches repositories.MatchRepository, heroes repositories.HeroRepository) *PriorityService {
+	return &PriorityService{priorities: priorities, matches: matches, heroes: heroes}
+}
+
+func (s *PriorityService) GetPriorityPicks(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.PriorityPickResponseDto, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return s.priorities.FindPriorityPicks(ctx, matchTeamDetail.MatchTeamDetailID, patchID)
+}
+
+func (s *PriorityService) GetPriorityPick(ctx context.Context, matchID, teamID, priorityPickID uint) (dto.PriorityPickResponseDto, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return dto.PriorityPickResponseDto{}, err
+	}
+	priorityPick, err := s.priorities.FindPriorityPickDetail(ctx, matchTeamDetail.MatchTeamDetailID, priorityPickID)
+	return priorityPick, notFound(err, "Priority pick")
+}
+
+func (s *PriorityService) AddPriorityPick(ctx context.Context, matchID, teamID uint, input dto.PriorityPickRequestDto) (models.PriorityPick, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.PriorityPick{}, err
+	}
+	if err := s.requireHero(ctx, *input.HeroID); err != nil {
+		return models.PriorityPick{}, err
+	}
+
+	priorityPick := models.PriorityPick{
+		MatchTeamDetailID: matchTeamDetail.MatchTeamDetailID,
+		HeroID:            *input.HeroID,
+		Total:             *input.Total,
+		Role:              *input.Role,
+		PickRate:          *input.PickRate,
+	}
+	return priorityPick, s.priorities.CreatePriorityPick(ctx, &priorityPick)
+}
+
+func (s *PriorityService) UpdatePriorityPick(ctx context.Context, matchID, teamID, priorityPickID uint, input dto.PriorityPickRequestDto) (models.PriorityPick, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.PriorityPick{}, err
+	}
+	priorityPick, err := s.priorities.FindPriorityPick(ctx, matchTeamDetail.MatchTeamDetailID, priorityPickID)
+	if err != nil {
+		return priorityPick, notFound(err, "Priority pick")
+	}
+	if err := s.requireHero(ctx, *input.HeroID); err != nil {
+		return priorityPick, err
+	}
+
+	priorityPick.HeroID = *input.HeroID
+	priorityPick.Total = *input.Total
+	priorityPick.Role = *input.Role
+	priorityPick.PickRate = *input.PickRate
+	return priorityPick, s.priorities.UpdatePriorityPick(ctx, &priorityPick)
+}
+
+func (s *PriorityService) RemovePriorityPick(ctx context.Context, matchID, teamID, priorityPickID uint) error {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return err
+	}
+	priorityPick, err := s.priorities.FindPriorityPick(ctx, matchTeamDetail.MatchTeamDetailID, priorityPickID)
+	if err != nil {
+		return notFound(err, "Priority pick")
+	}
+	return s.priorities.DeletePriorityPick(ctx, priorityPick)
+}
+
+func (s *PriorityService) GetPriorityBans(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.PriorityBanResponseDto, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return s.priorities.FindPriorityBans(ctx, matchTeamDetail.MatchTeamDetailID, patchID)
+}
+
+func (s *PriorityService) GetPriorityBan(ctx context.Context, matchID, teamID, priorityBanID uint) (dto.PriorityBanResponseDto, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return dto.PriorityBanResponseDto{}, err
+	}
+	priorityBan, err := s.priorities.FindPriorityBanDetail(ctx, matchTeamDetail.MatchTeamDetailID, priorityBanID)
+	return priorityBan, notFound(err, "Priority ban")
+}
+
+func (s *PriorityService) AddPriorityBan(ctx context.Context, matchID, teamID uint, input dto.PriorityBanRequestDto) (models.PriorityBan, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.PriorityBan{}, err
+	}
+	if err := s.requireHero(ctx, *input.HeroID); err != nil {
+		return models.PriorityBan{}, err
+	}
+
+	priorityBan := models.PriorityBan{
+		MatchTeamDetailID: matchTeamDetail.MatchTeamDetailID,
+		HeroID:            *input.HeroID,
+		Total:             *input.Total,
+		Role:              *input.Role,
+		BanRate:           *input.BanRate,
+	}
+	return priorityBan, s.priorities.CreatePriorityBan(ctx, &priorityBan)
+}
+
+func (s *PriorityService) UpdatePriorityBan(ctx context.Context, matchID, teamID, priorityBanID uint, input dto.PriorityBanRequestDto) (models.PriorityBan, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.PriorityBan{}, err
+	}
+	priorityBan, err := s.priorities.FindPriorityBan(ctx, matchTeamDetail.MatchTeamDetailID, priorityBanID)
+	if err != nil {
+		return priorityBan, notFound(err, "Priority ban")
+	}
+	if err := s.requireHero(ctx, *input.HeroID); err != nil {
+		return priorityBan, err
+	}
+
+	priorityBan.HeroID = *input.HeroID
+	priorityBan.Total = *input.Total
+	priorityBan.Role = *input.Role
+	priorityBan.BanRate = *input.BanRate
+	return priorityBan, s.priorities.UpdatePriorityBan(ctx, &priorityBan)
+}
+
+func (s *PriorityService) RemovePriorityBan(ctx context.Context, matchID, teamID, priorityBanID uint) error {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return err
+	}
+	priorityBan, err := s.priorities.FindPriorityBan(ctx, matchTeamDetail.MatchTeamDetailID, priorityBanID)
+	if err != nil {
+		return notFound(err, "Priority ban")
+	}
+	return s.priorities.DeletePriorityBan(ctx, priorityBan)
+}
+
+func (s *PriorityService) GetFlexPicks(ctx context.Context, matchID, teamID uint, patchID *uint) ([]dto.FlexPickResponseDto, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return s.priorities.FindFlexPicks(ctx, matchTeamDetail.MatchTeamDetailID, patchID)
+}
+
+func (s *PriorityService) GetFlexPick(ctx context.Context, matchID, teamID, flexPickID uint) (dto.FlexPickResponseDto, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return dto.FlexPickResponseDto{}, err
+	}
+	flexPick, err := s.priorities.FindFlexPickDetail(ctx, matchTeamDetail.MatchTeamDetailID, flexPickID)
+	return flexPick, notFound(err, "Flex pick")
+}
+
+func (s *PriorityService) AddFlexPick(ctx context.Context, matchID, teamID uint, input dto.FlexPickRequestDto) (models.FlexPick, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.FlexPick{}, err
+	}
+	if err := s.requireHero(ctx, *input.HeroID); err != nil {
+		return models.FlexPick{}, err
+	}
+
+	flexPick := models.FlexPick{
+		MatchTeamDetailID: matchTeamDetail.MatchTeamDetailID,
+		HeroID:            *input.HeroID,
+		Total:             *input.Total,
+		Role:              *input.Role,
+		PickRate:          *input.PickRate,
+	}
+	return flexPick, s.priorities.CreateFlexPick(ctx, &flexPick)
+}
+
+func (s *PriorityService) UpdateFlexPick(ctx context.Context, matchID, teamID, flexPickID uint, input dto.FlexPickRequestDto) (models.FlexPick, error) {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return models.FlexPick{}, err
+	}
+	flexPick, err := s.priorities.FindFlexPick(ctx, matchTeamDetail.MatchTeamDetailID, flexPickID)
+	if err != nil {
+		return flexPick, notFound(err, "Flex pick")
+	}
+	if err := s.requireHero(ctx, *input.HeroID); err != nil {
+		return flexPick, err
+	}
+
+	flexPick.HeroID = *input.HeroID
+	flexPick.Total = *input.Total
+	flexPick.Role = *input.Role
+	flexPick.PickRate = *input.PickRate
+	return flexPick, s.priorities.UpdateFlexPick(ctx, &flexPick)
+}
+
+func (s *PriorityService) RemoveFlexPick(ctx context.Context, matchID, teamID, flexPickID uint) error {
+	matchTeamDetail, err := s.teamDetail(ctx, matchID, teamID)
+	if err != nil {
+		return err
+	}
+	flexPick, err := s.priorities.FindFlexPick(ctx, matchTeamDetail.MatchTeamDetailID, flexPickID)
+	if err != nil {
+		return notFound(err, "Flex pick")
+	}
+	return s.priorities.DeleteFlexPick(ctx, flexPick)
+}
+
+func (s *PriorityService) requireHero(ctx context.Context, heroID uint) error {
+	_, err := s.heroes.FindByID(ctx, heroID)
+	return notFound(err, "Hero")
+}
+
+func (s *PriorityService) teamDetail(ctx context.Context, matchID, teamID uint) (models.MatchTeamDetail, error) {
+	matchTeamDetail, err := s.matches.FindTeamDetail(ctx, matchID, teamID)
+	return matchTeamDetail, notFound(err, "Match or team")
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// PurgeService menghapus permanen data yang sudah melewati masa retensi
+// soft delete.
+type PurgeService struct {
+	repos repositories.Repositories
+}
+
+func NewPurgeService(repos repositories.Repositories) *PurgeService {
+	return &PurgeService{repos: repos}
+}
+
+// PurgeDeletedBefore menghapus permanen Tournament, Team, Match dan Game yang
+// sudah di-soft delete sebelum deletedBefore.
+func (s *PurgeService) PurgeDeletedBefore(ctx context.Context, deletedBefore time.Time) error {
+	purgers := []interface {
+		PurgeDeletedBefore(ctx context.Context, t time.Time) (int, error)
+	}{
+		s.repos.Tournaments,
+		s.repos.Teams,
+		s.repos.Matches,
+		s.repos.Games,
+	}
+
+	for _, purger := range purgers {
+		if _, err := purger.PurgeDeletedBefore(ctx, deletedBefore); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start menjalankan PurgeDeletedBefore di background setiap interval untuk
+// data yang sudah di-soft delete lebih lama dari retention.
+func (s *PurgeService) Start(retention, interval time.Duration) {
+	ctx := WithAuditActor(context.Background(), models.User{Username: "purge-job"})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.PurgeDeletedBefore(ctx, time.Now().Add(-retention)); err != nil {
+				log.Printf("Purge job gagal: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,64 @@
+package services
+
+import "ml-master-data/repositories"
+
+// Services mengelompokkan semua service yang dipakai controller, CLI dan
+// background job.
+type Services struct {
+	Tournaments *TournamentService
+	Teams       *TeamService
+	Heroes      *HeroService
+	Matches     *MatchService
+	Games       *GameService
+	Drafts      *DraftService
+	Purge       *PurgeService
+	Users       *UserService
+	Audits      *AuditService
+	Stats       *StatsService
+	MasterData  *MasterDataService
+	Media       *MediaService
+	Patches     *PatchService
+	Exports     *ExportService
+	SheetImport *SheetImportService
+	Backups     *TournamentBackupService
+	Liquipedia  *LiquipediaImportService
+	Names       *NameResolverService
+	Objectives  *ObjectiveService
+	Lanes       *LaneService
+	Lineups     *LineupService
+	Priorities  *PriorityService
+	Events      *EventBus
+}
+
+// New membuat semua service di atas repos.
+func New(repos repositories.Repositories) Services {
+	names := NewNameResolverService(repos.Names)
+	events := NewEventBus()
+	media := NewMediaService(repos.Media)
+	sheetImport := NewSheetImportService(repos.SheetImport, repos.Tournaments, repos.Teams, repos.Heroes, repos.Patches, names)
+	return Services{
+		Tournaments: NewTournamentService(repos.Tournaments, repos.Heroes),
+		Teams:       NewTeamService(repos.Teams, names),
+		Heroes:      NewHeroService(repos.Heroes),
+		Matches:     NewMatchService(repos.Matches, repos.Tournaments, repos.Teams, events),
+		Games:       NewGameService(repos.Games, repos.Matches, repos.Patches, events),
+		Drafts:      NewDraftService(repos.Drafts, repos.Matches, repos.Tournaments, events),
+		Purge:       NewPurgeService(repos),
+		Users:       NewUserService(repos.Users),
+		Audits:      NewAuditService(repos.Audits),
+		Stats:       NewStatsService(repos.Stats),
+		MasterData:  NewMasterDataService(repos.MasterData),
+		Media:       media,
+		Patches:     NewPatchService(repos.Patches),
+		Exports:     NewExportService(repos.Exports, repos.Tournaments),
+		SheetImport: sheetImport,
+		Backups:     NewTournamentBackupService(repos.Backups, media),
+		Liquipedia:  NewLiquipediaImportService(sheetImport, repos.Tournaments, names),
+		Names:       names,
+		Objectives:  NewObjectiveService(repos.Objectives, repos.Games, repos.Matches, events),
+		Lanes:       NewLaneService(repos.Lanes, repos.Games, repos.Matches),
+		Lineups:     NewLineupService(repos.Lineups, repos.Matches, repos.Teams),
+		Priorities:  NewPriorityService(repos.Priorities, repos.Matches, repos.Heroes),
+		Events:      events,
+	}
+}
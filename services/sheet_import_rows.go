@@ -0,0 +1,510 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+)
+
+// Fungsi di file ini memproses satu baris setiap sheet template import.
+
+func (im *sheetImport) matchRow(r *importRow) {
+	key := r.required("match_id")
+	stage := r.required("stage")
+	day := r.integer("day")
+	date := r.integer("date")
+	var playedAt *time.Time
+	if r.has("played_at") {
+		playedAt = r.date("played_at")
+	}
+	teamA := r.team("team_a")
+	teamB := r.team("team_b")
+	scoreA := r.integer("team_a_score")
+	scoreB := r.integer("team_b_score")
+	if teamA != nil && teamB != nil && teamA.TeamID == teamB.TeamID {
+		r.fail("team_b", "Team A and Team B must be different")
+	}
+	if r.failed || !im.claim(r, entityKey{"match", nil, key}) {
+		return
+	}
+
+	m := im.matches[key]
+	if m == nil {
+		m = im.sameMatch(stage, day, date, teamA.TeamID, teamB.TeamID)
+	}
+	created := m == nil
+	if created {
+		m = &importMatch{key: key, match: &models.Match{TournamentID: im.tournamentID}, details: map[uint]*models.MatchTeamDetail{}, games: map[int]*models.Game{}}
+	} else if m.match.TeamAID != teamA.TeamID || m.match.TeamBID != teamB.TeamID {
+		r.fail("team_a", "Teams of existing match %s cannot be changed", key)
+		return
+	}
+
+	match := m.match
+	var changes changeSet
+	changes.set("stage", &match.Stage, stage)
+	changes.set("day", &match.Day, day)
+	changes.set("date", &match.Date, date)
+	if r.has("played_at") {
+		changes.set("played_at", &match.PlayedAt, playedAt)
+	}
+	changes.setName("team_a", &match.TeamAID, teamA.TeamID, im.teamName)
+	changes.setName("team_b", &match.TeamBID, teamB.TeamID, im.teamName)
+	changes.set("team_a_score", &match.TeamAScore, scoreA)
+	changes.set("team_b_score", &match.TeamBScore, scoreB)
+	im.save(r, "match", "match "+key, match, created, &changes, nil)
+
+	im.matches[key] = m
+	if created {
+		// Seperti MatchRepository.Create, setiap tim mendapat MatchTeamDetail
+		for _, teamID := range []uint{match.TeamAID, match.TeamBID} {
+			detail := &models.MatchTeamDetail{TeamID: teamID}
+			m.details[teamID] = detail
+			im.ops = append(im.ops, repositories.SheetImportOp{Value: detail, Link: func() { detail.MatchID = match.MatchID }})
+		}
+	}
+}
+
+// sameMatch mencari Match lama dengan stage, hari, tanggal dan tim yang
+// sama, agar sheet yang memakai kunci selain ID bisa di-import ulang tanpa
+// membuat Match ganda.
+func (im *sheetImport) sameMatch(stage string, day, date int, teamAID, teamBID uint) *importMatch {
+	for _, m := range im.matches {
+		match := m.match
+		if match.MatchID != 0 && strings.EqualFold(match.Stage, stage) && match.Day == day && match.Date == date &&
+			match.TeamAID == teamAID && match.TeamBID == teamBID {
+			return m
+		}
+	}
+	return nil
+}
+
+func (im *sheetImport) playerRow(r *importRow) {
+	m := r.match()
+	team, detail := r.side(m, "team")
+	player := r.player("player", team)
+	role := r.oneOf("role", "goldlaner", "explaner", "roamer", "midlaner", "jungler")
+	if r.failed {
+		return
+	}
+	k := entityKey{"player_match", detail, fmt.Sprint(player.PlayerID)}
+	if !im.claim(r, k) {
+		return
+	}
+
+	row, _ := im.entities[k].(*models.PlayerMatch)
+	created := row == nil
+	if created {
+		row = &models.PlayerMatch{PlayerID: player.PlayerID}
+		im.entities[k] = row
+	}
+	var changes changeSet
+	changes.set("role", &row.Role, role)
+	im.save(r, "player_match", fmt.Sprintf("match %s %s / %s", m.key, team.Name, player.Name), row, created, &changes,
+		func() { row.MatchTeamDetailID = detail.MatchTeamDetailID })
+}
+
+func (im *sheetImport) gameRow(r *importRow) {
+	m := r.match()
+	number := r.integer("game_number")
+	if number < 1 && !r.failed {
+		r.fail("game_number", "Must be at least 1")
+	}
+	first := r.team("first_pick_team")
+	second := r.team("second_pick_team")
+	winner := r.team("winner_team")
+
+	var patch *models.Patch
+	if version := r.text("patch"); version != "" {
+		for i := range im.patches {
+			if strings.EqualFold(im.patches[i].Version, version) {
+				patch = &im.patches[i]
+			}
+		}
+		if patch == nil {
+			r.fail("patch", "Unknown patch %q", version)
+		}
+	}
+	if r.failed {
+		return
+	}
+
+	columns := map[string]*models.Team{"first_pick_team": first, "second_pick_team": second, "winner_team": winner}
+	for _, column := range []string{"first_pick_team", "second_pick_team", "winner_team"} {
+		if m.details[columns[column].TeamID] == nil {
+			r.fail(column, "Team %s does not play in match %s", columns[column].Name, m.key)
+		}
+	}
+	if first.TeamID == second.TeamID {
+		r.fail("second_pick_team", "First and second pick teams must be different")
+	}
+	if r.failed || !im.claim(r, entityKey{"game", m, fmt.Sprint(number)}) {
+		return
+	}
+
+	game := m.games[number]
+	created := game == nil
+	if created {
+		game = &models.Game{GameNumber: number}
+		m.games[number] = game
+		if patch == nil {
+			patch = im.defaultPatch(m.match)
+		}
+	}
+
+	var changes changeSet
+	changes.setName("first_pick_team", &game.FirstPickTeamID, first.TeamID, im.teamName)
+	changes.setName("second_pick_team", &game.SecondPickTeamID, second.TeamID, im.teamName)
+	changes.setName("winner_team", &game.WinnerTeamID, winner.TeamID, im.teamName)
+	if r.has("video_link") {
+		changes.set("video_link", &game.VideoLink, r.text("video_link"))
+	}
+	if patch != nil && (game.PatchID == nil || *game.PatchID != patch.PatchID) {
+		changes.fields = append(changes.fields, fieldChange("patch", im.patchVersion(game.PatchID), patch.Version))
+		game.PatchID = &patch.PatchID
+	}
+	im.save(r, "game", fmt.Sprintf("match %s game %d", m.key, number), game, created, &changes,
+		func() { game.MatchID = m.match.MatchID })
+}
+
+// heroDraft membaca kolom yang sama di sheet Hero Picks dan Hero Bans.
+func (r *importRow) heroDraft() (m *importMatch, team *models.Team, detail *models.MatchTeamDetail, hero *models.Hero, phases [3]int) {
+	m = r.match()
+	team, detail = r.side(m, "team")
+	hero = r.hero("hero")
+	phases = [3]int{r.integer("first_phase"), r.integer("second_phase"), r.integer("total")}
+	return
+}
+
+func (im *sheetImport) heroPickRow(r *importRow) {
+	m, team, detail, hero, phases := r.heroDraft()
+	if r.failed {
+		return
+	}
+	k := entityKey{"hero_pick", detail, fmt.Sprint(hero.HeroID)}
+	if !im.claim(r, k) {
+		return
+	}
+
+	row, _ := im.entities[k].(*models.HeroPick)
+	created := row == nil
+	if created {
+		row = &models.HeroPick{HeroID: hero.HeroID}
+		im.entities[k] = row
+	}
+	var changes changeSet
+	changes.set("first_phase", &row.FirstPhase, phases[0])
+	changes.set("second_phase", &row.SecondPhase, phases[1])
+	changes.set("total", &row.Total, phases[2])
+	if im.save(r, "hero_pick", fmt.Sprintf("match %s %s / %s", m.key, team.Name, hero.Name), row, created, &changes,
+		func() { row.MatchTeamDetailID = detail.MatchTeamDetailID }) {
+		im.draft(r, m, detail, row)
+	}
+}
+
+func (im *sheetImport) heroBanRow(r *importRow) {
+	m, team, detail, hero, phases := r.heroDraft()
+	if r.failed {
+		return
+	}
+	k := entityKey{"hero_ban", detail, fmt.Sprint(hero.HeroID)}
+	if !im.claim(r, k) {
+		return
+	}
+
+	row, _ := im.entities[k].(*models.HeroBan)
+	created := row == nil
+	if created {
+		row = &models.HeroBan{HeroID: hero.HeroID}
+		im.entities[k] = row
+	}
+	var changes changeSet
+	changes.set("first_phase", &row.FirstPhase, phases[0])
+	changes.set("second_phase", &row.SecondPhase, phases[1])
+	changes.set("total", &row.Total, phases[2])
+	if im.save(r, "hero_ban", fmt.Sprintf("match %s %s / %s", m.key, team.Name, hero.Name), row, created, &changes,
+		func() { row.MatchTeamDetailID = detail.MatchTeamDetailID }) {
+		im.draft(r, m, detail, row)
+	}
+}
+
+// draftRow menandai hero di-pick atau di-ban di satu Game. HeroPick atau
+// HeroBan-nya harus sudah ada, di database atau di sheet Hero Picks/Hero
+// Bans.
+func (im *sheetImport) draftRow(r *importRow) {
+	m := r.match()
+	game := r.game(m)
+	team, detail := r.side(m, "team")
+	action := r.oneOf("action", "pick", "ban")
+	hero := r.hero("hero")
+	if r.failed {
+		return
+	}
+	label := fmt.Sprintf("match %s game %d %s / %s", m.key, game.GameNumber, team.Name, hero.Name)
+
+	switch action {
+	case "pick":
+		pick, _ := im.entities[entityKey{"hero_pick", detail, fmt.Sprint(hero.HeroID)}].(*models.HeroPick)
+		if pick == nil {
+			r.fail("hero", "%s has no hero pick for %s; add it to the Hero Picks sheet", team.Name, hero.Name)
+			return
+		}
+		k := entityKey{"hero_pick_game", pick, fmt.Sprint(game.GameNumber)}
+		if !im.claim(r, k) {
+			return
+		}
+		row, _ := im.entities[k].(*models.HeroPickGame)
+		created := row == nil
+		if created {
+			row = &models.HeroPickGame{GameNumber: game.GameNumber}
+			im.entities[k] = row
+		}
+		var changes changeSet
+		changes.set("is_picked", &row.IsPicked, true)
+		if im.save(r, "hero_pick_game", label, row, created, &changes, func() {
+			row.HeroPickID = pick.HeroPickID
+			row.GameID = game.GameID
+		}) {
+			im.draft(r, m, detail, pick)
+		}
+
+	case "ban":
+		ban, _ := im.entities[entityKey{"hero_ban", detail, fmt.Sprint(hero.HeroID)}].(*models.HeroBan)
+		if ban == nil {
+			r.fail("hero", "%s has no hero ban for %s; add it to the Hero Bans sheet", team.Name, hero.Name)
+			return
+		}
+		k := entityKey{"hero_ban_game", ban, fmt.Sprint(game.GameNumber)}
+		if !im.claim(r, k) {
+			return
+		}
+		row, _ := im.entities[k].(*models.HeroBanGame)
+		created := row == nil
+		if created {
+			row = &models.HeroBanGame{GameNumber: game.GameNumber}
+			im.entities[k] = row
+		}
+		var changes changeSet
+		changes.set("is_banned", &row.IsBanned, true)
+		if im.save(r, "hero_ban_game", label, row, created, &changes, func() {
+			row.HeroBanID = ban.HeroBanID
+			row.GameID = game.GameID
+		}) {
+			im.draft(r, m, detail, ban)
+		}
+	}
+}
+
+// priority membaca kolom yang sama di sheet Priority Picks, Priority Bans
+// dan Flex Picks.
+func (r *importRow) priority() (m *importMatch, team *models.Team, detail *models.MatchTeamDetail, hero *models.Hero, role string, total int, rate float64) {
+	m = r.match()
+	team, detail = r.side(m, "team")
+	hero = r.hero("hero")
+	role = r.oneOf("role", models.Lanes...)
+	total = r.integer("total")
+	rate = r.number("rate")
+	return
+}
+
+func (im *sheetImport) priorityPickRow(r *importRow) {
+	m, team, detail, hero, role, total, rate := r.priority()
+	if r.failed {
+		return
+	}
+	k := entityKey{"priority_pick", detail, priorityKey(hero.HeroID, role)}
+	if !im.claim(r, k) {
+		return
+	}
+
+	row, _ := im.entities[k].(*models.PriorityPick)
+	created := row == nil
+	if created {
+		row = &models.PriorityPick{HeroID: hero.HeroID, Role: role}
+		im.entities[k] = row
+	}
+	var changes changeSet
+	changes.set("total", &row.Total, total)
+	changes.set("rate", &row.PickRate, rate)
+	im.save(r, "priority_pick", fmt.Sprintf("match %s %s / %s %s", m.key, team.Name, hero.Name, role), row, created, &changes,
+		func() { row.MatchTeamDetailID = detail.MatchTeamDetailID })
+}
+
+func (im *sheetImport) priorityBanRow(r *importRow) {
+	m, team, detail, hero, role, total, rate := r.priority()
+	if r.failed {
+		return
+	}
+	k := entityKey{"priority_ban", detail, priorityKey(hero.HeroID, role)}
+	if !im.claim(r, k) {
+		return
+	}
+
+	row, _ := im.entities[k].(*models.PriorityBan)
+	created := row == nil
+	if created {
+		row = &models.PriorityBan{HeroID: hero.HeroID, Role: role}
+		im.entities[k] = row
+	}
+	var changes changeSet
+	changes.set("total", &row.Total, total)
+	changes.set("rate", &row.BanRate, rate)
+	im.save(r, "priority_ban", fmt.Sprintf("match %s %s / %s %s", m.key, team.Name, hero.Name, role), row, created, &changes,
+		func() { row.MatchTeamDetailID = detail.MatchTeamDetailID })
+}
+
+func (im *sheetImport) flexPickRow(r *importRow) {
+	m, team, detail, hero, role, total, rate := r.priority()
+	if r.failed {
+		return
+	}
+	k := entityKey{"flex_pick", detail, priorityKey(hero.HeroID, role)}
+	if !im.claim(r, k) {
+		return
+	}
+
+	row, _ := im.entities[k].(*models.FlexPick)
+	created := row == nil
+	if created {
+		row = &models.FlexPick{HeroID: hero.HeroID, Role: role}
+		im.entities[k] = row
+	}
+	var changes changeSet
+	changes.set("total", &row.Total, total)
+	changes.set("rate", &row.PickRate, rate)
+	im.save(r, "flex_pick", fmt.Sprintf("match %s %s / %s %s", m.key, team.Name, hero.Name, role), row, created, &changes,
+		func() { row.MatchTeamDetailID = detail.MatchTeamDetailID })
+}
+
+// laneRow menyimpan hasil early game explaner, goldlaner atau satu hero
+// trio mid. Setiap tim punya satu explaner dan satu goldlaner per Game,
+// serta satu hero trio mid per role.
+func (im *sheetImport) laneRow(r *importRow) {
+	m := r.match()
+	game := r.game(m)
+	team, _ := r.side(m, "team")
+	lane := r.oneOf("lane", "explaner", "goldlaner", "trio_mid")
+	hero := r.hero("hero")
+	result := r.oneOf("early_result", "win", "draw", "lose")
+	role := ""
+	if lane == "trio_mid" {
+		role = r.oneOf("role", "jungler", "midlaner", "roamer")
+	}
+	if r.failed {
+		return
+	}
+	label := fmt.Sprintf("match %s game %d %s", m.key, game.GameNumber, team.Name)
+	link := func(gameID *uint) func() {
+		return func() { *gameID = game.GameID }
+	}
+
+	switch lane {
+	case "explaner":
+		k := entityKey{"explaner", game, fmt.Sprint(team.TeamID)}
+		if !im.claim(r, k) {
+			return
+		}
+		row, _ := im.entities[k].(*models.Explaner)
+		created := row == nil
+		if created {
+			row = &models.Explaner{TeamID: team.TeamID}
+			im.entities[k] = row
+		}
+		var changes changeSet
+		changes.setName("hero", &row.HeroID, hero.HeroID, im.heroName)
+		changes.set("early_result", &row.EarlyResult, result)
+		im.save(r, "explaner", label, row, created, &changes, link(&row.GameID))
+
+	case "goldlaner":
+		k := entityKey{"goldlaner", game, fmt.Sprint(team.TeamID)}
+		if !im.claim(r, k) {
+			return
+		}
+		row, _ := im.entities[k].(*models.Goldlaner)
+		created := row == nil
+		if created {
+			row = &models.Goldlaner{TeamID: team.TeamID}
+			im.entities[k] = row
+		}
+		var changes changeSet
+		changes.setName("hero", &row.HeroID, hero.HeroID, im.heroName)
+		changes.set("early_result", &row.EarlyResult, result)
+		im.save(r, "goldlaner", label, row, created, &changes, link(&row.GameID))
+
+	case "trio_mid":
+		trioKey := entityKey{"trio_mid", game, fmt.Sprint(team.TeamID)}
+		trioMid, _ := im.entities[trioKey].(*models.TrioMid)
+		if trioMid == nil {
+			trioMid = &models.TrioMid{TeamID: team.TeamID}
+			im.entities[trioKey] = trioMid
+			im.save(r, "trio_mid", label, trioMid, true, &changeSet{}, link(&trioMid.GameID))
+		}
+
+		k := entityKey{"trio_mid_hero", trioMid, role}
+		if !im.claim(r, k) {
+			return
+		}
+		row, _ := im.entities[k].(*models.TrioMidHero)
+		created := row == nil
+		if created {
+			row = &models.TrioMidHero{Role: role}
+			im.entities[k] = row
+		}
+		var changes changeSet
+		changes.setName("hero", &row.HeroID, hero.HeroID, im.heroName)
+		changes.set("early_result", &row.EarlyResult, result)
+		im.save(r, "trio_mid_hero", label+" "+role, row, created, &changes, func() { row.TrioMidID = trioMid.TrioMidID })
+	}
+}
+
+// objectiveRow menyimpan LordResult atau TurtleResult, satu per tim dan
+// phase di setiap Game.
+func (im *sheetImport) objectiveRow(r *importRow) {
+	m := r.match()
+	game := r.game(m)
+	team, _ := r.side(m, "team")
+	objective := r.oneOf("objective", "lord", "turtle")
+	phase := r.required("phase")
+	setup := r.oneOf("setup", "early", "late", "no")
+	initiate := r.oneOf("initiate", "yes", "no")
+	result := r.oneOf("result", "yes", "no")
+	if r.failed {
+		return
+	}
+	k := entityKey{objective, game, objectiveKey(team.TeamID, phase)}
+	if !im.claim(r, k) {
+		return
+	}
+	label := fmt.Sprintf("match %s game %d %s phase %s", m.key, game.GameNumber, team.Name, phase)
+
+	switch objective {
+	case "lord":
+		row, _ := im.entities[k].(*models.LordResult)
+		created := row == nil
+		if created {
+			row = &models.LordResult{TeamID: team.TeamID, Phase: phase}
+			im.entities[k] = row
+		}
+		var changes changeSet
+		changes.set("setup", &row.Setup, setup)
+		changes.set("initiate", &row.Initiate, initiate)
+		changes.set("result", &row.Result, result)
+		im.save(r, "lord_result", label, row, created, &changes, func() { row.GameID = game.GameID })
+
+	case "turtle":
+		row, _ := im.entities[k].(*models.TurtleResult)
+		created := row == nil
+		if created {
+			row = &models.TurtleResult{TeamID: team.TeamID, Phase: phase}
+			im.entities[k] = row
+		}
+		var changes changeSet
+		changes.set("setup", &row.Setup, setup)
+		changes.set("initiate", &row.Initiate, initiate)
+		changes.set("result", &row.Result, result)
+		im.save(r, "turtle_result", label, row, created, &changes, func() { row.GameID = game.GameID })
+	}
+}
@@ -0,0 +1,817 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/tabular"
+)
+
+// sheetImportSource adalah asal nama di antrean review.
+const sheetImportSource = "sheet import"
+
+// importSheet adalah satu sheet template import: nama sheet, kolom yang
+// wajib ada dan fungsi yang memproses setiap barisnya. Sheet diproses
+// dengan urutan importSheets, bukan urutan di workbook, agar data induk
+// selalu dibuat lebih dulu.
+type importSheet struct {
+	name    string
+	columns []string
+	row     func(*sheetImport, *importRow)
+}
+
+var importSheets = []importSheet{
+	{"Matches", []string{"match_id", "stage", "day", "date", "team_a", "team_b", "team_a_score", "team_b_score"}, (*sheetImport).matchRow},
+	{"Match Players", []string{"match_id", "team", "player", "role"}, (*sheetImport).playerRow},
+	{"Games", []string{"match_id", "game_number", "first_pick_team", "second_pick_team", "winner_team"}, (*sheetImport).gameRow},
+	{"Hero Picks", []string{"match_id", "team", "hero", "first_phase", "second_phase", "total"}, (*sheetImport).heroPickRow},
+	{"Hero Bans", []string{"match_id", "team", "hero", "first_phase", "second_phase", "total"}, (*sheetImport).heroBanRow},
+	{"Drafts", []string{"match_id", "game_number", "team", "action", "hero"}, (*sheetImport).draftRow},
+	{"Priority Picks", []string{"match_id", "team", "hero", "role", "total", "rate"}, (*sheetImport).priorityPickRow},
+	{"Priority Bans", []string{"match_id", "team", "hero", "role", "total", "rate"}, (*sheetImport).priorityBanRow},
+	{"Flex Picks", []string{"match_id", "team", "hero", "role", "total", "rate"}, (*sheetImport).flexPickRow},
+	{"Lane Results", []string{"match_id", "game_number", "team", "lane", "hero", "early_result"}, (*sheetImport).laneRow},
+	{"Objectives", []string{"match_id", "game_number", "team", "objective", "phase", "setup", "initiate", "result"}, (*sheetImport).objectiveRow},
+}
+
+type SheetImportService struct {
+	imports     repositories.SheetImportRepository
+	tournaments repositories.TournamentRepository
+	teams       repositories.TeamRepository
+	heroes      repositories.HeroRepository
+	patches     repositories.PatchRepository
+	names       *NameResolverService
+}
+
+func NewSheetImportService(imports repositories.SheetImportRepository, tournaments repositories.TournamentRepository, teams repositories.TeamRepository, heroes repositories.HeroRepository, patches repositories.PatchRepository, names *NameResolverService) *SheetImportService {
+	return &SheetImportService{imports: imports, tournaments: tournaments, teams: teams, heroes: heroes, patches: patches, names: names}
+}
+
+// Import memvalidasi semua baris sheets terhadap team, hero, pemain dan
+// patch yang ada, serta hero pick dan hero ban yang berubah terhadap aturan
+// draft Tournament seperti DraftService, lalu menyimpan semua perubahannya
+// dalam satu transaksi.
+// Tim, hero dan pemain boleh ditulis dengan ID, nama atau alias. match_id
+// yang belum ada di Tournament dianggap kunci Match baru di sheet Matches.
+// Jika ada baris yang tidak valid atau dryRun, tidak ada yang disimpan dan
+// hasilnya hanya berisi diff. Nama yang tidak cocok masuk antrean review
+// kecuali dryRun.
+func (s *SheetImportService) Import(ctx context.Context, tournamentID uint, sheets []tabular.Sheet, dryRun bool) (dto.SheetImportResultDto, error) {
+	result := dto.SheetImportResultDto{DryRun: dryRun, Changes: []dto.SheetImportChangeDto{}, Errors: []dto.SheetImportErrorDto{}}
+	if _, err := s.tournaments.FindByID(ctx, tournamentID); err != nil {
+		return result, notFound(err, "Tournament")
+	}
+
+	im, err := s.load(ctx, tournamentID, &result)
+	if err != nil {
+		return result, err
+	}
+	im.run(sheets)
+	im.checkDrafts()
+
+	if len(result.Errors) > 0 {
+		if !dryRun {
+			if err := s.names.Queue(ctx, sheetImportSource, im.unmatched); err != nil {
+				return result, err
+			}
+		}
+		return result, &ValidationError{Message: fmt.Sprintf("%d problems found, nothing was imported", len(result.Errors))}
+	}
+	if dryRun {
+		return result, nil
+	}
+	if err := s.imports.Apply(ctx, im.ops); err != nil {
+		return result, err
+	}
+	result.Applied = true
+	return result, nil
+}
+
+// sheetImport menyimpan keadaan satu proses import: data yang sudah ada,
+// data baru dari baris sebelumnya dan operasi yang akan disimpan.
+type sheetImport struct {
+	tournamentID uint
+	result       *dto.SheetImportResultDto
+	ops          []repositories.SheetImportOp
+
+	teamNames   *nameMatchers
+	teamIDs     map[uint]*models.Team
+	heroNames   *nameMatchers
+	heroIDs     map[uint]*models.Hero
+	playerNames *nameMatchers
+	players     map[uint][]models.Player // per team
+	patches     []models.Patch           // terbaru lebih dulu
+	// rule adalah aturan draft Tournament, nil jika belum diatur.
+	rule *models.TournamentRule
+	// unmatched berisi nama yang tidak cocok, untuk antrean review.
+	unmatched []dto.NameResolutionDto
+
+	matches map[string]*importMatch // per match_id di sheet
+	// entities berisi baris turunan Match yang sudah ada atau baru,
+	// dicari lewat jenis, induk dan kuncinya.
+	entities map[entityKey]interface{}
+	// seen mencatat baris pertama yang mengubah sebuah entity, untuk
+	// menolak baris ganda.
+	seen map[entityKey]int
+	// drafted berisi HeroPick dan HeroBan yang diubah import, untuk
+	// diperiksa checkDrafts.
+	drafted []draftedRow
+}
+
+// draftedRow adalah HeroPick atau HeroBan yang diubah import beserta baris
+// pertama yang mengubahnya.
+type draftedRow struct {
+	sheet  string
+	line   int
+	match  *importMatch
+	detail *models.MatchTeamDetail
+	entry  interface{} // *models.HeroPick atau *models.HeroBan
+}
+
+type importMatch struct {
+	key     string
+	match   *models.Match
+	details map[uint]*models.MatchTeamDetail // per team
+	games   map[int]*models.Game             // per game_number
+}
+
+type entityKey struct {
+	kind   string
+	parent interface{}
+	key    string
+}
+
+func (s *SheetImportService) load(ctx context.Context, tournamentID uint, result *dto.SheetImportResultDto) (*sheetImport, error) {
+	im := &sheetImport{
+		tournamentID: tournamentID,
+		result:       result,
+		teamIDs:      map[uint]*models.Team{},
+		heroIDs:      map[uint]*models.Hero{},
+		players:      map[uint][]models.Player{},
+		matches:      map[string]*importMatch{},
+		entities:     map[entityKey]interface{}{},
+		seen:         map[entityKey]int{},
+	}
+
+	teams, err := s.teams.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range teams {
+		team := &teams[i]
+		im.teamIDs[team.TeamID] = team
+		if im.players[team.TeamID], err = s.teams.FindPlayers(ctx, team.TeamID); err != nil {
+			return nil, err
+		}
+	}
+
+	heroes, err := s.heroes.FindAll(ctx, repositories.HeroFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range heroes {
+		im.heroIDs[heroes[i].HeroID] = &heroes[i]
+	}
+
+	if im.teamNames, err = s.names.matchers(ctx, models.NameKindTeam); err != nil {
+		return nil, err
+	}
+	if im.heroNames, err = s.names.matchers(ctx, models.NameKindHero); err != nil {
+		return nil, err
+	}
+	if im.playerNames, err = s.names.matchers(ctx, models.NameKindPlayer); err != nil {
+		return nil, err
+	}
+
+	if im.patches, err = s.patches.FindAll(ctx); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.tournaments.FindRule(ctx, tournamentID)
+	if err == nil {
+		im.rule = &rule
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	state, err := s.imports.Load(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	im.index(&state)
+	return im, nil
+}
+
+// index memasukkan data Tournament yang sudah ada ke matches dan entities.
+func (im *sheetImport) index(state *repositories.SheetImportState) {
+	matchIDs := map[uint]*importMatch{}
+	for i := range state.Matches {
+		match := &state.Matches[i]
+		m := &importMatch{key: strconv.FormatUint(uint64(match.MatchID), 10), match: match, details: map[uint]*models.MatchTeamDetail{}, games: map[int]*models.Game{}}
+		im.matches[m.key] = m
+		matchIDs[match.MatchID] = m
+	}
+
+	details := map[uint]*models.MatchTeamDetail{}
+	for i := range state.MatchTeamDetails {
+		detail := &state.MatchTeamDetails[i]
+		details[detail.MatchTeamDetailID] = detail
+		if m := matchIDs[detail.MatchID]; m != nil && m.details[detail.TeamID] == nil {
+			m.details[detail.TeamID] = detail
+		}
+	}
+	games := map[uint]*models.Game{}
+	for i := range state.Games {
+		game := &state.Games[i]
+		games[game.GameID] = game
+		if m := matchIDs[game.MatchID]; m != nil && m.games[game.GameNumber] == nil {
+			m.games[game.GameNumber] = game
+		}
+	}
+
+	add := func(kind string, parent interface{}, key interface{}, value interface{}) {
+		k := entityKey{kind, parent, fmt.Sprint(key)}
+		if parent != nil && im.entities[k] == nil {
+			im.entities[k] = value
+		}
+	}
+	// parent mengembalikan nil (bukan pointer nil bertipe) jika induknya
+	// tidak ada, agar add bisa melewatinya.
+	detail := func(id uint) interface{} {
+		if d := details[id]; d != nil {
+			return d
+		}
+		return nil
+	}
+	game := func(id uint) interface{} {
+		if g := games[id]; g != nil {
+			return g
+		}
+		return nil
+	}
+
+	for i := range state.PlayerMatches {
+		row := &state.PlayerMatches[i]
+		add("player_match", detail(row.MatchTeamDetailID), row.PlayerID, row)
+	}
+	heroPicks := map[uint]*models.HeroPick{}
+	for i := range state.HeroPicks {
+		row := &state.HeroPicks[i]
+		heroPicks[row.HeroPickID] = row
+		add("hero_pick", detail(row.MatchTeamDetailID), row.HeroID, row)
+	}
+	for i := range state.HeroPickGames {
+		row := &state.HeroPickGames[i]
+		if pick := heroPicks[row.HeroPickID]; pick != nil {
+			add("hero_pick_game", pick, row.GameNumber, row)
+		}
+	}
+	heroBans := map[uint]*models.HeroBan{}
+	for i := range state.HeroBans {
+		row := &state.HeroBans[i]
+		heroBans[row.HeroBanID] = row
+		add("hero_ban", detail(row.MatchTeamDetailID), row.HeroID, row)
+	}
+	for i := range state.HeroBanGames {
+		row := &state.HeroBanGames[i]
+		if ban := heroBans[row.HeroBanID]; ban != nil {
+			add("hero_ban_game", ban, row.GameNumber, row)
+		}
+	}
+	for i := range state.PriorityPicks {
+		row := &state.PriorityPicks[i]
+		add("priority_pick", detail(row.MatchTeamDetailID), priorityKey(row.HeroID, row.Role), row)
+	}
+	for i := range state.PriorityBans {
+		row := &state.PriorityBans[i]
+		add("priority_ban", detail(row.MatchTeamDetailID), priorityKey(row.HeroID, row.Role), row)
+	}
+	for i := range state.FlexPicks {
+		row := &state.FlexPicks[i]
+		add("flex_pick", detail(row.MatchTeamDetailID), priorityKey(row.HeroID, row.Role), row)
+	}
+	for i := range state.Explaners {
+		row := &state.Explaners[i]
+		add("explaner", game(row.GameID), row.TeamID, row)
+	}
+	for i := range state.Goldlaners {
+		row := &state.Goldlaners[i]
+		add("goldlaner", game(row.GameID), row.TeamID, row)
+	}
+	trioMids := map[uint]*models.TrioMid{}
+	for i := range state.TrioMids {
+		row := &state.TrioMids[i]
+		trioMids[row.TrioMidID] = row
+		add("trio_mid", game(row.GameID), row.TeamID, row)
+	}
+	for i := range state.TrioMidHeroes {
+		row := &state.TrioMidHeroes[i]
+		if trioMid := trioMids[row.TrioMidID]; trioMid != nil {
+			add("trio_mid_hero", trioMid, row.Role, row)
+		}
+	}
+	for i := range state.LordResults {
+		row := &state.LordResults[i]
+		add("lord", game(row.GameID), objectiveKey(row.TeamID, row.Phase), row)
+	}
+	for i := range state.TurtleResults {
+		row := &state.TurtleResults[i]
+		add("turtle", game(row.GameID), objectiveKey(row.TeamID, row.Phase), row)
+	}
+}
+
+func priorityKey(heroID uint, role string) string {
+	return fmt.Sprintf("%d/%s", heroID, role)
+}
+
+func objectiveKey(teamID uint, phase string) string {
+	return fmt.Sprintf("%d/%s", teamID, strings.ToLower(phase))
+}
+
+// run memproses sheets sesuai urutan importSheets. Sheet yang tidak dikenal
+// dilaporkan sebagai error agar salah ketik nama sheet tidak terlewat.
+func (im *sheetImport) run(sheets []tabular.Sheet) {
+	byKey := map[string]tabular.Sheet{}
+	for _, sheet := range sheets {
+		byKey[tabular.Key(sheet.Name)] = sheet
+	}
+	known := map[string]bool{}
+	for _, spec := range importSheets {
+		known[tabular.Key(spec.name)] = true
+	}
+	for _, sheet := range sheets {
+		if !known[tabular.Key(sheet.Name)] {
+			im.fail(sheet.Name, 0, "", "Unknown sheet")
+		}
+	}
+	if len(sheets) == 0 {
+		im.fail("", 0, "", "The file has no sheets")
+	}
+
+	for _, spec := range importSheets {
+		sheet, ok := byKey[tabular.Key(spec.name)]
+		if !ok {
+			continue
+		}
+
+		columns := map[string]int{}
+		for i, column := range sheet.Table.Columns {
+			columns[tabular.Key(column)] = i
+		}
+		missing := false
+		for _, column := range spec.columns {
+			if _, ok := columns[column]; !ok {
+				im.fail(spec.name, 0, column, "Missing column")
+				missing = true
+			}
+		}
+		if missing {
+			continue
+		}
+
+		for i, values := range sheet.Table.Rows {
+			if values == nil {
+				continue
+			}
+			row := &importRow{im: im, sheet: spec.name, line: i + 2, columns: columns, values: values}
+			spec.row(im, row)
+		}
+	}
+}
+
+func (im *sheetImport) fail(sheet string, row int, column, format string, args ...interface{}) {
+	im.result.Errors = append(im.result.Errors, dto.SheetImportErrorDto{Sheet: sheet, Row: row, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+// claim menandai entity k diubah oleh row, dan gagal jika baris lain sudah
+// mengubahnya lebih dulu.
+func (im *sheetImport) claim(row *importRow, k entityKey) bool {
+	if first, ok := im.seen[k]; ok {
+		row.fail("", "Duplicates row %d", first)
+		return false
+	}
+	im.seen[k] = row.line
+	return true
+}
+
+// save mencatat perubahan entity dari satu baris. Entity baru selalu
+// disimpan; entity lama hanya jika ada field yang berubah. link mengisi
+// foreign key dari induk yang mungkin juga baru. Hasilnya true jika entity
+// akan disimpan.
+func (im *sheetImport) save(row *importRow, entity, key string, value interface{}, created bool, changes *changeSet, link func()) bool {
+	action := "update"
+	switch {
+	case created:
+		action = "create"
+		im.result.Created++
+	case len(changes.fields) > 0:
+		im.result.Updated++
+	default:
+		im.result.Unchanged++
+		return false
+	}
+
+	im.ops = append(im.ops, repositories.SheetImportOp{Value: value, Link: link})
+	im.result.Changes = append(im.result.Changes, dto.SheetImportChangeDto{
+		Sheet:  row.sheet,
+		Row:    row.line,
+		Action: action,
+		Entity: entity,
+		Key:    key,
+		Fields: changes.fields,
+	})
+	return true
+}
+
+// draft mencatat HeroPick atau HeroBan entry yang diubah row. Setiap entry
+// hanya dicatat sekali, pada baris pertama yang mengubahnya.
+func (im *sheetImport) draft(row *importRow, m *importMatch, detail *models.MatchTeamDetail, entry interface{}) {
+	for _, drafted := range im.drafted {
+		if drafted.entry == entry {
+			return
+		}
+	}
+	im.drafted = append(im.drafted, draftedRow{sheet: row.sheet, line: row.line, match: m, detail: detail, entry: entry})
+}
+
+// checkDrafts memeriksa setiap HeroPick dan HeroBan yang diubah import
+// dengan checkDraft. Draft series dibentuk dari data Match yang sudah ada
+// digabung dengan baris import, sehingga pick/ban lain di file yang sama
+// ikut diperiksa.
+func (im *sheetImport) checkDrafts() {
+	if im.rule == nil || len(im.drafted) == 0 {
+		return
+	}
+
+	// ID sementara agar HeroPick/HeroBan baru bisa dibedakan di checkDraft
+	ids := map[interface{}]uint{}
+	entryID := func(entry interface{}) uint {
+		if _, ok := ids[entry]; !ok {
+			ids[entry] = uint(len(ids) + 1)
+		}
+		return ids[entry]
+	}
+
+	matchOf := map[*models.MatchTeamDetail]*importMatch{}
+	for _, m := range im.matches {
+		for _, detail := range m.details {
+			matchOf[detail] = m
+		}
+	}
+	games := map[interface{}][]int{}
+	for k, value := range im.entities {
+		switch row := value.(type) {
+		case *models.HeroPickGame:
+			if row.IsPicked {
+				games[k.parent] = append(games[k.parent], row.GameNumber)
+			}
+		case *models.HeroBanGame:
+			if row.IsBanned {
+				games[k.parent] = append(games[k.parent], row.GameNumber)
+			}
+		}
+	}
+
+	candidate := func(detail *models.MatchTeamDetail, entry interface{}) draftCandidate {
+		c := draftCandidate{EntryID: entryID(entry), TeamID: detail.TeamID, Games: games[entry]}
+		switch row := entry.(type) {
+		case *models.HeroPick:
+			c.HeroID, c.FirstPhase, c.SecondPhase = row.HeroID, row.FirstPhase, row.SecondPhase
+		case *models.HeroBan:
+			c.Ban, c.HeroID, c.FirstPhase, c.SecondPhase = true, row.HeroID, row.FirstPhase, row.SecondPhase
+		}
+		slices.Sort(c.Games)
+		return c
+	}
+
+	series := map[*importMatch][]repositories.DraftEntry{}
+	for k, value := range im.entities {
+		if k.kind != "hero_pick" && k.kind != "hero_ban" {
+			continue
+		}
+		detail := k.parent.(*models.MatchTeamDetail)
+		m := matchOf[detail]
+		c := candidate(detail, value)
+		for _, game := range c.Games {
+			series[m] = append(series[m], repositories.DraftEntry{Ban: c.Ban, EntryID: c.EntryID, TeamID: c.TeamID, HeroID: c.HeroID,
+				GameNumber: game, FirstPhase: c.FirstPhase, SecondPhase: c.SecondPhase})
+		}
+	}
+
+	for _, drafted := range im.drafted {
+		if err := checkDraft(*im.rule, series[drafted.match], candidate(drafted.detail, drafted.entry)); err != nil {
+			im.fail(drafted.sheet, drafted.line, "hero", "%s", err.Error())
+		}
+	}
+}
+
+// defaultPatch mengembalikan patch yang berlaku saat match dimainkan, atau
+// nil jika tanggalnya tidak diketahui, seperti GameService.
+func (im *sheetImport) defaultPatch(match *models.Match) *models.Patch {
+	if match.PlayedAt == nil {
+		return nil
+	}
+	for i := range im.patches {
+		if !im.patches[i].ReleaseDate.After(*match.PlayedAt) {
+			return &im.patches[i]
+		}
+	}
+	return nil
+}
+
+func (im *sheetImport) teamName(teamID uint) string {
+	if team := im.teamIDs[teamID]; team != nil {
+		return team.Name
+	}
+	return ""
+}
+
+func (im *sheetImport) heroName(heroID uint) string {
+	if hero := im.heroIDs[heroID]; hero != nil {
+		return hero.Name
+	}
+	return ""
+}
+
+func (im *sheetImport) patchVersion(patchID *uint) string {
+	for _, patch := range im.patches {
+		if patchID != nil && patch.PatchID == *patchID {
+			return patch.Version
+		}
+	}
+	return ""
+}
+
+// importRow adalah satu baris sheet. Helper pembaca kolom mencatat error
+// dan mengembalikan nilai nol jika kolomnya tidak valid; pemanggil cukup
+// memeriksa failed setelah membaca semua kolom.
+type importRow struct {
+	im      *sheetImport
+	sheet   string
+	line    int
+	columns map[string]int
+	values  []string
+	failed  bool
+}
+
+func (r *importRow) fail(column, format string, args ...interface{}) {
+	r.failed = true
+	r.im.fail(r.sheet, r.line, column, format, args...)
+}
+
+// has mengecek apakah kolom opsional ada di sheet.
+func (r *importRow) has(column string) bool {
+	_, ok := r.columns[column]
+	return ok
+}
+
+func (r *importRow) text(column string) string {
+	if i, ok := r.columns[column]; ok {
+		return r.values[i]
+	}
+	return ""
+}
+
+func (r *importRow) required(column string) string {
+	value := r.text(column)
+	if value == "" {
+		r.fail(column, "Required")
+	}
+	return value
+}
+
+// integer membaca bilangan bulat tidak negatif. Spreadsheet kadang menulis
+// angka bulat sebagai "2.0", yang juga diterima.
+func (r *importRow) integer(column string) int {
+	value := r.required(column)
+	if value == "" {
+		return 0
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 || number != float64(int(number)) {
+		r.fail(column, "Must be a whole number, got %q", value)
+		return 0
+	}
+	return int(number)
+}
+
+func (r *importRow) number(column string) float64 {
+	value := r.required(column)
+	if value == "" {
+		return 0
+	}
+	number, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil || number < 0 {
+		r.fail(column, "Must be a number, got %q", value)
+		return 0
+	}
+	if strings.HasSuffix(value, "%") {
+		number /= 100
+	}
+	return number
+}
+
+func (r *importRow) date(column string) *time.Time {
+	value := r.text(column)
+	// Sel tanggal yang ditulis dengan jam tetap diterima
+	if len(value) > len(dateLayout) {
+		value = value[:len(dateLayout)]
+	}
+	date, err := parseDate(value, column)
+	if err != nil {
+		r.fail(column, "Must be a date in YYYY-MM-DD format, got %q", r.text(column))
+	}
+	return date
+}
+
+func (r *importRow) oneOf(column string, values ...string) string {
+	value := strings.ToLower(r.required(column))
+	if value == "" {
+		return ""
+	}
+	for _, allowed := range values {
+		if value == allowed {
+			return value
+		}
+	}
+	r.fail(column, "Must be one of %s, got %q", strings.Join(values, ", "), value)
+	return ""
+}
+
+// name mencocokkan value dengan nama atau alias di names, dan mencatat
+// value di unmatched jika tidak cocok.
+func (im *sheetImport) name(names *nameMatchers, value string, teamID uint) (uint, bool) {
+	resolution := names.resolve(value, teamID)
+	if resolution.Match != nil {
+		return resolution.Match.ID, true
+	}
+	im.unmatched = append(im.unmatched, resolution)
+	return 0, false
+}
+
+// unknown menulis pesan nama yang tidak cocok beserta kandidat
+// teratasnya dari unmatched.
+func (im *sheetImport) unknown(kind, value string) string {
+	message := fmt.Sprintf("Unknown %s %q", kind, value)
+	if len(im.unmatched) > 0 {
+		if last := im.unmatched[len(im.unmatched)-1]; last.Name == value && len(last.Candidates) > 0 {
+			message += fmt.Sprintf("; did you mean %q?", last.Candidates[0].Name)
+		}
+	}
+	return message
+}
+
+func (r *importRow) team(column string) *models.Team {
+	value := r.required(column)
+	if value == "" {
+		return nil
+	}
+	if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+		if team := r.im.teamIDs[uint(id)]; team != nil {
+			return team
+		}
+	}
+	if id, ok := r.im.name(r.im.teamNames, value, 0); ok && r.im.teamIDs[id] != nil {
+		return r.im.teamIDs[id]
+	}
+	r.fail(column, "%s", r.im.unknown("team", value))
+	return nil
+}
+
+func (r *importRow) hero(column string) *models.Hero {
+	value := r.required(column)
+	if value == "" {
+		return nil
+	}
+	if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+		if hero := r.im.heroIDs[uint(id)]; hero != nil {
+			return hero
+		}
+	}
+	if id, ok := r.im.name(r.im.heroNames, value, 0); ok && r.im.heroIDs[id] != nil {
+		return r.im.heroIDs[id]
+	}
+	r.fail(column, "%s", r.im.unknown("hero", value))
+	return nil
+}
+
+// player mencari pemain team lewat ID atau nama.
+func (r *importRow) player(column string, team *models.Team) *models.Player {
+	value := r.required(column)
+	if value == "" || team == nil {
+		return nil
+	}
+	players := r.im.players[team.TeamID]
+	for i := range players {
+		if strconv.FormatUint(uint64(players[i].PlayerID), 10) == value {
+			return &players[i]
+		}
+	}
+	if id, ok := r.im.name(r.im.playerNames, value, team.TeamID); ok {
+		for i := range players {
+			if players[i].PlayerID == id {
+				return &players[i]
+			}
+		}
+	}
+	r.fail(column, "%s in team %s", r.im.unknown("player", value), team.Name)
+	return nil
+}
+
+// match mencari Match lewat match_id, baik yang sudah ada maupun yang
+// dibuat di sheet Matches.
+func (r *importRow) match() *importMatch {
+	value := r.required("match_id")
+	if value == "" {
+		return nil
+	}
+	if m := r.im.matches[value]; m != nil {
+		return m
+	}
+	r.fail("match_id", "Unknown match %q; new matches must be listed in the Matches sheet", value)
+	return nil
+}
+
+// side mencari MatchTeamDetail tim pada Match.
+func (r *importRow) side(m *importMatch, column string) (*models.Team, *models.MatchTeamDetail) {
+	team := r.team(column)
+	if team == nil || m == nil {
+		return team, nil
+	}
+	if detail := m.details[team.TeamID]; detail != nil {
+		return team, detail
+	}
+	r.fail(column, "Team %s does not play in match %s", team.Name, m.key)
+	return team, nil
+}
+
+// game mencari Game nomor game_number pada Match.
+func (r *importRow) game(m *importMatch) *models.Game {
+	number := r.integer("game_number")
+	if m == nil || r.failed {
+		return nil
+	}
+	if game := m.games[number]; game != nil {
+		return game
+	}
+	r.fail("game_number", "Match %s has no game %d; add it to the Games sheet", m.key, number)
+	return nil
+}
+
+// changeSet mengisi field entity dengan nilai dari baris dan mencatat field
+// yang berubah.
+type changeSet struct {
+	fields []dto.SheetImportFieldDto
+}
+
+// set mengganti *dest dengan value jika berbeda. dest adalah pointer ke
+// field dengan tipe yang sama dengan value.
+func (c *changeSet) set(field string, dest, value interface{}) {
+	target := reflect.ValueOf(dest).Elem()
+	before, after := formatField(target), formatField(reflect.ValueOf(value))
+	if before == after {
+		return
+	}
+	target.Set(reflect.ValueOf(value))
+	c.fields = append(c.fields, fieldChange(field, before, after))
+}
+
+// setName seperti set untuk foreign key, tetapi mencatat nama yang mudah
+// dibaca alih-alih ID-nya.
+func (c *changeSet) setName(field string, dest *uint, value uint, name func(uint) string) {
+	if *dest == value {
+		return
+	}
+	before := ""
+	if *dest != 0 {
+		before = name(*dest)
+	}
+	c.fields = append(c.fields, dto.SheetImportFieldDto{Field: field, Before: before, After: name(value)})
+	*dest = value
+}
+
+func formatField(value reflect.Value) string {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if t, ok := value.Interface().(time.Time); ok {
+		return t.Format(dateLayout)
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+func fieldChange(field, before, after string) dto.SheetImportFieldDto {
+	return dto.SheetImportFieldDto{Field: field, Before: before, After: after}
+}
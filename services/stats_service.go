@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+
+	"ml-master-data/dto"
+	"ml-master-data/repositories"
+)
+
+type StatsService struct {
+	stats repositories.StatsRepository
+}
+
+func NewStatsService(stats repositories.StatsRepository) *StatsService {
+	return &StatsService{stats: stats}
+}
+
+// Recompute memperbaiki skor match, hasil game dan patch game untuk satu
+// tournament, atau semua tournament jika tournamentID 0.
+func (s *StatsService) Recompute(ctx context.Context, tournamentID uint) (repositories.StatsRecompute, error) {
+	return s.stats.Recompute(ctx, tournamentID)
+}
+
+func (s *StatsService) PlayerStatistics(ctx context.Context, playerID, tournamentID uint, patchID *uint) (dto.PlayerStats, error) {
+	return s.stats.PlayerStatistics(ctx, playerID, tournamentID, patchID)
+}
+
+func (s *StatsService) CoachStatistics(ctx context.Context, coachID, tournamentID uint, patchID *uint) (dto.CoachStats, error) {
+	return s.stats.CoachStatistics(ctx, coachID, tournamentID, patchID)
+}
+
+// TeamStatistics merekap hasil match dari skor dan hasil game per sisi draft
+// (first/second pick) sebuah tim di satu tournament.
+func (s *StatsService) TeamStatistics(ctx context.Context, teamID, tournamentID uint, patchID *uint) (dto.TeamStatisticsDto, error) {
+	stats := dto.TeamStatisticsDto{TeamID: teamID}
+
+	matches, err := s.stats.FindTeamMatches(ctx, teamID, tournamentID, patchID)
+	if err != nil {
+		return stats, err
+	}
+	stats.TotalMatch = len(matches)
+
+	matchIDs := make([]uint, 0, len(matches))
+	for _, match := range matches {
+		matchIDs = append(matchIDs, match.MatchID)
+		if match.TeamAID == teamID {
+			if match.TeamAScore > match.TeamBScore {
+				stats.TotalMatchAndWin++
+			} else if match.TeamAScore < match.TeamBScore {
+				stats.TotalMatchAndLose++
+			}
+		} else if match.TeamBID == teamID {
+			if match.TeamBScore > match.TeamAScore {
+				stats.TotalMatchAndWin++
+			} else if match.TeamBScore < match.TeamAScore {
+				stats.TotalMatchAndLose++
+			}
+		}
+	}
+
+	games, err := s.stats.FindTeamGames(ctx, teamID, matchIDs, patchID)
+	if err != nil {
+		return stats, err
+	}
+	stats.TotalGame = len(games)
+
+	for _, game := range games {
+		won := game.WinnerTeamID == teamID
+		if game.FirstPickTeamID == teamID {
+			stats.TotalFirstPick++
+			if won {
+				stats.TotalGameAndWin++
+				stats.TotalFirstPickAndWin++
+			} else {
+				stats.TotalGameAndLose++
+				stats.TotalFirstPickAndLose++
+			}
+		} else if game.SecondPickTeamID == teamID {
+			stats.TotalSecondPick++
+			if won {
+				stats.TotalGameAndWin++
+				stats.TotalSecondPickAndWin++
+			} else {
+				stats.TotalGameAndLose++
+				stats.TotalSecondPickAndLose++
+			}
+		}
+	}
+	return stats, nil
+}
@@ -1,91 +1,126 @@
 package services
 
 import (
-	"fmt"
-	"log"
-	"os"
-	"strings"
+	"context"
 
 	"ml-master-data/models"
-
-	"gorm.io/gorm"
+	"ml-master-data/repositories"
 )
 
-// Fungsi untuk menghapus Team beserta semua relasi terkait termasuk Match
-func DeleteTeam(db *gorm.DB, team models.Team) error {
-	// Mulai transaksi
-	tx := db.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
+// TeamService mengelola Team beserta Player dan Coach di dalamnya.
+type TeamService struct {
+	teams repositories.TeamRepository
+	names *NameResolverService
+}
+
+func NewTeamService(teams repositories.TeamRepository, names *NameResolverService) *TeamService {
+	return &TeamService{teams: teams, names: names}
+}
+
+func (s *TeamService) GetAll(ctx context.Context) ([]models.Team, error) {
+	return s.teams.FindAll(ctx)
+}
 
-	matches := []models.Match{}
-	if err := tx.Where("team_a_id = ? OR team_b_id = ?", team.TeamID, team.TeamID).Find(&matches).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal mendapatkan semua Match: %w", err)
+func (s *TeamService) GetByID(ctx context.Context, teamID uint) (models.Team, error) {
+	team, err := s.teams.FindByID(ctx, teamID)
+	return team, notFound(err, "Team")
+}
+
+// Create menyimpan Team baru. Nama yang cocok dengan nama atau alias Team
+// lain ditolak agar tidak ada Team ganda.
+func (s *TeamService) Create(ctx context.Context, team *models.Team) error {
+	if err := s.names.CheckName(ctx, models.NameKindTeam, 0, 0, team.Name); err != nil {
+		return err
 	}
+	return s.teams.Create(ctx, team)
+}
 
-	// 2. Panggil DeleteMatch untuk setiap Match terkait
-	for _, match := range matches {
-		if err := DeleteMatch(db, match.MatchID); err != nil {
-			tx.Rollback()
-			return err
-		}
+func (s *TeamService) Update(ctx context.Context, team *models.Team) error {
+	if err := s.names.CheckName(ctx, models.NameKindTeam, 0, team.TeamID, team.Name); err != nil {
+		return err
 	}
+	return s.teams.Update(ctx, team)
+}
 
-	players := []models.Player{}
-	if err := tx.Where("team_id = ?", team.TeamID).Find(&players).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal mendapatkan semua Player: %w", err)
+func (s *TeamService) Delete(ctx context.Context, teamID uint) error {
+	team, err := s.GetByID(ctx, teamID)
+	if err != nil {
+		return err
 	}
+	return s.teams.Delete(ctx, team)
+}
+
+func (s *TeamService) Restore(ctx context.Context, teamID uint) error {
+	return restoreError(s.teams.Restore(ctx, teamID), "Team")
+}
 
-	//panggil DeletePlayer untuk setiap Player terkait
-	for _, player := range players {
-		if err := DeletePlayer(db, player); err != nil {
-			tx.Rollback()
-			return err
-		}
+func (s *TeamService) GetPlayers(ctx context.Context, teamID uint) ([]models.Player, error) {
+	if _, err := s.GetByID(ctx, teamID); err != nil {
+		return nil, err
 	}
+	return s.teams.FindPlayers(ctx, teamID)
+}
+
+func (s *TeamService) GetPlayerByID(ctx context.Context, playerID uint) (models.Player, error) {
+	player, err := s.teams.FindPlayerByID(ctx, playerID)
+	return player, notFound(err, "Player")
+}
 
-	coaches := []models.Coach{}
-	if err := tx.Where("team_id = ?", team.TeamID).Find(&coaches).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal mendapatkan semua Coach: %w", err)
+// CreatePlayer menyimpan Player baru di Team player.TeamID. Seperti
+// Create, nama yang cocok dengan Player lain di tim yang sama ditolak.
+func (s *TeamService) CreatePlayer(ctx context.Context, player *models.Player) error {
+	if _, err := s.GetByID(ctx, player.TeamID); err != nil {
+		return err
+	}
+	if err := s.names.CheckName(ctx, models.NameKindPlayer, player.TeamID, 0, player.Name); err != nil {
+		return err
 	}
+	return s.teams.CreatePlayer(ctx, player)
+}
 
-	//panggil DeleteCoach untuk setiap Coach terkait
-	for _, coach := range coaches {
-		if err := DeleteCoach(db, coach); err != nil {
-			tx.Rollback()
-			return err
-		}
+func (s *TeamService) UpdatePlayer(ctx context.Context, player *models.Player) error {
+	if err := s.names.CheckName(ctx, models.NameKindPlayer, player.TeamID, player.PlayerID, player.Name); err != nil {
+		return err
 	}
+	return s.teams.UpdatePlayer(ctx, player)
+}
 
-	// 7. Hapus Team itu sendiri
-	if err := tx.Delete(&models.Team{}, team.TeamID).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("gagal menghapus Team: %w", err)
+func (s *TeamService) DeletePlayer(ctx context.Context, playerID uint) error {
+	player, err := s.GetPlayerByID(ctx, playerID)
+	if err != nil {
+		return err
 	}
+	return s.teams.DeletePlayer(ctx, player)
+}
 
-	// Commit transaksi
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("gagal commit transaksi: %w", err)
+func (s *TeamService) GetCoaches(ctx context.Context, teamID uint) ([]models.Coach, error) {
+	if _, err := s.GetByID(ctx, teamID); err != nil {
+		return nil, err
 	}
+	return s.teams.FindCoaches(ctx, teamID)
+}
+
+func (s *TeamService) GetCoachByID(ctx context.Context, coachID uint) (models.Coach, error) {
+	coach, err := s.teams.FindCoachByID(ctx, coachID)
+	return coach, notFound(err, "Coach")
+}
 
-	if team.Image != "" && team.Image != "https://placehold.co/400x600" && strings.HasPrefix(team.Image, os.Getenv("BASE_URL")) {
-		team.Image = strings.Replace(team.Image, os.Getenv("BASE_URL")+"/", "", 1)
-		// Cek apakah file Image lama ada di sistem
-		if _, err := os.Stat(team.Image); err == nil {
-			// Jika file ada, hapus file Image lama dari folder images
-			if err := os.Remove(team.Image); err != nil {
-				return fmt.Errorf("gagal menghapus gambar lama: %w", err)
-			}
-		} else if os.IsNotExist(err) {
-			// Jika file tidak ada, lanjutkan ke tahap selanjutnya
-			log.Printf("File gambar lama tidak ditemukan: %s", team.Image)
-		}
+// CreateCoach menyimpan Coach baru di Team coach.TeamID.
+func (s *TeamService) CreateCoach(ctx context.Context, coach *models.Coach) error {
+	if _, err := s.GetByID(ctx, coach.TeamID); err != nil {
+		return err
 	}
+	return s.teams.CreateCoach(ctx, coach)
+}
 
-	log.Printf("Team dengan ID %d dan semua data terkait telah dihapus.", team.TeamID)
-	return nil
+func (s *TeamService) UpdateCoach(ctx context.Context, coach *models.Coach) error {
+	return s.teams.UpdateCoach(ctx, coach)
+}
+
+func (s *TeamService) DeleteCoach(ctx context.Context, coachID uint) error {
+	coach, err := s.GetCoachByID(ctx, coachID)
+	if err != nil {
+		return err
+	}
+	return s.teams.DeleteCoach(ctx, coach)
 }
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"ml-master-data/dto"
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+	"ml-master-data/storage"
+)
+
+// MaxBundleFiles membatasi jumlah file gambar di satu bundle.
+const MaxBundleFiles = 500
+
+type TournamentBackupService struct {
+	backups repositories.TournamentBackupRepository
+	media   *MediaService
+}
+
+func NewTournamentBackupService(backups repositories.TournamentBackupRepository, media *MediaService) *TournamentBackupService {
+	return &TournamentBackupService{backups: backups, media: media}
+}
+
+// Backup mengembalikan bundle JSON Tournament beserta semua data di
+// bawahnya.
+func (s *TournamentBackupService) Backup(ctx context.Context, tournamentID uint) (dto.TournamentBundleDto, error) {
+	bundle, err := s.backups.Export(ctx, tournamentID)
+	return bundle, notFound(err, "Tournament")
+}
+
+// Restore membuat Tournament baru dari bundle. name, jika tidak kosong,
+// mengganti nama Tournament di bundle. Seluruh bundle divalidasi dulu
+// sehingga bundle yang tidak valid tidak tersimpan sebagian. Bundle yang
+// merujuk team yang sudah dihapus ditolak sampai team di-restore.
+func (s *TournamentBackupService) Restore(ctx context.Context, bundle dto.TournamentBundleDto, name string) (dto.TournamentRestoreDto, error) {
+	if name = strings.TrimSpace(name); name != "" {
+		bundle.Tournament.Name = name
+	}
+	if err := checkBundle(bundle); err != nil {
+		return dto.TournamentRestoreDto{}, err
+	}
+
+	stored, err := s.storeFiles(ctx, &bundle)
+	if err != nil {
+		return dto.TournamentRestoreDto{}, err
+	}
+	result, err := s.backups.Import(ctx, bundle)
+	if err != nil {
+		s.removeImages(ctx, stored)
+		var deleted *repositories.DeletedTeamError
+		if errors.As(err, &deleted) {
+			return result, &ConflictError{Message: deleted.Error()}
+		}
+		return result, err
+	}
+	result.Created["file"] = len(stored)
+	return result, nil
+}
+
+// bundleImage adalah satu referensi gambar di bundle beserta policy upload
+// entity pemiliknya.
+type bundleImage struct {
+	ref    *string
+	policy UploadPolicy
+}
+
+func bundleImages(bundle *dto.TournamentBundleDto) []bundleImage {
+	var images []bundleImage
+	for i := range bundle.Teams {
+		images = append(images, bundleImage{&bundle.Teams[i].Image, TeamUploads})
+	}
+	for i := range bundle.Players {
+		images = append(images, bundleImage{&bundle.Players[i].Image, PlayerUploads})
+	}
+	for i := range bundle.Coaches {
+		images = append(images, bundleImage{&bundle.Coaches[i].Image, CoachUploads})
+	}
+	for i := range bundle.Heroes {
+		images = append(images, bundleImage{&bundle.Heroes[i].Image, HeroUploads})
+	}
+	for i := range bundle.Matches {
+		for j := range bundle.Matches[i].Games {
+			images = append(images, bundleImage{&bundle.Matches[i].Games[j].FullDraftImage, DraftUploads})
+		}
+	}
+	return images
+}
+
+// storeFiles memproses setiap file bundle lewat pipeline imaging seperti
+// upload biasa, menyimpannya dengan key baru, lalu mengganti referensi
+// gambar di bundle. Isi dan key dari bundle tidak pernah ditulis apa
+// adanya; referensi key storage yang filenya tidak ikut diganti dengan
+// placeholder. Gambar yang tersimpan dikembalikan agar bisa dihapus jika
+// restore gagal.
+func (s *TournamentBackupService) storeFiles(ctx context.Context, bundle *dto.TournamentBundleDto) ([]models.Image, error) {
+	keys := map[string]string{}
+	var stored []models.Image
+	for _, image := range bundleImages(bundle) {
+		key, ok := models.Image(*image.ref).Key()
+		if !ok {
+			continue
+		}
+		data, ok := bundle.Files[key]
+		if !ok {
+			*image.ref = ""
+			if image.policy != DraftUploads {
+				*image.ref = string(models.PlaceholderImage)
+			}
+			continue
+		}
+
+		if _, ok := keys[key]; !ok {
+			saved, err := s.media.Store(ctx, image.policy, bytes.NewReader(data))
+			if err != nil {
+				s.removeImages(ctx, stored)
+				var invalid *ValidationError
+				if errors.As(err, &invalid) {
+					return nil, &ValidationError{Message: fmt.Sprintf("files[%q]: %s", key, invalid.Message)}
+				}
+				return nil, err
+			}
+			keys[key] = string(saved)
+			stored = append(stored, saved)
+		}
+		*image.ref = keys[key]
+	}
+	return stored, nil
+}
+
+func (s *TournamentBackupService) removeImages(ctx context.Context, images []models.Image) {
+	for _, image := range images {
+		_ = s.media.Remove(ctx, image)
+	}
+}
+
+// bundleCheck mencatat masalah pertama yang ditemukan di bundle beserta
+// letaknya, misalnya "matches[2].games[0]".
+type bundleCheck struct {
+	err     error
+	teams   map[uint]bool
+	players map[uint]uint
+	coaches map[uint]uint
+	heroes  map[uint]bool
+	patches map[uint]bool
+}
+
+func (c *bundleCheck) fail(path, format string, args ...interface{}) {
+	if c.err == nil {
+		c.err = &ValidationError{Message: path + ": " + fmt.Sprintf(format, args...)}
+	}
+}
+
+func (c *bundleCheck) oneOf(path, field, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		c.fail(path, "invalid %s %q, must be one of %s", field, value, strings.Join(allowed, ", "))
+	}
+}
+
+func (c *bundleCheck) hero(path string, heroID uint) {
+	if !c.heroes[heroID] {
+		c.fail(path, "unknown hero_id %d", heroID)
+	}
+}
+
+// team memeriksa teamID adalah salah satu tim di match.
+func (c *bundleCheck) team(path, field string, teamID uint, match dto.BundleMatchDto) {
+	if teamID != match.TeamAID && teamID != match.TeamBID {
+		c.fail(path, "%s %d does not play in the match", field, teamID)
+	}
+}
+
+func checkBundle(bundle dto.TournamentBundleDto) error {
+	if bundle.Version < 1 || bundle.Version > dto.TournamentBundleVersion {
+		return &ValidationError{Message: fmt.Sprintf("Unsupported tournament bundle version %d", bundle.Version)}
+	}
+	if strings.TrimSpace(bundle.Tournament.Name) == "" {
+		return &ValidationError{Message: "Tournament name is required"}
+	}
+
+	c := &bundleCheck{teams: map[uint]bool{}, players: map[uint]uint{}, coaches: map[uint]uint{}, heroes: map[uint]bool{}, patches: map[uint]bool{}}
+	for i, team := range bundle.Teams {
+		path := fmt.Sprintf("teams[%d]", i)
+		if team.Name == "" {
+			c.fail(path, "name is required")
+		}
+		if c.teams[team.TeamID] {
+			c.fail(path, "duplicate team_id %d", team.TeamID)
+		}
+		c.teams[team.TeamID] = true
+	}
+	for i, player := range bundle.Players {
+		path := fmt.Sprintf("players[%d]", i)
+		if player.Name == "" {
+			c.fail(path, "name is required")
+		}
+		if !c.teams[player.TeamID] {
+			c.fail(path, "unknown team_id %d", player.TeamID)
+		}
+		if _, ok := c.players[player.PlayerID]; ok {
+			c.fail(path, "duplicate player_id %d", player.PlayerID)
+		}
+		c.players[player.PlayerID] = player.TeamID
+	}
+	for i, coach := range bundle.Coaches {
+		path := fmt.Sprintf("coaches[%d]", i)
+		if coach.Name == "" {
+			c.fail(path, "name is required")
+		}
+		if !c.teams[coach.TeamID] {
+			c.fail(path, "unknown team_id %d", coach.TeamID)
+		}
+		if _, ok := c.coaches[coach.CoachID]; ok {
+			c.fail(path, "duplicate coach_id %d", coach.CoachID)
+		}
+		c.coaches[coach.CoachID] = coach.TeamID
+	}
+	for i, hero := range bundle.Heroes {
+		path := fmt.Sprintf("heroes[%d]", i)
+		if hero.Name == "" {
+			c.fail(path, "name is required")
+		}
+		if c.heroes[hero.HeroID] {
+			c.fail(path, "duplicate hero_id %d", hero.HeroID)
+		}
+		for _, class := range []string{hero.PrimaryClass, hero.SecondaryClass} {
+			if class != "" {
+				c.oneOf(path, "class", class, models.HeroClasses...)
+			}
+		}
+		for _, lane := range hero.Lanes {
+			c.oneOf(path, "lane", lane, models.Lanes...)
+		}
+		c.heroes[hero.HeroID] = true
+	}
+	for i, patch := range bundle.Patches {
+		path := fmt.Sprintf("patches[%d]", i)
+		if patch.Version == "" {
+			c.fail(path, "version is required")
+		}
+		if c.patches[patch.PatchID] {
+			c.fail(path, "duplicate patch_id %d", patch.PatchID)
+		}
+		c.patches[patch.PatchID] = true
+	}
+
+	if rule := bundle.Tournament.Rule; rule != nil {
+		c.oneOf("tournament.rule", "draft_format", rule.DraftFormat, models.DraftFormats...)
+		c.oneOf("tournament.rule", "fearless_mode", rule.FearlessMode, models.FearlessModes...)
+		for _, heroID := range rule.DisabledHeroIDs {
+			c.hero("tournament.rule", heroID)
+		}
+	}
+
+	for i, match := range bundle.Matches {
+		c.match(fmt.Sprintf("matches[%d]", i), match)
+	}
+	c.files(bundle)
+	return c.err
+}
+
+// files memastikan bundle tidak membawa terlalu banyak file dan setiap file
+// adalah sumber salah satu gambar di bundle, dengan key berawalan jenis
+// upload entity pemiliknya.
+func (c *bundleCheck) files(bundle dto.TournamentBundleDto) {
+	if len(bundle.Files) > MaxBundleFiles {
+		c.fail("files", "must not contain more than %d files", MaxBundleFiles)
+		return
+	}
+
+	keys := map[string]bool{}
+	for _, image := range bundleImages(&bundle) {
+		if key, ok := models.Image(*image.ref).Key(); ok && strings.HasPrefix(key, image.policy.Kind+"_") {
+			keys[key] = true
+		}
+	}
+	for key := range bundle.Files {
+		if !storage.ValidKey(key) || !keys[key] {
+			c.fail("files", "unknown file %q", key)
+		}
+	}
+}
+
+func (c *bundleCheck) match(path string, match dto.BundleMatchDto) {
+	for _, teamID := range []uint{match.TeamAID, match.TeamBID} {
+		if !c.teams[teamID] {
+			c.fail(path, "unknown team_id %d", teamID)
+		}
+	}
+	if match.TeamAID == match.TeamBID {
+		c.fail(path, "team A and team B must be different")
+	}
+
+	games := map[int]bool{}
+	for i, game := range match.Games {
+		gamePath := fmt.Sprintf("%s.games[%d]", path, i)
+		if game.GameNumber < 1 || games[game.GameNumber] {
+			c.fail(gamePath, "invalid or duplicate game_number %d", game.GameNumber)
+		}
+		games[game.GameNumber] = true
+		c.game(gamePath, game, match)
+	}
+
+	teams := map[uint]bool{}
+	for i, team := range match.Teams {
+		teamPath := fmt.Sprintf("%s.teams[%d]", path, i)
+		c.team(teamPath, "team_id", team.TeamID, match)
+		if teams[team.TeamID] {
+			c.fail(teamPath, "duplicate team_id %d", team.TeamID)
+		}
+		teams[team.TeamID] = true
+
+		for j, player := range team.Players {
+			playerPath := fmt.Sprintf("%s.players[%d]", teamPath, j)
+			if teamID, ok := c.players[player.PlayerID]; !ok || teamID != team.TeamID {
+				c.fail(playerPath, "unknown player_id %d for team %d", player.PlayerID, team.TeamID)
+			}
+			c.oneOf(playerPath, "role", player.Role, "goldlaner", "explaner", "roamer", "midlaner", "jungler")
+		}
+		for j, coach := range team.Coaches {
+			if teamID, ok := c.coaches[coach.CoachID]; !ok || teamID != team.TeamID {
+				c.fail(fmt.Sprintf("%s.coaches[%d]", teamPath, j), "unknown coach_id %d for team %d", coach.CoachID, team.TeamID)
+			}
+		}
+		drafts := map[string][]dto.BundleHeroDraftDto{"hero_picks": team.HeroPicks, "hero_bans": team.HeroBans}
+		for _, field := range []string{"hero_picks", "hero_bans"} {
+			for j, draft := range drafts[field] {
+				draftPath := fmt.Sprintf("%s.%s[%d]", teamPath, field, j)
+				c.hero(draftPath, draft.HeroID)
+				for _, game := range draft.Games {
+					if !games[game.GameNumber] {
+						c.fail(draftPath, "unknown game_number %d", game.GameNumber)
+					}
+				}
+			}
+		}
+		priorities := map[string][]dto.BundlePriorityDto{"priority_picks": team.PriorityPicks, "priority_bans": team.PriorityBans, "flex_picks": team.FlexPicks}
+		for _, field := range []string{"priority_picks", "priority_bans", "flex_picks"} {
+			for j, priority := range priorities[field] {
+				priorityPath := fmt.Sprintf("%s.%s[%d]", teamPath, field, j)
+				c.hero(priorityPath, priority.HeroID)
+				c.oneOf(priorityPath, "role", priority.Role, models.Lanes...)
+			}
+		}
+	}
+}
+
+func (c *bundleCheck) game(path string, game dto.BundleGameDto, match dto.BundleMatchDto) {
+	c.team(path, "first_pick_team_id", game.FirstPickTeamID, match)
+	c.team(path, "second_pick_team_id", game.SecondPickTeamID, match)
+	c.team(path, "winner_team_id", game.WinnerTeamID, match)
+	if game.PatchID != nil && !c.patches[*game.PatchID] {
+		c.fail(path, "unknown patch_id %d", *game.PatchID)
+	}
+
+	earlyResults := []string{"win", "draw", "lose"}
+	for i, result := range game.Results {
+		resultPath := fmt.Sprintf("%s.results[%d]", path, i)
+		c.team(resultPath, "team_id", result.TeamID, match)
+		c.oneOf(resultPath, "result", result.Result, "win", "lose")
+	}
+	lanes := map[string][]dto.BundleLaneDto{"explaners": game.Explaners, "goldlaners": game.Goldlaners}
+	for _, field := range []string{"explaners", "goldlaners"} {
+		for i, lane := range lanes[field] {
+			lanePath := fmt.Sprintf("%s.%s[%d]", path, field, i)
+			c.team(lanePath, "team_id", lane.TeamID, match)
+			c.hero(lanePath, lane.HeroID)
+			c.oneOf(lanePath, "early_result", lane.EarlyResult, earlyResults...)
+		}
+	}
+	for i, trioMid := range game.TrioMids {
+		trioMidPath := fmt.Sprintf("%s.trio_mids[%d]", path, i)
+		c.team(trioMidPath, "team_id", trioMid.TeamID, match)
+		if trioMid.EarlyResult != nil {
+			c.oneOf(trioMidPath, "early_result", *trioMid.EarlyResult, earlyResults...)
+		}
+		for j, hero := range trioMid.Heroes {
+			heroPath := fmt.Sprintf("%s.heroes[%d]", trioMidPath, j)
+			c.hero(heroPath, hero.HeroID)
+			c.oneOf(heroPath, "role", hero.Role, "jungler", "midlaner", "roamer")
+			c.oneOf(heroPath, "early_result", hero.EarlyResult, earlyResults...)
+		}
+	}
+	objectives := map[string][]dto.BundleObjectiveDto{"lord_results": game.LordResults, "turtle_results": game.TurtleResults}
+	for _, field := range []string{"lord_results", "turtle_results"} {
+		for i, objective := range objectives[field] {
+			objectivePath := fmt.Sprintf("%s.%s[%d]", path, field, i)
+			c.team(objectivePath, "team_id", objective.TeamID, match)
+			c.oneOf(objectivePath, "setup", objective.Setup, "early", "late", "no")
+			c.oneOf(objectivePath, "initiate", objective.Initiate, "yes", "no")
+			c.oneOf(objectivePath, "result", objective.Result, "yes", "no")
+		}
+	}
+}
@@ -1,47 +1,117 @@
 package services
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
 
+	"ml-master-data/dto"
 	"ml-master-data/models"
-
-	"gorm.io/gorm"
+	"ml-master-data/repositories"
 )
 
-// Fungsi untuk menghapus Tournament dan semua relasi terkait
-func DeleteTournament(db *gorm.DB, tournamentID uint) error {
-	// Mulai transaksi
-	tx := db.Begin()
-	if tx.Error != nil {
-		return tx.Error
+type TournamentService struct {
+	tournaments repositories.TournamentRepository
+	heroes      repositories.HeroRepository
+}
+
+func NewTournamentService(tournaments repositories.TournamentRepository, heroes repositories.HeroRepository) *TournamentService {
+	return &TournamentService{tournaments: tournaments, heroes: heroes}
+}
+
+func (s *TournamentService) GetAll(ctx context.Context) ([]models.Tournament, error) {
+	return s.tournaments.FindAll(ctx)
+}
+
+func (s *TournamentService) GetByID(ctx context.Context, tournamentID uint) (models.Tournament, error) {
+	tournament, err := s.tournaments.FindByID(ctx, tournamentID)
+	return tournament, notFound(err, "Tournament")
+}
+
+func (s *TournamentService) Create(ctx context.Context, input dto.TournamentRequestDto) (models.Tournament, error) {
+	tournament := models.Tournament{Name: input.Name}
+	err := s.tournaments.Create(ctx, &tournament)
+	return tournament, err
+}
+
+// Update hanya mengganti field yang diisi pada input.
+func (s *TournamentService) Update(ctx context.Context, tournamentID uint, input dto.TournamentRequestDto) (models.Tournament, error) {
+	tournament, err := s.GetByID(ctx, tournamentID)
+	if err != nil {
+		return tournament, err
 	}
 
-	// 1. Temukan semua Match terkait dengan Tournament ini
-	var matches []models.Match
-	if err := tx.Where("tournament_id = ?", tournamentID).Find(&matches).Error; err != nil {
-		tx.Rollback()
+	if input.Name != "" {
+		tournament.Name = input.Name
+	}
+
+	err = s.tournaments.Update(ctx, &tournament)
+	return tournament, err
+}
+
+func (s *TournamentService) Delete(ctx context.Context, tournamentID uint) error {
+	if _, err := s.GetByID(ctx, tournamentID); err != nil {
 		return err
 	}
+	return s.tournaments.Delete(ctx, tournamentID)
+}
 
-	// 2. Panggil DeleteMatch untuk setiap Match terkait
-	for _, match := range matches {
-		if err := DeleteMatch(db, match.MatchID); err != nil {
-			tx.Rollback()
-			return err
-		}
+func (s *TournamentService) Restore(ctx context.Context, tournamentID uint) error {
+	return restoreError(s.tournaments.Restore(ctx, tournamentID), "Tournament")
+}
+
+// GetRule mengembalikan aturan draft Tournament, atau aturan default jika
+// belum diatur.
+func (s *TournamentService) GetRule(ctx context.Context, tournamentID uint) (models.TournamentRule, error) {
+	if _, err := s.GetByID(ctx, tournamentID); err != nil {
+		return models.TournamentRule{}, err
+	}
+	rule, err := s.tournaments.FindRule(ctx, tournamentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return models.DefaultTournamentRule(tournamentID), nil
 	}
+	return rule, err
+}
 
-	// 3. Hapus Tournament itu sendiri
-	if err := tx.Delete(&models.Tournament{}, tournamentID).Error; err != nil {
-		tx.Rollback()
-		return err
+// UpdateRule hanya mengganti field yang diisi pada input. Draft yang sudah
+// tersimpan tidak divalidasi ulang.
+func (s *TournamentService) UpdateRule(ctx context.Context, tournamentID uint, input dto.TournamentRuleRequestDto) (models.TournamentRule, error) {
+	rule, err := s.GetRule(ctx, tournamentID)
+	if err != nil {
+		return rule, err
 	}
 
-	// Commit transaksi
-	if err := tx.Commit().Error; err != nil {
-		return err
+	if input.DraftFormat != nil {
+		rule.DraftFormat = *input.DraftFormat
+	}
+	if input.BansFirstPhase != nil {
+		rule.BansFirstPhase = *input.BansFirstPhase
+	}
+	if input.BansSecondPhase != nil {
+		rule.BansSecondPhase = *input.BansSecondPhase
+	}
+	if input.FearlessMode != nil {
+		rule.FearlessMode = *input.FearlessMode
+	}
+	if input.DisabledHeroIDs != nil {
+		disabled := models.TournamentDisabledHeroes{}
+		seen := map[uint]bool{}
+		for _, heroID := range input.DisabledHeroIDs {
+			if seen[heroID] {
+				continue
+			}
+			seen[heroID] = true
+			if _, err := s.heroes.FindByID(ctx, heroID); err != nil {
+				if errors.Is(err, repositories.ErrNotFound) {
+					return rule, &ValidationError{Message: fmt.Sprintf("Hero %d does not exist", heroID)}
+				}
+				return rule, err
+			}
+			disabled = append(disabled, models.TournamentDisabledHero{TournamentID: tournamentID, HeroID: heroID})
+		}
+		rule.DisabledHeroes = disabled
 	}
 
-	log.Printf("Tournament with ID %d and all related records have been deleted.", tournamentID)
-	return nil
+	err = s.tournaments.SaveRule(ctx, &rule)
+	return rule, err
 }
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"ml-master-data/models"
+	"ml-master-data/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidCredentials dikembalikan Authenticate jika username tidak ada
+// atau password salah.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// minPasswordLength adalah panjang minimal password user baru.
+const minPasswordLength = 8
+
+type UserService struct {
+	users repositories.UserRepository
+}
+
+func NewUserService(users repositories.UserRepository) *UserService {
+	return &UserService{users: users}
+}
+
+// Create membuat user dengan password yang di-hash bcrypt.
+func (s *UserService) Create(ctx context.Context, username, password string) (models.User, error) {
+	if username == "" {
+		return models.User{}, &ValidationError{Message: "Username is required"}
+	}
+	if len(password) < minPasswordLength {
+		return models.User{}, &ValidationError{Message: "Password must be at least 8 characters"}
+	}
+
+	_, err := s.users.FindByUsername(ctx, username)
+	if err == nil {
+		return models.User{}, &ConflictError{Message: "Username already exists"}
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return models.User{}, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	user := models.User{Username: username, Password: string(hashedPassword)}
+	return user, s.users.Create(ctx, &user)
+}
+
+// Authenticate mencocokkan username dan password dengan hash bcrypt yang
+// tersimpan.
+func (s *UserService) Authenticate(ctx context.Context, username, password string) (models.User, error) {
+	user, err := s.users.FindByUsername(ctx, username)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return models.User{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return models.User{}, ErrInvalidCredentials
+	}
+	return user, nil
+}
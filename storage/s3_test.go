@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 adalah pengganti MinIO di memori yang memeriksa header SigV4.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/") || !strings.Contains(auth, "/us-east-1/s3/aws4_request") || r.Header.Get("X-Amz-Date") == "" {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		if r.URL.Query().Get("list-type") == "2" {
+			f.list(w, r)
+			return
+		}
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// list menjawab ListObjectsV2 dua objek per halaman.
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	bucket := r.URL.Path + "/"
+	var keys []string
+	for path := range f.objects {
+		key := strings.TrimPrefix(path, bucket)
+		if strings.HasPrefix(key, r.URL.Query().Get("prefix")) && key > r.URL.Query().Get("continuation-token") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	fmt.Fprint(w, "<ListBucketResult>")
+	for i, key := range keys {
+		if i == 2 {
+			fmt.Fprintf(w, "<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>", keys[1])
+			break
+		}
+		fmt.Fprintf(w, "<Contents><Key>%s</Key><Size>%d</Size><LastModified>2024-01-02T03:04:05.000Z</LastModified></Contents>", key, len(f.objects[bucket+key]))
+	}
+	fmt.Fprint(w, "</ListBucketResult>")
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	files, err := NewS3(S3Options{Endpoint: server.URL, Bucket: "media", AccessKey: "access", SecretKey: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := files.Put(ctx, "heroes/miya.png", bytes.NewReader([]byte("png")), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fake.objects["/media/heroes/miya.png"]; !ok {
+		t.Fatalf("objects = %v", fake.objects)
+	}
+
+	body, err := files.Get(ctx, "heroes/miya.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(body)
+	body.Close()
+	if string(content) != "png" {
+		t.Fatalf("content = %q", content)
+	}
+	if url := files.URL("heroes/miya.png"); url != server.URL+"/media/heroes/miya.png" {
+		t.Fatalf("url = %q", url)
+	}
+
+	for _, key := range []string{"heroes/nana.png", "heroes/zilong.png", "teams/rrq.png"} {
+		if err := files.Put(ctx, key, bytes.NewReader([]byte("png")), "image/png"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	objects, err := files.List(ctx, "heroes/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	if strings.Join(keys, ",") != "heroes/miya.png,heroes/nana.png,heroes/zilong.png" || objects[0].Size != 3 || objects[0].ModTime.Year() != 2024 {
+		t.Fatalf("list = %+v", objects)
+	}
+
+	if err := files.Delete(ctx, "heroes/miya.png"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := files.Get(ctx, "heroes/miya.png"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("get deleted object: err = %v", err)
+	}
+	if err := files.Put(ctx, "../escape.png", bytes.NewReader(nil), ""); err == nil {
+		t.Fatal("key outside the bucket was accepted")
+	}
+
+	denied, _ := NewS3(S3Options{Endpoint: server.URL, Bucket: "media", AccessKey: "other", SecretKey: "secret"})
+	if err := denied.Put(ctx, "a.png", bytes.NewReader(nil), ""); err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("denied put: err = %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Package storage menyimpan file media (gambar hero, team, pemain, pelatih
+// dan draft) di backend yang bisa diganti. Database hanya menyimpan key
+// objek; URL publik dibentuk oleh backend saat dibutuhkan sehingga
+// perubahan BASE_URL atau pindah backend tidak memutus link gambar.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrNotFound dikembalikan jika objek dengan key tersebut tidak ada.
+var ErrNotFound = errors.New("object not found")
+
+// Storage adalah backend penyimpanan objek.
+type Storage interface {
+	// Put menyimpan body dengan key; objek yang sudah ada ditimpa.
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// Get membuka objek dengan key. Pemanggil wajib menutup reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete menghapus objek; key yang tidak ada bukan error.
+	Delete(ctx context.Context, key string) error
+	// URL mengembalikan URL publik objek.
+	URL(key string) string
+	// List mengembalikan semua objek yang key-nya berawalan prefix,
+	// terurut menurut key.
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object adalah informasi satu objek hasil List.
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// ValidKey memastikan key relatif, tidak kosong dan tidak keluar dari root
+// storage lewat "..".
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func invalidKey(key string) error {
+	return errors.New("invalid storage key " + `"` + key + `"`)
+}
@@ -0,0 +1,111 @@
+package tabular
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrUnknownFormat dikembalikan Read untuk file selain .csv dan .xlsx.
+var ErrUnknownFormat = errors.New("file must be .csv or .xlsx")
+
+// Read membaca file spreadsheet sesuai ekstensi filename. File CSV menjadi
+// satu sheet yang namanya diambil dari nama file, misalnya
+// "hero-picks.csv" menjadi sheet "hero-picks".
+func Read(filename string, r io.Reader) ([]Sheet, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch ext {
+	case ".xlsx":
+		return ReadXLSX(r)
+	case ".csv":
+		table, err := ReadCSV(r)
+		if err != nil {
+			return nil, err
+		}
+		return []Sheet{{Name: strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)), Table: table}}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// ReadCSV membaca CSV dengan baris pertama sebagai header. BOM UTF-8 di
+// awal file diabaikan. Semua kolom berjenis Text.
+func ReadCSV(r io.Reader) (Table, error) {
+	reader := bufio.NewReader(r)
+	if bom, err := reader.Peek(len(BOM)); err == nil && string(bom) == BOM {
+		reader.Discard(len(BOM))
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return Table{}, err
+	}
+	return fromRecords(records), nil
+}
+
+// ReadXLSX membaca setiap sheet workbook dengan baris pertama sebagai
+// header. Nilai sel dibaca sesuai format tampilannya, sehingga tanggal
+// dengan format yyyy-mm-dd terbaca sebagai DateLayout.
+func ReadXLSX(r io.Reader) ([]Sheet, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var sheets []Sheet
+	for _, name := range f.GetSheetList() {
+		records, err := f.GetRows(name)
+		if err != nil {
+			return nil, err
+		}
+		sheets = append(sheets, Sheet{Name: name, Table: fromRecords(records)})
+	}
+	return sheets, nil
+}
+
+// fromRecords membuat Table dari baris mentah, membuang baris kosong,
+// mengembalikan sel hasil EscapeFormula ke teks aslinya dan
+// menyamakan panjang setiap baris dengan header.
+func fromRecords(records [][]string) Table {
+	var table Table
+	if len(records) == 0 {
+		return table
+	}
+
+	for _, column := range records[0] {
+		table.Columns = append(table.Columns, strings.TrimSpace(column))
+	}
+	table.Kinds = make([]Kind, len(table.Columns))
+	for _, record := range records[1:] {
+		row := make([]string, len(table.Columns))
+		empty := true
+		for i := 0; i < len(record) && i < len(row); i++ {
+			row[i] = unescapeFormula(strings.TrimSpace(record[i]))
+			empty = empty && row[i] == ""
+		}
+		// Baris kosong tetap dihitung agar nomor baris sesuai spreadsheet
+		if empty {
+			row = nil
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// Key mengubah judul header seperti "Team A Score" atau nama kolom seperti
+// "team_a_score" menjadi bentuk kolom "team_a_score".
+func Key(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "_")
+}
@@ -0,0 +1,328 @@
+// Package tabular mengubah data respon API menjadi tabel kolom dan baris
+// teks untuk export CSV dan spreadsheet. Data di-encode ke JSON lebih dulu
+// agar hasilnya sama dengan respon JSON, lalu object bersarang diratakan
+// menjadi kolom bertitik seperti "hero.name".
+package tabular
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported dikembalikan jika data bukan object atau daftar object.
+var ErrUnsupported = errors.New("data cannot be converted to a table")
+
+// BOM ditulis di awal CSV agar Excel membacanya sebagai UTF-8.
+const BOM = "\xEF\xBB\xBF"
+
+// Kind adalah jenis nilai sebuah kolom.
+type Kind int
+
+const (
+	Text Kind = iota
+	Number
+	Date
+)
+
+// Table adalah data yang sudah diratakan. Urutan kolom mengikuti urutan
+// field struct, sehingga stabil antar request. Kinds berisi jenis tiap
+// kolom: Number atau Date jika semua nilai yang terisi berjenis itu.
+type Table struct {
+	Columns []string
+	Kinds   []Kind
+	Rows    [][]string
+}
+
+// New membuat Table dari v: slice menjadi satu baris per elemen, struct atau
+// map menjadi satu baris. Kolom diambil dari tipe elemen, sehingga daftar
+// kosong tetap punya header.
+func New(v interface{}) (Table, error) {
+	var table Table
+	columns := map[string]int{}
+	addColumn := func(name string) {
+		if _, ok := columns[name]; !ok {
+			columns[name] = len(table.Columns)
+			table.Columns = append(table.Columns, name)
+		}
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	var records []interface{}
+	var elemType reflect.Type
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		elemType = value.Type().Elem()
+		for i := 0; i < value.Len(); i++ {
+			records = append(records, value.Index(i).Interface())
+		}
+	case reflect.Struct, reflect.Map:
+		elemType = value.Type()
+		records = append(records, value.Interface())
+	default:
+		return table, ErrUnsupported
+	}
+
+	// Header dari nilai kosong tipe elemen, dengan pointer struct diisi agar
+	// kolom bersarang ikut muncul
+	if template, ok := zeroRecord(elemType); ok {
+		fields, err := flatten(template)
+		if err != nil {
+			return table, err
+		}
+		for _, field := range fields {
+			addColumn(field.name)
+		}
+	}
+
+	var rows [][]field
+	for _, record := range records {
+		fields, err := flatten(record)
+		if err != nil {
+			return table, err
+		}
+		for _, field := range fields {
+			addColumn(field.name)
+		}
+		rows = append(rows, fields)
+	}
+
+	kinds := make([]*Kind, len(table.Columns))
+	for _, fields := range rows {
+		row := make([]string, len(table.Columns))
+		for _, field := range fields {
+			i := columns[field.name]
+			row[i] = field.value
+			if field.value == "" {
+				continue
+			}
+			switch {
+			case kinds[i] == nil:
+				kind := field.kind
+				kinds[i] = &kind
+			case *kinds[i] != field.kind:
+				*kinds[i] = Text
+			}
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	table.Kinds = make([]Kind, len(table.Columns))
+	for i, kind := range kinds {
+		if kind != nil {
+			table.Kinds[i] = *kind
+		}
+	}
+	return table, nil
+}
+
+// WriteCSV menulis table sebagai CSV dengan BOM UTF-8 dan baris CRLF agar
+// langsung terbaca di Excel.
+func (t Table) WriteCSV(w io.Writer) error {
+	if _, err := io.WriteString(w, BOM); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+	if err := writer.Write(t.Columns); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		record := make([]string, len(row))
+		for i, text := range row {
+			record[i] = text
+			if t.kind(i) == Text {
+				record[i] = EscapeFormula(text)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formulaPrefixes adalah karakter awal yang membuat Excel dan Google Sheets
+// membaca sel sebagai formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// EscapeFormula menambahkan ' di depan teks yang akan dibaca sebagai
+// formula oleh aplikasi spreadsheet, misalnya nama tim "=HYPERLINK(...)",
+// sehingga export tidak bisa menjalankan formula dari data pengguna.
+func EscapeFormula(text string) string {
+	if text != "" && strings.ContainsRune(formulaPrefixes, rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// unescapeFormula membuang ' yang ditambahkan EscapeFormula, agar file hasil
+// export bisa dibaca kembali apa adanya.
+func unescapeFormula(text string) string {
+	if len(text) > 1 && text[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(text[1])) {
+		return text[1:]
+	}
+	return text
+}
+
+// zeroRecord membuat nilai kosong tipe t untuk header. Hanya struct yang
+// punya kolom tetap; map tidak.
+func zeroRecord(t reflect.Type) (interface{}, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	value := reflect.New(t).Elem()
+	fillPointers(value, 0)
+	return value.Interface(), true
+}
+
+// fillPointers mengisi field pointer ke struct dengan nilai kosong sampai
+// kedalaman tertentu, agar tipe yang saling merujuk tidak berulang terus.
+func fillPointers(value reflect.Value, depth int) {
+	if depth > 3 {
+		return
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch {
+		case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct && field.Type().Elem() != reflect.TypeOf(time.Time{}):
+			field.Set(reflect.New(field.Type().Elem()))
+			fillPointers(field.Elem(), depth+1)
+		case field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(time.Time{}):
+			fillPointers(field, depth+1)
+		}
+	}
+}
+
+type field struct {
+	name, value string
+	kind        Kind
+}
+
+// flatten meng-encode record ke JSON lalu meratakan object-nya dengan
+// urutan key yang dipertahankan.
+func flatten(record interface{}) ([]field, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, ErrUnsupported
+	}
+	var fields []field
+	err = flattenObject(decoder, "", &fields)
+	return fields, err
+}
+
+func flattenObject(decoder *json.Decoder, prefix string, fields *[]field) error {
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		name := prefix + token.(string)
+
+		next, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch next {
+		case json.Delim('{'):
+			if err := flattenObject(decoder, name+".", fields); err != nil {
+				return err
+			}
+		case json.Delim('['):
+			value, err := arrayText(decoder)
+			if err != nil {
+				return err
+			}
+			*fields = append(*fields, field{name: name, value: value})
+		default:
+			value, kind := scalar(next)
+			*fields = append(*fields, field{name: name, value: value, kind: kind})
+		}
+	}
+	_, err := decoder.Token() // '}'
+	return err
+}
+
+// arrayText menulis array berisi nilai sederhana sebagai daftar dipisah
+// koma, dan array berisi object sebagai JSON ringkas.
+func arrayText(decoder *json.Decoder) (string, error) {
+	var items []interface{}
+	for decoder.More() {
+		var item interface{}
+		if err := decoder.Decode(&item); err != nil {
+			return "", err
+		}
+		items = append(items, item)
+	}
+	if _, err := decoder.Token(); err != nil { // ']'
+		return "", err
+	}
+
+	texts := make([]string, 0, len(items))
+	for _, item := range items {
+		switch item.(type) {
+		case map[string]interface{}, []interface{}:
+			data, err := json.Marshal(items)
+			return string(data), err
+		}
+		text, _ := scalar(item)
+		texts = append(texts, text)
+	}
+	return strings.Join(texts, ", "), nil
+}
+
+// DateLayout dan TimeLayout adalah format waktu di dalam Table.
+const (
+	DateLayout = "2006-01-02"
+	TimeLayout = "2006-01-02 15:04:05"
+)
+
+// scalar menulis nilai JSON sederhana beserta jenisnya. Waktu RFC 3339
+// ditulis dalam format yang dikenali spreadsheet: tanggal saja jika jamnya
+// 00:00:00 UTC.
+func scalar(token interface{}) (string, Kind) {
+	switch value := token.(type) {
+	case nil:
+		return "", Text
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			if t.Equal(t.Truncate(24*time.Hour)) && t.Location() == time.UTC {
+				return t.Format(DateLayout), Date
+			}
+			return t.Format(TimeLayout), Date
+		}
+		return value, Text
+	case json.Number:
+		return value.String(), Number
+	case float64:
+		return fmt.Sprint(value), Number
+	default:
+		return fmt.Sprint(value), Text
+	}
+}
@@ -0,0 +1,169 @@
+package tabular
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Sheet adalah satu sheet workbook XLSX.
+type Sheet struct {
+	Name  string
+	Table Table
+}
+
+// maxColumnWidth membatasi lebar kolom agar teks panjang tidak membuat
+// sheet sulit dibaca.
+const maxColumnWidth = 50
+
+// WriteXLSX menulis sheets sebagai satu workbook. Header ditulis tebal
+// dengan judul yang mudah dibaca, dibekukan dan diberi filter; kolom Number
+// dan Date ditulis sebagai angka dan tanggal spreadsheet.
+func WriteXLSX(w io.Writer, sheets ...Sheet) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	header, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: "FFFFFF"},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"1F4E78"}},
+		Alignment: &excelize.Alignment{Vertical: "center"},
+	})
+	if err != nil {
+		return err
+	}
+	dateFormat, timeFormat := "yyyy-mm-dd", "yyyy-mm-dd hh:mm:ss"
+	date, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return err
+	}
+	datetime, err := f.NewStyle(&excelize.Style{CustomNumFmt: &timeFormat})
+	if err != nil {
+		return err
+	}
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			err = f.SetSheetName(f.GetSheetName(0), sheet.Name)
+		} else {
+			_, err = f.NewSheet(sheet.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if err := writeSheet(f, sheet, header, date, datetime); err != nil {
+			return err
+		}
+	}
+	return f.Write(w)
+}
+
+func writeSheet(f *excelize.File, sheet Sheet, header, date, datetime int) error {
+	table := sheet.Table
+	if len(table.Columns) == 0 {
+		return nil
+	}
+
+	widths := make([]int, len(table.Columns))
+	withTime := make([]bool, len(table.Columns))
+	titles := make([]interface{}, len(table.Columns))
+	for i, column := range table.Columns {
+		titles[i] = Title(column)
+		widths[i] = len(titles[i].(string))
+	}
+	if err := f.SetSheetRow(sheet.Name, "A1", &titles); err != nil {
+		return err
+	}
+
+	for r, row := range table.Rows {
+		values := make([]interface{}, len(row))
+		for i, text := range row {
+			values[i] = cellValue(text, table.kind(i))
+			if table.kind(i) == Date && len(text) > len(DateLayout) {
+				withTime[i] = true
+			}
+			if len(text) > widths[i] {
+				widths[i] = len(text)
+			}
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, r+2)
+		if err := f.SetSheetRow(sheet.Name, cell, &values); err != nil {
+			return err
+		}
+	}
+
+	last, _ := excelize.ColumnNumberToName(len(table.Columns))
+	lastRow := strconv.Itoa(len(table.Rows) + 1)
+	if err := f.SetCellStyle(sheet.Name, "A1", last+"1", header); err != nil {
+		return err
+	}
+	for i, width := range widths {
+		name, _ := excelize.ColumnNumberToName(i + 1)
+		if err := f.SetColWidth(sheet.Name, name, name, float64(min(width, maxColumnWidth)+2)); err != nil {
+			return err
+		}
+		if table.kind(i) == Date && len(table.Rows) > 0 {
+			style := date
+			if withTime[i] {
+				style = datetime
+			}
+			if err := f.SetCellStyle(sheet.Name, name+"2", name+lastRow, style); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := f.SetPanes(sheet.Name, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	return f.AutoFilter(sheet.Name, "A1:"+last+lastRow, nil)
+}
+
+// cellValue mengubah teks sel ke angka atau waktu sesuai jenis kolomnya;
+// teks lain di-escape dengan EscapeFormula.
+func cellValue(text string, kind Kind) interface{} {
+	if text == "" {
+		return nil
+	}
+	switch kind {
+	case Number:
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return number
+		}
+	case Date:
+		if t, err := time.Parse(TimeLayout, text); err == nil {
+			return t
+		}
+		if t, err := time.Parse(DateLayout, text); err == nil {
+			return t
+		}
+	}
+	return EscapeFormula(text)
+}
+
+// Title mengubah nama kolom seperti "team_a.match_id" menjadi judul header
+// "Team A Match ID".
+func Title(column string) string {
+	words := strings.FieldsFunc(column, func(r rune) bool {
+		return r == '_' || r == '.' || r == ' '
+	})
+	for i, word := range words {
+		if word == "id" {
+			words[i] = "ID"
+			continue
+		}
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// kind mengembalikan jenis kolom i; Table yang dibuat tanpa Kinds dianggap
+// berisi Text.
+func (t Table) kind(i int) Kind {
+	if i < len(t.Kinds) {
+		return t.Kinds[i]
+	}
+	return Text
+}
@@ -3,7 +3,6 @@ package utils
 import (
 	"errors"
 	"ml-master-data/models"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -14,11 +13,9 @@ type JWTClaim struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateJWT(user models.User) (string, error) {
-	// Get JWT secret from environment variable
-	jwtSecret := os.Getenv("JWT_SECRET")
+func GenerateJWT(jwtSecret string, user models.User) (string, error) {
 	if jwtSecret == "" {
-		return "", errors.New("JWT_SECRET not found in environment")
+		return "", errors.New("JWT secret is empty")
 	}
 
 	// Create claims with multiple fields populated
@@ -45,11 +42,9 @@ func GenerateJWT(user models.User) (string, error) {
 	return tokenString, nil
 }
 
-func ValidateJWT(tokenString string) (*JWTClaim, error) {
-	// Get JWT secret from environment variable
-	jwtSecret := os.Getenv("JWT_SECRET")
+func ValidateJWT(jwtSecret, tokenString string) (*JWTClaim, error) {
 	if jwtSecret == "" {
-		return nil, errors.New("JWT_SECRET not found in environment")
+		return nil, errors.New("JWT secret is empty")
 	}
 
 	// Parse and validate token